@@ -12,6 +12,7 @@ SERVICES=(
     "emailservice"
     "frontend"
     "loadgenerator"
+    "orderservice"
     "paymentservice"
     "productcatalogservice"
     "recommendationservice"
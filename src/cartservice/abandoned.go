@@ -0,0 +1,183 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultAbandonedAfter         = time.Hour
+	defaultAbandonedCheckInterval = time.Minute
+)
+
+// abandonedCart is the event emitted for a cart with items that has been idle
+// past the abandonment threshold.
+type abandonedCart struct {
+	UserId      string          `json:"user_id"`
+	Items       []abandonedItem `json:"items"`
+	LastUpdated time.Time       `json:"last_updated"`
+}
+
+type abandonedItem struct {
+	ProductId string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+func newAbandonedCart(c idleCart) abandonedCart {
+	event := abandonedCart{UserId: c.Cart.GetUserId(), LastUpdated: c.LastUpdated.UTC()}
+	for _, item := range c.Cart.GetItems() {
+		event.Items = append(event.Items, abandonedItem{ProductId: item.GetProductId(), Quantity: item.GetQuantity()})
+	}
+	return event
+}
+
+// abandonedCartSink receives abandoned cart events.
+type abandonedCartSink interface {
+	Notify(ctx context.Context, event abandonedCart) error
+}
+
+// logSink logs abandoned carts.
+type logSink struct{}
+
+func (logSink) Notify(ctx context.Context, event abandonedCart) error {
+	sugar.Infow("cart abandoned", "userId", event.UserId, "items", len(event.Items), "lastUpdated", event.LastUpdated)
+	return nil
+}
+
+// webhookSink posts abandoned carts as JSON to a URL.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (w *webhookSink) Notify(ctx context.Context, event abandonedCart) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s returned %s", w.url, res.Status)
+	}
+	return nil
+}
+
+// emailSink asks the email service to remind users of their abandoned carts.
+type emailSink struct {
+	client pb.EmailServiceClient
+}
+
+func (e *emailSink) Notify(ctx context.Context, event abandonedCart) error {
+	req := &pb.SendCartReminderRequest{UserId: event.UserId, LastUpdated: event.LastUpdated.Unix()}
+	for _, item := range event.Items {
+		req.Items = append(req.Items, &pb.CartItem{ProductId: item.ProductId, Quantity: item.Quantity})
+	}
+	_, err := e.client.SendCartReminder(ctx, req)
+	return err
+}
+
+// abandonedCartSinkFromEnv returns the sink selected by the
+// ABANDONED_CART_SINK environment variable: "log" (default), "webhook" to
+// ABANDONED_CART_WEBHOOK_URL, "email" through the email service at
+// EMAIL_SERVICE_ADDR, or "none", in which case it returns nil.
+func abandonedCartSinkFromEnv() (abandonedCartSink, error) {
+	switch kind := os.Getenv("ABANDONED_CART_SINK"); kind {
+	case "", "log":
+		return logSink{}, nil
+	case "none":
+		return nil, nil
+	case "webhook":
+		url := os.Getenv("ABANDONED_CART_WEBHOOK_URL")
+		if url == "" {
+			return nil, fmt.Errorf("environment variable \"ABANDONED_CART_WEBHOOK_URL\" not set")
+		}
+		return &webhookSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}, nil
+	case "email":
+		addr := os.Getenv("EMAIL_SERVICE_ADDR")
+		if addr == "" {
+			return nil, fmt.Errorf("environment variable \"EMAIL_SERVICE_ADDR\" not set")
+		}
+		conn, err := grpc.Dial(addr, grpc.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		return &emailSink{client: pb.NewEmailServiceClient(conn)}, nil
+	default:
+		return nil, fmt.Errorf("unknown ABANDONED_CART_SINK %q, want log, webhook, email or none", kind)
+	}
+}
+
+// abandonedCartDetector emits an event to sink for every cart with items that
+// has not been updated for after.
+type abandonedCartDetector struct {
+	store CartStore
+	sink  abandonedCartSink
+	after time.Duration
+}
+
+// check claims the carts that became idle since the last check, and notifies
+// the sink of those with items. It returns how many carts it notified.
+func (d *abandonedCartDetector) check(ctx context.Context) (int, error) {
+	idle, err := d.store.ClaimIdleCarts(now().Add(-d.after))
+	notified := 0
+	for _, c := range idle {
+		if len(c.Cart.GetItems()) == 0 {
+			continue
+		}
+		// A claimed cart is not returned again, so an event that cannot be
+		// delivered is dropped.
+		if err := d.sink.Notify(ctx, newAbandonedCart(c)); err != nil {
+			sugar.Warnf("failed to notify abandoned cart of userId=%v: %v", c.Cart.GetUserId(), err)
+			continue
+		}
+		notified++
+	}
+	return notified, err
+}
+
+// run checks for abandoned carts every interval until ctx is done.
+func (d *abandonedCartDetector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := d.check(ctx); err != nil {
+			sugar.Warnf("failed to check for abandoned carts: %v", err)
+		}
+	}
+}
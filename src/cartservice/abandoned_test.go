@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAbandonedCartWebhook(t *testing.T) {
+	var events []abandonedCart
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		var event abandonedCart
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Error(err)
+		}
+		events = append(events, event)
+	}))
+	defer ts.Close()
+
+	// Stop the clock, so that the time of the last update is known.
+	advanceClock(t, 0)
+	store := newMemoryStore(0)
+	d := &abandonedCartDetector{
+		store: store,
+		sink:  &webhookSink{url: ts.URL, client: ts.Client()},
+		after: time.Hour,
+	}
+	if err := store.AddItem("u", "a", 2); err != nil {
+		t.Fatal(err)
+	}
+	// Idle carts without items are not abandoned.
+	if err := store.AddItem("empty", "a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RemoveItem("empty", "a"); err != nil {
+		t.Fatal(err)
+	}
+	updated := now()
+
+	if n, err := d.check(context.Background()); err != nil || n != 0 {
+		t.Fatalf("check before the threshold = %d, %v, want 0 carts", n, err)
+	}
+	advanceClock(t, 2*time.Hour)
+	if n, err := d.check(context.Background()); err != nil || n != 1 {
+		t.Fatalf("check after the threshold = %d, %v, want 1 cart", n, err)
+	}
+	if n, err := d.check(context.Background()); err != nil || n != 0 {
+		t.Fatalf("check again = %d, %v, want 0 carts", n, err)
+	}
+
+	want := []abandonedCart{{
+		UserId:      "u",
+		Items:       []abandonedItem{{ProductId: "a", Quantity: 2}},
+		LastUpdated: updated.UTC(),
+	}}
+	for i := range events {
+		events[i].LastUpdated = events[i].LastUpdated.UTC()
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
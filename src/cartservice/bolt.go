@@ -0,0 +1,298 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+	bolt "go.etcd.io/bbolt"
+)
+
+const defaultBoltFile = "carts.db"
+
+var (
+	// boltBucket is the bucket that maps user IDs to their carts.
+	boltBucket = []byte("carts")
+	// boltUpdatedBucket maps user IDs to when their carts were last updated,
+	// in nanoseconds since the epoch, followed by a byte set to 1 once the
+	// cart is claimed as idle.
+	boltUpdatedBucket = []byte("updated")
+)
+
+// boltStore keeps carts in a BoltDB file, for running the service on a single
+// machine without redis. Carts are encoded like in redis.
+type boltStore struct {
+	db  *bolt.DB
+	ttl time.Duration
+}
+
+func newBoltStore(path string, ttl time.Duration) (*boltStore, error) {
+	// The timeout keeps a second process opening the same file from
+	// blocking forever on its lock.
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(boltBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(boltUpdatedBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStore{db: db, ttl: ttl}, nil
+}
+
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
+
+// boltUpdate is when a cart was last updated, and whether it was claimed as
+// idle since.
+type boltUpdate struct {
+	at      time.Time
+	claimed bool
+}
+
+func (u boltUpdate) encode() []byte {
+	buf := make([]byte, 9)
+	binary.BigEndian.PutUint64(buf, uint64(u.at.UnixNano()))
+	if u.claimed {
+		buf[8] = 1
+	}
+	return buf
+}
+
+func decodeBoltUpdate(buf []byte) (boltUpdate, bool) {
+	if len(buf) != 9 {
+		return boltUpdate{}, false
+	}
+	return boltUpdate{
+		at:      time.Unix(0, int64(binary.BigEndian.Uint64(buf))),
+		claimed: buf[8] == 1,
+	}, true
+}
+
+// expired reports whether the cart of a user has outlived the TTL.
+func (b *boltStore) expired(tx *bolt.Tx, userId string) bool {
+	if b.ttl <= 0 {
+		return false
+	}
+	u, ok := decodeBoltUpdate(tx.Bucket(boltUpdatedBucket).Get([]byte(userId)))
+	return ok && now().Sub(u.at) >= b.ttl
+}
+
+// boltCart decodes the cart of a user, which is empty if the user has none or
+// if it has expired.
+func (b *boltStore) boltCart(tx *bolt.Tx, userId string) (*pb.Cart, error) {
+	value := tx.Bucket(boltBucket).Get([]byte(userId))
+	if value == nil || b.expired(tx, userId) {
+		return &pb.Cart{UserId: userId}, nil
+	}
+	return decodeCart(value)
+}
+
+func (b *boltStore) GetCart(userId string) (*pb.Cart, error) {
+	var cart *pb.Cart
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		cart, err = b.boltCart(tx, userId)
+		return err
+	})
+	return cart, err
+}
+
+// update applies mutate to the cart of a user in a write transaction, which
+// BoltDB runs one at a time.
+func (b *boltStore) update(userId string, mutate func(cart *pb.Cart) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		cart, err := b.boltCart(tx, userId)
+		if err != nil {
+			return err
+		}
+		if err := mutate(cart); err != nil {
+			return err
+		}
+		if len(cart.GetItems()) == 0 {
+			return deleteBoltCart(tx, userId)
+		}
+		return putBoltCart(tx, cart)
+	})
+}
+
+// putBoltCart writes cart, and records that it was updated now.
+func putBoltCart(tx *bolt.Tx, cart *pb.Cart) error {
+	buf, err := encodeCart(cart, storeEncoding)
+	if err != nil {
+		return err
+	}
+	userId := []byte(cart.GetUserId())
+	if err := tx.Bucket(boltBucket).Put(userId, buf); err != nil {
+		return err
+	}
+	return tx.Bucket(boltUpdatedBucket).Put(userId, boltUpdate{at: now()}.encode())
+}
+
+// deleteBoltCart deletes the cart of a user.
+func deleteBoltCart(tx *bolt.Tx, userId string) error {
+	if err := tx.Bucket(boltBucket).Delete([]byte(userId)); err != nil {
+		return err
+	}
+	return tx.Bucket(boltUpdatedBucket).Delete([]byte(userId))
+}
+
+func (b *boltStore) AddItem(userId, productId string, quantity int32) error {
+	return b.update(userId, func(cart *pb.Cart) error { return addItem(cart, productId, quantity) })
+}
+
+func (b *boltStore) RemoveItem(userId, productId string) error {
+	return b.update(userId, func(cart *pb.Cart) error { return removeItem(cart, productId) })
+}
+
+func (b *boltStore) UpdateItemQuantity(userId, productId string, quantity int32) error {
+	return b.update(userId, func(cart *pb.Cart) error { return setItemQuantity(cart, productId, quantity) })
+}
+
+func (b *boltStore) EmptyCart(userId string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return deleteBoltCart(tx, userId)
+	})
+}
+
+func (b *boltStore) MergeCarts(fromUserId, toUserId string) (*pb.Cart, error) {
+	var cart *pb.Cart
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		from, err := b.boltCart(tx, fromUserId)
+		if err != nil {
+			return err
+		}
+		if cart, err = b.boltCart(tx, toUserId); err != nil {
+			return err
+		}
+		if err := deleteBoltCart(tx, fromUserId); err != nil {
+			return err
+		}
+		if len(from.GetItems()) == 0 {
+			return nil
+		}
+		if err := mergeCart(cart, from); err != nil {
+			return err
+		}
+		if len(cart.GetItems()) == 0 {
+			return deleteBoltCart(tx, toUserId)
+		}
+		return putBoltCart(tx, cart)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// ClaimIdleCarts also deletes the carts that have expired, since BoltDB does
+// not expire keys itself.
+func (b *boltStore) ClaimIdleCarts(idleSince time.Time) ([]idleCart, error) {
+	var idle []idleCart
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		updated := tx.Bucket(boltUpdatedBucket)
+		var expired, claimed [][]byte
+		err := updated.ForEach(func(k, v []byte) error {
+			userId := string(k)
+			u, ok := decodeBoltUpdate(v)
+			switch {
+			case !ok:
+				return nil
+			case b.expired(tx, userId):
+				expired = append(expired, k)
+				return nil
+			case u.claimed || u.at.After(idleSince):
+				return nil
+			}
+			cart, err := b.boltCart(tx, userId)
+			if err != nil {
+				return err
+			}
+			claimed = append(claimed, k)
+			idle = append(idle, idleCart{Cart: cart, LastUpdated: u.at})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// Buckets cannot be modified while iterating over them.
+		for _, k := range expired {
+			if err := deleteBoltCart(tx, string(k)); err != nil {
+				return err
+			}
+		}
+		for i, k := range claimed {
+			u := boltUpdate{at: idle[i].LastUpdated, claimed: true}
+			if err := updated.Put(k, u.encode()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idle, nil
+}
+
+// MigrateCarts rewrites the carts that are not in the current format, in a
+// single transaction.
+func (b *boltStore) MigrateCarts(enc cartEncoding) (int, error) {
+	migrated := 0
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		rewritten := make(map[string][]byte)
+		err := bucket.ForEach(func(k, v []byte) error {
+			if isCurrentEncoding(v, enc) {
+				return nil
+			}
+			cart, err := decodeCart(v)
+			if err != nil {
+				return fmt.Errorf("migrating the cart of userId=%s: %v", k, err)
+			}
+			if rewritten[string(k)], err = encodeCart(cart, enc); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// Buckets cannot be modified while iterating over them.
+		for k, v := range rewritten {
+			if err := bucket.Put([]byte(k), v); err != nil {
+				return err
+			}
+		}
+		migrated = len(rewritten)
+		return nil
+	})
+	return migrated, err
+}
+
+// Ping always succeeds, since the file stays open while the service runs.
+func (b *boltStore) Ping(ctx context.Context) error { return nil }
@@ -0,0 +1,126 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+)
+
+// Stored carts are wrapped in an envelope:
+//
+//	"cart" | schema version (1 byte) | encoding (1 byte) | payload
+//
+// The payload of schema version 1 is a hipstershop.Cart message, encoded
+// with the protobuf wire format or as JSON. Unlike the msgpack encoding of
+// the generated Go struct that carts used to be stored in, both only depend
+// on the message definition, so they survive regenerating the Go code.
+// Records without the envelope are read as msgpack.
+const (
+	cartMagic         = "cart"
+	cartSchemaVersion = 1
+	cartHeaderLen     = len(cartMagic) + 2
+)
+
+// cartEncoding is how the payload of a stored cart is encoded.
+type cartEncoding byte
+
+const (
+	protoEncoding cartEncoding = 'p'
+	jsonEncoding  cartEncoding = 'j'
+)
+
+// storeEncoding is the encoding that stores write carts in, set by the
+// CART_ENCODING environment variable.
+var storeEncoding = protoEncoding
+
+func parseCartEncoding(s string) (cartEncoding, error) {
+	switch s {
+	case "", "proto":
+		return protoEncoding, nil
+	case "json":
+		return jsonEncoding, nil
+	}
+	return 0, fmt.Errorf("unknown cart encoding %q, want proto or json", s)
+}
+
+// encodeCart returns cart wrapped in an envelope, with its payload encoded
+// with enc.
+func encodeCart(cart *pb.Cart, enc cartEncoding) ([]byte, error) {
+	buf := bytes.NewBufferString(cartMagic)
+	buf.WriteByte(cartSchemaVersion)
+	buf.WriteByte(byte(enc))
+	switch enc {
+	case protoEncoding:
+		payload, err := proto.Marshal(cart)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(payload)
+	case jsonEncoding:
+		if err := (&jsonpb.Marshaler{}).Marshal(buf, cart); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown cart encoding %q", enc)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeCart decodes a stored cart, in an envelope or in msgpack.
+func decodeCart(data []byte) (*pb.Cart, error) {
+	if !bytes.HasPrefix(data, []byte(cartMagic)) {
+		var cart pb.Cart
+		if err := decodeMsgPack(data, &cart); err != nil {
+			return nil, fmt.Errorf("decoding msgpack cart: %v", err)
+		}
+		return &cart, nil
+	}
+	if len(data) < cartHeaderLen {
+		return nil, fmt.Errorf("truncated cart envelope")
+	}
+	if v := data[len(cartMagic)]; v != cartSchemaVersion {
+		return nil, fmt.Errorf("unsupported cart schema version %d", v)
+	}
+	var cart pb.Cart
+	payload := data[cartHeaderLen:]
+	switch enc := cartEncoding(data[len(cartMagic)+1]); enc {
+	case protoEncoding:
+		if err := proto.Unmarshal(payload, &cart); err != nil {
+			return nil, fmt.Errorf("decoding protobuf cart: %v", err)
+		}
+	case jsonEncoding:
+		if err := jsonpb.Unmarshal(bytes.NewReader(payload), &cart); err != nil {
+			return nil, fmt.Errorf("decoding JSON cart: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown cart encoding %q", enc)
+	}
+	return &cart, nil
+}
+
+// isCurrentEncoding reports whether a stored cart is in an envelope of the
+// current schema version with its payload encoded with enc, so that
+// migrating it would not change it.
+func isCurrentEncoding(data []byte, enc cartEncoding) bool {
+	return len(data) >= cartHeaderLen &&
+		bytes.HasPrefix(data, []byte(cartMagic)) &&
+		data[len(cartMagic)] == cartSchemaVersion &&
+		cartEncoding(data[len(cartMagic)+1]) == enc
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+)
+
+var testCart = &pb.Cart{
+	UserId: "u",
+	Items:  []*pb.CartItem{{ProductId: "a", Quantity: 1}, {ProductId: "b", Quantity: 2}},
+}
+
+// legacyCart is testCart as it was stored in msgpack by the cart service
+// before carts were versioned.
+const legacyCart = "\x85\xa5Items\x92" +
+	"\x85\xa9ProductId\xa1a\xa8Quantity\x01\xb4XXX_NoUnkeyedLiteral\x80\xadXXX_sizecache\x00\xb0XXX_unrecognized\xc0" +
+	"\x85\xa9ProductId\xa1b\xa8Quantity\x02\xb4XXX_NoUnkeyedLiteral\x80\xadXXX_sizecache\x00\xb0XXX_unrecognized\xc0" +
+	"\xa6UserId\xa1u\xb4XXX_NoUnkeyedLiteral\x80\xadXXX_sizecache\x00\xb0XXX_unrecognized\xc0"
+
+func TestCartCodec(t *testing.T) {
+	for _, enc := range []cartEncoding{protoEncoding, jsonEncoding} {
+		data, err := encodeCart(testCart, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isCurrentEncoding(data, enc) {
+			t.Errorf("encoding %c: %q is not in the current encoding", enc, data)
+		}
+		got, err := decodeCart(data)
+		if err != nil {
+			t.Fatalf("encoding %c: %v", enc, err)
+		}
+		if !proto.Equal(got, testCart) {
+			t.Errorf("encoding %c: decoded %v, want %v", enc, got, testCart)
+		}
+	}
+
+	legacy := []byte(legacyCart)
+	if isCurrentEncoding(legacy, protoEncoding) {
+		t.Error("a msgpack cart is in the current encoding")
+	}
+	got, err := decodeCart(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, testCart) {
+		t.Errorf("msgpack: decoded %v, want %v", got, testCart)
+	}
+}
+
+func TestDecodeCartErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"truncated":        "cart\x01",
+		"future version":   "cart\x02p",
+		"unknown encoding": "cart\x01x",
+		"bad protobuf":     "cart\x01p\xff",
+		"bad JSON":         "cart\x01j{",
+		"bad msgpack":      "\xc1",
+	} {
+		if _, err := decodeCart([]byte(data)); err == nil {
+			t.Errorf("%s: decoded %q", name, data)
+		}
+	}
+}
@@ -3,10 +3,12 @@ module github.com/triplewy/microservices-demo/src/cartservice
 go 1.14
 
 require (
+	github.com/alicebob/miniredis/v2 v2.11.4
 	github.com/go-redis/redis/v7 v7.2.0
 	github.com/golang/protobuf v1.3.5
 	github.com/google/go-cmp v0.3.0 // indirect
 	github.com/hashicorp/go-msgpack v0.5.5
+	go.etcd.io/bbolt v1.3.4
 	go.uber.org/zap v1.14.1
 	golang.org/x/net v0.0.0-20200301022130-244492dfa37a // indirect
 	golang.org/x/sys v0.0.0-20200301204400-5d559ad92b82 // indirect
@@ -1,22 +1,43 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"github.com/go-redis/redis/v7"
 	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
 	"math"
+	"math/rand"
+	"strconv"
 	"time"
 )
 
 const (
 	CartFieldName = "cart"
 	RedisRetryNum = 5
+
+	// CartTxMaxRetries is how many times a cart update is retried when
+	// another update of the same cart wins the race.
+	CartTxMaxRetries = 20
+	CartTxMinBackoff = time.Millisecond
+	CartTxMaxBackoff = 50 * time.Millisecond
+
+	// CartUpdatedKey is a sorted set of the user IDs of carts that were not
+	// claimed since their last update, scored by the time of that update in
+	// milliseconds since the epoch.
+	CartUpdatedKey = "carts:updated"
 )
 
 type redisClient struct {
 	client *redis.Client
+	ttl    time.Duration
+	// trackIdle is whether updates are recorded in CartUpdatedKey, for
+	// ClaimIdleCarts to find the idle carts.
+	trackIdle bool
 }
 
-func newRedisClient(addr string) *redisClient {
+// newRedisClient connects to redis at addr, retrying a few times in case it is
+// still starting.
+func newRedisClient(addr string, ttl time.Duration, trackIdle bool) (*redisClient, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:            addr,
 		Password:        "",
@@ -25,62 +46,152 @@ func newRedisClient(addr string) *redisClient {
 		MinRetryBackoff: 1 * time.Second,
 	})
 
+	var err error
 	for i := 0; i < 3; i++ {
-		if err := client.Ping().Err(); err != nil {
-			time.Sleep(time.Duration(math.Pow(2, float64(i))) * time.Second)
-		} else {
-			return &redisClient{client: client}
+		if err = client.Ping().Err(); err == nil {
+			return &redisClient{client: client, ttl: ttl, trackIdle: trackIdle}, nil
 		}
+		time.Sleep(time.Duration(math.Pow(2, float64(i))) * time.Second)
 	}
+	client.Close()
+	return nil, fmt.Errorf("could not connect to redis at %s: %v", addr, err)
+}
 
-	panic("Could not connect to redis")
+// Ping reports whether redis is reachable.
+func (r *redisClient) Ping(ctx context.Context) error {
+	return r.client.WithContext(ctx).Ping().Err()
 }
 
-func (r *redisClient) AddItem(req *pb.AddItemRequest) error {
-	sugar.Infof("AddItem called with userId=%v, productId=%v, quantity=%v", req.GetUserId(), req.GetItem().GetProductId(), req.GetItem().GetQuantity())
+func (r *redisClient) AddItem(userId, productId string, quantity int32) error {
+	return r.update(userId, func(cart *pb.Cart) error { return addItem(cart, productId, quantity) })
+}
 
-	value, err := r.client.HGet(req.GetUserId(), CartFieldName).Result()
-	if err != nil && err != redis.Nil {
-		return err
-	}
+func (r *redisClient) RemoveItem(userId, productId string) error {
+	return r.update(userId, func(cart *pb.Cart) error { return removeItem(cart, productId) })
+}
 
-	var cart pb.Cart
+func (r *redisClient) UpdateItemQuantity(userId, productId string, quantity int32) error {
+	return r.update(userId, func(cart *pb.Cart) error { return setItemQuantity(cart, productId, quantity) })
+}
 
-	if value == "" {
-		cart = pb.Cart{
-			UserId: req.GetUserId(),
-			Items:  nil,
+// update applies mutate to the cart of a user atomically. The write
+// refreshes the expiration of the cart and records when it was updated. A
+// cart left empty is deleted instead.
+func (r *redisClient) update(userId string, mutate func(cart *pb.Cart) error) error {
+	return r.transact(func(tx *redis.Tx) error {
+		cart, err := loadCart(tx, userId)
+		if err != nil {
+			return err
 		}
-	} else {
-		if err := decodeMsgPack([]byte(value), &cart); err != nil {
+		if err := mutate(cart); err != nil {
 			return err
 		}
-	}
-
-	found := false
-	for _, item := range cart.Items {
-		if item.GetProductId() == req.GetItem().GetProductId() {
-			item.Quantity += req.GetItem().GetQuantity()
-			found = true
-			break
+		if len(cart.GetItems()) == 0 {
+			_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+				deleteCart(pipe, userId)
+				return nil
+			})
+			return err
+		}
+		buf, err := encodeCart(cart, storeEncoding)
+		if err != nil {
+			return err
 		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			r.putCart(pipe, userId, buf)
+			return nil
+		})
+		return err
+	}, userId)
+}
+
+// putCart writes an encoded cart, refreshes its expiration and records when
+// it was updated if idle carts are tracked.
+func (r *redisClient) putCart(pipe redis.Pipeliner, userId string, value []byte) {
+	pipe.HSet(userId, CartFieldName, value)
+	if r.ttl > 0 {
+		pipe.Expire(userId, r.ttl)
+	}
+	if r.trackIdle {
+		pipe.ZAdd(CartUpdatedKey, &redis.Z{Score: float64(millis(now())), Member: userId})
 	}
+}
 
-	if !found {
-		cart.Items = append(cart.Items, req.GetItem())
+// deleteCart deletes the cart of a user and its entry in CartUpdatedKey.
+func deleteCart(pipe redis.Pipeliner, userId string) {
+	pipe.Del(userId)
+	pipe.ZRem(CartUpdatedKey, userId)
+}
+
+// transact runs fn in a transaction that fails if any of the keys changes
+// before it commits, in which case transact starts over, up to
+// CartTxMaxRetries times.
+func (r *redisClient) transact(fn func(tx *redis.Tx) error, keys ...string) error {
+	backoff := CartTxMinBackoff
+	for i := 0; ; i++ {
+		err := r.client.Watch(fn, keys...)
+		if err != redis.TxFailedErr {
+			return err
+		}
+		if i == CartTxMaxRetries {
+			sugar.Warnf("giving up updating the carts %v after %d conflicts", keys, i+1)
+			return errCartContention
+		}
+		// Sleep for a random time up to the backoff, so that the writers
+		// that conflicted do not retry in lockstep.
+		time.Sleep(time.Duration(rand.Int63n(int64(backoff))))
+		if backoff *= 2; backoff > CartTxMaxBackoff {
+			backoff = CartTxMaxBackoff
+		}
 	}
+}
 
-	buf, err := encodeMsgPack(cart)
-	if err != nil {
+func (r *redisClient) MergeCarts(fromUserId, toUserId string) (*pb.Cart, error) {
+	var cart *pb.Cart
+	err := r.transact(func(tx *redis.Tx) error {
+		from, err := loadCart(tx, fromUserId)
+		if err != nil {
+			return err
+		}
+		if cart, err = loadCart(tx, toUserId); err != nil {
+			return err
+		}
+		if err := mergeCart(cart, from); err != nil {
+			return err
+		}
+		buf, err := encodeCart(cart, storeEncoding)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			deleteCart(pipe, fromUserId)
+			switch {
+			case len(cart.GetItems()) == 0:
+				deleteCart(pipe, toUserId)
+			case len(from.GetItems()) > 0:
+				r.putCart(pipe, toUserId, buf)
+			}
+			return nil
+		})
 		return err
+	}, fromUserId, toUserId)
+	if err != nil {
+		return nil, err
 	}
-	return r.client.HSet(req.GetUserId(), []string{CartFieldName, buf.String()}).Err()
+	return cart, nil
 }
 
 func (r *redisClient) GetCart(userId string) (*pb.Cart, error) {
-	sugar.Infof("GetCart called with userId=%v", userId)
+	return loadCart(r.client, userId)
+}
 
-	value, err := r.client.HGet(userId, CartFieldName).Result()
+// hgetter is a redis client or transaction.
+type hgetter interface {
+	HGet(key, field string) *redis.StringCmd
+}
+
+func loadCart(r hgetter, userId string) (*pb.Cart, error) {
+	value, err := r.HGet(userId, CartFieldName).Result()
 	if err != nil && err != redis.Nil {
 		sugar.Error(err)
 		return nil, err
@@ -93,18 +204,136 @@ func (r *redisClient) GetCart(userId string) (*pb.Cart, error) {
 		}, nil
 	}
 
-	var cart pb.Cart
+	cart, err := decodeCart([]byte(value))
+	if err != nil {
+		sugar.Errorf("failed to decode the cart of userId=%v: %v", userId, err)
+		return nil, err
+	}
+	return cart, nil
+}
+
+func (r *redisClient) EmptyCart(userId string) error {
+	_, err := r.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		deleteCart(pipe, userId)
+		return nil
+	})
+	return err
+}
 
-	if err := decodeMsgPack([]byte(value), &cart); err != nil {
-		sugar.Errorf("value: %v, err: %v", value, err)
+// ClaimIdleCarts also forgets the carts that have expired, since redis
+// expires them without removing them from CartUpdatedKey.
+func (r *redisClient) ClaimIdleCarts(idleSince time.Time) ([]idleCart, error) {
+	if r.ttl > 0 {
+		expired := "(" + strconv.FormatInt(millis(now().Add(-r.ttl)), 10)
+		if err := r.client.ZRemRangeByScore(CartUpdatedKey, "-inf", expired).Err(); err != nil {
+			return nil, err
+		}
+	}
+	max := strconv.FormatInt(millis(idleSince), 10)
+	userIds, err := r.client.ZRangeByScore(CartUpdatedKey, &redis.ZRangeBy{Min: "-inf", Max: max}).Result()
+	if err != nil {
 		return nil, err
 	}
+	var idle []idleCart
+	for _, userId := range userIds {
+		c, err := r.claim(userId, idleSince)
+		if err != nil {
+			return idle, err
+		}
+		if c != nil {
+			idle = append(idle, *c)
+		}
+	}
+	return idle, nil
+}
 
-	return &cart, nil
+// claim removes the cart of a user from CartUpdatedKey if it is still idle,
+// and returns it if this call removed it. The cart is watched so that the
+// claim fails if it is updated meanwhile.
+func (r *redisClient) claim(userId string, idleSince time.Time) (*idleCart, error) {
+	var claimed *idleCart
+	err := r.client.Watch(func(tx *redis.Tx) error {
+		score, err := tx.ZScore(CartUpdatedKey, userId).Result()
+		if err == redis.Nil || (err == nil && int64(score) > millis(idleSince)) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		cart, err := loadCart(tx, userId)
+		if err != nil {
+			return err
+		}
+		var removed *redis.IntCmd
+		if _, err := tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			removed = pipe.ZRem(CartUpdatedKey, userId)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if removed.Val() == 1 {
+			claimed = &idleCart{Cart: cart, LastUpdated: time.Unix(0, int64(score)*int64(time.Millisecond))}
+		}
+		return nil
+	}, userId)
+	if err == redis.TxFailedErr {
+		// The cart was updated, so it is no longer idle.
+		return nil, nil
+	}
+	return claimed, err
 }
 
-func (r *redisClient) EmptyCart(userId string) error {
-	sugar.Infof("EmptyCart called with userId=%v", userId)
+// millis returns t in milliseconds since the epoch.
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
 
-	return r.client.HSet(userId, []string{CartFieldName, ""}).Err()
+// MigrateCarts rewrites the carts that are not in the current format. Carts
+// emptied before EmptyCart deleted them are deleted. The rewrite keeps the
+// expiration of a cart, and does not count as an update of it.
+func (r *redisClient) MigrateCarts(enc cartEncoding) (int, error) {
+	migrated := 0
+	iter := r.client.Scan(0, "*", 100).Iterator()
+	for iter.Next() {
+		key := iter.Val()
+		err := r.transact(func(tx *redis.Tx) error {
+			if t, err := tx.Type(key).Result(); err != nil || t != "hash" {
+				return err
+			}
+			value, err := tx.HGet(key, CartFieldName).Result()
+			if err == redis.Nil {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if isCurrentEncoding([]byte(value), enc) {
+				return nil
+			}
+			var buf []byte
+			if value != "" {
+				cart, err := decodeCart([]byte(value))
+				if err != nil {
+					return err
+				}
+				if buf, err = encodeCart(cart, enc); err != nil {
+					return err
+				}
+			}
+			_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+				if buf == nil {
+					deleteCart(pipe, key)
+				} else {
+					pipe.HSet(key, CartFieldName, buf)
+				}
+				return nil
+			})
+			if err == nil {
+				migrated++
+			}
+			return err
+		}, key)
+		if err != nil {
+			return migrated, fmt.Errorf("migrating the cart of userId=%v: %v", key, err)
+		}
+	}
+	return migrated, iter.Err()
 }
@@ -20,13 +20,16 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
+	"github.com/triplewy/microservices-demo/src/cartservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/status"
 )
 
@@ -55,50 +58,171 @@ func init() {
 }
 
 func main() {
+	migrate := flag.Bool("migrate", false, "rewrite the stored carts in the current format and exit")
 	flag.Parse()
 
 	defer zLogger.Sync()
 
+	var sink abandonedCartSink
+	if !*migrate {
+		var err error
+		if sink, err = abandonedCartSinkFromEnv(); err != nil {
+			sugar.Fatal(err)
+		}
+	}
+	store, err := newCartStore(sink != nil)
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	if *migrate {
+		m, ok := store.(cartMigrator)
+		if !ok {
+			sugar.Fatal("the cart store keeps no carts to migrate")
+		}
+		n, err := m.MigrateCarts(storeEncoding)
+		if err != nil {
+			sugar.Fatalf("migrated %d carts before failing: %v", n, err)
+		}
+		sugar.Infof("migrated %d carts", n)
+		return
+	}
+	if sink != nil {
+		after, err := positiveDurationEnv("ABANDONED_CART_AFTER", defaultAbandonedAfter)
+		if err != nil {
+			sugar.Fatal(err)
+		}
+		interval, err := positiveDurationEnv("ABANDONED_CART_CHECK_INTERVAL", defaultAbandonedCheckInterval)
+		if err != nil {
+			sugar.Fatal(err)
+		}
+		sugar.Infof("reporting carts idle for %v as abandoned", after)
+		d := &abandonedCartDetector{store: store, sink: sink, after: after}
+		go d.run(context.Background(), interval)
+	}
+
 	sugar.Infof("starting grpc server at :%s", port)
-	run(port)
+	run(port, store)
 	select {}
 }
 
-func run(port string) string {
+// positiveDurationEnv returns the duration in the environment variable name,
+// or def if it is not set.
+func positiveDurationEnv(name string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("environment variable %q is not a positive duration: %q", name, v)
+	}
+	return d, nil
+}
+
+func run(port string, store CartStore) string {
 	l, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		sugar.Fatal(err)
 	}
-	srv := grpc.NewServer()
-	svc := &cart{
-		redis: newRedisClient(redisAddr),
-	}
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
+	svc := &cart{store: store}
+	hs := health.NewServer()
+	go watchHealth(hs, store.Ping, "hipstershop.CartService")
 	pb.RegisterCartServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, hs)
+	pb.RegisterFaultServiceServer(srv, faults)
 	go srv.Serve(l)
 	return l.Addr().String()
 }
 
 type cart struct {
-	redis *redisClient
+	store CartStore
 }
 
 func (c *cart) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.Empty, error) {
-	return &pb.Empty{}, c.redis.AddItem(req)
+	if err := validateItem(req.GetUserId(), req.GetItem().GetProductId()); err != nil {
+		return nil, err
+	}
+	if req.GetItem().GetQuantity() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity must be positive, got %d", req.GetItem().GetQuantity())
+	}
+	sugar.Infof("AddItem called with userId=%v, productId=%v, quantity=%v", req.GetUserId(), req.GetItem().GetProductId(), req.GetItem().GetQuantity())
+	return &pb.Empty{}, storeError(c.store.AddItem(req.GetUserId(), req.GetItem().GetProductId(), req.GetItem().GetQuantity()))
 }
 
 func (c *cart) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.Cart, error) {
-	return c.redis.GetCart(req.GetUserId())
+	sugar.Infof("GetCart called with userId=%v", req.GetUserId())
+	return c.store.GetCart(req.GetUserId())
 }
 
 func (c *cart) EmptyCart(ctx context.Context, req *pb.EmptyCartRequest) (*pb.Empty, error) {
-	return &pb.Empty{}, c.redis.EmptyCart(req.GetUserId())
+	sugar.Infof("EmptyCart called with userId=%v", req.GetUserId())
+	return &pb.Empty{}, c.store.EmptyCart(req.GetUserId())
 }
 
-func (c *cart) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+func (c *cart) RemoveItem(ctx context.Context, req *pb.RemoveItemRequest) (*pb.Empty, error) {
+	if err := validateItem(req.GetUserId(), req.GetProductId()); err != nil {
+		return nil, err
+	}
+	sugar.Infof("RemoveItem called with userId=%v, productId=%v", req.GetUserId(), req.GetProductId())
+	return &pb.Empty{}, storeError(c.store.RemoveItem(req.GetUserId(), req.GetProductId()))
 }
 
-func (c *cart) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+func (c *cart) UpdateItemQuantity(ctx context.Context, req *pb.UpdateItemQuantityRequest) (*pb.Empty, error) {
+	if err := validateItem(req.GetUserId(), req.GetProductId()); err != nil {
+		return nil, err
+	}
+	if req.GetQuantity() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity must not be negative, got %d", req.GetQuantity())
+	}
+	if req.GetQuantity() > maxItemQuantity {
+		return nil, storeError(errQuantityTooLarge)
+	}
+	sugar.Infof("UpdateItemQuantity called with userId=%v, productId=%v, quantity=%v", req.GetUserId(), req.GetProductId(), req.GetQuantity())
+	err := c.store.UpdateItemQuantity(req.GetUserId(), req.GetProductId(), req.GetQuantity())
+	if err == errItemNotFound {
+		return nil, status.Errorf(codes.NotFound, "product %q is not in the cart", req.GetProductId())
+	}
+	return &pb.Empty{}, storeError(err)
+}
+
+func (c *cart) MergeCarts(ctx context.Context, req *pb.MergeCartsRequest) (*pb.Cart, error) {
+	switch {
+	case req.GetFromUserId() == "":
+		return nil, status.Error(codes.InvalidArgument, "from_user_id must be set")
+	case req.GetToUserId() == "":
+		return nil, status.Error(codes.InvalidArgument, "to_user_id must be set")
+	case req.GetFromUserId() == req.GetToUserId():
+		return nil, status.Error(codes.InvalidArgument, "cannot merge a cart into itself")
+	}
+	sugar.Infof("MergeCarts called with fromUserId=%v, toUserId=%v", req.GetFromUserId(), req.GetToUserId())
+	cart, err := c.store.MergeCarts(req.GetFromUserId(), req.GetToUserId())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return cart, nil
+}
+
+// storeError maps errCartContention to Aborted, which tells clients that the
+// request can be retried, and errQuantityTooLarge to InvalidArgument.
+func storeError(err error) error {
+	switch err {
+	case errCartContention:
+		return status.Error(codes.Aborted, err.Error())
+	case errQuantityTooLarge:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// validateItem checks that a request names a cart and a product.
+func validateItem(userId, productId string) error {
+	if userId == "" {
+		return status.Error(codes.InvalidArgument, "user_id must be set")
+	}
+	if productId == "" {
+		return status.Error(codes.InvalidArgument, "product_id must be set")
+	}
+	return nil
 }
@@ -0,0 +1,363 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+)
+
+// testStore is a cart store, with a function that moves its clock forward.
+type testStore struct {
+	CartStore
+	advance func(d time.Duration)
+}
+
+// testStores creates every kind of cart store, so that tests cover them all.
+var testStores = map[string]func(t *testing.T, ttl time.Duration) testStore{
+	"redis": func(t *testing.T, ttl time.Duration) testStore {
+		mr, err := miniredis.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(mr.Close)
+		r, err := newRedisClient(mr.Addr(), ttl, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return testStore{r, func(d time.Duration) {
+			advanceClock(t, d)
+			mr.FastForward(d)
+		}}
+	},
+	"memory": func(t *testing.T, ttl time.Duration) testStore {
+		return testStore{newMemoryStore(ttl), func(d time.Duration) { advanceClock(t, d) }}
+	},
+	"bolt": func(t *testing.T, ttl time.Duration) testStore {
+		b, err := newBoltStore(filepath.Join(t.TempDir(), "carts.db"), ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { b.Close() })
+		return testStore{b, func(d time.Duration) { advanceClock(t, d) }}
+	},
+}
+
+// advanceClock moves the clock of the stores forward by d until the end of
+// the test.
+func advanceClock(t *testing.T, d time.Duration) {
+	prev := now
+	at := now().Add(d)
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = prev })
+}
+
+// forEachStore runs test with a cart service backed by each kind of store.
+func forEachStore(t *testing.T, test func(t *testing.T, c *cart)) {
+	for name, newStore := range testStores {
+		t.Run(name, func(t *testing.T) {
+			test(t, &cart{store: newStore(t, 0)})
+		})
+	}
+}
+
+// items returns the quantity of every product in the cart of a user.
+func items(t *testing.T, c *cart, userId string) map[string]int32 {
+	t.Helper()
+	res, err := c.GetCart(context.Background(), &pb.GetCartRequest{UserId: userId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string]int32)
+	for _, item := range res.GetItems() {
+		out[item.GetProductId()] = item.GetQuantity()
+	}
+	return out
+}
+
+func TestUpdateCart(t *testing.T) {
+	forEachStore(t, testUpdateCart)
+}
+
+func testUpdateCart(t *testing.T, c *cart) {
+	ctx := context.Background()
+	for _, item := range []*pb.CartItem{
+		{ProductId: "a", Quantity: 1},
+		{ProductId: "b", Quantity: 2},
+		{ProductId: "a", Quantity: 3},
+	} {
+		if _, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: item}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := items(t, c, "u"), map[string]int32{"a": 4, "b": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after adding: cart = %v, want %v", got, want)
+	}
+
+	if _, err := c.UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{UserId: "u", ProductId: "b", Quantity: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := items(t, c, "u"), map[string]int32{"a": 4, "b": 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after updating: cart = %v, want %v", got, want)
+	}
+
+	if _, err := c.RemoveItem(ctx, &pb.RemoveItemRequest{UserId: "u", ProductId: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RemoveItem(ctx, &pb.RemoveItemRequest{UserId: "u", ProductId: "missing"}); err != nil {
+		t.Errorf("removing a missing product: %v", err)
+	}
+	if got, want := items(t, c, "u"), map[string]int32{"b": 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after removing: cart = %v, want %v", got, want)
+	}
+
+	if _, err := c.UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{UserId: "u", ProductId: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := items(t, c, "u"); len(got) != 0 {
+		t.Errorf("after setting the quantity to zero: cart = %v, want empty", got)
+	}
+}
+
+func TestCartValidation(t *testing.T) {
+	forEachStore(t, testCartValidation)
+}
+
+func testCartValidation(t *testing.T, c *cart) {
+	ctx := context.Background()
+	if _, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: "a", Quantity: 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, call := range map[string]func() error{
+		"add without user": func() error {
+			_, err := c.AddItem(ctx, &pb.AddItemRequest{Item: &pb.CartItem{ProductId: "a", Quantity: 1}})
+			return err
+		},
+		"add without product": func() error {
+			_, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{Quantity: 1}})
+			return err
+		},
+		"add zero": func() error {
+			_, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: "a"}})
+			return err
+		},
+		"add negative": func() error {
+			_, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: "a", Quantity: -1}})
+			return err
+		},
+		"add overflowing": func() error {
+			_, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: "a", Quantity: math.MaxInt32}})
+			return err
+		},
+		"add past the maximum": func() error {
+			_, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: "a", Quantity: maxItemQuantity}})
+			return err
+		},
+		"update past the maximum": func() error {
+			_, err := c.UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{UserId: "u", ProductId: "a", Quantity: maxItemQuantity + 1})
+			return err
+		},
+		"update negative": func() error {
+			_, err := c.UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{UserId: "u", ProductId: "a", Quantity: -1})
+			return err
+		},
+		"remove without product": func() error {
+			_, err := c.RemoveItem(ctx, &pb.RemoveItemRequest{UserId: "u"})
+			return err
+		},
+	} {
+		if err := call(); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+	}
+
+	_, err := c.UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{UserId: "u", ProductId: "missing", Quantity: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("updating a missing product: got %v, want NotFound", err)
+	}
+	if got, want := items(t, c, "u"), map[string]int32{"a": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after invalid requests: cart = %v, want %v", got, want)
+	}
+}
+
+func TestMergeCarts(t *testing.T) {
+	forEachStore(t, testMergeCarts)
+}
+
+func testMergeCarts(t *testing.T, c *cart) {
+	ctx := context.Background()
+	for _, req := range []*pb.AddItemRequest{
+		{UserId: "anon", Item: &pb.CartItem{ProductId: "a", Quantity: 1}},
+		{UserId: "anon", Item: &pb.CartItem{ProductId: "b", Quantity: 2}},
+		{UserId: "user", Item: &pb.CartItem{ProductId: "a", Quantity: 3}},
+		{UserId: "user", Item: &pb.CartItem{ProductId: "c", Quantity: 1}},
+	} {
+		if _, err := c.AddItem(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]int32{"a": 4, "b": 2, "c": 1}
+	res, err := c.MergeCarts(ctx, &pb.MergeCartsRequest{FromUserId: "anon", ToUserId: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.GetItems()) != len(want) {
+		t.Errorf("MergeCarts = %v, want %v", res.GetItems(), want)
+	}
+	if got := items(t, c, "user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("after merging: cart = %v, want %v", got, want)
+	}
+	if got := items(t, c, "anon"); len(got) != 0 {
+		t.Errorf("after merging: merged cart = %v, want empty", got)
+	}
+
+	// Merging an empty cart leaves the other one as it is.
+	if _, err := c.MergeCarts(ctx, &pb.MergeCartsRequest{FromUserId: "anon", ToUserId: "user"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := items(t, c, "user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("after merging an empty cart: cart = %v, want %v", got, want)
+	}
+
+	// A merge that would put too many of a product in the cart fails and
+	// leaves both carts as they are.
+	if _, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "anon", Item: &pb.CartItem{ProductId: "a", Quantity: maxItemQuantity}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.MergeCarts(ctx, &pb.MergeCartsRequest{FromUserId: "anon", ToUserId: "user"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("merging past the maximum quantity: got %v, want InvalidArgument", err)
+	}
+	if got := items(t, c, "user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("after a failed merge: cart = %v, want %v", got, want)
+	}
+	if got, want := items(t, c, "anon"), map[string]int32{"a": maxItemQuantity}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after a failed merge: merged cart = %v, want %v", got, want)
+	}
+
+	for _, req := range []*pb.MergeCartsRequest{
+		{ToUserId: "user"},
+		{FromUserId: "anon"},
+		{FromUserId: "user", ToUserId: "user"},
+	} {
+		if _, err := c.MergeCarts(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("MergeCarts(%v): got %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+// TestConcurrentAddItem checks that concurrent updates of a cart are not
+// lost. Updates that give up after too many conflicts must fail with Aborted
+// and leave the cart unchanged.
+func TestConcurrentAddItem(t *testing.T) {
+	forEachStore(t, testConcurrentAddItem)
+}
+
+func testConcurrentAddItem(t *testing.T, c *cart) {
+	ctx := context.Background()
+	const workers, adds = 16, 25
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added = make(map[string]int32)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				// Every worker adds to a shared product and to its own.
+				for _, id := range []string{"shared", fmt.Sprintf("own-%d", w)} {
+					_, err := c.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: id, Quantity: 1}})
+					if status.Code(err) == codes.Aborted {
+						continue
+					}
+					if err != nil {
+						t.Errorf("AddItem: %v", err)
+						continue
+					}
+					mu.Lock()
+					added[id]++
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if added["shared"] == 0 {
+		t.Fatal("every concurrent AddItem was aborted")
+	}
+	if got := items(t, c, "u"); !reflect.DeepEqual(got, added) {
+		t.Errorf("cart = %v, want %v", got, added)
+	}
+}
+
+// TestServer checks the cart service end to end over gRPC, with carts in
+// memory.
+func TestServer(t *testing.T) {
+	addr := run("0", newMemoryStore(0))
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewCartServiceClient(conn)
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "a"} {
+		if _, err := client.AddItem(ctx, &pb.AddItemRequest{UserId: "u", Item: &pb.CartItem{ProductId: id, Quantity: 1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := client.GetCart(ctx, &pb.GetCartRequest{UserId: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*pb.CartItem{{ProductId: "a", Quantity: 2}, {ProductId: "b", Quantity: 1}}
+	if len(res.GetItems()) != len(want) {
+		t.Fatalf("GetCart = %v, want %v", res.GetItems(), want)
+	}
+	for i, item := range res.GetItems() {
+		if item.GetProductId() != want[i].GetProductId() || item.GetQuantity() != want[i].GetQuantity() {
+			t.Errorf("item %d = %v, want %v", i, item, want[i])
+		}
+	}
+
+	if _, err := client.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: "u"}); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = client.GetCart(ctx, &pb.GetCartRequest{UserId: "u"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.GetItems()) != 0 {
+		t.Errorf("after EmptyCart: GetCart = %v, want empty", res.GetItems())
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+)
+
+const (
+	// defaultCartTTL is how long carts are kept after their last update.
+	defaultCartTTL = 30 * 24 * time.Hour
+	// maxItemQuantity is the largest quantity of a product a cart can hold.
+	maxItemQuantity = 1000
+)
+
+var (
+	// errItemNotFound is returned when updating a product that is not in a
+	// cart.
+	errItemNotFound = errors.New("item not found in cart")
+	// errCartContention is returned when a cart update keeps conflicting
+	// with concurrent updates of the same cart.
+	errCartContention = errors.New("too many concurrent updates of the cart")
+	// errQuantityTooLarge is returned when an update would put more than
+	// maxItemQuantity of a product in a cart.
+	errQuantityTooLarge = fmt.Errorf("a cart cannot hold more than %d of a product", maxItemQuantity)
+)
+
+// CartStore stores the carts of users. Every update of a cart is atomic.
+type CartStore interface {
+	// GetCart returns the cart of a user, which is empty if the user has
+	// none.
+	GetCart(userId string) (*pb.Cart, error)
+	// AddItem adds quantity to a product in the cart of a user.
+	AddItem(userId, productId string, quantity int32) error
+	// RemoveItem removes a product from the cart of a user, if it is in it.
+	RemoveItem(userId, productId string) error
+	// UpdateItemQuantity sets the quantity of a product in the cart of a
+	// user, or removes it if quantity is zero. It returns errItemNotFound if
+	// the product is not in the cart.
+	UpdateItemQuantity(userId, productId string, quantity int32) error
+	// EmptyCart deletes the cart of a user.
+	EmptyCart(userId string) error
+	// MergeCarts adds the items of the cart of fromUserId to the cart of
+	// toUserId, deletes the first cart and returns the second.
+	MergeCarts(fromUserId, toUserId string) (*pb.Cart, error)
+	// ClaimIdleCarts returns the carts last updated at or before idleSince,
+	// and marks them so that they are not returned again until they are
+	// updated. A cart is returned only to one of the callers claiming it at
+	// the same time.
+	ClaimIdleCarts(idleSince time.Time) ([]idleCart, error)
+	// Ping reports whether the store is reachable.
+	Ping(ctx context.Context) error
+}
+
+// cartMigrator is a store that can rewrite the carts it stores in the current
+// format.
+type cartMigrator interface {
+	// MigrateCarts rewrites every cart that is not in an envelope of the
+	// current schema version with its payload encoded with enc, and returns
+	// how many it rewrote.
+	MigrateCarts(enc cartEncoding) (int, error)
+}
+
+// idleCart is a cart that has not been updated for a while.
+type idleCart struct {
+	Cart        *pb.Cart
+	LastUpdated time.Time
+}
+
+// now returns the current time. Tests replace it to move the clock of the
+// stores.
+var now = time.Now
+
+// newCartStore returns the store selected by the CART_STORE environment
+// variable: "redis" (default) at REDIS_ADDR, "memory", or "bolt" in the file
+// CART_STORE_FILE. Carts expire CART_TTL after their last update, or never if
+// it is zero, and are written with the CART_ENCODING encoding. Unless
+// trackIdle is set, the redis store does not record when carts are updated,
+// as nothing claims idle carts.
+func newCartStore(trackIdle bool) (CartStore, error) {
+	enc, err := parseCartEncoding(os.Getenv("CART_ENCODING"))
+	if err != nil {
+		return nil, err
+	}
+	storeEncoding = enc
+	ttl := defaultCartTTL
+	if v := os.Getenv("CART_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("environment variable \"CART_TTL\" is not a non-negative duration: %q", v)
+		}
+		ttl = d
+	}
+	switch kind := os.Getenv("CART_STORE"); kind {
+	case "", "redis":
+		sugar.Infof("storing carts in redis at %s for %v", redisAddr, ttl)
+		return newRedisClient(redisAddr, ttl, trackIdle)
+	case "memory":
+		sugar.Infof("storing carts in memory for %v", ttl)
+		return newMemoryStore(ttl), nil
+	case "bolt":
+		path := os.Getenv("CART_STORE_FILE")
+		if path == "" {
+			path = defaultBoltFile
+		}
+		sugar.Infof("storing carts in %s for %v", path, ttl)
+		return newBoltStore(path, ttl)
+	default:
+		return nil, fmt.Errorf("unknown CART_STORE %q, want redis, memory or bolt", kind)
+	}
+}
+
+// The following functions apply an update to a cart, for the stores to run
+// atomically.
+
+func addItem(cart *pb.Cart, productId string, quantity int32) error {
+	if quantity > maxItemQuantity {
+		return errQuantityTooLarge
+	}
+	for _, item := range cart.Items {
+		if item.GetProductId() == productId {
+			// Both quantities are at most maxItemQuantity, so the sum
+			// cannot overflow.
+			if item.Quantity+quantity > maxItemQuantity {
+				return errQuantityTooLarge
+			}
+			item.Quantity += quantity
+			return nil
+		}
+	}
+	cart.Items = append(cart.Items, &pb.CartItem{ProductId: productId, Quantity: quantity})
+	return nil
+}
+
+func removeItem(cart *pb.Cart, productId string) error {
+	for i, item := range cart.Items {
+		if item.GetProductId() == productId {
+			cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func setItemQuantity(cart *pb.Cart, productId string, quantity int32) error {
+	for i, item := range cart.Items {
+		if item.GetProductId() == productId {
+			if quantity == 0 {
+				cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+			} else {
+				item.Quantity = quantity
+			}
+			return nil
+		}
+	}
+	return errItemNotFound
+}
+
+// mergeCart adds the items of from to cart. Items of from without a positive
+// quantity are dropped.
+func mergeCart(cart, from *pb.Cart) error {
+	for _, item := range from.GetItems() {
+		if item.GetQuantity() <= 0 {
+			continue
+		}
+		if err := addItem(cart, item.GetProductId(), item.GetQuantity()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoryStore keeps carts in memory. They are lost when the service stops.
+type memoryStore struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	carts map[string]*memoryCart
+}
+
+type memoryCart struct {
+	cart    *pb.Cart
+	updated time.Time
+	// claimed is set once ClaimIdleCarts returns the cart.
+	claimed bool
+}
+
+func newMemoryStore(ttl time.Duration) *memoryStore {
+	return &memoryStore{ttl: ttl, carts: make(map[string]*memoryCart)}
+}
+
+// get returns the cart of a user, deleting it if it has expired. m.mu must be
+// held.
+func (m *memoryStore) get(userId string) *memoryCart {
+	c, ok := m.carts[userId]
+	if !ok {
+		return nil
+	}
+	if m.ttl > 0 && now().Sub(c.updated) >= m.ttl {
+		delete(m.carts, userId)
+		return nil
+	}
+	return c
+}
+
+func (m *memoryStore) GetCart(userId string) (*pb.Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c := m.get(userId); c != nil {
+		return proto.Clone(c.cart).(*pb.Cart), nil
+	}
+	return &pb.Cart{UserId: userId}, nil
+}
+
+// update applies mutate to a copy of the cart of a user, and keeps the copy
+// if mutate succeeds.
+func (m *memoryStore) update(userId string, mutate func(cart *pb.Cart) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cart := &pb.Cart{UserId: userId}
+	if c := m.get(userId); c != nil {
+		cart = proto.Clone(c.cart).(*pb.Cart)
+	}
+	if err := mutate(cart); err != nil {
+		return err
+	}
+	if len(cart.GetItems()) == 0 {
+		delete(m.carts, userId)
+		return nil
+	}
+	m.carts[userId] = &memoryCart{cart: cart, updated: now()}
+	return nil
+}
+
+func (m *memoryStore) AddItem(userId, productId string, quantity int32) error {
+	return m.update(userId, func(cart *pb.Cart) error { return addItem(cart, productId, quantity) })
+}
+
+func (m *memoryStore) RemoveItem(userId, productId string) error {
+	return m.update(userId, func(cart *pb.Cart) error { return removeItem(cart, productId) })
+}
+
+func (m *memoryStore) UpdateItemQuantity(userId, productId string, quantity int32) error {
+	return m.update(userId, func(cart *pb.Cart) error { return setItemQuantity(cart, productId, quantity) })
+}
+
+func (m *memoryStore) EmptyCart(userId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.carts, userId)
+	return nil
+}
+
+func (m *memoryStore) MergeCarts(fromUserId, toUserId string) (*pb.Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cart := &pb.Cart{UserId: toUserId}
+	to := m.get(toUserId)
+	if to != nil {
+		cart = proto.Clone(to.cart).(*pb.Cart)
+	}
+	from := m.get(fromUserId)
+	if from == nil || len(from.cart.GetItems()) == 0 {
+		delete(m.carts, fromUserId)
+		return cart, nil
+	}
+	if err := mergeCart(cart, from.cart); err != nil {
+		return nil, err
+	}
+	delete(m.carts, fromUserId)
+	if len(cart.GetItems()) == 0 {
+		delete(m.carts, toUserId)
+		return cart, nil
+	}
+	m.carts[toUserId] = &memoryCart{cart: proto.Clone(cart).(*pb.Cart), updated: now()}
+	return cart, nil
+}
+
+func (m *memoryStore) ClaimIdleCarts(idleSince time.Time) ([]idleCart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var idle []idleCart
+	for userId := range m.carts {
+		c := m.get(userId)
+		if c == nil || c.claimed || c.updated.After(idleSince) {
+			continue
+		}
+		c.claimed = true
+		idle = append(idle, idleCart{Cart: proto.Clone(c.cart).(*pb.Cart), LastUpdated: c.updated})
+	}
+	return idle, nil
+}
+
+func (m *memoryStore) Ping(ctx context.Context) error { return nil }
@@ -0,0 +1,224 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/cartservice/genproto"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestCartExpiry(t *testing.T) {
+	for name, newStore := range testStores {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t, time.Hour)
+			if err := s.AddItem("u", "a", 1); err != nil {
+				t.Fatal(err)
+			}
+			s.advance(50 * time.Minute)
+			// Updating the cart refreshes its TTL.
+			if err := s.AddItem("u", "a", 1); err != nil {
+				t.Fatal(err)
+			}
+			s.advance(50 * time.Minute)
+			cart, err := s.GetCart("u")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cart.GetItems()) != 1 || cart.GetItems()[0].GetQuantity() != 2 {
+				t.Errorf("before the TTL: cart = %v, want 2 of a", cart.GetItems())
+			}
+
+			s.advance(time.Hour)
+			if cart, err = s.GetCart("u"); err != nil {
+				t.Fatal(err)
+			}
+			if len(cart.GetItems()) != 0 {
+				t.Errorf("after the TTL: cart = %v, want empty", cart.GetItems())
+			}
+			// An expired cart starts over when it is updated.
+			if err := s.AddItem("u", "b", 1); err != nil {
+				t.Fatal(err)
+			}
+			if cart, err = s.GetCart("u"); err != nil {
+				t.Fatal(err)
+			}
+			if len(cart.GetItems()) != 1 || cart.GetItems()[0].GetProductId() != "b" {
+				t.Errorf("after updating an expired cart: cart = %v, want 1 of b", cart.GetItems())
+			}
+		})
+	}
+}
+
+// claimedUsers returns the sorted user IDs of the carts that idle for d are
+// claimed.
+func claimedUsers(t *testing.T, s CartStore, d time.Duration) []string {
+	t.Helper()
+	idle, err := s.ClaimIdleCarts(now().Add(-d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var users []string
+	for _, c := range idle {
+		users = append(users, c.Cart.GetUserId())
+	}
+	sort.Strings(users)
+	return users
+}
+
+func TestClaimIdleCarts(t *testing.T) {
+	for name, newStore := range testStores {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t, 0)
+			for _, u := range []string{"idle", "active", "emptied"} {
+				if err := s.AddItem(u, "a", 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			s.advance(time.Hour)
+			if err := s.AddItem("active", "a", 1); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.EmptyCart("emptied"); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := claimedUsers(t, s, 30*time.Minute); len(got) != 1 || got[0] != "idle" {
+				t.Errorf("first claim = %v, want [idle]", got)
+			}
+			if got := claimedUsers(t, s, 30*time.Minute); len(got) != 0 {
+				t.Errorf("second claim = %v, want none", got)
+			}
+
+			// Updating a claimed cart makes it claimable again once idle.
+			if err := s.AddItem("idle", "b", 1); err != nil {
+				t.Fatal(err)
+			}
+			s.advance(time.Hour)
+			if got := claimedUsers(t, s, 30*time.Minute); len(got) != 2 || got[0] != "active" || got[1] != "idle" {
+				t.Errorf("claim after updates = %v, want [active idle]", got)
+			}
+		})
+	}
+}
+
+func TestRedisIdleTracking(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	// Updates are not recorded when nothing claims idle carts.
+	r, err := newRedisClient(mr.Addr(), time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddItem("u", "a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(CartUpdatedKey) {
+		t.Errorf("%s exists with idle tracking off", CartUpdatedKey)
+	}
+
+	// Expired carts are forgotten, even if they are not idle for long enough
+	// to be claimed.
+	r.trackIdle = true
+	if err := r.AddItem("u", "a", 1); err != nil {
+		t.Fatal(err)
+	}
+	advanceClock(t, 2*time.Hour)
+	mr.FastForward(2 * time.Hour)
+	if idle, err := r.ClaimIdleCarts(now().Add(-3 * time.Hour)); err != nil || len(idle) != 0 {
+		t.Fatalf("ClaimIdleCarts = %v, %v, want no carts", idle, err)
+	}
+	if n, err := r.client.ZCard(CartUpdatedKey).Result(); err != nil || n != 0 {
+		t.Errorf("%s has %d members after the cart expired, want 0 (%v)", CartUpdatedKey, n, err)
+	}
+
+	// Removing the last item deletes the cart and forgets it.
+	if err := r.AddItem("v", "a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RemoveItem("v", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists("v") {
+		t.Error("an emptied cart still exists")
+	}
+	if n, err := r.client.ZCard(CartUpdatedKey).Result(); err != nil || n != 0 {
+		t.Errorf("%s has %d members after the cart was emptied, want 0 (%v)", CartUpdatedKey, n, err)
+	}
+}
+
+// putRaw stores value as the cart of a user, bypassing the encoding of s.
+func putRaw(t *testing.T, s CartStore, userId string, value []byte) {
+	t.Helper()
+	var err error
+	switch s := s.(type) {
+	case *redisClient:
+		err = s.client.HSet(userId, CartFieldName, value).Err()
+	case *boltStore:
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(boltBucket).Put([]byte(userId), value)
+		})
+	default:
+		t.Fatalf("cannot store raw carts in %T", s)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrateCarts(t *testing.T) {
+	for _, name := range []string{"redis", "bolt"} {
+		t.Run(name, func(t *testing.T) {
+			s := testStores[name](t, 0)
+			putRaw(t, s.CartStore, "u", []byte(legacyCart))
+			if err := s.AddItem("v", "a", 1); err != nil {
+				t.Fatal(err)
+			}
+
+			m := s.CartStore.(cartMigrator)
+			if n, err := m.MigrateCarts(jsonEncoding); err != nil || n != 2 {
+				t.Fatalf("MigrateCarts(json) = %d, %v, want 2 carts", n, err)
+			}
+			if n, err := m.MigrateCarts(jsonEncoding); err != nil || n != 0 {
+				t.Fatalf("MigrateCarts(json) again = %d, %v, want 0 carts", n, err)
+			}
+			if n, err := m.MigrateCarts(protoEncoding); err != nil || n != 2 {
+				t.Fatalf("MigrateCarts(proto) = %d, %v, want 2 carts", n, err)
+			}
+
+			for userId, want := range map[string]*pb.Cart{
+				"u": testCart,
+				"v": {UserId: "v", Items: []*pb.CartItem{{ProductId: "a", Quantity: 1}}},
+			} {
+				got, err := s.GetCart(userId)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !proto.Equal(got, want) {
+					t.Errorf("after migrating: cart of %s = %v, want %v", userId, got, want)
+				}
+			}
+		})
+	}
+}
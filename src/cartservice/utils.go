@@ -5,19 +5,11 @@ import (
 	"github.com/hashicorp/go-msgpack/codec"
 )
 
-// Decode reverses the encode operation on a byte slice input
+// Decode reads a msgpack-encoded object from a byte slice input. Carts used to
+// be stored in msgpack, and decodeCart still reads them with it.
 func decodeMsgPack(buf []byte, out interface{}) error {
 	r := bytes.NewBuffer(buf)
 	hd := codec.MsgpackHandle{}
 	dec := codec.NewDecoder(r, &hd)
 	return dec.Decode(out)
 }
-
-// Encode writes an encoded object to a new bytes buffer
-func encodeMsgPack(in interface{}) (*bytes.Buffer, error) {
-	buf := bytes.NewBuffer(nil)
-	hd := codec.MsgpackHandle{}
-	enc := codec.NewEncoder(buf, &hd)
-	err := enc.Encode(in)
-	return buf, err
-}
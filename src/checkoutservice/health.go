@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthCheckInterval is how often dependencies are probed to derive the
+// serving status reported by the health service.
+const healthCheckInterval = 5 * time.Second
+
+// watchHealth runs probe every healthCheckInterval and reports the result as
+// the serving status of each of services. The status of the whole server, "",
+// stays SERVING for as long as the process is alive, so that liveness probes
+// do not restart it when a dependency fails. Watch streams on hs are
+// notified of every transition.
+func watchHealth(hs *health.Server, probe func(context.Context) error, services ...string) {
+	current := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckInterval)
+		err := probe(ctx)
+		cancel()
+
+		next := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			next = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if next != current {
+			if err != nil {
+				log.Warnf("health status changed to %v: %v", next, err)
+			} else {
+				log.Infof("health status changed to %v", next)
+			}
+			for _, s := range services {
+				hs.SetServingStatus(s, next)
+			}
+			current = next
+		}
+		time.Sleep(healthCheckInterval)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"container/list"
+	"context"
+	"os"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// idempotencyKeyHeader is the gRPC metadata key that carries the
+	// idempotency key of requests that do not set it in the message.
+	idempotencyKeyHeader = "idempotency-key"
+
+	defaultIdempotencyCacheSize = 10000
+)
+
+// idempotencyKey returns the idempotency key of a request: field if it is
+// set, otherwise the idempotency-key metadata of ctx.
+func idempotencyKey(ctx context.Context, field string) string {
+	if field != "" {
+		return field
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(idempotencyKeyHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+type indexEntry struct {
+	key     string
+	orderID string
+}
+
+// orderIndex maps the idempotency keys of the most recent orders to their
+// records in the order store, so that a replayed PlaceOrder returns the
+// order that was already placed. Keys are scoped by user.
+type orderIndex struct {
+	store *fileOrderStore
+
+	mu       sync.Mutex
+	size     int
+	entries  map[string]*list.Element // of *indexEntry
+	lru      *list.List               // most recently used first
+	inflight map[string]chan struct{}
+}
+
+func newOrderIndex(store *fileOrderStore, size int) *orderIndex {
+	return &orderIndex{
+		store:    store,
+		size:     size,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		inflight: make(map[string]chan struct{}),
+	}
+}
+
+func indexKey(userID, key string) string {
+	return userID + "/" + key
+}
+
+// seed indexes the most recent orders in the store that were placed with an
+// idempotency key, so that replays are recognized across restarts.
+func (ix *orderIndex) seed() error {
+	records, err := ix.store.all()
+	if err != nil {
+		return err
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].UpdatedAt.Before(records[j].UpdatedAt) })
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	for _, r := range records {
+		if r.IdempotencyKey != "" {
+			ix.add(indexKey(r.UserID, r.IdempotencyKey), r.OrderID)
+		}
+	}
+	return nil
+}
+
+// begin claims key for an order of userID. If an order was already placed
+// with the key, its record is returned. Otherwise the caller places the order
+// and must call done with its ID, or with "" if no order was created.
+// Concurrent calls with the same key wait until done is called.
+func (ix *orderIndex) begin(ctx context.Context, userID, key string) (*orderRecord, func(orderID string), error) {
+	k := indexKey(userID, key)
+	for {
+		ix.mu.Lock()
+		ch, busy := ix.inflight[k]
+		if !busy {
+			break
+		}
+		ix.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+			}
+			return nil, nil, status.Error(codes.Canceled, ctx.Err().Error())
+		}
+	}
+	defer ix.mu.Unlock()
+
+	if el, ok := ix.entries[k]; ok {
+		ix.lru.MoveToFront(el)
+		orderID := el.Value.(*indexEntry).orderID
+		r, err := ix.store.load(orderID)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return nil, nil, status.Errorf(codes.Internal, "failed to load order %s: %+v", orderID, err)
+		case r.State == orderCompleted:
+			return r, nil, nil
+		case !r.State.terminal():
+			return nil, nil, status.Errorf(codes.Aborted, "order %s with idempotency key %q is still being processed", orderID, key)
+		}
+		// The order was rolled back, so it can be placed again.
+	}
+
+	ch := make(chan struct{})
+	ix.inflight[k] = ch
+	done := func(orderID string) {
+		ix.mu.Lock()
+		if orderID != "" {
+			ix.add(k, orderID)
+		}
+		delete(ix.inflight, k)
+		ix.mu.Unlock()
+		close(ch)
+	}
+	return nil, done, nil
+}
+
+// add points k to orderID and evicts the least recently used keys beyond the
+// size of the index. ix.mu must be held.
+func (ix *orderIndex) add(k, orderID string) {
+	if el, ok := ix.entries[k]; ok {
+		el.Value.(*indexEntry).orderID = orderID
+		ix.lru.MoveToFront(el)
+		return
+	}
+	ix.entries[k] = ix.lru.PushFront(&indexEntry{key: k, orderID: orderID})
+	for ix.lru.Len() > ix.size {
+		el := ix.lru.Back()
+		ix.lru.Remove(el)
+		delete(ix.entries, el.Value.(*indexEntry).key)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// placeTestOrder claims key for user-1 and stores an order in state.
+func placeTestOrder(t *testing.T, ix *orderIndex, key, orderID string, state orderState) {
+	t.Helper()
+	prev, done, err := ix.begin(context.Background(), "user-1", key)
+	if err != nil || prev != nil {
+		t.Fatalf("begin(%q) = %v, %v, want a new claim", key, prev, err)
+	}
+	r := &orderRecord{OrderID: orderID, UserID: "user-1", IdempotencyKey: key, State: state}
+	if err := ix.store.save(r); err != nil {
+		t.Fatal(err)
+	}
+	done(orderID)
+}
+
+func TestOrderIndexReplay(t *testing.T) {
+	ix := newOrderIndex(newTestStore(t), 10)
+	ctx := context.Background()
+	placeTestOrder(t, ix, "key-1", "order-1", orderCompleted)
+
+	prev, _, err := ix.begin(ctx, "user-1", "key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev == nil || prev.OrderID != "order-1" {
+		t.Fatalf("replay returned %v, want order-1", prev)
+	}
+
+	// Keys are scoped by user.
+	prev, done, err := ix.begin(ctx, "user-2", "key-1")
+	if err != nil || prev != nil {
+		t.Fatalf("begin for another user = %v, %v, want a new claim", prev, err)
+	}
+	done("")
+
+	// A seeded index recognizes the order after a restart.
+	restarted := newOrderIndex(ix.store, 10)
+	if err := restarted.seed(); err != nil {
+		t.Fatal(err)
+	}
+	if prev, _, err := restarted.begin(ctx, "user-1", "key-1"); err != nil || prev == nil {
+		t.Errorf("replay after restart = %v, %v, want order-1", prev, err)
+	}
+}
+
+func TestOrderIndexRolledBack(t *testing.T) {
+	ix := newOrderIndex(newTestStore(t), 10)
+	placeTestOrder(t, ix, "key-1", "order-1", orderRolledBack)
+
+	prev, done, err := ix.begin(context.Background(), "user-1", "key-1")
+	if err != nil || prev != nil {
+		t.Fatalf("begin after rollback = %v, %v, want a new claim", prev, err)
+	}
+	done("")
+}
+
+func TestOrderIndexIncomplete(t *testing.T) {
+	ix := newOrderIndex(newTestStore(t), 10)
+	placeTestOrder(t, ix, "key-1", "order-1", orderAuthorized)
+
+	if _, _, err := ix.begin(context.Background(), "user-1", "key-1"); status.Code(err) != codes.Aborted {
+		t.Errorf("begin for an incomplete order: got %v, want Aborted", err)
+	}
+}
+
+func TestOrderIndexConcurrent(t *testing.T) {
+	ix := newOrderIndex(newTestStore(t), 10)
+	ctx := context.Background()
+	_, done, err := ix.begin(ctx, "user-1", "key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := make(chan *orderRecord)
+	go func() {
+		prev, _, err := ix.begin(ctx, "user-1", "key-1")
+		if err != nil {
+			t.Error(err)
+		}
+		replayed <- prev
+	}()
+
+	select {
+	case <-replayed:
+		t.Fatal("concurrent begin returned before the first order was done")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := ix.store.save(&orderRecord{OrderID: "order-1", UserID: "user-1", State: orderCompleted}); err != nil {
+		t.Fatal(err)
+	}
+	done("order-1")
+	if prev := <-replayed; prev == nil || prev.OrderID != "order-1" {
+		t.Errorf("concurrent begin returned %v, want order-1", prev)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, done, _ = ix.begin(ctx, "user-1", "key-2")
+	defer done("")
+	if _, _, err := ix.begin(timeout, "user-1", "key-2"); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("begin while in flight: got %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestOrderIndexEviction(t *testing.T) {
+	ix := newOrderIndex(newTestStore(t), 2)
+	for i, key := range []string{"a", "b", "c"} {
+		placeTestOrder(t, ix, key, "order-"+key, orderCompleted)
+		if got := ix.lru.Len(); got > 2 {
+			t.Fatalf("index holds %d keys after %d orders, want at most 2", got, i+1)
+		}
+	}
+	prev, done, err := ix.begin(context.Background(), "user-1", "a")
+	if err != nil || prev != nil {
+		t.Errorf("begin for an evicted key = %v, %v, want a new claim", prev, err)
+	}
+	done("")
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, "from-metadata"))
+	if got := idempotencyKey(ctx, ""); got != "from-metadata" {
+		t.Errorf("idempotencyKey from metadata = %q", got)
+	}
+	if got := idempotencyKey(ctx, "from-field"); got != "from-field" {
+		t.Errorf("idempotencyKey with field = %q, want the field to take precedence", got)
+	}
+	if got := idempotencyKey(context.Background(), ""); got != "" {
+		t.Errorf("idempotencyKey without key = %q, want empty", got)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc/metadata"
@@ -27,8 +29,11 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 
+	"github.com/triplewy/microservices-demo/src/checkoutservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/checkoutservice/genproto"
 	money "github.com/triplewy/microservices-demo/src/checkoutservice/money"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -37,6 +42,16 @@ import (
 const (
 	listenPort  = "5050"
 	usdCurrency = "USD"
+
+	defaultCallTimeout      = 5 * time.Second
+	defaultKeepaliveTime    = 5 * time.Minute
+	defaultKeepaliveTimeout = 20 * time.Second
+	defaultMaxBackoffDelay  = 10 * time.Second
+
+	// defaultOrderRetention is how long the records of finished orders are
+	// kept, during which they can be replayed by idempotency key.
+	defaultOrderRetention = 24 * time.Hour
+	orderPruneInterval    = time.Hour
 )
 
 var log *logrus.Logger
@@ -56,12 +71,36 @@ func init() {
 }
 
 type checkoutService struct {
-	productCatalogSvcAddr string
-	cartSvcAddr           string
-	currencySvcAddr       string
-	shippingSvcAddr       string
-	emailSvcAddr          string
-	paymentSvcAddr        string
+	productCatalogSvcAddr    string
+	productCatalogSvcConn    *grpc.ClientConn
+	productCatalogSvcTimeout time.Duration
+
+	cartSvcAddr    string
+	cartSvcConn    *grpc.ClientConn
+	cartSvcTimeout time.Duration
+
+	currencySvcAddr    string
+	currencySvcConn    *grpc.ClientConn
+	currencySvcTimeout time.Duration
+
+	shippingSvcAddr    string
+	shippingSvcConn    *grpc.ClientConn
+	shippingSvcTimeout time.Duration
+
+	emailSvcAddr    string
+	emailSvcConn    *grpc.ClientConn
+	emailSvcTimeout time.Duration
+
+	paymentSvcAddr    string
+	paymentSvcConn    *grpc.ClientConn
+	paymentSvcTimeout time.Duration
+
+	orderSvcAddr    string
+	orderSvcConn    *grpc.ClientConn
+	orderSvcTimeout time.Duration
+
+	orders      *fileOrderStore
+	idempotency *orderIndex
 }
 
 func main() {
@@ -77,16 +116,87 @@ func main() {
 	mustMapEnv(&svc.currencySvcAddr, "CURRENCY_SERVICE_ADDR")
 	mustMapEnv(&svc.emailSvcAddr, "EMAIL_SERVICE_ADDR")
 	mustMapEnv(&svc.paymentSvcAddr, "PAYMENT_SERVICE_ADDR")
+	mustMapEnv(&svc.orderSvcAddr, "ORDER_SERVICE_ADDR")
+
+	mustMapEnvDuration(&svc.shippingSvcTimeout, "SHIPPING_SERVICE_TIMEOUT", defaultCallTimeout)
+	mustMapEnvDuration(&svc.productCatalogSvcTimeout, "PRODUCT_CATALOG_SERVICE_TIMEOUT", defaultCallTimeout)
+	mustMapEnvDuration(&svc.cartSvcTimeout, "CART_SERVICE_TIMEOUT", defaultCallTimeout)
+	mustMapEnvDuration(&svc.currencySvcTimeout, "CURRENCY_SERVICE_TIMEOUT", defaultCallTimeout)
+	mustMapEnvDuration(&svc.emailSvcTimeout, "EMAIL_SERVICE_TIMEOUT", defaultCallTimeout)
+	mustMapEnvDuration(&svc.paymentSvcTimeout, "PAYMENT_SERVICE_TIMEOUT", defaultCallTimeout)
+	mustMapEnvDuration(&svc.orderSvcTimeout, "ORDER_SERVICE_TIMEOUT", defaultCallTimeout)
+
+	var keepaliveTime, keepaliveTimeout, maxBackoffDelay time.Duration
+	mustMapEnvDuration(&keepaliveTime, "GRPC_KEEPALIVE_TIME", defaultKeepaliveTime)
+	mustMapEnvDuration(&keepaliveTimeout, "GRPC_KEEPALIVE_TIMEOUT", defaultKeepaliveTimeout)
+	mustMapEnvDuration(&maxBackoffDelay, "GRPC_MAX_BACKOFF_DELAY", defaultMaxBackoffDelay)
+	dialOpts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor),
+		grpc.WithBackoffConfig(grpc.BackoffConfig{MaxDelay: maxBackoffDelay}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}),
+	}
+
+	ctx := context.Background()
+	mustConnGRPC(ctx, &svc.shippingSvcConn, svc.shippingSvcAddr, dialOpts...)
+	mustConnGRPC(ctx, &svc.productCatalogSvcConn, svc.productCatalogSvcAddr, dialOpts...)
+	mustConnGRPC(ctx, &svc.cartSvcConn, svc.cartSvcAddr, dialOpts...)
+	mustConnGRPC(ctx, &svc.currencySvcConn, svc.currencySvcAddr, dialOpts...)
+	mustConnGRPC(ctx, &svc.emailSvcConn, svc.emailSvcAddr, dialOpts...)
+	mustConnGRPC(ctx, &svc.paymentSvcConn, svc.paymentSvcAddr, dialOpts...)
+	mustConnGRPC(ctx, &svc.orderSvcConn, svc.orderSvcAddr, dialOpts...)
+
+	orderStoreDir := filepath.Join(os.TempDir(), "checkoutservice", "orders")
+	if os.Getenv("ORDER_STORE_DIR") != "" {
+		orderStoreDir = os.Getenv("ORDER_STORE_DIR")
+	}
+	orders, err := newFileOrderStore(orderStoreDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	svc.orders = orders
+	var orderRetention time.Duration
+	mustMapEnvDuration(&orderRetention, "ORDER_RETENTION", defaultOrderRetention)
+	if err := orders.prune(time.Now().Add(-orderRetention)); err != nil {
+		log.Fatal(err)
+	}
+	go orders.pruneEvery(context.Background(), orderPruneInterval, orderRetention)
+
+	idempotencyCacheSize := defaultIdempotencyCacheSize
+	if v := os.Getenv("IDEMPOTENCY_CACHE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			panic(fmt.Sprintf("environment variable %q is not a positive integer", "IDEMPOTENCY_CACHE_SIZE"))
+		}
+		idempotencyCacheSize = n
+	}
+	svc.idempotency = newOrderIndex(orders, idempotencyCacheSize)
+	if err := svc.idempotency.seed(); err != nil {
+		log.Fatal(err)
+	}
 
 	log.Infof("service config: %+v", svc)
 
+	go func() {
+		if err := svc.recoverOrders(); err != nil {
+			log.Errorf("failed to recover orders: %+v", err)
+		}
+	}()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		log.Fatal(err)
 	}
-	srv := grpc.NewServer()
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
+	hs := health.NewServer()
+	go watchHealth(hs, svc.checkDownstreams, "hipstershop.CheckoutService")
 	pb.RegisterCheckoutServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, hs)
+	pb.RegisterFaultServiceServer(srv, faults)
 	log.Infof("starting to listen on tcp: %q", lis.Addr().String())
 	err = srv.Serve(lis)
 	log.Fatal(err)
@@ -100,22 +210,110 @@ func mustMapEnv(target *string, envKey string) {
 	*target = v
 }
 
-func (cs *checkoutService) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+// mustMapEnvDuration sets target from the duration in envKey, or to def if
+// envKey is not set.
+func mustMapEnvDuration(target *time.Duration, envKey string, def time.Duration) {
+	v := os.Getenv(envKey)
+	if v == "" {
+		*target = def
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		panic(fmt.Sprintf("environment variable %q is not a duration: %v", envKey, err))
+	}
+	*target = d
+}
+
+// mustConnGRPC creates a long-lived connection to addr. The connection is
+// established in the background and re-established with backoff whenever it
+// breaks, so downstreams do not have to be up when the service starts.
+func mustConnGRPC(ctx context.Context, conn **grpc.ClientConn, addr string, opts ...grpc.DialOption) {
+	var err error
+	*conn, err = grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("grpc: failed to connect %s: %+v", addr, err))
+	}
+}
+
+// checkDownstreams reports whether every service that PlaceOrder depends on
+// is reachable and serving. The order and email services are not checked,
+// since orders are placed even if they cannot be recorded or confirmed.
+func (cs *checkoutService) checkDownstreams(ctx context.Context) error {
+	for name, conn := range map[string]*grpc.ClientConn{
+		"product catalog": cs.productCatalogSvcConn,
+		"cart":            cs.cartSvcConn,
+		"currency":        cs.currencySvcConn,
+		"shipping":        cs.shippingSvcConn,
+		"payment":         cs.paymentSvcConn,
+	} {
+		if err := checkHealth(ctx, conn); err != nil {
+			return fmt.Errorf("%s service: %+v", name, err)
+		}
+	}
+	return nil
 }
 
-func (cs *checkoutService) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+func checkHealth(ctx context.Context, conn *grpc.ClientConn) error {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("health check failed: %+v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("status is %v", resp.GetStatus())
+	}
+	return nil
 }
 
 func (cs *checkoutService) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
 	log.Infof("[PlaceOrder] user_id=%q user_currency=%q", req.UserId, req.UserCurrency)
 
+	key := idempotencyKey(ctx, req.IdempotencyKey)
+	var done func(orderID string)
+	if key != "" {
+		prev, d, err := cs.idempotency.begin(ctx, req.UserId, key)
+		if err != nil {
+			return nil, err
+		}
+		if prev != nil {
+			log.Infof("replaying order %s for idempotency key %q", prev.OrderID, key)
+			return &pb.PlaceOrderResponse{Order: prev.result()}, nil
+		}
+		done = d
+	}
+
+	r, err := cs.placeOrder(ctx, req, key)
+	if done != nil {
+		var orderID string
+		if r != nil {
+			orderID = r.OrderID
+		}
+		done(orderID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	orderResult := r.result()
+	cs.recordOrder(ctx, r)
+	cs.confirmOrder(ctx, r.Email, orderResult)
+	resp := &pb.PlaceOrderResponse{Order: orderResult}
+	return resp, nil
+}
+
+// placeOrder prepares the order of req and runs its saga. The record is
+// returned whenever it was stored, even if the order failed.
+func (cs *checkoutService) placeOrder(ctx context.Context, req *pb.PlaceOrderRequest, idempotencyKey string) (*orderRecord, error) {
 	orderID, err := uuid.NewUUID()
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate order uuid")
 	}
 
+	cardToken, err := cs.cardToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	prep, err := cs.prepareOrderItemsAndShippingQuoteFromCart(ctx, req.UserId, req.UserCurrency, req.Address)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
@@ -129,34 +327,159 @@ func (cs *checkoutService) PlaceOrder(ctx context.Context, req *pb.PlaceOrderReq
 		total = money.Must(money.Sum(total, *it.Cost))
 	}
 
-	txID, err := cs.chargeCard(ctx, &total, req.CreditCard)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to charge card: %+v", err)
+	r := &orderRecord{
+		OrderID:        orderID.String(),
+		UserID:         req.UserId,
+		UserCurrency:   req.UserCurrency,
+		Email:          req.Email,
+		Address:        req.Address,
+		CartItems:      prep.cartItems,
+		OrderItems:     prep.orderItems,
+		ShippingCost:   prep.shippingCostLocalized,
+		Total:          &total,
+		IdempotencyKey: idempotencyKey,
+		State:          orderPending,
 	}
-	log.Infof("payment went through (transaction_id: %s)", txID)
+	s := &saga{store: cs.orders, steps: cs.orderSteps(cardToken)}
+	return r, s.run(ctx, r)
+}
 
-	shippingTrackingID, err := cs.shipOrder(ctx, req.Address, prep.cartItems)
-	if err != nil {
-		return nil, status.Errorf(codes.Unavailable, "shipping error: %+v", err)
+// orderSteps returns the side effects of placing an order. The cart is
+// emptied first so that the same cart cannot be ordered twice concurrently.
+// The payment is authorized before the order is shipped, and only captured
+// once it is. Authorizations and shipments are made idempotently under the
+// order ID, by which they are also released if their outcome is unknown.
+// cardToken is empty when an order is recovered, in which case it
+// can no longer be authorized.
+func (cs *checkoutService) orderSteps(cardToken string) []sagaStep {
+	return []sagaStep{
+		{
+			name:  "empty cart",
+			state: orderCartEmptied,
+			action: func(ctx context.Context, r *orderRecord) error {
+				if err := cs.emptyUserCart(ctx, r.UserID); err != nil {
+					return status.Errorf(codes.Internal, "failed to empty cart: %+v", err)
+				}
+				return nil
+			},
+			compensate: func(ctx context.Context, r *orderRecord) error {
+				return cs.restoreUserCart(ctx, r.UserID, r.CartItems)
+			},
+		},
+		{
+			name:  "authorize payment",
+			state: orderAuthorized,
+			action: func(ctx context.Context, r *orderRecord) error {
+				if cardToken == "" {
+					return status.Errorf(codes.FailedPrecondition, "credit card of order %s is not available", r.OrderID)
+				}
+				txID, err := cs.authorizePayment(ctx, r, cardToken)
+				if err != nil {
+					return paymentError(err, "failed to authorize payment")
+				}
+				log.Infof("payment authorized (transaction_id: %s)", txID)
+				r.TransactionID = txID
+				return nil
+			},
+			compensate: func(ctx context.Context, r *orderRecord) error {
+				return cs.releasePayment(ctx, r)
+			},
+			compensateUnfinished: true,
+		},
+		{
+			name:  "ship order",
+			state: orderShipped,
+			action: func(ctx context.Context, r *orderRecord) error {
+				trackingID, err := cs.shipOrder(ctx, r)
+				if err != nil {
+					return status.Errorf(codes.Unavailable, "shipping error: %+v", err)
+				}
+				r.TrackingID = trackingID
+				return nil
+			},
+			compensate: func(ctx context.Context, r *orderRecord) error {
+				return cs.cancelShipment(ctx, r)
+			},
+			compensateUnfinished: true,
+		},
+		{
+			name:  "capture payment",
+			state: orderCaptured,
+			action: func(ctx context.Context, r *orderRecord) error {
+				if err := cs.capturePayment(ctx, r.TransactionID, r.Total); err != nil {
+					return paymentError(err, "failed to capture payment")
+				}
+				log.Infof("payment captured (transaction_id: %s)", r.TransactionID)
+				return nil
+			},
+			compensate: func(ctx context.Context, r *orderRecord) error {
+				return cs.releasePayment(ctx, r)
+			},
+		},
 	}
+}
 
-	_ = cs.emptyUserCart(ctx, req.UserId)
-
-	orderResult := &pb.OrderResult{
-		OrderId:            orderID.String(),
-		ShippingTrackingId: shippingTrackingID,
-		ShippingCost:       prep.shippingCostLocalized,
-		ShippingAddress:    req.Address,
-		Items:              prep.orderItems,
+// recordOrder adds the completed order r to the order history. Failing to do
+// so does not fail the order.
+func (cs *checkoutService) recordOrder(ctx context.Context, r *orderRecord) {
+	ctx, cancel := context.WithTimeout(ctx, cs.orderSvcTimeout)
+	defer cancel()
+
+	_, err := pb.NewOrderServiceClient(cs.orderSvcConn).AddOrder(ctx, &pb.AddOrderRequest{
+		Order: &pb.Order{
+			Result:   r.result(),
+			UserId:   r.UserID,
+			Email:    r.Email,
+			Total:    r.Total,
+			PlacedAt: r.UpdatedAt.Unix(),
+		}})
+	if err != nil {
+		log.Warnf("failed to record order %s: %+v", r.OrderID, err)
 	}
+}
 
-	if err := cs.sendOrderConfirmation(ctx, req.Email, orderResult); err != nil {
-		log.Warnf("failed to send order confirmation to %q: %+v", req.Email, err)
+// confirmOrder sends the order confirmation email. Failing to do so does not
+// fail the order.
+func (cs *checkoutService) confirmOrder(ctx context.Context, email string, order *pb.OrderResult) {
+	if err := cs.sendOrderConfirmation(ctx, email, order); err != nil {
+		log.Warnf("failed to send order confirmation to %q: %+v", email, err)
 	} else {
-		log.Infof("order confirmation email sent to %q", req.Email)
+		log.Infof("order confirmation email sent to %q", email)
 	}
-	resp := &pb.PlaceOrderResponse{Order: orderResult}
-	return resp, nil
+}
+
+// recoverOrders finishes the orders that were interrupted by a crash. Orders
+// that were shipped are completed; all others are rolled back, since the
+// customer was never told that they went through.
+func (cs *checkoutService) recoverOrders() error {
+	records, err := cs.orders.incomplete()
+	if err != nil {
+		return err
+	}
+	s := &saga{store: cs.orders, steps: cs.orderSteps("")}
+	for _, r := range records {
+		log.Infof("recovering order %s in state %s (rolling back: %v)", r.OrderID, r.State, r.RollingBack)
+		if r.State == orderCharged {
+			if err := cs.refundCharge(context.Background(), r); err != nil {
+				log.Errorf("order %s: failed to refund its charge, which must be refunded by hand: %+v", r.OrderID, err)
+				continue
+			}
+			r.State = orderCartEmptied
+		}
+		if r.RollingBack || r.State < orderShipped {
+			if err := s.rollback(r); err != nil {
+				log.Errorf("order %s: rollback failed: %+v", r.OrderID, err)
+			}
+			continue
+		}
+		if err := s.run(context.Background(), r); err != nil {
+			log.Errorf("order %s: failed to resume: %+v", r.OrderID, err)
+			continue
+		}
+		cs.recordOrder(context.Background(), r)
+		cs.confirmOrder(context.Background(), r.Email, r.result())
+	}
+	return nil
 }
 
 type orderPrep struct {
@@ -191,15 +514,10 @@ func (cs *checkoutService) prepareOrderItemsAndShippingQuoteFromCart(ctx context
 }
 
 func (cs *checkoutService) quoteShipping(ctx context.Context, address *pb.Address, items []*pb.CartItem) (*pb.Money, error) {
-	conn, err := grpc.DialContext(ctx, cs.shippingSvcAddr,
-		grpc.WithInsecure(),
-		grpc.WithUnaryInterceptor(UnaryClientInterceptor))
-	if err != nil {
-		return nil, fmt.Errorf("could not connect shipping service: %+v", err)
-	}
-	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, cs.shippingSvcTimeout)
+	defer cancel()
 
-	shippingQuote, err := pb.NewShippingServiceClient(conn).
+	shippingQuote, err := pb.NewShippingServiceClient(cs.shippingSvcConn).
 		GetQuote(ctx, &pb.GetQuoteRequest{
 			Address: address,
 			Items:   items})
@@ -210,13 +528,10 @@ func (cs *checkoutService) quoteShipping(ctx context.Context, address *pb.Addres
 }
 
 func (cs *checkoutService) getUserCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
-	conn, err := grpc.DialContext(ctx, cs.cartSvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
-	if err != nil {
-		return nil, fmt.Errorf("could not connect cart service: %+v", err)
-	}
-	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, cs.cartSvcTimeout)
+	defer cancel()
 
-	cart, err := pb.NewCartServiceClient(conn).GetCart(ctx, &pb.GetCartRequest{UserId: userID})
+	cart, err := pb.NewCartServiceClient(cs.cartSvcConn).GetCart(ctx, &pb.GetCartRequest{UserId: userID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user cart during checkout: %+v", err)
 	}
@@ -224,108 +539,281 @@ func (cs *checkoutService) getUserCart(ctx context.Context, userID string) ([]*p
 }
 
 func (cs *checkoutService) emptyUserCart(ctx context.Context, userID string) error {
-	conn, err := grpc.DialContext(ctx, cs.cartSvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
-	if err != nil {
-		return fmt.Errorf("could not connect cart service: %+v", err)
-	}
-	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, cs.cartSvcTimeout)
+	defer cancel()
 
-	if _, err = pb.NewCartServiceClient(conn).EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID}); err != nil {
+	if _, err := pb.NewCartServiceClient(cs.cartSvcConn).EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID}); err != nil {
 		return fmt.Errorf("failed to empty user cart during checkout: %+v", err)
 	}
 	return nil
 }
 
-func (cs *checkoutService) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, error) {
-	out := make([]*pb.OrderItem, len(items))
-
-	conn, err := grpc.DialContext(ctx, cs.productCatalogSvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
-	if err != nil {
-		return nil, fmt.Errorf("could not connect product catalog service: %+v", err)
+// restoreUserCart puts items back into the cart of userID.
+func (cs *checkoutService) restoreUserCart(ctx context.Context, userID string, items []*pb.CartItem) error {
+	cl := pb.NewCartServiceClient(cs.cartSvcConn)
+	for _, item := range items {
+		if err := cs.addCartItem(ctx, cl, userID, item); err != nil {
+			return fmt.Errorf("failed to restore user cart: %+v", err)
+		}
 	}
-	defer conn.Close()
-	cl := pb.NewProductCatalogServiceClient(conn)
+	return nil
+}
+
+func (cs *checkoutService) addCartItem(ctx context.Context, cl pb.CartServiceClient, userID string, item *pb.CartItem) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.cartSvcTimeout)
+	defer cancel()
+	_, err := cl.AddItem(ctx, &pb.AddItemRequest{UserId: userID, Item: item})
+	return err
+}
+
+func (cs *checkoutService) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, error) {
+	cl := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn)
 
+	pricesUSD := make([]*pb.Money, len(items))
 	for i, item := range items {
-		product, err := cl.GetProduct(ctx, &pb.GetProductRequest{Id: item.GetProductId()})
+		product, err := cs.getProduct(ctx, cl, item.GetProductId())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get product #%q", item.GetProductId())
 		}
-		price, err := cs.convertCurrency(ctx, product.GetPriceUsd(), userCurrency)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
-		}
+		pricesUSD[i] = product.GetPriceUsd()
+	}
+	prices, err := cs.convertCurrencies(ctx, pricesUSD, userCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert prices to %s: %+v", userCurrency, err)
+	}
+
+	out := make([]*pb.OrderItem, len(items))
+	for i, item := range items {
 		out[i] = &pb.OrderItem{
 			Item: item,
-			Cost: price}
+			Cost: prices[i]}
 	}
 	return out, nil
 }
 
-func (cs *checkoutService) convertCurrency(ctx context.Context, from *pb.Money, toCurrency string) (*pb.Money, error) {
-	conn, err := grpc.DialContext(ctx, cs.currencySvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
+func (cs *checkoutService) getProduct(ctx context.Context, cl pb.ProductCatalogServiceClient, id string) (*pb.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, cs.productCatalogSvcTimeout)
+	defer cancel()
+	return cl.GetProduct(ctx, &pb.GetProductRequest{Id: id})
+}
+
+// convertCurrencies converts many amounts to toCurrency in one round trip.
+func (cs *checkoutService) convertCurrencies(ctx context.Context, from []*pb.Money, toCurrency string) ([]*pb.Money, error) {
+	ctx, cancel := context.WithTimeout(ctx, cs.currencySvcTimeout)
+	defer cancel()
+
+	res, err := pb.NewCurrencyServiceClient(cs.currencySvcConn).ConvertBatch(ctx, &pb.ConvertBatchRequest{
+		From:   from,
+		ToCode: toCurrency})
 	if err != nil {
-		return nil, fmt.Errorf("could not connect currency service: %+v", err)
+		return nil, fmt.Errorf("failed to convert currency: %+v", err)
+	}
+	if len(res.GetResults()) != len(from) {
+		return nil, fmt.Errorf("currency service converted %d of %d amounts", len(res.GetResults()), len(from))
 	}
-	defer conn.Close()
-	result, err := pb.NewCurrencyServiceClient(conn).Convert(context.TODO(), &pb.CurrencyConversionRequest{
+	return res.GetResults(), nil
+}
+
+func (cs *checkoutService) convertCurrency(ctx context.Context, from *pb.Money, toCurrency string) (*pb.Money, error) {
+	ctx, cancel := context.WithTimeout(ctx, cs.currencySvcTimeout)
+	defer cancel()
+
+	result, err := pb.NewCurrencyServiceClient(cs.currencySvcConn).Convert(ctx, &pb.CurrencyConversionRequest{
 		From:   from,
 		ToCode: toCurrency})
-
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert currency: %+v", err)
 	}
 	return result, err
 }
 
-func (cs *checkoutService) chargeCard(ctx context.Context, amount *pb.Money, paymentInfo *pb.CreditCardInfo) (string, error) {
-	conn, err := grpc.DialContext(ctx, cs.paymentSvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
+// cardToken returns the card token of req, tokenizing its card if it has no
+// token, so that the card itself is never sent further than the vault.
+func (cs *checkoutService) cardToken(ctx context.Context, req *pb.PlaceOrderRequest) (string, error) {
+	switch {
+	case req.GetCreditCard() != nil && req.GetCardToken() != "":
+		return "", status.Error(codes.InvalidArgument, "only one of credit_card and card_token may be set")
+	case req.GetCardToken() != "":
+		return req.GetCardToken(), nil
+	case req.GetCreditCard() == nil:
+		return "", status.Error(codes.InvalidArgument, "credit_card or card_token must be set")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cs.paymentSvcTimeout)
+	defer cancel()
+
+	resp, err := pb.NewPaymentServiceClient(cs.paymentSvcConn).Tokenize(ctx, &pb.TokenizeRequest{CreditCard: req.GetCreditCard()})
 	if err != nil {
-		return "", fmt.Errorf("failed to connect payment service: %+v", err)
+		return "", paymentError(err, "failed to tokenize card")
 	}
-	defer conn.Close()
+	return resp.GetToken(), nil
+}
 
-	paymentResp, err := pb.NewPaymentServiceClient(conn).Charge(ctx, &pb.ChargeRequest{
-		Amount:     amount,
-		CreditCard: paymentInfo})
+// authorizePayment authorizes the total of order r on the card of cardToken.
+// The authorization is idempotent per order, so that an order holds at most
+// one authorization.
+func (cs *checkoutService) authorizePayment(ctx context.Context, r *orderRecord, cardToken string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, cs.paymentSvcTimeout)
+	defer cancel()
+
+	tx, err := pb.NewPaymentServiceClient(cs.paymentSvcConn).Authorize(ctx, &pb.AuthorizeRequest{
+		Amount:         r.Total,
+		CardToken:      cardToken,
+		IdempotencyKey: r.OrderID,
+		SessionId:      r.UserID,
+		BillingCountry: r.Address.GetCountry()})
 	if err != nil {
-		return "", fmt.Errorf("could not charge the card: %+v", err)
+		return "", err
 	}
-	return paymentResp.GetTransactionId(), nil
+	return tx.GetTransactionId(), nil
 }
 
-func (cs *checkoutService) sendOrderConfirmation(ctx context.Context, email string, order *pb.OrderResult) error {
-	conn, err := grpc.DialContext(ctx, cs.emailSvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
+func (cs *checkoutService) capturePayment(ctx context.Context, transactionID string, amount *pb.Money) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.paymentSvcTimeout)
+	defer cancel()
+
+	_, err := pb.NewPaymentServiceClient(cs.paymentSvcConn).Capture(ctx, &pb.CaptureRequest{
+		TransactionId: transactionID,
+		Amount:        amount})
+	return err
+}
+
+// paymentError returns the error of an order whose payment failed with err.
+// Invalid cards fail the order with INVALID_ARGUMENT, declined cards with
+// FAILED_PRECONDITION and an unavailable payment gateway with UNAVAILABLE,
+// keeping the reason and the details, such as the violations of the card or
+// the fraud assessment, given by the payment service, so that shoppers can
+// be told why.
+func paymentError(err error, msg string) error {
+	switch st := status.Convert(err); st.Code() {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.Unavailable:
+		p := st.Proto()
+		p.Message = msg + ": " + p.Message
+		return status.ErrorProto(p)
+	}
+	return status.Errorf(codes.Internal, "%s: %+v", msg, err)
+}
+
+// releasePayment undoes the payment of an order: an authorization is voided,
+// and a captured payment is refunded in full. The refund is idempotent per
+// order, so releasing a payment again does nothing. If the outcome of the
+// authorization is unknown, it is looked up by its idempotency key, the
+// order ID.
+func (cs *checkoutService) releasePayment(ctx context.Context, r *orderRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.paymentSvcTimeout)
+	defer cancel()
+
+	client := pb.NewPaymentServiceClient(cs.paymentSvcConn)
+	if r.TransactionID == "" {
+		tx, err := client.GetTransaction(ctx, &pb.GetTransactionRequest{IdempotencyKey: r.OrderID})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Infof("order %s has no authorization to release", r.OrderID)
+			return nil
+		case err != nil:
+			return fmt.Errorf("could not look up the authorization: %+v", err)
+		case tx.GetPendingChange() != nil:
+			// The payment service itself does not know whether the
+			// gateway made the authorization.
+			return fmt.Errorf("authorization %s has an unknown outcome with the gateway", tx.GetTransactionId())
+		case tx.GetState() == pb.Transaction_FAILED:
+			return nil
+		}
+		r.TransactionID = tx.GetTransactionId()
+	}
+	transactionID := r.TransactionID
+	tx, err := client.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: transactionID})
+	if status.Code(err) == codes.NotFound {
+		// The payment service forgot the transaction, e.g. because it
+		// restarted without a ledger file. The payment may still hold
+		// money, so the order is left rolling back rather than released.
+		return fmt.Errorf("transaction %s to release is unknown to the payment service", transactionID)
+	} else if err != nil {
+		return fmt.Errorf("could not get the transaction: %+v", err)
+	}
+
+	switch tx.GetState() {
+	case pb.Transaction_AUTHORIZED:
+		if _, err := client.Void(ctx, &pb.VoidRequest{TransactionId: transactionID}); err != nil {
+			return fmt.Errorf("could not void the payment: %+v", err)
+		}
+		log.Infof("payment voided (transaction_id: %s)", transactionID)
+	case pb.Transaction_CAPTURED, pb.Transaction_PARTIALLY_REFUNDED:
+		resp, err := client.Refund(ctx, &pb.RefundRequest{
+			TransactionId:  transactionID,
+			IdempotencyKey: r.OrderID})
+		if err != nil {
+			return fmt.Errorf("could not refund the payment: %+v", err)
+		}
+		log.Infof("payment refunded (transaction_id: %s, refund_id: %s)", transactionID, resp.GetRefundId())
+	}
+	return nil
+}
+
+// refundCharge refunds in full the charge of an order placed before payments
+// were authorized and captured separately.
+func (cs *checkoutService) refundCharge(ctx context.Context, r *orderRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.paymentSvcTimeout)
+	defer cancel()
+
+	resp, err := pb.NewPaymentServiceClient(cs.paymentSvcConn).Refund(ctx, &pb.RefundRequest{
+		TransactionId:  r.TransactionID,
+		IdempotencyKey: r.OrderID})
 	if err != nil {
-		return fmt.Errorf("failed to connect email service: %+v", err)
+		return fmt.Errorf("could not refund the charge: %+v", err)
 	}
-	defer conn.Close()
-	_, err = pb.NewEmailServiceClient(conn).SendOrderConfirmation(ctx, &pb.SendOrderConfirmationRequest{
+	log.Infof("charge refunded (transaction_id: %s, refund_id: %s)", r.TransactionID, resp.GetRefundId())
+	return nil
+}
+
+func (cs *checkoutService) sendOrderConfirmation(ctx context.Context, email string, order *pb.OrderResult) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.emailSvcTimeout)
+	defer cancel()
+
+	_, err := pb.NewEmailServiceClient(cs.emailSvcConn).SendOrderConfirmation(ctx, &pb.SendOrderConfirmationRequest{
 		Email: email,
 		Order: order})
 	return err
 }
 
-func (cs *checkoutService) shipOrder(ctx context.Context, address *pb.Address, items []*pb.CartItem) (string, error) {
-	conn, err := grpc.DialContext(ctx, cs.shippingSvcAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
-	if err != nil {
-		return "", fmt.Errorf("failed to connect email service: %+v", err)
-	}
-	defer conn.Close()
-	resp, err := pb.NewShippingServiceClient(conn).ShipOrder(ctx, &pb.ShipOrderRequest{
-		Address: address,
-		Items:   items})
+func (cs *checkoutService) shipOrder(ctx context.Context, r *orderRecord) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, cs.shippingSvcTimeout)
+	defer cancel()
+
+	resp, err := pb.NewShippingServiceClient(cs.shippingSvcConn).ShipOrder(ctx, &pb.ShipOrderRequest{
+		Address: r.Address,
+		Items:   r.CartItems,
+		OrderId: r.OrderID})
 	if err != nil {
 		return "", fmt.Errorf("shipment failed: %+v", err)
 	}
 	return resp.GetTrackingId(), nil
 }
 
+// cancelShipment cancels the shipment of an order, which is looked up by the
+// order ID if its tracking ID is unknown.
+func (cs *checkoutService) cancelShipment(ctx context.Context, r *orderRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.shippingSvcTimeout)
+	defer cancel()
+
+	if _, err := pb.NewShippingServiceClient(cs.shippingSvcConn).CancelShipment(ctx, &pb.CancelShipmentRequest{
+		TrackingId: r.TrackingID,
+		OrderId:    r.OrderID}); err != nil {
+		// The shipping service forgets shipments when it restarts, and has
+		// none for an order it did not ship; there is nothing to cancel
+		// then.
+		if status.Code(err) == codes.NotFound {
+			log.Warnf("shipment of order %s to cancel is unknown", r.OrderID)
+			return nil
+		}
+		return fmt.Errorf("could not cancel shipment: %+v", err)
+	}
+	return nil
+}
+
 func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		ctx = metadata.NewOutgoingContext(ctx, md)
 	}
 	return invoker(ctx, method, req, reply, cc, opts...)
 }
-
-// TODO: Dial and create client once, reuse.
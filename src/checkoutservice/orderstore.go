@@ -0,0 +1,269 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/checkoutservice/genproto"
+)
+
+// orderState is the progress of an order through PlaceOrder. States are
+// ordered: an order in a given state has completed every step of the states
+// before it.
+type orderState int
+
+const (
+	orderPending orderState = iota
+	orderCartEmptied
+	// orderCharged is the state of orders placed before payments were
+	// authorized and captured separately, which were charged in full before
+	// they were shipped. Such orders are only recovered: their charge is
+	// refunded before they are rolled back.
+	orderCharged
+	orderAuthorized
+	orderShipped
+	orderCaptured
+	orderCompleted
+	orderRolledBack
+)
+
+var orderStateNames = map[orderState]string{
+	orderPending:     "PENDING",
+	orderCartEmptied: "CART_EMPTIED",
+	orderCharged:     "CHARGED",
+	orderAuthorized:  "AUTHORIZED",
+	orderShipped:     "SHIPPED",
+	orderCaptured:    "CAPTURED",
+	orderCompleted:   "COMPLETED",
+	orderRolledBack:  "ROLLED_BACK",
+}
+
+func (s orderState) String() string {
+	if n, ok := orderStateNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("orderState(%d)", int(s))
+}
+
+func (s orderState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *orderState) UnmarshalText(b []byte) error {
+	for k, v := range orderStateNames {
+		if v == string(b) {
+			*s = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown order state %q", b)
+}
+
+// terminal reports whether no further step will be taken for the order.
+func (s orderState) terminal() bool {
+	return s == orderCompleted || s == orderRolledBack
+}
+
+// orderRecordVersion is the version of the records written since payments
+// are authorized and captured separately. Older records have no version.
+const orderRecordVersion = 1
+
+// orderRecord is the persisted state of an order. It holds everything needed
+// to finish or undo the order after a crash, except for the credit card,
+// which is never written to disk.
+type orderRecord struct {
+	OrderID      string          `json:"order_id"`
+	UserID       string          `json:"user_id"`
+	UserCurrency string          `json:"user_currency"`
+	Email        string          `json:"email"`
+	Address      *pb.Address     `json:"address"`
+	CartItems    []*pb.CartItem  `json:"cart_items"`
+	OrderItems   []*pb.OrderItem `json:"order_items"`
+	ShippingCost *pb.Money       `json:"shipping_cost"`
+	Total        *pb.Money       `json:"total"`
+
+	// IdempotencyKey is the key the order was placed with, if any.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+	TransactionID string `json:"transaction_id,omitempty"`
+	TrackingID    string `json:"tracking_id,omitempty"`
+
+	Version int        `json:"version,omitempty"`
+	State   orderState `json:"state"`
+	// RollingBack is set once a step has failed. State then walks back as
+	// the completed steps are compensated.
+	RollingBack bool      `json:"rolling_back,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// upgrade brings a record without a version up to date. Such orders were
+// charged in full before they were shipped, so a shipped one is as good as
+// captured.
+func (r *orderRecord) upgrade() {
+	if r.Version == 0 && r.State == orderShipped {
+		r.State = orderCaptured
+	}
+	r.Version = orderRecordVersion
+}
+
+// result returns the OrderResult of a completed order.
+func (r *orderRecord) result() *pb.OrderResult {
+	return &pb.OrderResult{
+		OrderId:            r.OrderID,
+		ShippingTrackingId: r.TrackingID,
+		ShippingCost:       r.ShippingCost,
+		ShippingAddress:    r.Address,
+		Items:              r.OrderItems,
+	}
+}
+
+// fileOrderStore persists one JSON file per order in a directory.
+type fileOrderStore struct {
+	dir string
+}
+
+func newFileOrderStore(dir string) (*fileOrderStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create order store directory: %+v", err)
+	}
+	return &fileOrderStore{dir: dir}, nil
+}
+
+// save atomically replaces the stored record of r.
+func (s *fileOrderStore) save(r *orderRecord) error {
+	r.Version = orderRecordVersion
+	r.UpdatedAt = time.Now()
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode order %s: %+v", r.OrderID, err)
+	}
+	tmp, err := ioutil.TempFile(s.dir, r.OrderID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save order %s: %+v", r.OrderID, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save order %s: %+v", r.OrderID, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save order %s: %+v", r.OrderID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save order %s: %+v", r.OrderID, err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, r.OrderID+".json")); err != nil {
+		return fmt.Errorf("failed to save order %s: %+v", r.OrderID, err)
+	}
+	return nil
+}
+
+// load returns the stored record of orderID.
+func (s *fileOrderStore) load(orderID string) (*orderRecord, error) {
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, orderID+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var r orderRecord
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode order %s: %+v", orderID, err)
+	}
+	r.upgrade()
+	return &r, nil
+}
+
+// incomplete returns the records of all orders that are not in a terminal
+// state.
+func (s *fileOrderStore) incomplete() ([]*orderRecord, error) {
+	records, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	var out []*orderRecord
+	for _, r := range records {
+		if !r.State.terminal() {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// prune deletes the records of orders that reached a terminal state before
+// the given time, so that the store does not grow without bound.
+func (s *fileOrderStore) prune(before time.Time) error {
+	records, err := s.all()
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if !r.State.terminal() || !r.UpdatedAt.Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, r.OrderID+".json")); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete order %s: %+v", r.OrderID, err)
+		}
+	}
+	return nil
+}
+
+// pruneEvery deletes the records of orders that reached a terminal state
+// more than retention ago, every interval until ctx is done.
+func (s *fileOrderStore) pruneEvery(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.prune(time.Now().Add(-retention)); err != nil {
+			log.Warnf("failed to prune the order store: %+v", err)
+		}
+	}
+}
+
+// all returns the records of all orders.
+func (s *fileOrderStore) all() ([]*orderRecord, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %+v", err)
+	}
+	var out []*orderRecord
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		r, err := s.load(strings.TrimSuffix(f.Name(), ".json"))
+		if os.IsNotExist(err) {
+			// The record was pruned since the directory was listed.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// sagaStep is one side effect of placing an order. A successful action
+// moves the order into state; compensate undoes the action.
+type sagaStep struct {
+	name       string
+	state      orderState
+	action     func(ctx context.Context, r *orderRecord) error
+	compensate func(ctx context.Context, r *orderRecord) error
+	// compensateUnfinished is set for steps whose action may take effect
+	// even though it did not succeed, e.g. because it timed out or the
+	// service crashed during it. Their compensation also runs when the
+	// action did not finish, and must succeed if it had no effect.
+	compensateUnfinished bool
+}
+
+// saga executes the steps of an order in order and persists the record after
+// every transition, so that an order interrupted by a crash can be finished
+// or rolled back later.
+type saga struct {
+	store *fileOrderStore
+	steps []sagaStep
+}
+
+// run executes the steps r has not completed yet and marks it completed. If
+// a step fails, the completed steps are compensated in reverse order and the
+// error of the step is returned.
+func (s *saga) run(ctx context.Context, r *orderRecord) error {
+	if r.RollingBack {
+		return s.rollback(r)
+	}
+	if err := s.store.save(r); err != nil {
+		return err
+	}
+	for _, step := range s.steps {
+		if step.state <= r.State {
+			continue
+		}
+		if err := step.action(ctx, r); err != nil {
+			r.Error = fmt.Sprintf("%s: %+v", step.name, err)
+			if rbErr := s.rollback(r); rbErr != nil {
+				log.Errorf("order %s: rollback failed: %+v", r.OrderID, rbErr)
+			}
+			return err
+		}
+		r.State = step.state
+		if err := s.store.save(r); err != nil {
+			// The step took effect but cannot be recorded; undo it rather
+			// than leave an order that could not be recovered.
+			r.Error = err.Error()
+			if rbErr := s.rollback(r); rbErr != nil {
+				log.Errorf("order %s: rollback failed: %+v", r.OrderID, rbErr)
+			}
+			return err
+		}
+		log.Infof("order %s: %s", r.OrderID, r.State)
+	}
+	r.State = orderCompleted
+	if err := s.store.save(r); err != nil {
+		log.Warnf("order %s: failed to record completion: %+v", r.OrderID, err)
+	}
+	return nil
+}
+
+// rollback compensates the completed steps of r in reverse order, after the
+// step that did not finish if its outcome is unknown. It runs detached from
+// the request context, which may already be done. If a compensation fails, r
+// is left rolling back so that it can be retried.
+func (s *saga) rollback(r *orderRecord) error {
+	ctx := context.Background()
+	r.RollingBack = true
+	if err := s.store.save(r); err != nil {
+		log.Warnf("order %s: failed to record rollback: %+v", r.OrderID, err)
+	}
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		step := s.steps[i]
+		if step.state > r.State {
+			if !step.compensateUnfinished || !s.unfinished(i, r) {
+				continue
+			}
+		}
+		if err := step.compensate(ctx, r); err != nil {
+			return fmt.Errorf("failed to compensate %s: %+v", step.name, err)
+		}
+		r.State = orderPending
+		if i > 0 {
+			r.State = s.steps[i-1].state
+		}
+		if err := s.store.save(r); err != nil {
+			log.Warnf("order %s: failed to record rollback: %+v", r.OrderID, err)
+		}
+		log.Infof("order %s: compensated %s", r.OrderID, step.name)
+	}
+	r.State = orderRolledBack
+	if err := s.store.save(r); err != nil {
+		log.Warnf("order %s: failed to record rollback: %+v", r.OrderID, err)
+	}
+	return nil
+}
+
+// unfinished reports whether the i-th step is the first one r has not
+// completed, which may have been under way when r failed.
+func (s *saga) unfinished(i int, r *orderRecord) bool {
+	return s.steps[i].state > r.State && (i == 0 || s.steps[i-1].state <= r.State)
+}
@@ -0,0 +1,280 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/checkoutservice/genproto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestStore(t *testing.T) *fileOrderStore {
+	dir, err := ioutil.TempDir("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	s, err := newFileOrderStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// testSteps returns three steps that append to calls, failing the action of
+// failAction and the compensation of failCompensate.
+func testSteps(calls *[]string, failAction, failCompensate string) []sagaStep {
+	step := func(name string, state orderState) sagaStep {
+		return sagaStep{
+			name:  name,
+			state: state,
+			action: func(context.Context, *orderRecord) error {
+				*calls = append(*calls, name)
+				if name == failAction {
+					return errors.New("action failed")
+				}
+				return nil
+			},
+			compensate: func(context.Context, *orderRecord) error {
+				*calls = append(*calls, "undo "+name)
+				if name == failCompensate {
+					return errors.New("compensation failed")
+				}
+				return nil
+			},
+		}
+	}
+	return []sagaStep{
+		step("empty cart", orderCartEmptied),
+		step("authorize payment", orderAuthorized),
+		step("ship order", orderShipped),
+	}
+}
+
+func TestSagaCompletes(t *testing.T) {
+	store := newTestStore(t)
+	var calls []string
+	s := &saga{store: store, steps: testSteps(&calls, "", "")}
+
+	r := &orderRecord{OrderID: "order-1"}
+	if err := s.run(context.Background(), r); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"empty cart", "authorize payment", "ship order"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	stored, err := store.load("order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.State != orderCompleted {
+		t.Errorf("stored state = %s, want %s", stored.State, orderCompleted)
+	}
+}
+
+func TestSagaRollsBack(t *testing.T) {
+	store := newTestStore(t)
+	var calls []string
+	s := &saga{store: store, steps: testSteps(&calls, "ship order", "")}
+
+	r := &orderRecord{OrderID: "order-2"}
+	if err := s.run(context.Background(), r); err == nil {
+		t.Fatal("run succeeded, want error")
+	}
+	want := []string{"empty cart", "authorize payment", "ship order", "undo authorize payment", "undo empty cart"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	stored, err := store.load("order-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.State != orderRolledBack || stored.Error == "" {
+		t.Errorf("stored record = %+v, want rolled back with error", stored)
+	}
+}
+
+func TestSagaCompensatesUnfinishedStep(t *testing.T) {
+	store := newTestStore(t)
+	var calls []string
+	steps := testSteps(&calls, "ship order", "")
+	for i := range steps[1:] {
+		steps[i+1].compensateUnfinished = true
+	}
+	s := &saga{store: store, steps: steps}
+
+	// A shipment that failed may have been made, and is cancelled too.
+	r := &orderRecord{OrderID: "order-4"}
+	if err := s.run(context.Background(), r); err == nil {
+		t.Fatal("run succeeded, want error")
+	}
+	want := []string{"empty cart", "authorize payment", "ship order", "undo ship order", "undo authorize payment", "undo empty cart"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// So is an authorization that was under way when the service crashed,
+	// but not the steps after it.
+	calls = nil
+	r = &orderRecord{OrderID: "order-5", State: orderCartEmptied}
+	if err := s.rollback(r); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"undo authorize payment", "undo empty cart"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestSagaResumesRollback(t *testing.T) {
+	store := newTestStore(t)
+	var calls []string
+	s := &saga{store: store, steps: testSteps(&calls, "ship order", "empty cart")}
+
+	r := &orderRecord{OrderID: "order-3"}
+	if err := s.run(context.Background(), r); err == nil {
+		t.Fatal("run succeeded, want error")
+	}
+
+	// The failed compensation leaves the order rolling back with the cart
+	// still to be restored.
+	incomplete, err := store.incomplete()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incomplete) != 1 {
+		t.Fatalf("got %d incomplete orders, want 1", len(incomplete))
+	}
+	stored := incomplete[0]
+	if !stored.RollingBack || stored.State != orderCartEmptied {
+		t.Fatalf("stored record = %+v, want rolling back from %s", stored, orderCartEmptied)
+	}
+
+	calls = nil
+	s.steps = testSteps(&calls, "", "")
+	if err := s.run(context.Background(), stored); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"undo empty cart"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if incomplete, _ := store.incomplete(); len(incomplete) != 0 {
+		t.Errorf("got %d incomplete orders after resuming, want 0", len(incomplete))
+	}
+}
+
+func TestOrderRecordUpgrade(t *testing.T) {
+	store := newTestStore(t)
+	for _, tc := range []struct {
+		id     string
+		record string
+		want   orderState
+	}{
+		// Orders used to be charged before they were shipped.
+		{"charged", `{"state": "CHARGED"}`, orderCharged},
+		{"legacy-shipped", `{"state": "SHIPPED"}`, orderCaptured},
+		{"shipped", `{"version": 1, "state": "SHIPPED"}`, orderShipped},
+	} {
+		if err := ioutil.WriteFile(filepath.Join(store.dir, tc.id+".json"), []byte(tc.record), 0600); err != nil {
+			t.Fatal(err)
+		}
+		r, err := store.load(tc.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.State != tc.want || r.Version != orderRecordVersion {
+			t.Errorf("order %s loaded in state %v, version %d, want %v, version %d", tc.id, r.State, r.Version, tc.want, orderRecordVersion)
+		}
+	}
+}
+
+func TestOrderStorePrune(t *testing.T) {
+	store := newTestStore(t)
+	for _, r := range []*orderRecord{
+		{OrderID: "completed", State: orderCompleted},
+		{OrderID: "rolled-back", State: orderRolledBack},
+		{OrderID: "shipped", State: orderShipped},
+	} {
+		if err := store.save(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Records are kept until they are older than the cutoff.
+	if err := store.prune(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := store.all(); len(all) != 3 {
+		t.Errorf("got %d orders after pruning none, want 3", len(all))
+	}
+	if err := store.prune(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	all, err := store.all()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].OrderID != "shipped" {
+		t.Errorf("orders after pruning = %v, want only the incomplete one", all)
+	}
+}
+
+func TestPaymentError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want codes.Code
+	}{
+		{status.Error(codes.FailedPrecondition, "card declined (insufficient_funds): Your card has insufficient funds."), codes.FailedPrecondition},
+		{status.Error(codes.Unavailable, "payment gateway timed out"), codes.Unavailable},
+		{status.Error(codes.InvalidArgument, "invalid credit card: security code must have 3 digits"), codes.InvalidArgument},
+		{status.Error(codes.Unknown, "expired credit card"), codes.Internal},
+		{errors.New("connection reset"), codes.Internal},
+	} {
+		err := paymentError(tc.err, "failed to authorize payment")
+		if status.Code(err) != tc.want || !strings.Contains(err.Error(), status.Convert(tc.err).Message()) {
+			t.Errorf("paymentError(%v) = %v, want %v with the reason", tc.err, err, tc.want)
+		}
+	}
+
+	st, err := status.New(codes.FailedPrecondition, "payment declined by fraud checks").
+		WithDetails(&pb.FraudAssessment{Score: 100, Decision: pb.FraudAssessment_DECLINE, Reasons: []string{"card BIN 400000 is blocked"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	details := status.Convert(paymentError(st.Err(), "failed to authorize payment")).Details()
+	if len(details) != 1 || details[0].(*pb.FraudAssessment).GetDecision() != pb.FraudAssessment_DECLINE {
+		t.Errorf("details of a fraud decline = %v, want the assessment", details)
+	}
+
+	violation := &errdetails.BadRequest_FieldViolation{Field: "credit_card.credit_card_cvv", Description: "security code must have 3 digits"}
+	if st, err = status.New(codes.InvalidArgument, "invalid credit card").
+		WithDetails(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{violation}}); err != nil {
+		t.Fatal(err)
+	}
+	details = status.Convert(paymentError(st.Err(), "failed to tokenize card")).Details()
+	if len(details) != 1 || details[0].(*errdetails.BadRequest).GetFieldViolations()[0].GetField() != violation.Field {
+		t.Errorf("details of an invalid card = %v, want the field violations", details)
+	}
+}
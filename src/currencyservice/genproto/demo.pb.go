@@ -24,6 +24,43 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ShipmentStatus_State int32
+
+const (
+	ShipmentStatus_UNKNOWN          ShipmentStatus_State = 0
+	ShipmentStatus_LABEL_CREATED    ShipmentStatus_State = 1
+	ShipmentStatus_IN_TRANSIT       ShipmentStatus_State = 2
+	ShipmentStatus_OUT_FOR_DELIVERY ShipmentStatus_State = 3
+	ShipmentStatus_DELIVERED        ShipmentStatus_State = 4
+	ShipmentStatus_CANCELLED        ShipmentStatus_State = 5
+)
+
+var ShipmentStatus_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "LABEL_CREATED",
+	2: "IN_TRANSIT",
+	3: "OUT_FOR_DELIVERY",
+	4: "DELIVERED",
+	5: "CANCELLED",
+}
+
+var ShipmentStatus_State_value = map[string]int32{
+	"UNKNOWN":          0,
+	"LABEL_CREATED":    1,
+	"IN_TRANSIT":       2,
+	"OUT_FOR_DELIVERY": 3,
+	"DELIVERED":        4,
+	"CANCELLED":        5,
+}
+
+func (x ShipmentStatus_State) String() string {
+	return proto.EnumName(ShipmentStatus_State_name, int32(x))
+}
+
+func (ShipmentStatus_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{23, 0}
+}
+
 type CartItem struct {
 	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity             int32    `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
@@ -368,10 +405,14 @@ type Product struct {
 	PriceUsd    *Money `protobuf:"bytes,5,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
 	// Categories such as "vintage" or "gardening" that can be used to look up
 	// other related products.
-	Categories           []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Categories []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Shipping weight in kilograms and size of the package, used to quote
+	// shipping. Unset if unknown.
+	WeightKg             float64     `protobuf:"fixed64,7,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	Dimensions           *Dimensions `protobuf:"bytes,8,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *Product) Reset()         { *m = Product{} }
@@ -441,6 +482,75 @@ func (m *Product) GetCategories() []string {
 	return nil
 }
 
+func (m *Product) GetWeightKg() float64 {
+	if m != nil {
+		return m.WeightKg
+	}
+	return 0
+}
+
+func (m *Product) GetDimensions() *Dimensions {
+	if m != nil {
+		return m.Dimensions
+	}
+	return nil
+}
+
+type Dimensions struct {
+	LengthCm             float64  `protobuf:"fixed64,1,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm              float64  `protobuf:"fixed64,2,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm             float64  `protobuf:"fixed64,3,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dimensions) Reset()         { *m = Dimensions{} }
+func (m *Dimensions) String() string { return proto.CompactTextString(m) }
+func (*Dimensions) ProtoMessage()    {}
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{9}
+}
+
+func (m *Dimensions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dimensions.Unmarshal(m, b)
+}
+func (m *Dimensions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dimensions.Marshal(b, m, deterministic)
+}
+func (m *Dimensions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dimensions.Merge(m, src)
+}
+func (m *Dimensions) XXX_Size() int {
+	return xxx_messageInfo_Dimensions.Size(m)
+}
+func (m *Dimensions) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dimensions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dimensions proto.InternalMessageInfo
+
+func (m *Dimensions) GetLengthCm() float64 {
+	if m != nil {
+		return m.LengthCm
+	}
+	return 0
+}
+
+func (m *Dimensions) GetWidthCm() float64 {
+	if m != nil {
+		return m.WidthCm
+	}
+	return 0
+}
+
+func (m *Dimensions) GetHeightCm() float64 {
+	if m != nil {
+		return m.HeightCm
+	}
+	return 0
+}
+
 type ListProductsResponse struct {
 	Products             []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -452,7 +562,7 @@ func (m *ListProductsResponse) Reset()         { *m = ListProductsResponse{} }
 func (m *ListProductsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListProductsResponse) ProtoMessage()    {}
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{9}
+	return fileDescriptor_ca53982754088a9d, []int{10}
 }
 
 func (m *ListProductsResponse) XXX_Unmarshal(b []byte) error {
@@ -491,7 +601,7 @@ func (m *GetProductRequest) Reset()         { *m = GetProductRequest{} }
 func (m *GetProductRequest) String() string { return proto.CompactTextString(m) }
 func (*GetProductRequest) ProtoMessage()    {}
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{10}
+	return fileDescriptor_ca53982754088a9d, []int{11}
 }
 
 func (m *GetProductRequest) XXX_Unmarshal(b []byte) error {
@@ -530,7 +640,7 @@ func (m *SearchProductsRequest) Reset()         { *m = SearchProductsRequest{} }
 func (m *SearchProductsRequest) String() string { return proto.CompactTextString(m) }
 func (*SearchProductsRequest) ProtoMessage()    {}
 func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{11}
+	return fileDescriptor_ca53982754088a9d, []int{12}
 }
 
 func (m *SearchProductsRequest) XXX_Unmarshal(b []byte) error {
@@ -569,7 +679,7 @@ func (m *SearchProductsResponse) Reset()         { *m = SearchProductsResponse{}
 func (m *SearchProductsResponse) String() string { return proto.CompactTextString(m) }
 func (*SearchProductsResponse) ProtoMessage()    {}
 func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{12}
+	return fileDescriptor_ca53982754088a9d, []int{13}
 }
 
 func (m *SearchProductsResponse) XXX_Unmarshal(b []byte) error {
@@ -609,7 +719,7 @@ func (m *GetQuoteRequest) Reset()         { *m = GetQuoteRequest{} }
 func (m *GetQuoteRequest) String() string { return proto.CompactTextString(m) }
 func (*GetQuoteRequest) ProtoMessage()    {}
 func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{13}
+	return fileDescriptor_ca53982754088a9d, []int{14}
 }
 
 func (m *GetQuoteRequest) XXX_Unmarshal(b []byte) error {
@@ -645,17 +755,20 @@ func (m *GetQuoteRequest) GetItems() []*CartItem {
 }
 
 type GetQuoteResponse struct {
-	CostUsd              *Money   `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// The cost of the cheapest option.
+	CostUsd *Money `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The service levels available for the address, cheapest first.
+	Options              []*QuoteOption `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GetQuoteResponse) Reset()         { *m = GetQuoteResponse{} }
 func (m *GetQuoteResponse) String() string { return proto.CompactTextString(m) }
 func (*GetQuoteResponse) ProtoMessage()    {}
 func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{14}
+	return fileDescriptor_ca53982754088a9d, []int{15}
 }
 
 func (m *GetQuoteResponse) XXX_Unmarshal(b []byte) error {
@@ -683,6 +796,70 @@ func (m *GetQuoteResponse) GetCostUsd() *Money {
 	return nil
 }
 
+func (m *GetQuoteResponse) GetOptions() []*QuoteOption {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type QuoteOption struct {
+	// The name of the service level, such as "standard" or "overnight".
+	ServiceLevel string `protobuf:"bytes,1,opt,name=service_level,json=serviceLevel,proto3" json:"service_level,omitempty"`
+	CostUsd      *Money `protobuf:"bytes,2,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The number of business days until delivery.
+	TransitDays          int32    `protobuf:"varint,3,opt,name=transit_days,json=transitDays,proto3" json:"transit_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteOption) Reset()         { *m = QuoteOption{} }
+func (m *QuoteOption) String() string { return proto.CompactTextString(m) }
+func (*QuoteOption) ProtoMessage()    {}
+func (*QuoteOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{16}
+}
+
+func (m *QuoteOption) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteOption.Unmarshal(m, b)
+}
+func (m *QuoteOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteOption.Marshal(b, m, deterministic)
+}
+func (m *QuoteOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteOption.Merge(m, src)
+}
+func (m *QuoteOption) XXX_Size() int {
+	return xxx_messageInfo_QuoteOption.Size(m)
+}
+func (m *QuoteOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteOption.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteOption proto.InternalMessageInfo
+
+func (m *QuoteOption) GetServiceLevel() string {
+	if m != nil {
+		return m.ServiceLevel
+	}
+	return ""
+}
+
+func (m *QuoteOption) GetCostUsd() *Money {
+	if m != nil {
+		return m.CostUsd
+	}
+	return nil
+}
+
+func (m *QuoteOption) GetTransitDays() int32 {
+	if m != nil {
+		return m.TransitDays
+	}
+	return 0
+}
+
 type ShipOrderRequest struct {
 	Address              *Address    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Items                []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
@@ -695,7 +872,7 @@ func (m *ShipOrderRequest) Reset()         { *m = ShipOrderRequest{} }
 func (m *ShipOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*ShipOrderRequest) ProtoMessage()    {}
 func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{15}
+	return fileDescriptor_ca53982754088a9d, []int{17}
 }
 
 func (m *ShipOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -741,7 +918,7 @@ func (m *ShipOrderResponse) Reset()         { *m = ShipOrderResponse{} }
 func (m *ShipOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*ShipOrderResponse) ProtoMessage()    {}
 func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{16}
+	return fileDescriptor_ca53982754088a9d, []int{18}
 }
 
 func (m *ShipOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -769,6 +946,245 @@ func (m *ShipOrderResponse) GetTrackingId() string {
 	return ""
 }
 
+type CancelShipmentRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelShipmentRequest) Reset()         { *m = CancelShipmentRequest{} }
+func (m *CancelShipmentRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelShipmentRequest) ProtoMessage()    {}
+func (*CancelShipmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{19}
+}
+
+func (m *CancelShipmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelShipmentRequest.Unmarshal(m, b)
+}
+func (m *CancelShipmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelShipmentRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelShipmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelShipmentRequest.Merge(m, src)
+}
+func (m *CancelShipmentRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelShipmentRequest.Size(m)
+}
+func (m *CancelShipmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelShipmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelShipmentRequest proto.InternalMessageInfo
+
+func (m *CancelShipmentRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type GetShipmentStatusRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetShipmentStatusRequest) Reset()         { *m = GetShipmentStatusRequest{} }
+func (m *GetShipmentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShipmentStatusRequest) ProtoMessage()    {}
+func (*GetShipmentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{20}
+}
+
+func (m *GetShipmentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShipmentStatusRequest.Unmarshal(m, b)
+}
+func (m *GetShipmentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShipmentStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShipmentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShipmentStatusRequest.Merge(m, src)
+}
+func (m *GetShipmentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShipmentStatusRequest.Size(m)
+}
+func (m *GetShipmentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShipmentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShipmentStatusRequest proto.InternalMessageInfo
+
+func (m *GetShipmentStatusRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type ValidateTrackingIdRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateTrackingIdRequest) Reset()         { *m = ValidateTrackingIdRequest{} }
+func (m *ValidateTrackingIdRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateTrackingIdRequest) ProtoMessage()    {}
+func (*ValidateTrackingIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{21}
+}
+
+func (m *ValidateTrackingIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateTrackingIdRequest.Unmarshal(m, b)
+}
+func (m *ValidateTrackingIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateTrackingIdRequest.Marshal(b, m, deterministic)
+}
+func (m *ValidateTrackingIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateTrackingIdRequest.Merge(m, src)
+}
+func (m *ValidateTrackingIdRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateTrackingIdRequest.Size(m)
+}
+func (m *ValidateTrackingIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateTrackingIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateTrackingIdRequest proto.InternalMessageInfo
+
+func (m *ValidateTrackingIdRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type ValidateTrackingIdResponse struct {
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Why the tracking ID is invalid, if it is.
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateTrackingIdResponse) Reset()         { *m = ValidateTrackingIdResponse{} }
+func (m *ValidateTrackingIdResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateTrackingIdResponse) ProtoMessage()    {}
+func (*ValidateTrackingIdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{22}
+}
+
+func (m *ValidateTrackingIdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateTrackingIdResponse.Unmarshal(m, b)
+}
+func (m *ValidateTrackingIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateTrackingIdResponse.Marshal(b, m, deterministic)
+}
+func (m *ValidateTrackingIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateTrackingIdResponse.Merge(m, src)
+}
+func (m *ValidateTrackingIdResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateTrackingIdResponse.Size(m)
+}
+func (m *ValidateTrackingIdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateTrackingIdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateTrackingIdResponse proto.InternalMessageInfo
+
+func (m *ValidateTrackingIdResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ValidateTrackingIdResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ShipmentStatus struct {
+	TrackingId string               `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	State      ShipmentStatus_State `protobuf:"varint,2,opt,name=state,proto3,enum=hipstershop.ShipmentStatus_State" json:"state,omitempty"`
+	// Unix time in seconds at which the shipment entered state.
+	UpdatedAt int64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Unix time in seconds at which the shipment is expected to be delivered,
+	// or 0 once it is delivered or cancelled.
+	EstimatedDelivery    int64    `protobuf:"varint,4,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	Address              *Address `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShipmentStatus) Reset()         { *m = ShipmentStatus{} }
+func (m *ShipmentStatus) String() string { return proto.CompactTextString(m) }
+func (*ShipmentStatus) ProtoMessage()    {}
+func (*ShipmentStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{23}
+}
+
+func (m *ShipmentStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShipmentStatus.Unmarshal(m, b)
+}
+func (m *ShipmentStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShipmentStatus.Marshal(b, m, deterministic)
+}
+func (m *ShipmentStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShipmentStatus.Merge(m, src)
+}
+func (m *ShipmentStatus) XXX_Size() int {
+	return xxx_messageInfo_ShipmentStatus.Size(m)
+}
+func (m *ShipmentStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShipmentStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShipmentStatus proto.InternalMessageInfo
+
+func (m *ShipmentStatus) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+func (m *ShipmentStatus) GetState() ShipmentStatus_State {
+	if m != nil {
+		return m.State
+	}
+	return ShipmentStatus_UNKNOWN
+}
+
+func (m *ShipmentStatus) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *ShipmentStatus) GetEstimatedDelivery() int64 {
+	if m != nil {
+		return m.EstimatedDelivery
+	}
+	return 0
+}
+
+func (m *ShipmentStatus) GetAddress() *Address {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
 type Address struct {
 	StreetAddress        string   `protobuf:"bytes,1,opt,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
 	City                 string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
@@ -784,7 +1200,7 @@ func (m *Address) Reset()         { *m = Address{} }
 func (m *Address) String() string { return proto.CompactTextString(m) }
 func (*Address) ProtoMessage()    {}
 func (*Address) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{17}
+	return fileDescriptor_ca53982754088a9d, []int{24}
 }
 
 func (m *Address) XXX_Unmarshal(b []byte) error {
@@ -863,7 +1279,7 @@ func (m *Money) Reset()         { *m = Money{} }
 func (m *Money) String() string { return proto.CompactTextString(m) }
 func (*Money) ProtoMessage()    {}
 func (*Money) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{18}
+	return fileDescriptor_ca53982754088a9d, []int{25}
 }
 
 func (m *Money) XXX_Unmarshal(b []byte) error {
@@ -907,17 +1323,20 @@ func (m *Money) GetNanos() int32 {
 
 type GetSupportedCurrenciesResponse struct {
 	// The 3-letter currency code defined in ISO 4217.
-	CurrencyCodes        []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CurrencyCodes []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
+	// The metadata of the supported currencies, in the order of
+	// currency_codes.
+	Currencies           []*CurrencyInfo `protobuf:"bytes,2,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *GetSupportedCurrenciesResponse) Reset()         { *m = GetSupportedCurrenciesResponse{} }
 func (m *GetSupportedCurrenciesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetSupportedCurrenciesResponse) ProtoMessage()    {}
 func (*GetSupportedCurrenciesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{19}
+	return fileDescriptor_ca53982754088a9d, []int{26}
 }
 
 func (m *GetSupportedCurrenciesResponse) XXX_Unmarshal(b []byte) error {
@@ -945,10 +1364,92 @@ func (m *GetSupportedCurrenciesResponse) GetCurrencyCodes() []string {
 	return nil
 }
 
-type CurrencyConversionRequest struct {
+func (m *GetSupportedCurrenciesResponse) GetCurrencies() []*CurrencyInfo {
+	if m != nil {
+		return m.Currencies
+	}
+	return nil
+}
+
+// The ISO 4217 metadata of a currency.
+type CurrencyInfo struct {
+	// The 3-letter currency code defined in ISO 4217.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The name of the currency, such as "US Dollar".
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The symbol of the currency, such as "$".
+	Symbol string `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// The number of digits after the decimal separator, such as 2 for cents or
+	// 0 for yen.
+	MinorUnits           int32    `protobuf:"varint,4,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CurrencyInfo) Reset()         { *m = CurrencyInfo{} }
+func (m *CurrencyInfo) String() string { return proto.CompactTextString(m) }
+func (*CurrencyInfo) ProtoMessage()    {}
+func (*CurrencyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{27}
+}
+
+func (m *CurrencyInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrencyInfo.Unmarshal(m, b)
+}
+func (m *CurrencyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrencyInfo.Marshal(b, m, deterministic)
+}
+func (m *CurrencyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrencyInfo.Merge(m, src)
+}
+func (m *CurrencyInfo) XXX_Size() int {
+	return xxx_messageInfo_CurrencyInfo.Size(m)
+}
+func (m *CurrencyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrencyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CurrencyInfo proto.InternalMessageInfo
+
+func (m *CurrencyInfo) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *CurrencyInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CurrencyInfo) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *CurrencyInfo) GetMinorUnits() int32 {
+	if m != nil {
+		return m.MinorUnits
+	}
+	return 0
+}
+
+type CurrencyConversionRequest struct {
 	From *Money `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	// The 3-letter currency code defined in ISO 4217.
-	ToCode               string   `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	ToCode string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// If set, converts with this version of the exchange rates instead of the
+	// current one, for example to audit a past order.
+	RateVersion string `protobuf:"bytes,3,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	// If set, converts with the exchange rates that were current at this time,
+	// in seconds since the epoch. Cannot be combined with rate_version.
+	AsOf                 int64    `protobuf:"varint,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -958,7 +1459,7 @@ func (m *CurrencyConversionRequest) Reset()         { *m = CurrencyConversionReq
 func (m *CurrencyConversionRequest) String() string { return proto.CompactTextString(m) }
 func (*CurrencyConversionRequest) ProtoMessage()    {}
 func (*CurrencyConversionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{20}
+	return fileDescriptor_ca53982754088a9d, []int{28}
 }
 
 func (m *CurrencyConversionRequest) XXX_Unmarshal(b []byte) error {
@@ -993,6 +1494,203 @@ func (m *CurrencyConversionRequest) GetToCode() string {
 	return ""
 }
 
+func (m *CurrencyConversionRequest) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
+}
+
+func (m *CurrencyConversionRequest) GetAsOf() int64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
+type CurrencyConversionResponse struct {
+	Result *Money `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// The version of the exchange rates used for the conversion.
+	RateVersion string `protobuf:"bytes,2,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	// When that version of the exchange rates became current, in seconds since
+	// the epoch.
+	RatesEffectiveAt     int64    `protobuf:"varint,3,opt,name=rates_effective_at,json=ratesEffectiveAt,proto3" json:"rates_effective_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CurrencyConversionResponse) Reset()         { *m = CurrencyConversionResponse{} }
+func (m *CurrencyConversionResponse) String() string { return proto.CompactTextString(m) }
+func (*CurrencyConversionResponse) ProtoMessage()    {}
+func (*CurrencyConversionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{29}
+}
+
+func (m *CurrencyConversionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrencyConversionResponse.Unmarshal(m, b)
+}
+func (m *CurrencyConversionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrencyConversionResponse.Marshal(b, m, deterministic)
+}
+func (m *CurrencyConversionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrencyConversionResponse.Merge(m, src)
+}
+func (m *CurrencyConversionResponse) XXX_Size() int {
+	return xxx_messageInfo_CurrencyConversionResponse.Size(m)
+}
+func (m *CurrencyConversionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrencyConversionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CurrencyConversionResponse proto.InternalMessageInfo
+
+func (m *CurrencyConversionResponse) GetResult() *Money {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *CurrencyConversionResponse) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
+}
+
+func (m *CurrencyConversionResponse) GetRatesEffectiveAt() int64 {
+	if m != nil {
+		return m.RatesEffectiveAt
+	}
+	return 0
+}
+
+type ConvertBatchRequest struct {
+	// The amounts to convert, in any supported currencies.
+	From []*Money `protobuf:"bytes,1,rep,name=from,proto3" json:"from,omitempty"`
+	// The 3-letter currency code defined in ISO 4217.
+	ToCode string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// As in CurrencyConversionRequest.
+	RateVersion          string   `protobuf:"bytes,3,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	AsOf                 int64    `protobuf:"varint,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConvertBatchRequest) Reset()         { *m = ConvertBatchRequest{} }
+func (m *ConvertBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*ConvertBatchRequest) ProtoMessage()    {}
+func (*ConvertBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{30}
+}
+
+func (m *ConvertBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConvertBatchRequest.Unmarshal(m, b)
+}
+func (m *ConvertBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConvertBatchRequest.Marshal(b, m, deterministic)
+}
+func (m *ConvertBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConvertBatchRequest.Merge(m, src)
+}
+func (m *ConvertBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_ConvertBatchRequest.Size(m)
+}
+func (m *ConvertBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConvertBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConvertBatchRequest proto.InternalMessageInfo
+
+func (m *ConvertBatchRequest) GetFrom() []*Money {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ConvertBatchRequest) GetToCode() string {
+	if m != nil {
+		return m.ToCode
+	}
+	return ""
+}
+
+func (m *ConvertBatchRequest) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
+}
+
+func (m *ConvertBatchRequest) GetAsOf() int64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
+type ConvertBatchResponse struct {
+	// The converted amounts, in the order of ConvertBatchRequest.from.
+	Results []*Money `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// The version of the exchange rates used for the conversions.
+	RateVersion string `protobuf:"bytes,2,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	// When that version of the exchange rates became current, in seconds since
+	// the epoch.
+	RatesEffectiveAt     int64    `protobuf:"varint,3,opt,name=rates_effective_at,json=ratesEffectiveAt,proto3" json:"rates_effective_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConvertBatchResponse) Reset()         { *m = ConvertBatchResponse{} }
+func (m *ConvertBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*ConvertBatchResponse) ProtoMessage()    {}
+func (*ConvertBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{31}
+}
+
+func (m *ConvertBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConvertBatchResponse.Unmarshal(m, b)
+}
+func (m *ConvertBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConvertBatchResponse.Marshal(b, m, deterministic)
+}
+func (m *ConvertBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConvertBatchResponse.Merge(m, src)
+}
+func (m *ConvertBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_ConvertBatchResponse.Size(m)
+}
+func (m *ConvertBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConvertBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConvertBatchResponse proto.InternalMessageInfo
+
+func (m *ConvertBatchResponse) GetResults() []*Money {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *ConvertBatchResponse) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
+}
+
+func (m *ConvertBatchResponse) GetRatesEffectiveAt() int64 {
+	if m != nil {
+		return m.RatesEffectiveAt
+	}
+	return 0
+}
+
 type CreditCardInfo struct {
 	CreditCardNumber          string   `protobuf:"bytes,1,opt,name=credit_card_number,json=creditCardNumber,proto3" json:"credit_card_number,omitempty"`
 	CreditCardCvv             int32    `protobuf:"varint,2,opt,name=credit_card_cvv,json=creditCardCvv,proto3" json:"credit_card_cvv,omitempty"`
@@ -1007,7 +1705,7 @@ func (m *CreditCardInfo) Reset()         { *m = CreditCardInfo{} }
 func (m *CreditCardInfo) String() string { return proto.CompactTextString(m) }
 func (*CreditCardInfo) ProtoMessage()    {}
 func (*CreditCardInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{21}
+	return fileDescriptor_ca53982754088a9d, []int{32}
 }
 
 func (m *CreditCardInfo) XXX_Unmarshal(b []byte) error {
@@ -1057,18 +1755,22 @@ func (m *CreditCardInfo) GetCreditCardExpirationMonth() int32 {
 }
 
 type ChargeRequest struct {
-	Amount               *Money          `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	CreditCard           *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Amount     *Money          `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreditCard *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Requests with the same idempotency_key charge the card only once and
+	// return the response of the first request. May also be set with the
+	// "idempotency-key" gRPC metadata.
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChargeRequest) Reset()         { *m = ChargeRequest{} }
 func (m *ChargeRequest) String() string { return proto.CompactTextString(m) }
 func (*ChargeRequest) ProtoMessage()    {}
 func (*ChargeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{22}
+	return fileDescriptor_ca53982754088a9d, []int{33}
 }
 
 func (m *ChargeRequest) XXX_Unmarshal(b []byte) error {
@@ -1103,6 +1805,13 @@ func (m *ChargeRequest) GetCreditCard() *CreditCardInfo {
 	return nil
 }
 
+func (m *ChargeRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type ChargeResponse struct {
 	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1114,7 +1823,7 @@ func (m *ChargeResponse) Reset()         { *m = ChargeResponse{} }
 func (m *ChargeResponse) String() string { return proto.CompactTextString(m) }
 func (*ChargeResponse) ProtoMessage()    {}
 func (*ChargeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{23}
+	return fileDescriptor_ca53982754088a9d, []int{34}
 }
 
 func (m *ChargeResponse) XXX_Unmarshal(b []byte) error {
@@ -1142,6 +1851,93 @@ func (m *ChargeResponse) GetTransactionId() string {
 	return ""
 }
 
+type RefundRequest struct {
+	// The transaction_id returned by Charge.
+	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount               *Money   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundRequest) Reset()         { *m = RefundRequest{} }
+func (m *RefundRequest) String() string { return proto.CompactTextString(m) }
+func (*RefundRequest) ProtoMessage()    {}
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{35}
+}
+
+func (m *RefundRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundRequest.Unmarshal(m, b)
+}
+func (m *RefundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundRequest.Marshal(b, m, deterministic)
+}
+func (m *RefundRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundRequest.Merge(m, src)
+}
+func (m *RefundRequest) XXX_Size() int {
+	return xxx_messageInfo_RefundRequest.Size(m)
+}
+func (m *RefundRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundRequest proto.InternalMessageInfo
+
+func (m *RefundRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *RefundRequest) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+type RefundResponse struct {
+	RefundId             string   `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundResponse) Reset()         { *m = RefundResponse{} }
+func (m *RefundResponse) String() string { return proto.CompactTextString(m) }
+func (*RefundResponse) ProtoMessage()    {}
+func (*RefundResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{36}
+}
+
+func (m *RefundResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundResponse.Unmarshal(m, b)
+}
+func (m *RefundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundResponse.Marshal(b, m, deterministic)
+}
+func (m *RefundResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundResponse.Merge(m, src)
+}
+func (m *RefundResponse) XXX_Size() int {
+	return xxx_messageInfo_RefundResponse.Size(m)
+}
+func (m *RefundResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundResponse proto.InternalMessageInfo
+
+func (m *RefundResponse) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
 type OrderItem struct {
 	Item                 *CartItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	Cost                 *Money    `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
@@ -1154,7 +1950,7 @@ func (m *OrderItem) Reset()         { *m = OrderItem{} }
 func (m *OrderItem) String() string { return proto.CompactTextString(m) }
 func (*OrderItem) ProtoMessage()    {}
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{24}
+	return fileDescriptor_ca53982754088a9d, []int{37}
 }
 
 func (m *OrderItem) XXX_Unmarshal(b []byte) error {
@@ -1204,7 +2000,7 @@ func (m *OrderResult) Reset()         { *m = OrderResult{} }
 func (m *OrderResult) String() string { return proto.CompactTextString(m) }
 func (*OrderResult) ProtoMessage()    {}
 func (*OrderResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{25}
+	return fileDescriptor_ca53982754088a9d, []int{38}
 }
 
 func (m *OrderResult) XXX_Unmarshal(b []byte) error {
@@ -1272,7 +2068,7 @@ func (m *SendOrderConfirmationRequest) Reset()         { *m = SendOrderConfirmat
 func (m *SendOrderConfirmationRequest) String() string { return proto.CompactTextString(m) }
 func (*SendOrderConfirmationRequest) ProtoMessage()    {}
 func (*SendOrderConfirmationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{26}
+	return fileDescriptor_ca53982754088a9d, []int{39}
 }
 
 func (m *SendOrderConfirmationRequest) XXX_Unmarshal(b []byte) error {
@@ -1308,21 +2104,25 @@ func (m *SendOrderConfirmationRequest) GetOrder() *OrderResult {
 }
 
 type PlaceOrderRequest struct {
-	UserId               string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserCurrency         string          `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
-	Address              *Address        `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Email                string          `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	CreditCard           *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	UserId       string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserCurrency string          `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
+	Address      *Address        `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Email        string          `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	CreditCard   *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Requests of a user with the same idempotency_key place the order only
+	// once and return the result of the first request. May also be set with
+	// the "idempotency-key" gRPC metadata.
+	IdempotencyKey       string   `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PlaceOrderRequest) Reset()         { *m = PlaceOrderRequest{} }
 func (m *PlaceOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*PlaceOrderRequest) ProtoMessage()    {}
 func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{27}
+	return fileDescriptor_ca53982754088a9d, []int{40}
 }
 
 func (m *PlaceOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -1378,6 +2178,13 @@ func (m *PlaceOrderRequest) GetCreditCard() *CreditCardInfo {
 	return nil
 }
 
+func (m *PlaceOrderRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type PlaceOrderResponse struct {
 	Order                *OrderResult `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
@@ -1389,7 +2196,7 @@ func (m *PlaceOrderResponse) Reset()         { *m = PlaceOrderResponse{} }
 func (m *PlaceOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*PlaceOrderResponse) ProtoMessage()    {}
 func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{28}
+	return fileDescriptor_ca53982754088a9d, []int{41}
 }
 
 func (m *PlaceOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -1417,6 +2224,235 @@ func (m *PlaceOrderResponse) GetOrder() *OrderResult {
 	return nil
 }
 
+type Order struct {
+	Result *OrderResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	UserId string       `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email  string       `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Total  *Money       `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Unix time in seconds at which the order was placed.
+	PlacedAt             int64    `protobuf:"varint,5,opt,name=placed_at,json=placedAt,proto3" json:"placed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+func (*Order) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{42}
+}
+
+func (m *Order) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Order.Unmarshal(m, b)
+}
+func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+}
+func (m *Order) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Order.Merge(m, src)
+}
+func (m *Order) XXX_Size() int {
+	return xxx_messageInfo_Order.Size(m)
+}
+func (m *Order) XXX_DiscardUnknown() {
+	xxx_messageInfo_Order.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Order proto.InternalMessageInfo
+
+func (m *Order) GetResult() *OrderResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *Order) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Order) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Order) GetTotal() *Money {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *Order) GetPlacedAt() int64 {
+	if m != nil {
+		return m.PlacedAt
+	}
+	return 0
+}
+
+type AddOrderRequest struct {
+	Order                *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddOrderRequest) Reset()         { *m = AddOrderRequest{} }
+func (m *AddOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*AddOrderRequest) ProtoMessage()    {}
+func (*AddOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{43}
+}
+
+func (m *AddOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddOrderRequest.Unmarshal(m, b)
+}
+func (m *AddOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *AddOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddOrderRequest.Merge(m, src)
+}
+func (m *AddOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_AddOrderRequest.Size(m)
+}
+func (m *AddOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddOrderRequest proto.InternalMessageInfo
+
+func (m *AddOrderRequest) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
+func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderRequest) ProtoMessage()    {}
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{44}
+}
+
+func (m *GetOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderRequest.Unmarshal(m, b)
+}
+func (m *GetOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderRequest.Merge(m, src)
+}
+func (m *GetOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderRequest.Size(m)
+}
+func (m *GetOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderRequest proto.InternalMessageInfo
+
+func (m *GetOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type ListOrdersByUserRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersByUserRequest) Reset()         { *m = ListOrdersByUserRequest{} }
+func (m *ListOrdersByUserRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersByUserRequest) ProtoMessage()    {}
+func (*ListOrdersByUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{45}
+}
+
+func (m *ListOrdersByUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersByUserRequest.Unmarshal(m, b)
+}
+func (m *ListOrdersByUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersByUserRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersByUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersByUserRequest.Merge(m, src)
+}
+func (m *ListOrdersByUserRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersByUserRequest.Size(m)
+}
+func (m *ListOrdersByUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersByUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersByUserRequest proto.InternalMessageInfo
+
+func (m *ListOrdersByUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+// Orders are sorted by placed_at, most recent first.
+type ListOrdersByUserResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersByUserResponse) Reset()         { *m = ListOrdersByUserResponse{} }
+func (m *ListOrdersByUserResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersByUserResponse) ProtoMessage()    {}
+func (*ListOrdersByUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{46}
+}
+
+func (m *ListOrdersByUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersByUserResponse.Unmarshal(m, b)
+}
+func (m *ListOrdersByUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersByUserResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersByUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersByUserResponse.Merge(m, src)
+}
+func (m *ListOrdersByUserResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersByUserResponse.Size(m)
+}
+func (m *ListOrdersByUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersByUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersByUserResponse proto.InternalMessageInfo
+
+func (m *ListOrdersByUserResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
 type AdRequest struct {
 	// List of important key words from the current page describing the context.
 	ContextKeys          []string `protobuf:"bytes,1,rep,name=context_keys,json=contextKeys,proto3" json:"context_keys,omitempty"`
@@ -1429,7 +2465,7 @@ func (m *AdRequest) Reset()         { *m = AdRequest{} }
 func (m *AdRequest) String() string { return proto.CompactTextString(m) }
 func (*AdRequest) ProtoMessage()    {}
 func (*AdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{29}
+	return fileDescriptor_ca53982754088a9d, []int{47}
 }
 
 func (m *AdRequest) XXX_Unmarshal(b []byte) error {
@@ -1468,7 +2504,7 @@ func (m *AdResponse) Reset()         { *m = AdResponse{} }
 func (m *AdResponse) String() string { return proto.CompactTextString(m) }
 func (*AdResponse) ProtoMessage()    {}
 func (*AdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{30}
+	return fileDescriptor_ca53982754088a9d, []int{48}
 }
 
 func (m *AdResponse) XXX_Unmarshal(b []byte) error {
@@ -1510,7 +2546,7 @@ func (m *Ad) Reset()         { *m = Ad{} }
 func (m *Ad) String() string { return proto.CompactTextString(m) }
 func (*Ad) ProtoMessage()    {}
 func (*Ad) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{31}
+	return fileDescriptor_ca53982754088a9d, []int{49}
 }
 
 func (m *Ad) XXX_Unmarshal(b []byte) error {
@@ -1546,7 +2582,18 @@ func (m *Ad) GetText() string {
 }
 
 type CreateRequest struct {
-	Svc                  string   `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	// Fully-qualified gRPC service the fault applies to, e.g.
+	// "hipstershop.CartService".
+	Svc string `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	// Full method name to fail, e.g. "/hipstershop.CartService/GetCart".
+	// Empty or "*" matches every method of svc.
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// Percentage of matching calls to affect, between 0 and 100.
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// gRPC status code returned by affected calls. OK (0) only delays them.
+	Code int32 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	// Delay in milliseconds added to affected calls.
+	DelayMs              int64    `protobuf:"varint,5,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1556,7 +2603,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{32}
+	return fileDescriptor_ca53982754088a9d, []int{50}
 }
 
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
@@ -1581,9 +2628,38 @@ func (m *CreateRequest) GetSvc() string {
 	if m != nil {
 		return m.Svc
 	}
-	return ""
+	return ""
+}
+
+func (m *CreateRequest) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetDelayMs() int64 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
 }
 
+// Removes every fault registered for svc.
 type DeleteRequest struct {
 	Svc                  string   `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1595,7 +2671,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{33}
+	return fileDescriptor_ca53982754088a9d, []int{51}
 }
 
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -1624,6 +2700,7 @@ func (m *DeleteRequest) GetSvc() string {
 }
 
 func init() {
+	proto.RegisterEnum("hipstershop.ShipmentStatus_State", ShipmentStatus_State_name, ShipmentStatus_State_value)
 	proto.RegisterType((*CartItem)(nil), "hipstershop.CartItem")
 	proto.RegisterType((*AddItemRequest)(nil), "hipstershop.AddItemRequest")
 	proto.RegisterType((*EmptyCartRequest)(nil), "hipstershop.EmptyCartRequest")
@@ -1633,26 +2710,44 @@ func init() {
 	proto.RegisterType((*ListRecommendationsRequest)(nil), "hipstershop.ListRecommendationsRequest")
 	proto.RegisterType((*ListRecommendationsResponse)(nil), "hipstershop.ListRecommendationsResponse")
 	proto.RegisterType((*Product)(nil), "hipstershop.Product")
+	proto.RegisterType((*Dimensions)(nil), "hipstershop.Dimensions")
 	proto.RegisterType((*ListProductsResponse)(nil), "hipstershop.ListProductsResponse")
 	proto.RegisterType((*GetProductRequest)(nil), "hipstershop.GetProductRequest")
 	proto.RegisterType((*SearchProductsRequest)(nil), "hipstershop.SearchProductsRequest")
 	proto.RegisterType((*SearchProductsResponse)(nil), "hipstershop.SearchProductsResponse")
 	proto.RegisterType((*GetQuoteRequest)(nil), "hipstershop.GetQuoteRequest")
 	proto.RegisterType((*GetQuoteResponse)(nil), "hipstershop.GetQuoteResponse")
+	proto.RegisterType((*QuoteOption)(nil), "hipstershop.QuoteOption")
 	proto.RegisterType((*ShipOrderRequest)(nil), "hipstershop.ShipOrderRequest")
 	proto.RegisterType((*ShipOrderResponse)(nil), "hipstershop.ShipOrderResponse")
+	proto.RegisterType((*CancelShipmentRequest)(nil), "hipstershop.CancelShipmentRequest")
+	proto.RegisterType((*GetShipmentStatusRequest)(nil), "hipstershop.GetShipmentStatusRequest")
+	proto.RegisterType((*ValidateTrackingIdRequest)(nil), "hipstershop.ValidateTrackingIdRequest")
+	proto.RegisterType((*ValidateTrackingIdResponse)(nil), "hipstershop.ValidateTrackingIdResponse")
+	proto.RegisterType((*ShipmentStatus)(nil), "hipstershop.ShipmentStatus")
 	proto.RegisterType((*Address)(nil), "hipstershop.Address")
 	proto.RegisterType((*Money)(nil), "hipstershop.Money")
 	proto.RegisterType((*GetSupportedCurrenciesResponse)(nil), "hipstershop.GetSupportedCurrenciesResponse")
+	proto.RegisterType((*CurrencyInfo)(nil), "hipstershop.CurrencyInfo")
 	proto.RegisterType((*CurrencyConversionRequest)(nil), "hipstershop.CurrencyConversionRequest")
+	proto.RegisterType((*CurrencyConversionResponse)(nil), "hipstershop.CurrencyConversionResponse")
+	proto.RegisterType((*ConvertBatchRequest)(nil), "hipstershop.ConvertBatchRequest")
+	proto.RegisterType((*ConvertBatchResponse)(nil), "hipstershop.ConvertBatchResponse")
 	proto.RegisterType((*CreditCardInfo)(nil), "hipstershop.CreditCardInfo")
 	proto.RegisterType((*ChargeRequest)(nil), "hipstershop.ChargeRequest")
 	proto.RegisterType((*ChargeResponse)(nil), "hipstershop.ChargeResponse")
+	proto.RegisterType((*RefundRequest)(nil), "hipstershop.RefundRequest")
+	proto.RegisterType((*RefundResponse)(nil), "hipstershop.RefundResponse")
 	proto.RegisterType((*OrderItem)(nil), "hipstershop.OrderItem")
 	proto.RegisterType((*OrderResult)(nil), "hipstershop.OrderResult")
 	proto.RegisterType((*SendOrderConfirmationRequest)(nil), "hipstershop.SendOrderConfirmationRequest")
 	proto.RegisterType((*PlaceOrderRequest)(nil), "hipstershop.PlaceOrderRequest")
 	proto.RegisterType((*PlaceOrderResponse)(nil), "hipstershop.PlaceOrderResponse")
+	proto.RegisterType((*Order)(nil), "hipstershop.Order")
+	proto.RegisterType((*AddOrderRequest)(nil), "hipstershop.AddOrderRequest")
+	proto.RegisterType((*GetOrderRequest)(nil), "hipstershop.GetOrderRequest")
+	proto.RegisterType((*ListOrdersByUserRequest)(nil), "hipstershop.ListOrdersByUserRequest")
+	proto.RegisterType((*ListOrdersByUserResponse)(nil), "hipstershop.ListOrdersByUserResponse")
 	proto.RegisterType((*AdRequest)(nil), "hipstershop.AdRequest")
 	proto.RegisterType((*AdResponse)(nil), "hipstershop.AdResponse")
 	proto.RegisterType((*Ad)(nil), "hipstershop.Ad")
@@ -1663,115 +2758,174 @@ func init() {
 func init() { proto.RegisterFile("demo.proto", fileDescriptor_ca53982754088a9d) }
 
 var fileDescriptor_ca53982754088a9d = []byte{
-	// 1573 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x6e, 0x13, 0xc7,
-	0x17, 0xcf, 0x26, 0xfe, 0x88, 0x8f, 0x63, 0x27, 0x99, 0x7f, 0x12, 0x8c, 0x03, 0x21, 0x99, 0x08,
-	0xfe, 0x50, 0x20, 0xa0, 0xb4, 0x12, 0x12, 0xd0, 0xd2, 0xc8, 0xa4, 0xc6, 0x2a, 0x14, 0xba, 0x21,
-	0x15, 0x15, 0x55, 0xad, 0x65, 0x67, 0x88, 0xb7, 0xd8, 0x3b, 0xcb, 0xcc, 0x6c, 0x84, 0xb9, 0x6c,
-	0xa5, 0xde, 0xf6, 0x3d, 0xfa, 0x02, 0x95, 0xfa, 0x08, 0xbd, 0xef, 0x2b, 0xf4, 0x39, 0xaa, 0x99,
-	0xdd, 0xd9, 0xaf, 0xd8, 0x09, 0xdc, 0xf4, 0x6e, 0xe7, 0xcc, 0x6f, 0xce, 0xf9, 0xcd, 0x99, 0xf3,
-	0x65, 0x03, 0x10, 0x3a, 0x62, 0x3b, 0x01, 0x67, 0x92, 0xa1, 0xfa, 0xc0, 0x0b, 0x84, 0xa4, 0x5c,
-	0x0c, 0x58, 0x80, 0xf7, 0x61, 0xbe, 0xe3, 0x70, 0xd9, 0x93, 0x74, 0x84, 0x2e, 0x02, 0x04, 0x9c,
-	0x91, 0xd0, 0x95, 0x7d, 0x8f, 0xb4, 0xac, 0x4d, 0xeb, 0x6a, 0xcd, 0xae, 0xc5, 0x92, 0x1e, 0x41,
-	0x6d, 0x98, 0x7f, 0x1b, 0x3a, 0xbe, 0xf4, 0xe4, 0xb8, 0x35, 0xbb, 0x69, 0x5d, 0x2d, 0xdb, 0xc9,
-	0x1a, 0x3f, 0x87, 0xe6, 0x1e, 0x21, 0x4a, 0x8b, 0x4d, 0xdf, 0x86, 0x54, 0x48, 0x74, 0x0e, 0xaa,
-	0xa1, 0xa0, 0x3c, 0xd5, 0x54, 0x51, 0xcb, 0x1e, 0x41, 0xd7, 0xa0, 0xe4, 0x49, 0x3a, 0xd2, 0x2a,
-	0xea, 0xbb, 0xab, 0x3b, 0x19, 0x36, 0x3b, 0x86, 0x8a, 0xad, 0x21, 0xf8, 0x3a, 0x2c, 0xed, 0x8f,
-	0x02, 0x39, 0x56, 0xe2, 0xb3, 0xf4, 0xe2, 0x6b, 0xd0, 0xec, 0x52, 0xf9, 0x41, 0xd0, 0xc7, 0x50,
-	0x52, 0xb8, 0xe9, 0x1c, 0xaf, 0x43, 0x59, 0x11, 0x10, 0xad, 0xd9, 0xcd, 0xb9, 0xe9, 0x24, 0x23,
-	0x0c, 0xae, 0x42, 0x59, 0xb3, 0xc4, 0xdf, 0x41, 0xfb, 0xb1, 0x27, 0xa4, 0x4d, 0x5d, 0x36, 0x1a,
-	0x51, 0x9f, 0x38, 0xd2, 0x63, 0xbe, 0x38, 0xd3, 0x21, 0x97, 0xa0, 0x9e, 0xba, 0x3d, 0x32, 0x59,
-	0xb3, 0x21, 0xf1, 0xbb, 0xc0, 0x5f, 0xc0, 0xfa, 0x44, 0xbd, 0x22, 0x60, 0xbe, 0xa0, 0xc5, 0xf3,
-	0xd6, 0x89, 0xf3, 0x7f, 0x5a, 0x50, 0x7d, 0x16, 0x2d, 0x51, 0x13, 0x66, 0x13, 0x02, 0xb3, 0x1e,
-	0x41, 0x08, 0x4a, 0xbe, 0x33, 0xa2, 0xfa, 0x35, 0x6a, 0xb6, 0xfe, 0x46, 0x9b, 0x50, 0x27, 0x54,
-	0xb8, 0xdc, 0x0b, 0x94, 0xa1, 0xd6, 0x9c, 0xde, 0xca, 0x8a, 0x50, 0x0b, 0xaa, 0x81, 0xe7, 0xca,
-	0x90, 0xd3, 0x56, 0x49, 0xef, 0x9a, 0x25, 0xba, 0x05, 0xb5, 0x80, 0x7b, 0x2e, 0xed, 0x87, 0x82,
-	0xb4, 0xca, 0xfa, 0x89, 0x51, 0xce, 0x7b, 0x4f, 0x98, 0x4f, 0xc7, 0xf6, 0xbc, 0x06, 0x1d, 0x0a,
-	0x82, 0x36, 0x00, 0x5c, 0x47, 0xd2, 0x23, 0xc6, 0x3d, 0x2a, 0x5a, 0x95, 0x88, 0x7c, 0x2a, 0xc1,
-	0x8f, 0x60, 0x45, 0x5d, 0x3e, 0xe6, 0x9f, 0xde, 0xfa, 0x36, 0xcc, 0xc7, 0x57, 0x8c, 0xae, 0x5c,
-	0xdf, 0x5d, 0xc9, 0xd9, 0x89, 0x0f, 0xd8, 0x09, 0x0a, 0x6f, 0xc3, 0x72, 0x97, 0x1a, 0x45, 0xe6,
-	0x55, 0x0a, 0xfe, 0xc0, 0x37, 0x61, 0xf5, 0x80, 0x3a, 0xdc, 0x1d, 0xa4, 0x06, 0x23, 0xe0, 0x0a,
-	0x94, 0xdf, 0x86, 0x94, 0x8f, 0x63, 0x6c, 0xb4, 0xc0, 0x8f, 0x60, 0xad, 0x08, 0x8f, 0xf9, 0xed,
-	0x40, 0x95, 0x53, 0x11, 0x0e, 0xcf, 0xa0, 0x67, 0x40, 0xd8, 0x87, 0xc5, 0x2e, 0x95, 0xdf, 0x86,
-	0x4c, 0x52, 0x63, 0x72, 0x07, 0xaa, 0x0e, 0x21, 0x9c, 0x0a, 0xa1, 0x8d, 0x16, 0x55, 0xec, 0x45,
-	0x7b, 0xb6, 0x01, 0x7d, 0x5c, 0xd4, 0xee, 0xc1, 0x52, 0x6a, 0x2f, 0xe6, 0x7c, 0x13, 0xe6, 0x5d,
-	0x26, 0xa4, 0x7e, 0x3b, 0x6b, 0xea, 0xdb, 0x55, 0x15, 0xe6, 0x50, 0x10, 0xcc, 0x60, 0xe9, 0x60,
-	0xe0, 0x05, 0x4f, 0x39, 0xa1, 0xfc, 0x3f, 0xe1, 0xfc, 0x19, 0x2c, 0x67, 0x0c, 0xa6, 0xe1, 0x2f,
-	0xb9, 0xe3, 0xbe, 0xf1, 0xfc, 0xa3, 0x34, 0xb7, 0xc0, 0x88, 0x7a, 0x04, 0xff, 0x66, 0x41, 0x35,
-	0xb6, 0x8b, 0x2e, 0x43, 0x53, 0x48, 0x4e, 0xa9, 0xec, 0x67, 0x59, 0xd6, 0xec, 0x46, 0x24, 0x35,
-	0x30, 0x04, 0x25, 0xd7, 0x94, 0xb9, 0x9a, 0xad, 0xbf, 0x55, 0x00, 0x08, 0xe9, 0x48, 0x1a, 0xe7,
-	0x43, 0xb4, 0x50, 0x99, 0xe0, 0xb2, 0xd0, 0x97, 0x7c, 0x6c, 0x32, 0x21, 0x5e, 0xa2, 0xf3, 0x30,
-	0xff, 0xde, 0x0b, 0xfa, 0x2e, 0x23, 0x54, 0x27, 0x42, 0xd9, 0xae, 0xbe, 0xf7, 0x82, 0x0e, 0x23,
-	0x14, 0xbf, 0x80, 0xb2, 0x76, 0x25, 0xda, 0x86, 0x86, 0x1b, 0x72, 0x4e, 0x7d, 0x77, 0x1c, 0x01,
-	0x23, 0x36, 0x0b, 0x46, 0xa8, 0xd0, 0xca, 0x70, 0xe8, 0x7b, 0x52, 0x68, 0x36, 0x73, 0x76, 0xb4,
-	0x50, 0x52, 0xdf, 0xf1, 0x99, 0xd0, 0x74, 0xca, 0x76, 0xb4, 0xc0, 0x5d, 0xd8, 0xe8, 0x52, 0x79,
-	0x10, 0x06, 0x01, 0xe3, 0x92, 0x92, 0x4e, 0xa4, 0xc7, 0xa3, 0x69, 0x5c, 0x5e, 0x86, 0x66, 0xce,
-	0xa4, 0x29, 0x18, 0x8d, 0xac, 0x4d, 0x81, 0x7f, 0x80, 0xf3, 0x9d, 0x44, 0xe0, 0x1f, 0x53, 0x2e,
-	0x3c, 0xe6, 0x9b, 0x47, 0xbe, 0x02, 0xa5, 0xd7, 0x9c, 0x8d, 0x4e, 0x89, 0x11, 0xbd, 0xaf, 0x4a,
-	0x9e, 0x64, 0xd1, 0xc5, 0x22, 0x4f, 0x56, 0x24, 0xd3, 0x0e, 0xf8, 0xc7, 0x82, 0x66, 0x87, 0x53,
-	0xe2, 0xa9, 0x7a, 0x4d, 0x7a, 0xfe, 0x6b, 0x86, 0x6e, 0x00, 0x72, 0xb5, 0xa4, 0xef, 0x3a, 0x9c,
-	0xf4, 0xfd, 0x70, 0xf4, 0x8a, 0xf2, 0xd8, 0x1f, 0x4b, 0x6e, 0x82, 0xfd, 0x46, 0xcb, 0xd1, 0x15,
-	0x58, 0xcc, 0xa2, 0xdd, 0xe3, 0xe3, 0xb8, 0x25, 0x35, 0x52, 0x68, 0xe7, 0xf8, 0x18, 0x7d, 0x0e,
-	0xeb, 0x59, 0x1c, 0x7d, 0x17, 0x78, 0x5c, 0x97, 0xcf, 0xfe, 0x98, 0x3a, 0x3c, 0xf6, 0x5d, 0x2b,
-	0x3d, 0xb3, 0x9f, 0x00, 0xbe, 0xa7, 0x0e, 0x47, 0x0f, 0xe0, 0xc2, 0x94, 0xe3, 0x23, 0xe6, 0xcb,
-	0x81, 0x7e, 0xf2, 0xb2, 0x7d, 0x7e, 0xd2, 0xf9, 0x27, 0x0a, 0x80, 0xc7, 0xd0, 0xe8, 0x0c, 0x1c,
-	0x7e, 0x94, 0xe4, 0xf4, 0x27, 0x50, 0x71, 0x46, 0x2a, 0x42, 0x4e, 0x71, 0x5e, 0x8c, 0x40, 0xf7,
-	0xa1, 0x9e, 0xb1, 0x1e, 0x37, 0xcc, 0xf5, 0x7c, 0x86, 0xe4, 0x9c, 0x68, 0x43, 0xca, 0x04, 0xdf,
-	0x81, 0xa6, 0x31, 0x9d, 0x3e, 0xbd, 0xe4, 0x8e, 0x2f, 0x1c, 0x57, 0x5f, 0x21, 0x49, 0x96, 0x46,
-	0x46, 0xda, 0x23, 0xf8, 0x47, 0xa8, 0xe9, 0x0c, 0xd3, 0x33, 0x81, 0xe9, 0xd6, 0xd6, 0x99, 0xdd,
-	0x5a, 0x45, 0x85, 0xaa, 0x0c, 0x31, 0xcf, 0x89, 0x51, 0xa1, 0xf6, 0xf1, 0xcf, 0xb3, 0x50, 0x37,
-	0x29, 0x1c, 0x0e, 0xa5, 0x4a, 0x14, 0xa6, 0x96, 0x29, 0xa1, 0xaa, 0x5e, 0xf7, 0x08, 0xba, 0x0d,
-	0x2b, 0x62, 0xe0, 0x05, 0x81, 0xca, 0xed, 0x6c, 0x92, 0x47, 0xd1, 0x84, 0xcc, 0xde, 0xf3, 0x24,
-	0xd9, 0xd1, 0x1d, 0x68, 0x24, 0x27, 0x34, 0x9b, 0xb9, 0xa9, 0x6c, 0x16, 0x0c, 0xb0, 0xc3, 0x84,
-	0x44, 0x0f, 0x60, 0x29, 0x39, 0x68, 0x6a, 0x43, 0xe9, 0x94, 0x0a, 0xb6, 0x68, 0xd0, 0xa6, 0x66,
-	0xdc, 0x30, 0x95, 0xac, 0xac, 0x2b, 0xd9, 0x5a, 0xee, 0x54, 0xe2, 0x50, 0x53, 0xca, 0x08, 0x5c,
-	0x38, 0xa0, 0x3e, 0xd1, 0xf2, 0x0e, 0xf3, 0x5f, 0x7b, 0x7c, 0xa4, 0xc3, 0x26, 0xd3, 0x6e, 0xe8,
-	0xc8, 0xf1, 0x86, 0xa6, 0xdd, 0xe8, 0x05, 0xda, 0x81, 0xb2, 0x76, 0x4d, 0xec, 0xe3, 0xd6, 0x49,
-	0x1b, 0x91, 0x4f, 0xed, 0x08, 0x86, 0xff, 0xb6, 0x60, 0xf9, 0xd9, 0xd0, 0x71, 0x69, 0xae, 0x46,
-	0x4f, 0x9d, 0x44, 0xb6, 0xa1, 0xa1, 0x37, 0x4c, 0x29, 0x88, 0xfd, 0xbc, 0xa0, 0x84, 0xa6, 0x1a,
-	0x64, 0x2b, 0xfc, 0xdc, 0x87, 0x54, 0xf8, 0xe4, 0x26, 0xe5, 0xec, 0x4d, 0x0a, 0xb1, 0x5d, 0xf9,
-	0xb8, 0xd8, 0x7e, 0x08, 0x28, 0x7b, 0xad, 0xa4, 0xe5, 0xc6, 0xde, 0xb1, 0x3e, 0xcc, 0x3b, 0x3b,
-	0x50, 0xdb, 0x23, 0xc6, 0x29, 0x5b, 0xb0, 0xe0, 0x32, 0x5f, 0xd2, 0x77, 0xb2, 0xff, 0x86, 0x8e,
-	0x4d, 0x55, 0xac, 0xc7, 0xb2, 0xaf, 0xe9, 0x58, 0xe0, 0x5b, 0x00, 0x0a, 0x1f, 0x5b, 0xdb, 0x82,
-	0x39, 0x87, 0x98, 0xe6, 0xbe, 0x58, 0xf0, 0x81, 0xad, 0xf6, 0xf0, 0x3d, 0x98, 0xdd, 0x23, 0x4a,
-	0xb3, 0x62, 0xce, 0xa9, 0x2b, 0xfb, 0x21, 0x37, 0x2f, 0x5a, 0x37, 0xb2, 0x43, 0x3e, 0x54, 0xfd,
-	0x46, 0x59, 0x31, 0xfd, 0x46, 0x7d, 0xe3, 0x2d, 0x68, 0x74, 0x38, 0x75, 0xd2, 0x71, 0x60, 0x09,
-	0xe6, 0xc4, 0xb1, 0x1b, 0x1f, 0x57, 0x9f, 0x0a, 0xf2, 0x90, 0x0e, 0xe9, 0x29, 0x90, 0xdd, 0xbf,
-	0x2c, 0xa8, 0xab, 0x3c, 0x3d, 0xa0, 0xfc, 0xd8, 0x73, 0x29, 0xba, 0xaf, 0x7b, 0xa1, 0x4e, 0xed,
-	0xf5, 0xe2, 0xbb, 0x65, 0xc6, 0xf7, 0x76, 0x3e, 0x61, 0xa2, 0xf9, 0x76, 0x06, 0xdd, 0x83, 0x6a,
-	0x3c, 0x63, 0x17, 0x4e, 0xe7, 0x27, 0xef, 0xf6, 0xf2, 0x89, 0x3a, 0x81, 0x67, 0xd0, 0x97, 0x50,
-	0x4b, 0xa6, 0x79, 0x74, 0xf1, 0xa4, 0xfe, 0xac, 0x82, 0x89, 0xe6, 0x77, 0x7f, 0xb1, 0x60, 0x35,
-	0x3f, 0x05, 0x9b, 0x6b, 0xfd, 0x04, 0xff, 0x9b, 0x30, 0x22, 0xa3, 0xff, 0xe7, 0xd4, 0x4c, 0x1f,
-	0xce, 0xdb, 0x57, 0xcf, 0x06, 0x46, 0xcf, 0xae, 0x58, 0xcc, 0xc2, 0x6a, 0x3c, 0xbe, 0x75, 0x1c,
-	0xe9, 0x0c, 0xd9, 0x91, 0x61, 0xd1, 0x85, 0x85, 0xec, 0xac, 0x8a, 0x26, 0xdc, 0xa2, 0xbd, 0x75,
-	0xc2, 0x52, 0x71, 0x74, 0xc4, 0x33, 0xe8, 0x21, 0x40, 0x3a, 0xaa, 0xa2, 0x8d, 0xa2, 0xab, 0xf3,
-	0x33, 0x6c, 0x7b, 0xe2, 0x64, 0x89, 0x67, 0xd0, 0x4b, 0x68, 0xe6, 0x87, 0x53, 0x84, 0x73, 0xc8,
-	0x89, 0x83, 0x6e, 0x7b, 0xfb, 0x54, 0x4c, 0xe2, 0x85, 0xdf, 0x2d, 0x58, 0x3c, 0x88, 0x4b, 0xa0,
-	0xb9, 0x7f, 0x0f, 0xe6, 0xcd, 0x4c, 0x89, 0x2e, 0x14, 0x49, 0x67, 0x47, 0xdb, 0xf6, 0xc5, 0x29,
-	0xbb, 0x89, 0x07, 0x1e, 0x43, 0x2d, 0x19, 0xf5, 0x0a, 0xc1, 0x52, 0x9c, 0x39, 0xdb, 0x1b, 0xd3,
-	0xb6, 0x13, 0xb2, 0x7f, 0x58, 0xb0, 0x68, 0x0a, 0x98, 0x21, 0xfb, 0x12, 0xd6, 0x26, 0x8f, 0x4a,
-	0x13, 0x9f, 0xed, 0x7a, 0x91, 0xf0, 0x29, 0x33, 0x16, 0x9e, 0x41, 0x5d, 0xa8, 0x46, 0x63, 0x93,
-	0x44, 0x57, 0xf2, 0xb9, 0x30, 0x6d, 0xa8, 0x6a, 0x4f, 0x68, 0x51, 0x78, 0x66, 0xf7, 0x10, 0x9a,
-	0xcf, 0x9c, 0xf1, 0x88, 0xfa, 0x49, 0x06, 0x77, 0xa0, 0x12, 0xf5, 0x75, 0xd4, 0xce, 0x6b, 0xce,
-	0xce, 0x19, 0xed, 0xf5, 0x89, 0x7b, 0x89, 0x43, 0x06, 0xb0, 0xb0, 0xaf, 0xea, 0xb0, 0x51, 0xfa,
-	0x42, 0xfd, 0xec, 0x99, 0xd0, 0x8e, 0xd0, 0xb5, 0x42, 0x34, 0x4c, 0x6f, 0x59, 0x53, 0x72, 0xf6,
-	0x15, 0x2c, 0x76, 0x06, 0xd4, 0x7d, 0xc3, 0xc2, 0xe4, 0x06, 0x4f, 0x01, 0xd2, 0xea, 0x5d, 0x88,
-	0xee, 0x13, 0xdd, 0xaa, 0x7d, 0x69, 0xea, 0x7e, 0x72, 0x9b, 0x47, 0xaa, 0x90, 0x1b, 0xed, 0xf7,
-	0xa0, 0xd2, 0x55, 0x93, 0xbc, 0x40, 0x6b, 0xc5, 0xa2, 0x1c, 0x6b, 0x3c, 0x77, 0x42, 0x9e, 0x68,
-	0xfa, 0xd5, 0x82, 0x85, 0xaf, 0x9c, 0x70, 0x98, 0x70, 0xbd, 0x0b, 0x95, 0xa8, 0x0a, 0x17, 0xbd,
-	0x9d, 0x2d, 0xcd, 0x53, 0xaa, 0xe5, 0x5d, 0xa8, 0x44, 0xe5, 0xb9, 0x70, 0x36, 0x57, 0xb3, 0xa7,
-	0xb8, 0xed, 0x01, 0xd4, 0x9f, 0x53, 0x91, 0xd0, 0xb8, 0x0d, 0x25, 0xb5, 0x9c, 0x18, 0x9a, 0x13,
-	0x15, 0xbc, 0xaa, 0xe8, 0x3f, 0x7b, 0x3e, 0xfd, 0x37, 0x00, 0x00, 0xff, 0xff, 0x92, 0x82, 0xef,
-	0x53, 0xfa, 0x11, 0x00, 0x00,
+	// 2521 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4d, 0x73, 0x1b, 0x49,
+	0xd5, 0x23, 0xeb, 0xf3, 0xe9, 0xc3, 0x72, 0xc7, 0x76, 0x64, 0x39, 0xc9, 0xda, 0x1d, 0x92, 0x38,
+	0x9b, 0xc4, 0x9b, 0x32, 0x54, 0x05, 0x36, 0x21, 0x41, 0x91, 0x15, 0x47, 0x1b, 0xc7, 0x0e, 0x63,
+	0x3b, 0xd9, 0xd4, 0x56, 0xa1, 0x9a, 0xcc, 0xb4, 0xad, 0x21, 0x9a, 0x19, 0x65, 0xa6, 0xe5, 0x5d,
+	0x6d, 0x71, 0x5a, 0x28, 0x8e, 0x40, 0x15, 0x70, 0x80, 0x23, 0x7f, 0x80, 0xff, 0xc1, 0x9d, 0xbf,
+	0xc0, 0x9d, 0x03, 0x57, 0x8a, 0xea, 0x9e, 0xee, 0xf9, 0xd2, 0xc8, 0x32, 0xb5, 0x5b, 0x7b, 0xd2,
+	0xf4, 0xfb, 0xee, 0xd7, 0xef, 0xbd, 0x7e, 0xfd, 0x04, 0x60, 0x10, 0xcb, 0xd9, 0x1a, 0xba, 0x0e,
+	0x75, 0x50, 0xb9, 0x6f, 0x0e, 0x3d, 0x4a, 0x5c, 0xaf, 0xef, 0x0c, 0x71, 0x07, 0x8a, 0x6d, 0xcd,
+	0xa5, 0x5d, 0x4a, 0x2c, 0x74, 0x15, 0x60, 0xe8, 0x3a, 0xc6, 0x48, 0xa7, 0x3d, 0xd3, 0x68, 0x28,
+	0xeb, 0xca, 0x66, 0x49, 0x2d, 0x09, 0x48, 0xd7, 0x40, 0x4d, 0x28, 0x7e, 0x18, 0x69, 0x36, 0x35,
+	0xe9, 0xb8, 0x91, 0x59, 0x57, 0x36, 0x73, 0x6a, 0xb0, 0xc6, 0x47, 0x50, 0x6b, 0x19, 0x06, 0x93,
+	0xa2, 0x92, 0x0f, 0x23, 0xe2, 0x51, 0x74, 0x19, 0x0a, 0x23, 0x8f, 0xb8, 0xa1, 0xa4, 0x3c, 0x5b,
+	0x76, 0x0d, 0x74, 0x1b, 0xb2, 0x26, 0x25, 0x16, 0x17, 0x51, 0xde, 0x5e, 0xde, 0x8a, 0x58, 0xb3,
+	0x25, 0x4d, 0x51, 0x39, 0x09, 0xbe, 0x03, 0xf5, 0x8e, 0x35, 0xa4, 0x63, 0x06, 0x9e, 0x25, 0x17,
+	0xdf, 0x86, 0xda, 0x2e, 0xa1, 0x17, 0x22, 0xdd, 0x83, 0x2c, 0xa3, 0x9b, 0x6e, 0xe3, 0x1d, 0xc8,
+	0x31, 0x03, 0xbc, 0x46, 0x66, 0x7d, 0x7e, 0xba, 0x91, 0x3e, 0x0d, 0x2e, 0x40, 0x8e, 0x5b, 0x89,
+	0x5f, 0x43, 0x73, 0xcf, 0xf4, 0xa8, 0x4a, 0x74, 0xc7, 0xb2, 0x88, 0x6d, 0x68, 0xd4, 0x74, 0x6c,
+	0x6f, 0xa6, 0x43, 0x3e, 0x82, 0x72, 0xe8, 0x76, 0x5f, 0x65, 0x49, 0x85, 0xc0, 0xef, 0x1e, 0x7e,
+	0x0c, 0x6b, 0xa9, 0x72, 0xbd, 0xa1, 0x63, 0x7b, 0x24, 0xc9, 0xaf, 0x4c, 0xf0, 0xff, 0x21, 0x03,
+	0x85, 0x57, 0xfe, 0x12, 0xd5, 0x20, 0x13, 0x18, 0x90, 0x31, 0x0d, 0x84, 0x20, 0x6b, 0x6b, 0x16,
+	0xe1, 0xa7, 0x51, 0x52, 0xf9, 0x37, 0x5a, 0x87, 0xb2, 0x41, 0x3c, 0xdd, 0x35, 0x87, 0x4c, 0x51,
+	0x63, 0x9e, 0xa3, 0xa2, 0x20, 0xd4, 0x80, 0xc2, 0xd0, 0xd4, 0xe9, 0xc8, 0x25, 0x8d, 0x2c, 0xc7,
+	0xca, 0x25, 0xfa, 0x04, 0x4a, 0x43, 0xd7, 0xd4, 0x49, 0x6f, 0xe4, 0x19, 0x8d, 0x1c, 0x3f, 0x62,
+	0x14, 0xf3, 0xde, 0x4b, 0xc7, 0x26, 0x63, 0xb5, 0xc8, 0x89, 0x8e, 0x3d, 0x03, 0x5d, 0x03, 0xd0,
+	0x35, 0x4a, 0x4e, 0x1d, 0xd7, 0x24, 0x5e, 0x23, 0xef, 0x1b, 0x1f, 0x42, 0xd0, 0x1a, 0x94, 0xbe,
+	0x24, 0xe6, 0x69, 0x9f, 0xf6, 0xde, 0x9f, 0x36, 0x0a, 0xeb, 0xca, 0xa6, 0xa2, 0x16, 0x7d, 0xc0,
+	0x8b, 0x53, 0xf4, 0x00, 0xc0, 0x30, 0x2d, 0x62, 0x7b, 0xcc, 0x21, 0x8d, 0x22, 0x57, 0x77, 0x39,
+	0xa6, 0x6e, 0x27, 0x40, 0xab, 0x11, 0x52, 0xac, 0x01, 0x84, 0x18, 0xa6, 0x63, 0x40, 0xec, 0x53,
+	0xda, 0xef, 0xe9, 0x16, 0xf7, 0x8d, 0xa2, 0x16, 0x7d, 0x40, 0xdb, 0x42, 0xab, 0x50, 0xfc, 0xd2,
+	0x34, 0x7c, 0x5c, 0x86, 0xe3, 0x0a, 0x7c, 0xdd, 0xb6, 0x18, 0x5f, 0xdf, 0xb7, 0x4d, 0xb7, 0xb8,
+	0x9b, 0x14, 0xb5, 0xe8, 0x03, 0xda, 0x16, 0x7e, 0x0e, 0x4b, 0xec, 0xd4, 0x84, 0xe3, 0xc3, 0xe3,
+	0xba, 0x0f, 0x45, 0x71, 0x36, 0xfe, 0x59, 0x95, 0xb7, 0x97, 0x62, 0x16, 0x0b, 0x06, 0x35, 0xa0,
+	0xc2, 0xd7, 0x61, 0x71, 0x97, 0x48, 0x41, 0x32, 0x9c, 0x12, 0x07, 0x89, 0xef, 0xc1, 0xf2, 0x21,
+	0xd1, 0x5c, 0xbd, 0x1f, 0x2a, 0xf4, 0x09, 0x97, 0x20, 0xf7, 0x61, 0x44, 0xdc, 0xb1, 0xa0, 0xf5,
+	0x17, 0xf8, 0x39, 0xac, 0x24, 0xc9, 0x85, 0x7d, 0x5b, 0x50, 0x70, 0x89, 0x37, 0x1a, 0xcc, 0x30,
+	0x4f, 0x12, 0x61, 0x1b, 0x16, 0x76, 0x09, 0xfd, 0xf9, 0xc8, 0xa1, 0x44, 0xaa, 0xdc, 0x82, 0x82,
+	0x66, 0x18, 0x2e, 0xf1, 0x3c, 0xae, 0x34, 0x29, 0xa2, 0xe5, 0xe3, 0x54, 0x49, 0xf4, 0xff, 0xa5,
+	0xdb, 0x08, 0xea, 0xa1, 0x3e, 0x61, 0xf3, 0x3d, 0x28, 0xea, 0x8e, 0x47, 0x79, 0xd0, 0x29, 0x53,
+	0x83, 0xae, 0xc0, 0x68, 0x58, 0xcc, 0x6d, 0x43, 0xc1, 0xe1, 0x81, 0x2c, 0x35, 0x36, 0x62, 0xd4,
+	0x5c, 0xf6, 0x01, 0x27, 0x50, 0x25, 0x21, 0xfe, 0x8d, 0x02, 0xe5, 0x08, 0x02, 0x5d, 0x87, 0xaa,
+	0x47, 0xdc, 0x33, 0x16, 0xea, 0x03, 0x72, 0x46, 0x06, 0xc2, 0xbd, 0x15, 0x01, 0xdc, 0x63, 0xb0,
+	0x98, 0x5d, 0x99, 0xd9, 0x76, 0x6d, 0x40, 0x85, 0xba, 0x9a, 0xed, 0x99, 0xb4, 0x67, 0x68, 0x63,
+	0x8f, 0x87, 0x54, 0x4e, 0x2d, 0x0b, 0xd8, 0x8e, 0x36, 0xf6, 0xb0, 0x03, 0xf5, 0xc3, 0xbe, 0x39,
+	0x3c, 0x70, 0x0d, 0xe2, 0x7e, 0x2f, 0xee, 0xfe, 0x11, 0x2c, 0x46, 0x14, 0x86, 0x25, 0x87, 0xba,
+	0x9a, 0xfe, 0xde, 0xb4, 0x4f, 0xc3, 0x7a, 0x06, 0x12, 0xd4, 0x35, 0xf0, 0x8f, 0x61, 0xb9, 0xad,
+	0xd9, 0x3a, 0x19, 0x30, 0x5e, 0x8b, 0xd8, 0x41, 0xd8, 0xce, 0xe4, 0x7c, 0x08, 0x8d, 0x5d, 0x42,
+	0x25, 0xdb, 0x21, 0xd5, 0xe8, 0xc8, 0xbb, 0x30, 0xf3, 0x23, 0x58, 0x7d, 0xad, 0x0d, 0x4c, 0x43,
+	0xa3, 0xe4, 0x28, 0x80, 0x5e, 0x98, 0xfb, 0x33, 0x68, 0xa6, 0x71, 0x8b, 0x3d, 0x2f, 0x41, 0xee,
+	0x4c, 0x1b, 0x08, 0xc6, 0xa2, 0xea, 0x2f, 0xd0, 0x0a, 0xe4, 0x5d, 0xa2, 0x79, 0x8e, 0x2d, 0x2a,
+	0xa8, 0x58, 0xe1, 0x7f, 0x66, 0xa0, 0x16, 0xdf, 0xc4, 0x4c, 0xfd, 0xe8, 0x01, 0xe4, 0x3c, 0xaa,
+	0x51, 0xbf, 0x18, 0xd7, 0xb6, 0x37, 0x62, 0xe7, 0x12, 0x17, 0xb6, 0xc5, 0x7e, 0x88, 0xea, 0xd3,
+	0xb3, 0x8b, 0x7b, 0x34, 0x64, 0x66, 0x1b, 0x3d, 0x8d, 0xf2, 0xa8, 0x99, 0x57, 0x4b, 0x02, 0xd2,
+	0xa2, 0xe8, 0x1e, 0x20, 0xe2, 0x51, 0xd3, 0xe2, 0x04, 0x06, 0x19, 0x98, 0x67, 0xac, 0x1c, 0x64,
+	0x39, 0xd9, 0x62, 0x80, 0xd9, 0x11, 0x88, 0x68, 0x38, 0xe5, 0x2e, 0x10, 0x4e, 0xf8, 0x3d, 0xe4,
+	0xb8, 0x35, 0xa8, 0x0c, 0x85, 0xe3, 0xfd, 0x17, 0xfb, 0x07, 0x6f, 0xf6, 0xeb, 0x73, 0x68, 0x11,
+	0xaa, 0x7b, 0xad, 0xa7, 0x9d, 0xbd, 0x5e, 0x5b, 0xed, 0xb4, 0x8e, 0x3a, 0x3b, 0x75, 0x05, 0xd5,
+	0x00, 0xba, 0xfb, 0xbd, 0x23, 0xb5, 0xb5, 0x7f, 0xd8, 0x3d, 0xaa, 0x67, 0xd0, 0x12, 0xd4, 0x0f,
+	0x8e, 0x8f, 0x7a, 0xcf, 0x0e, 0xd4, 0xde, 0x4e, 0x67, 0xaf, 0xfb, 0xba, 0xa3, 0xbe, 0xad, 0xcf,
+	0xa3, 0x2a, 0x94, 0xc4, 0xaa, 0xb3, 0x53, 0xcf, 0xb2, 0x65, 0xbb, 0xb5, 0xdf, 0xee, 0xec, 0xed,
+	0x75, 0x76, 0xea, 0x39, 0xfc, 0x7b, 0x05, 0x0a, 0xc2, 0x02, 0x74, 0x03, 0x6a, 0x1e, 0x75, 0x09,
+	0xa1, 0xbd, 0x68, 0xf8, 0x97, 0xd4, 0xaa, 0x0f, 0x95, 0x64, 0x08, 0xb2, 0xba, 0xec, 0x59, 0x4a,
+	0x2a, 0xff, 0x66, 0x87, 0xe9, 0xbb, 0xda, 0xbf, 0xdc, 0xfc, 0x05, 0xbb, 0xd6, 0x74, 0x67, 0x64,
+	0x53, 0xe1, 0x9d, 0x92, 0x2a, 0x97, 0xec, 0x12, 0xf8, 0xda, 0x1c, 0xf6, 0x74, 0xc7, 0x20, 0xdc,
+	0x29, 0x39, 0xb5, 0xf0, 0xb5, 0x39, 0x6c, 0x3b, 0x06, 0xc1, 0x9f, 0x43, 0x8e, 0xa7, 0x31, 0xab,
+	0x08, 0xfa, 0xc8, 0x75, 0x89, 0xad, 0x8f, 0x7d, 0x42, 0x51, 0x11, 0x24, 0x90, 0x51, 0x33, 0xc5,
+	0x23, 0xdb, 0xa4, 0x1e, 0xb7, 0x66, 0x5e, 0xf5, 0x17, 0x0c, 0x6a, 0x6b, 0xb6, 0x23, 0x33, 0xde,
+	0x5f, 0xe0, 0x6f, 0x14, 0xb8, 0xc6, 0x72, 0x61, 0x34, 0x1c, 0x3a, 0x2e, 0x25, 0x46, 0xdb, 0x17,
+	0x64, 0x92, 0xb0, 0x58, 0xdf, 0x80, 0x5a, 0x4c, 0xa7, 0xbc, 0xfe, 0xab, 0x51, 0xa5, 0x1e, 0xfa,
+	0x09, 0x80, 0x1e, 0x30, 0x8b, 0xb4, 0x5f, 0x8d, 0xa7, 0xbd, 0xa0, 0xef, 0xda, 0x27, 0x8e, 0x1a,
+	0x21, 0xc6, 0x0e, 0x54, 0xa2, 0x38, 0xee, 0xcd, 0x70, 0x73, 0xfc, 0x3b, 0xb5, 0x89, 0x58, 0x81,
+	0xbc, 0x37, 0xb6, 0xde, 0x39, 0x03, 0xe1, 0x62, 0xb1, 0x62, 0x59, 0x60, 0x99, 0xb6, 0xe3, 0xf6,
+	0x7c, 0x37, 0x64, 0xf9, 0x86, 0x81, 0x83, 0x8e, 0x19, 0x04, 0xff, 0x49, 0x81, 0xd5, 0x76, 0x60,
+	0xbd, 0x7d, 0x46, 0x5c, 0x76, 0x49, 0xcb, 0x24, 0xbe, 0x09, 0xd9, 0x13, 0xd7, 0xb1, 0xce, 0xa9,
+	0xf2, 0x1c, 0xcf, 0xba, 0x2d, 0xea, 0xf8, 0xc7, 0x20, 0x12, 0x93, 0x3a, 0xfc, 0x00, 0x36, 0xa0,
+	0xe2, 0x6a, 0x94, 0xf4, 0x84, 0x5c, 0xd9, 0xdd, 0x30, 0xd8, 0x6b, 0x1f, 0x84, 0x2e, 0x41, 0x4e,
+	0xf3, 0x7a, 0xce, 0x89, 0x48, 0x91, 0xac, 0xe6, 0x1d, 0x9c, 0xe0, 0xbf, 0x28, 0xd0, 0x4c, 0x33,
+	0x4b, 0x1c, 0xc4, 0xc7, 0x90, 0xf7, 0x2f, 0xc4, 0x73, 0x2c, 0x13, 0x14, 0x13, 0x26, 0x64, 0x26,
+	0x4d, 0xb8, 0x0b, 0x88, 0x2d, 0xbd, 0x1e, 0x39, 0x39, 0x21, 0x3a, 0x35, 0xcf, 0x48, 0x98, 0xd9,
+	0x75, 0x8e, 0xe9, 0x48, 0x44, 0x8b, 0xe2, 0xdf, 0x29, 0x70, 0xc9, 0xb7, 0x89, 0x3e, 0xd5, 0xa8,
+	0xde, 0x9f, 0x74, 0xd6, 0xfc, 0xf7, 0xeb, 0xac, 0x3f, 0x2b, 0xb0, 0x14, 0x37, 0x48, 0xb8, 0xe9,
+	0x6e, 0xb2, 0xb9, 0x48, 0xbd, 0x0f, 0x05, 0xc9, 0x77, 0xef, 0xa8, 0x7f, 0x29, 0x50, 0x6b, 0xbb,
+	0xc4, 0x30, 0xd9, 0x3b, 0xc1, 0xe0, 0xf1, 0x7c, 0x17, 0x90, 0xce, 0x21, 0x3d, 0x5d, 0x73, 0x8d,
+	0x9e, 0x3d, 0xb2, 0xde, 0x11, 0x57, 0x44, 0x77, 0x5d, 0x0f, 0x68, 0xf7, 0x39, 0x1c, 0xdd, 0x84,
+	0x85, 0x28, 0xb5, 0x7e, 0x76, 0x26, 0x9e, 0x42, 0xd5, 0x90, 0xb4, 0x7d, 0x76, 0x86, 0x7e, 0x0a,
+	0x6b, 0x51, 0x3a, 0xf2, 0xd5, 0xd0, 0x74, 0x79, 0xdb, 0xde, 0x1b, 0x13, 0xcd, 0x15, 0x69, 0xde,
+	0x08, 0x79, 0x3a, 0x01, 0xc1, 0x5b, 0xa2, 0xb9, 0xe8, 0x09, 0x5c, 0x99, 0xc2, 0x6e, 0x39, 0x36,
+	0xed, 0x8b, 0xac, 0x59, 0x4d, 0xe3, 0x7f, 0xc9, 0x08, 0xf0, 0xdf, 0x14, 0xa8, 0xb6, 0xfb, 0x9a,
+	0x7b, 0x1a, 0xf4, 0x64, 0x1f, 0x43, 0x5e, 0xb3, 0x58, 0x35, 0x3b, 0x2f, 0x40, 0x7d, 0x0a, 0xf4,
+	0x08, 0xca, 0x11, 0xf5, 0xa2, 0x73, 0x59, 0x8b, 0xd7, 0x8b, 0x98, 0x17, 0x55, 0x08, 0x4d, 0x41,
+	0xb7, 0x60, 0xc1, 0x34, 0x88, 0x35, 0x74, 0x28, 0x2f, 0x4b, 0xef, 0xc9, 0x58, 0xc4, 0x4d, 0x2d,
+	0x02, 0x7e, 0x41, 0xc6, 0xf8, 0x01, 0xd4, 0xa4, 0x8d, 0x61, 0x39, 0xe3, 0xcd, 0x8e, 0xa6, 0xf3,
+	0xcd, 0x06, 0xb7, 0x64, 0x35, 0x02, 0xed, 0x1a, 0xf8, 0x1d, 0x54, 0x55, 0x72, 0x32, 0xb2, 0x83,
+	0xab, 0xfd, 0x62, 0x7c, 0x11, 0x1f, 0x64, 0x66, 0xf9, 0x00, 0xdf, 0x83, 0x9a, 0xd4, 0x21, 0x8c,
+	0x5b, 0x83, 0x92, 0xcb, 0x21, 0xa1, 0xfc, 0xa2, 0x0f, 0xe8, 0x1a, 0xf8, 0x17, 0x50, 0xe2, 0x2d,
+	0x12, 0x7f, 0x48, 0xcb, 0x27, 0xae, 0x32, 0xf3, 0x89, 0xcb, 0x52, 0x94, 0x75, 0x7f, 0xe7, 0x18,
+	0xc4, 0xf1, 0xf8, 0x9b, 0x0c, 0x94, 0x65, 0x0f, 0xc6, 0x6a, 0xc8, 0x2a, 0x14, 0x1d, 0xb6, 0x0c,
+	0x6d, 0x29, 0xf0, 0x75, 0xd7, 0x40, 0xf7, 0x61, 0xc9, 0xeb, 0x9b, 0xc3, 0x21, 0xeb, 0x33, 0xa2,
+	0x0d, 0x87, 0x9f, 0x3d, 0x48, 0xe2, 0x8e, 0xa2, 0x8d, 0x47, 0x35, 0xe0, 0xe0, 0xd6, 0xcc, 0x4f,
+	0xb5, 0xa6, 0x22, 0x09, 0xdb, 0x8e, 0x47, 0xd1, 0x13, 0xa8, 0x07, 0x8c, 0xf2, 0x0e, 0xce, 0x9e,
+	0xd3, 0x33, 0x2c, 0x48, 0x6a, 0x01, 0x40, 0x77, 0x65, 0x2b, 0x9a, 0xe3, 0xd5, 0x60, 0x25, 0xc6,
+	0x15, 0x38, 0x54, 0xf6, 0xa2, 0x06, 0x5c, 0x39, 0x24, 0xb6, 0xc1, 0xe1, 0x6d, 0xc7, 0x3e, 0x31,
+	0x5d, 0x8b, 0xc7, 0x7c, 0xe4, 0xa9, 0x43, 0x2c, 0xcd, 0x94, 0xbd, 0xb8, 0xbf, 0x40, 0x5b, 0x90,
+	0xe3, 0xae, 0x11, 0x3e, 0x6e, 0x4c, 0xea, 0xf0, 0x7d, 0xaa, 0xfa, 0x64, 0xf8, 0xbf, 0x0a, 0x2c,
+	0xbe, 0x1a, 0x68, 0x3a, 0x89, 0x35, 0xd9, 0x53, 0x9f, 0xef, 0xd7, 0xa1, 0xca, 0x11, 0xf2, 0xc6,
+	0x15, 0x7e, 0xae, 0x30, 0xa0, 0xbc, 0x30, 0xa2, 0x3d, 0xd5, 0xfc, 0x45, 0x5a, 0xf4, 0x60, 0x27,
+	0xb9, 0xe8, 0x4e, 0x12, 0x79, 0x99, 0xff, 0xd6, 0x79, 0x59, 0x48, 0xcd, 0xcb, 0x1d, 0x40, 0xd1,
+	0xfd, 0x07, 0xef, 0x42, 0xe1, 0x46, 0xe5, 0x62, 0x6e, 0xfc, 0xbb, 0x02, 0x39, 0x0e, 0x46, 0xf7,
+	0x13, 0x77, 0xe3, 0x74, 0x56, 0x41, 0x17, 0x75, 0x76, 0x26, 0xe6, 0xec, 0xc0, 0x2f, 0xf3, 0x51,
+	0xbf, 0x6c, 0x42, 0x8e, 0x3a, 0x54, 0x1b, 0x34, 0xb2, 0x53, 0xe3, 0xd6, 0x27, 0x60, 0x39, 0x3c,
+	0x64, 0x5b, 0xe3, 0x8d, 0x72, 0x8e, 0xdf, 0x12, 0x45, 0x1f, 0xd0, 0xa2, 0xf8, 0x21, 0x2c, 0xb4,
+	0x0c, 0x23, 0x76, 0xea, 0x9b, 0xf1, 0x4d, 0xa3, 0x14, 0xcb, 0xc5, 0x76, 0xef, 0xf2, 0x67, 0x70,
+	0x8c, 0x79, 0x7a, 0x8e, 0xe2, 0x6d, 0xb8, 0xcc, 0x86, 0x03, 0x9c, 0xdc, 0x7b, 0x3a, 0x3e, 0xf6,
+	0x66, 0x07, 0x1a, 0x7e, 0x06, 0x8d, 0x49, 0x9e, 0xb0, 0xfd, 0xe0, 0xa2, 0xd3, 0xaf, 0x55, 0xdf,
+	0x2a, 0x41, 0x81, 0xb7, 0xa0, 0xd4, 0x0a, 0x2a, 0xe7, 0x06, 0x54, 0x74, 0xc7, 0xa6, 0xe4, 0x2b,
+	0xca, 0x02, 0x42, 0xb6, 0x8f, 0x65, 0x01, 0x7b, 0x41, 0xc6, 0x1e, 0xfe, 0x04, 0xa0, 0x15, 0x56,
+	0xc1, 0x0d, 0x98, 0xd7, 0x0c, 0xa9, 0x66, 0x21, 0x11, 0xc5, 0x2a, 0xc3, 0xe1, 0x87, 0x90, 0x69,
+	0xf1, 0xc7, 0x2c, 0x8b, 0x3d, 0x97, 0xe8, 0xb4, 0x37, 0x72, 0x65, 0x4e, 0x96, 0x25, 0xec, 0xd8,
+	0x1d, 0xb0, 0xbe, 0x91, 0x69, 0x91, 0x7d, 0x23, 0xfb, 0xc6, 0xbf, 0x82, 0x6a, 0xdb, 0x25, 0x5a,
+	0x38, 0x4c, 0xa8, 0xc3, 0xbc, 0x77, 0xa6, 0x0b, 0x76, 0xf6, 0xc9, 0x20, 0x23, 0xd7, 0x14, 0x5c,
+	0xec, 0x93, 0xcf, 0xa3, 0x88, 0xab, 0x13, 0x9b, 0x8a, 0x31, 0x8c, 0x5c, 0x06, 0xed, 0xaa, 0x7f,
+	0x63, 0xf2, 0x6f, 0x76, 0x2e, 0x06, 0x19, 0x68, 0xe3, 0x9e, 0xe5, 0x89, 0x18, 0x28, 0xf0, 0xf5,
+	0x4b, 0x0f, 0x6f, 0x40, 0x75, 0x87, 0x0c, 0xc8, 0x39, 0xda, 0xb7, 0xff, 0xa1, 0x40, 0x99, 0x15,
+	0xf1, 0x43, 0xff, 0xa1, 0x8f, 0x1e, 0xf1, 0x07, 0x09, 0xaf, 0xfb, 0x6b, 0xc9, 0xa4, 0x8e, 0x0c,
+	0x44, 0x9b, 0xf1, 0x23, 0xf1, 0x27, 0x86, 0x73, 0xe8, 0x21, 0x14, 0xc4, 0xd4, 0x32, 0xc1, 0x1d,
+	0x9f, 0x65, 0x36, 0x17, 0x27, 0x2e, 0x11, 0x3c, 0x87, 0x7e, 0x06, 0xa5, 0x60, 0x3e, 0x8a, 0xae,
+	0x4e, 0xca, 0x8f, 0x0a, 0x48, 0x55, 0xbf, 0xfd, 0x6b, 0x05, 0x96, 0xe3, 0x73, 0x45, 0xb9, 0xad,
+	0x5f, 0xc2, 0xa5, 0x94, 0xa1, 0x23, 0xba, 0x15, 0x13, 0x33, 0x7d, 0xdc, 0xd9, 0xdc, 0x9c, 0x4d,
+	0xe8, 0x47, 0x14, 0xb3, 0x22, 0x03, 0xcb, 0x62, 0xae, 0xd4, 0xd6, 0xa8, 0x36, 0x70, 0x4e, 0xa5,
+	0x15, 0xbb, 0x50, 0x89, 0x0e, 0xd1, 0x50, 0xca, 0x2e, 0x9a, 0x1b, 0x13, 0x9a, 0x92, 0x33, 0x2d,
+	0x3c, 0x87, 0x76, 0x00, 0xc2, 0x19, 0x1a, 0xba, 0x96, 0x74, 0x75, 0x7c, 0xb8, 0xd6, 0x4c, 0x1d,
+	0x79, 0xe1, 0x39, 0xf4, 0x05, 0xd4, 0xe2, 0x53, 0x33, 0x84, 0xe3, 0x8f, 0xf4, 0xb4, 0x09, 0x5c,
+	0xf3, 0xfa, 0xb9, 0x34, 0x81, 0x17, 0xfe, 0x98, 0x85, 0x85, 0x43, 0x71, 0x3f, 0xca, 0xfd, 0x77,
+	0xa1, 0x28, 0x87, 0x5d, 0xe8, 0x4a, 0xd2, 0xe8, 0xe8, 0xcc, 0xad, 0x79, 0x75, 0x0a, 0x36, 0xf0,
+	0xc0, 0x1e, 0x94, 0x82, 0x41, 0x4e, 0x22, 0x58, 0x92, 0x13, 0xa5, 0xe6, 0xb5, 0x69, 0xe8, 0x40,
+	0xda, 0x67, 0x50, 0x8b, 0x0f, 0x78, 0x12, 0x9e, 0x48, 0x9d, 0xfe, 0x4c, 0xc9, 0x81, 0xb7, 0x7c,
+	0xbe, 0x99, 0x98, 0x96, 0xdc, 0x48, 0xee, 0x27, 0x75, 0x24, 0xd4, 0x5c, 0x3b, 0x67, 0x48, 0x82,
+	0xe7, 0xd0, 0x1b, 0xa8, 0xbe, 0x61, 0x0f, 0x90, 0xc0, 0xca, 0xef, 0x44, 0xec, 0x7d, 0x05, 0x9d,
+	0x02, 0x9a, 0x9c, 0x15, 0xa1, 0x9b, 0x31, 0xb6, 0xa9, 0xa3, 0xa8, 0xe6, 0xad, 0x99, 0x74, 0x41,
+	0x54, 0xfc, 0x27, 0x03, 0x0b, 0xb2, 0x8d, 0x90, 0x51, 0xf1, 0x05, 0xac, 0xa4, 0xcf, 0x05, 0x52,
+	0xf3, 0xe3, 0xce, 0xc4, 0x96, 0xa7, 0x0f, 0x14, 0xf0, 0x1c, 0xda, 0x85, 0x82, 0x78, 0xba, 0x25,
+	0xb6, 0x33, 0xf5, 0x51, 0xde, 0x4c, 0xb9, 0x70, 0xf1, 0x1c, 0x22, 0x50, 0x17, 0x82, 0xde, 0x98,
+	0xb4, 0xaf, 0x6a, 0x94, 0x78, 0x17, 0x96, 0x78, 0x6b, 0x26, 0x5d, 0x60, 0xef, 0x31, 0x54, 0xa2,
+	0x4f, 0x4d, 0xb4, 0x1e, 0x67, 0x9d, 0x7c, 0x16, 0x37, 0x37, 0xce, 0xa1, 0x08, 0xfc, 0xfe, 0x57,
+	0x05, 0x6a, 0xaf, 0xb4, 0x31, 0x3f, 0x76, 0xe1, 0xf6, 0x36, 0xe4, 0xfd, 0xf7, 0x0a, 0x6a, 0xc6,
+	0x25, 0x44, 0x1f, 0x5a, 0xcd, 0xb5, 0x54, 0x5c, 0x60, 0x6e, 0x1b, 0xf2, 0xfe, 0xbb, 0x22, 0x21,
+	0x24, 0xf6, 0xa0, 0x69, 0xae, 0xa5, 0xe2, 0x02, 0xe3, 0xfa, 0x50, 0xe9, 0xb0, 0xce, 0x47, 0x5a,
+	0xf6, 0x39, 0x2c, 0xa7, 0x36, 0xc6, 0xe8, 0x76, 0xa2, 0xf4, 0x4c, 0x6f, 0x9e, 0xa7, 0x5c, 0x10,
+	0xef, 0x60, 0xa1, 0xdd, 0x27, 0xfa, 0x7b, 0x67, 0x14, 0xb8, 0xe1, 0x00, 0x20, 0x6c, 0x0f, 0x13,
+	0xa5, 0x74, 0xa2, 0x6f, 0x6e, 0x7e, 0x34, 0x15, 0x1f, 0xec, 0xe6, 0xdf, 0x0a, 0x54, 0x38, 0x4c,
+	0x6a, 0x78, 0x0c, 0x45, 0xd9, 0x88, 0x25, 0xaa, 0x5e, 0xa2, 0x3f, 0x9b, 0x52, 0x50, 0x1e, 0xf3,
+	0xaa, 0x99, 0xc6, 0x9f, 0x68, 0xd1, 0x9a, 0x29, 0x7d, 0x12, 0x9e, 0x43, 0x1a, 0xd4, 0x93, 0x9d,
+	0x16, 0xfa, 0xc1, 0xc4, 0x2d, 0x93, 0xd2, 0xbc, 0x35, 0x6f, 0xcc, 0xa0, 0x0a, 0xf6, 0xfc, 0x9c,
+	0x35, 0x61, 0x72, 0xbf, 0x0f, 0x21, 0xbf, 0xcb, 0xe6, 0x95, 0x1e, 0x5a, 0x49, 0x36, 0x54, 0x42,
+	0xee, 0xe5, 0x09, 0x78, 0x20, 0xe9, 0xb7, 0x0a, 0x54, 0x9e, 0x69, 0xa3, 0x41, 0x70, 0x3e, 0x9f,
+	0x42, 0xde, 0xef, 0xa0, 0x92, 0x61, 0x1a, 0x6d, 0xab, 0xa6, 0x78, 0xee, 0x53, 0xc8, 0xfb, 0xfd,
+	0x4f, 0x82, 0x37, 0xd6, 0x14, 0x4d, 0x09, 0x95, 0x27, 0x50, 0x3e, 0x22, 0x5e, 0x60, 0xc6, 0x7d,
+	0xc8, 0xb2, 0x65, 0x6a, 0x49, 0x4a, 0x15, 0xf0, 0x2e, 0xcf, 0xff, 0x9f, 0xfe, 0xe1, 0xff, 0x06,
+	0x00, 0x38, 0xa3, 0xa0, 0x68, 0xad, 0x1e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConnInterface
+var _ grpc.ClientConn
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+const _ = grpc.SupportPackageIsVersion4
 
 // CartServiceClient is the client API for CartService service.
 //
@@ -1783,10 +2937,10 @@ type CartServiceClient interface {
 }
 
 type cartServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewCartServiceClient(cc grpc.ClientConnInterface) CartServiceClient {
+func NewCartServiceClient(cc *grpc.ClientConn) CartServiceClient {
 	return &cartServiceClient{cc}
 }
 
@@ -1925,10 +3079,10 @@ type RecommendationServiceClient interface {
 }
 
 type recommendationServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewRecommendationServiceClient(cc grpc.ClientConnInterface) RecommendationServiceClient {
+func NewRecommendationServiceClient(cc *grpc.ClientConn) RecommendationServiceClient {
 	return &recommendationServiceClient{cc}
 }
 
@@ -1999,10 +3153,10 @@ type ProductCatalogServiceClient interface {
 }
 
 type productCatalogServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewProductCatalogServiceClient(cc grpc.ClientConnInterface) ProductCatalogServiceClient {
+func NewProductCatalogServiceClient(cc *grpc.ClientConn) ProductCatalogServiceClient {
 	return &productCatalogServiceClient{cc}
 }
 
@@ -2139,13 +3293,22 @@ var _ProductCatalogService_serviceDesc = grpc.ServiceDesc{
 type ShippingServiceClient interface {
 	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
 	ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error)
+	CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetShipmentStatus(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (*ShipmentStatus, error)
+	// Streams the status of a shipment whenever it changes, starting with the
+	// current status. The stream ends once the shipment is delivered or
+	// cancelled.
+	WatchShipment(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (ShippingService_WatchShipmentClient, error)
+	// Checks the format and check digit of a tracking ID. It does not check
+	// that a shipment with the ID exists.
+	ValidateTrackingId(ctx context.Context, in *ValidateTrackingIdRequest, opts ...grpc.CallOption) (*ValidateTrackingIdResponse, error)
 }
 
 type shippingServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewShippingServiceClient(cc grpc.ClientConnInterface) ShippingServiceClient {
+func NewShippingServiceClient(cc *grpc.ClientConn) ShippingServiceClient {
 	return &shippingServiceClient{cc}
 }
 
@@ -2167,10 +3330,78 @@ func (c *shippingServiceClient) ShipOrder(ctx context.Context, in *ShipOrderRequ
 	return out, nil
 }
 
+func (c *shippingServiceClient) CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/CancelShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) GetShipmentStatus(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (*ShipmentStatus, error) {
+	out := new(ShipmentStatus)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/GetShipmentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) WatchShipment(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (ShippingService_WatchShipmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ShippingService_serviceDesc.Streams[0], "/hipstershop.ShippingService/WatchShipment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shippingServiceWatchShipmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShippingService_WatchShipmentClient interface {
+	Recv() (*ShipmentStatus, error)
+	grpc.ClientStream
+}
+
+type shippingServiceWatchShipmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *shippingServiceWatchShipmentClient) Recv() (*ShipmentStatus, error) {
+	m := new(ShipmentStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shippingServiceClient) ValidateTrackingId(ctx context.Context, in *ValidateTrackingIdRequest, opts ...grpc.CallOption) (*ValidateTrackingIdResponse, error) {
+	out := new(ValidateTrackingIdResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/ValidateTrackingId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShippingServiceServer is the server API for ShippingService service.
 type ShippingServiceServer interface {
 	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
 	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+	CancelShipment(context.Context, *CancelShipmentRequest) (*Empty, error)
+	GetShipmentStatus(context.Context, *GetShipmentStatusRequest) (*ShipmentStatus, error)
+	// Streams the status of a shipment whenever it changes, starting with the
+	// current status. The stream ends once the shipment is delivered or
+	// cancelled.
+	WatchShipment(*GetShipmentStatusRequest, ShippingService_WatchShipmentServer) error
+	// Checks the format and check digit of a tracking ID. It does not check
+	// that a shipment with the ID exists.
+	ValidateTrackingId(context.Context, *ValidateTrackingIdRequest) (*ValidateTrackingIdResponse, error)
 }
 
 // UnimplementedShippingServiceServer can be embedded to have forward compatible implementations.
@@ -2183,6 +3414,18 @@ func (*UnimplementedShippingServiceServer) GetQuote(ctx context.Context, req *Ge
 func (*UnimplementedShippingServiceServer) ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShipOrder not implemented")
 }
+func (*UnimplementedShippingServiceServer) CancelShipment(ctx context.Context, req *CancelShipmentRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelShipment not implemented")
+}
+func (*UnimplementedShippingServiceServer) GetShipmentStatus(ctx context.Context, req *GetShipmentStatusRequest) (*ShipmentStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShipmentStatus not implemented")
+}
+func (*UnimplementedShippingServiceServer) WatchShipment(req *GetShipmentStatusRequest, srv ShippingService_WatchShipmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchShipment not implemented")
+}
+func (*UnimplementedShippingServiceServer) ValidateTrackingId(ctx context.Context, req *ValidateTrackingIdRequest) (*ValidateTrackingIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTrackingId not implemented")
+}
 
 func RegisterShippingServiceServer(s *grpc.Server, srv ShippingServiceServer) {
 	s.RegisterService(&_ShippingService_serviceDesc, srv)
@@ -2224,6 +3467,81 @@ func _ShippingService_ShipOrder_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShippingService_CancelShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).CancelShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/CancelShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).CancelShipment(ctx, req.(*CancelShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_GetShipmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).GetShipmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/GetShipmentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).GetShipmentStatus(ctx, req.(*GetShipmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_WatchShipment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetShipmentStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShippingServiceServer).WatchShipment(m, &shippingServiceWatchShipmentServer{stream})
+}
+
+type ShippingService_WatchShipmentServer interface {
+	Send(*ShipmentStatus) error
+	grpc.ServerStream
+}
+
+type shippingServiceWatchShipmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *shippingServiceWatchShipmentServer) Send(m *ShipmentStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ShippingService_ValidateTrackingId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTrackingIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).ValidateTrackingId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/ValidateTrackingId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).ValidateTrackingId(ctx, req.(*ValidateTrackingIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ShippingService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hipstershop.ShippingService",
 	HandlerType: (*ShippingServiceServer)(nil),
@@ -2236,8 +3554,26 @@ var _ShippingService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ShipOrder",
 			Handler:    _ShippingService_ShipOrder_Handler,
 		},
+		{
+			MethodName: "CancelShipment",
+			Handler:    _ShippingService_CancelShipment_Handler,
+		},
+		{
+			MethodName: "GetShipmentStatus",
+			Handler:    _ShippingService_GetShipmentStatus_Handler,
+		},
+		{
+			MethodName: "ValidateTrackingId",
+			Handler:    _ShippingService_ValidateTrackingId_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchShipment",
+			Handler:       _ShippingService_WatchShipment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
@@ -2247,13 +3583,19 @@ var _ShippingService_serviceDesc = grpc.ServiceDesc{
 type CurrencyServiceClient interface {
 	GetSupportedCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetSupportedCurrenciesResponse, error)
 	Convert(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*Money, error)
+	// ConvertWithRates converts like Convert, and also returns the version of
+	// the exchange rates used.
+	ConvertWithRates(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*CurrencyConversionResponse, error)
+	// ConvertBatch converts many amounts to one currency with the same version
+	// of the exchange rates.
+	ConvertBatch(ctx context.Context, in *ConvertBatchRequest, opts ...grpc.CallOption) (*ConvertBatchResponse, error)
 }
 
 type currencyServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewCurrencyServiceClient(cc grpc.ClientConnInterface) CurrencyServiceClient {
+func NewCurrencyServiceClient(cc *grpc.ClientConn) CurrencyServiceClient {
 	return &currencyServiceClient{cc}
 }
 
@@ -2275,10 +3617,34 @@ func (c *currencyServiceClient) Convert(ctx context.Context, in *CurrencyConvers
 	return out, nil
 }
 
+func (c *currencyServiceClient) ConvertWithRates(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*CurrencyConversionResponse, error) {
+	out := new(CurrencyConversionResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/ConvertWithRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) ConvertBatch(ctx context.Context, in *ConvertBatchRequest, opts ...grpc.CallOption) (*ConvertBatchResponse, error) {
+	out := new(ConvertBatchResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/ConvertBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CurrencyServiceServer is the server API for CurrencyService service.
 type CurrencyServiceServer interface {
 	GetSupportedCurrencies(context.Context, *Empty) (*GetSupportedCurrenciesResponse, error)
 	Convert(context.Context, *CurrencyConversionRequest) (*Money, error)
+	// ConvertWithRates converts like Convert, and also returns the version of
+	// the exchange rates used.
+	ConvertWithRates(context.Context, *CurrencyConversionRequest) (*CurrencyConversionResponse, error)
+	// ConvertBatch converts many amounts to one currency with the same version
+	// of the exchange rates.
+	ConvertBatch(context.Context, *ConvertBatchRequest) (*ConvertBatchResponse, error)
 }
 
 // UnimplementedCurrencyServiceServer can be embedded to have forward compatible implementations.
@@ -2291,6 +3657,12 @@ func (*UnimplementedCurrencyServiceServer) GetSupportedCurrencies(ctx context.Co
 func (*UnimplementedCurrencyServiceServer) Convert(ctx context.Context, req *CurrencyConversionRequest) (*Money, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
 }
+func (*UnimplementedCurrencyServiceServer) ConvertWithRates(ctx context.Context, req *CurrencyConversionRequest) (*CurrencyConversionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertWithRates not implemented")
+}
+func (*UnimplementedCurrencyServiceServer) ConvertBatch(ctx context.Context, req *ConvertBatchRequest) (*ConvertBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertBatch not implemented")
+}
 
 func RegisterCurrencyServiceServer(s *grpc.Server, srv CurrencyServiceServer) {
 	s.RegisterService(&_CurrencyService_serviceDesc, srv)
@@ -2332,6 +3704,42 @@ func _CurrencyService_Convert_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CurrencyService_ConvertWithRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyConversionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ConvertWithRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CurrencyService/ConvertWithRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ConvertWithRates(ctx, req.(*CurrencyConversionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ConvertBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ConvertBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CurrencyService/ConvertBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ConvertBatch(ctx, req.(*ConvertBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CurrencyService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hipstershop.CurrencyService",
 	HandlerType: (*CurrencyServiceServer)(nil),
@@ -2344,6 +3752,14 @@ var _CurrencyService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Convert",
 			Handler:    _CurrencyService_Convert_Handler,
 		},
+		{
+			MethodName: "ConvertWithRates",
+			Handler:    _CurrencyService_ConvertWithRates_Handler,
+		},
+		{
+			MethodName: "ConvertBatch",
+			Handler:    _CurrencyService_ConvertBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
@@ -2354,13 +3770,14 @@ var _CurrencyService_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type PaymentServiceClient interface {
 	Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
 }
 
 type paymentServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewPaymentServiceClient(cc grpc.ClientConnInterface) PaymentServiceClient {
+func NewPaymentServiceClient(cc *grpc.ClientConn) PaymentServiceClient {
 	return &paymentServiceClient{cc}
 }
 
@@ -2373,9 +3790,19 @@ func (c *paymentServiceClient) Charge(ctx context.Context, in *ChargeRequest, op
 	return out, nil
 }
 
+func (c *paymentServiceClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Refund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 type PaymentServiceServer interface {
 	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
+	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
 }
 
 // UnimplementedPaymentServiceServer can be embedded to have forward compatible implementations.
@@ -2385,6 +3812,9 @@ type UnimplementedPaymentServiceServer struct {
 func (*UnimplementedPaymentServiceServer) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Charge not implemented")
 }
+func (*UnimplementedPaymentServiceServer) Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
 
 func RegisterPaymentServiceServer(s *grpc.Server, srv PaymentServiceServer) {
 	s.RegisterService(&_PaymentService_serviceDesc, srv)
@@ -2408,6 +3838,24 @@ func _PaymentService_Charge_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.PaymentService/Refund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PaymentService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hipstershop.PaymentService",
 	HandlerType: (*PaymentServiceServer)(nil),
@@ -2416,6 +3864,10 @@ var _PaymentService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Charge",
 			Handler:    _PaymentService_Charge_Handler,
 		},
+		{
+			MethodName: "Refund",
+			Handler:    _PaymentService_Refund_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
@@ -2429,10 +3881,10 @@ type EmailServiceClient interface {
 }
 
 type emailServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewEmailServiceClient(cc grpc.ClientConnInterface) EmailServiceClient {
+func NewEmailServiceClient(cc *grpc.ClientConn) EmailServiceClient {
 	return &emailServiceClient{cc}
 }
 
@@ -2501,10 +3953,10 @@ type CheckoutServiceClient interface {
 }
 
 type checkoutServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewCheckoutServiceClient(cc grpc.ClientConnInterface) CheckoutServiceClient {
+func NewCheckoutServiceClient(cc *grpc.ClientConn) CheckoutServiceClient {
 	return &checkoutServiceClient{cc}
 }
 
@@ -2565,6 +4017,150 @@ var _CheckoutService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "demo.proto",
 }
 
+// OrderServiceClient is the client API for OrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OrderServiceClient interface {
+	AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	ListOrdersByUser(ctx context.Context, in *ListOrdersByUserRequest, opts ...grpc.CallOption) (*ListOrdersByUserResponse, error)
+}
+
+type orderServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrderServiceClient(cc *grpc.ClientConn) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/AddOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListOrdersByUser(ctx context.Context, in *ListOrdersByUserRequest, opts ...grpc.CallOption) (*ListOrdersByUserResponse, error) {
+	out := new(ListOrdersByUserResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/ListOrdersByUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderServiceServer is the server API for OrderService service.
+type OrderServiceServer interface {
+	AddOrder(context.Context, *AddOrderRequest) (*Empty, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	ListOrdersByUser(context.Context, *ListOrdersByUserRequest) (*ListOrdersByUserResponse, error)
+}
+
+// UnimplementedOrderServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOrderServiceServer struct {
+}
+
+func (*UnimplementedOrderServiceServer) AddOrder(ctx context.Context, req *AddOrderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrder not implemented")
+}
+func (*UnimplementedOrderServiceServer) GetOrder(ctx context.Context, req *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedOrderServiceServer) ListOrdersByUser(ctx context.Context, req *ListOrdersByUserRequest) (*ListOrdersByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrdersByUser not implemented")
+}
+
+func RegisterOrderServiceServer(s *grpc.Server, srv OrderServiceServer) {
+	s.RegisterService(&_OrderService_serviceDesc, srv)
+}
+
+func _OrderService_AddOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/AddOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddOrder(ctx, req.(*AddOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListOrdersByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrdersByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/ListOrdersByUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrdersByUser(ctx, req.(*ListOrdersByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OrderService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddOrder",
+			Handler:    _OrderService_AddOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrdersByUser",
+			Handler:    _OrderService_ListOrdersByUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
 // AdServiceClient is the client API for AdService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
@@ -2573,10 +4169,10 @@ type AdServiceClient interface {
 }
 
 type adServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewAdServiceClient(cc grpc.ClientConnInterface) AdServiceClient {
+func NewAdServiceClient(cc *grpc.ClientConn) AdServiceClient {
 	return &adServiceClient{cc}
 }
 
@@ -2646,10 +4242,10 @@ type FaultServiceClient interface {
 }
 
 type faultServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewFaultServiceClient(cc grpc.ClientConnInterface) FaultServiceClient {
+func NewFaultServiceClient(cc *grpc.ClientConn) FaultServiceClient {
 	return &faultServiceClient{cc}
 }
 
@@ -2753,10 +4349,10 @@ type TestServiceClient interface {
 }
 
 type testServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewTestServiceClient(cc grpc.ClientConnInterface) TestServiceClient {
+func NewTestServiceClient(cc *grpc.ClientConn) TestServiceClient {
 	return &testServiceClient{cc}
 }
 
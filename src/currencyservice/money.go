@@ -0,0 +1,179 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	pb "github.com/triplewy/microservices-demo/src/currencyservice/genproto"
+)
+
+const nanosPerUnit = 1000000000
+
+var errOverflow = errors.New("amount is too large")
+
+// roundingMode is how an amount is rounded to the minor unit of a currency.
+type roundingMode string
+
+const (
+	// roundHalfEven rounds ties to the nearest even minor unit (banker's
+	// rounding).
+	roundHalfEven roundingMode = "half_even"
+	// roundHalfUp rounds ties away from zero.
+	roundHalfUp roundingMode = "half_up"
+	// roundTruncate rounds toward zero.
+	roundTruncate roundingMode = "truncate"
+)
+
+func (m *roundingMode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	switch mode := roundingMode(s); mode {
+	case roundHalfEven, roundHalfUp, roundTruncate:
+		*m = mode
+		return nil
+	}
+	return fmt.Errorf("unknown rounding mode %q", s)
+}
+
+// currencyInfo is the ISO 4217 metadata of a currency, and how its amounts
+// are rounded.
+type currencyInfo struct {
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+	// MinorUnits is the number of decimals of the currency, such as 2 for
+	// cents and 0 for yen.
+	MinorUnits int          `json:"minor_units"`
+	Rounding   roundingMode `json:"rounding"`
+}
+
+// currencyTable holds the metadata of every known currency, and the rounding
+// of the others.
+type currencyTable struct {
+	Default    currencyInfo
+	Currencies map[string]currencyInfo
+}
+
+// parseCurrencies parses currency metadata. Currencies inherit the minor
+// units and rounding of the default unless they override them.
+func parseCurrencies(b []byte) (*currencyTable, error) {
+	var raw struct {
+		Default    currencyInfo               `json:"default"`
+		Currencies map[string]json.RawMessage `json:"currencies"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	t := &currencyTable{
+		Default:    currencyInfo{MinorUnits: raw.Default.MinorUnits, Rounding: raw.Default.Rounding},
+		Currencies: make(map[string]currencyInfo),
+	}
+	if t.Default.Rounding == "" {
+		t.Default.Rounding = roundHalfEven
+	}
+	if t.Default.MinorUnits < 0 || t.Default.MinorUnits > 9 {
+		return nil, errors.New("default: minor_units must be between 0 and 9")
+	}
+	for code, js := range raw.Currencies {
+		if !validCurrencyCode(code) {
+			return nil, fmt.Errorf("%q is not a currency code", code)
+		}
+		info := t.Default
+		if err := json.Unmarshal(js, &info); err != nil {
+			return nil, fmt.Errorf("currency %s: %v", code, err)
+		}
+		if info.MinorUnits < 0 || info.MinorUnits > 9 {
+			return nil, fmt.Errorf("currency %s: minor_units must be between 0 and 9", code)
+		}
+		t.Currencies[code] = info
+	}
+	return t, nil
+}
+
+// info returns the metadata of a currency, or the default rounding for
+// currencies without metadata.
+func (t *currencyTable) info(code string) currencyInfo {
+	if info, ok := t.Currencies[code]; ok {
+		return info
+	}
+	return t.Default
+}
+
+// validCurrencyCode reports whether code has the form of an ISO 4217 code:
+// three capital letters.
+func validCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMoney checks that the units and nanos of m are consistent.
+func validateMoney(m *pb.Money) error {
+	nanos := m.GetNanos()
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit {
+		return fmt.Errorf("nanos must be between -999999999 and 999999999, got %d", nanos)
+	}
+	if (m.GetUnits() > 0 && nanos < 0) || (m.GetUnits() < 0 && nanos > 0) {
+		return errors.New("units and nanos must have the same sign")
+	}
+	return nil
+}
+
+// moneyToRat returns the exact amount of m.
+func moneyToRat(m *pb.Money) *big.Rat {
+	n := new(big.Int).Mul(big.NewInt(m.GetUnits()), big.NewInt(nanosPerUnit))
+	n.Add(n, big.NewInt(int64(m.GetNanos())))
+	return new(big.Rat).SetFrac(n, big.NewInt(nanosPerUnit))
+}
+
+// roundToMoney rounds amount to the minor unit of the currency as info says.
+// Negative amounts are rounded like their absolute value.
+func roundToMoney(amount *big.Rat, code string, info currencyInfo) (*pb.Money, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(info.MinorUnits)), nil)
+	scaled := new(big.Rat).Mul(amount, new(big.Rat).SetInt(scale))
+
+	// QuoRem truncates toward zero, so minor and rem have the sign of amount.
+	minor, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() != 0 && info.Rounding != roundTruncate {
+		// Compare the discarded fraction with one half.
+		twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+		cmp := twice.Cmp(scaled.Denom())
+		if cmp > 0 || (cmp == 0 && (info.Rounding == roundHalfUp || minor.Bit(0) == 1)) {
+			minor.Add(minor, big.NewInt(int64(amount.Sign())))
+		}
+	}
+
+	if !minor.IsInt64() {
+		return nil, errOverflow
+	}
+	perUnit := scale.Int64()
+	m := minor.Int64()
+	return &pb.Money{
+		CurrencyCode: code,
+		Units:        m / perUnit,
+		Nanos:        int32(m % perUnit * (nanosPerUnit / perUnit)),
+	}, nil
+}
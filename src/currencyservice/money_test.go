@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRoundToMoney(t *testing.T) {
+	for _, tc := range []struct {
+		amount string
+		info   currencyInfo
+		units  int64
+		nanos  int32
+	}{
+		{"2.345", currencyInfo{MinorUnits: 2, Rounding: roundHalfEven}, 2, 340000000},
+		{"2.355", currencyInfo{MinorUnits: 2, Rounding: roundHalfEven}, 2, 360000000},
+		{"2.3451", currencyInfo{MinorUnits: 2, Rounding: roundHalfEven}, 2, 350000000},
+		{"2.345", currencyInfo{MinorUnits: 2, Rounding: roundHalfUp}, 2, 350000000},
+		{"2.344", currencyInfo{MinorUnits: 2, Rounding: roundHalfUp}, 2, 340000000},
+		{"2.349", currencyInfo{MinorUnits: 2, Rounding: roundTruncate}, 2, 340000000},
+		{"-2.345", currencyInfo{MinorUnits: 2, Rounding: roundHalfEven}, -2, -340000000},
+		{"-2.345", currencyInfo{MinorUnits: 2, Rounding: roundHalfUp}, -2, -350000000},
+		{"-2.349", currencyInfo{MinorUnits: 2, Rounding: roundTruncate}, -2, -340000000},
+		{"-0.005", currencyInfo{MinorUnits: 2, Rounding: roundHalfUp}, 0, -10000000},
+		{"2.5", currencyInfo{MinorUnits: 0, Rounding: roundHalfEven}, 2, 0},
+		{"3.5", currencyInfo{MinorUnits: 0, Rounding: roundHalfEven}, 4, 0},
+		{"2.5", currencyInfo{MinorUnits: 0, Rounding: roundHalfUp}, 3, 0},
+		{"1/3", currencyInfo{MinorUnits: 9, Rounding: roundHalfEven}, 0, 333333333},
+	} {
+		amount, _ := new(big.Rat).SetString(tc.amount)
+		m, err := roundToMoney(amount, "XXX", tc.info)
+		assert.NilError(t, err)
+		assert.Equal(t, tc.units, m.GetUnits(), "%s with %+v", tc.amount, tc.info)
+		assert.Equal(t, tc.nanos, m.GetNanos(), "%s with %+v", tc.amount, tc.info)
+	}
+
+	huge, _ := new(big.Rat).SetString("1e20")
+	_, err := roundToMoney(huge, "XXX", currencyInfo{MinorUnits: 2, Rounding: roundHalfEven})
+	assert.Equal(t, errOverflow, err)
+}
+
+func TestParseCurrencies(t *testing.T) {
+	table, err := parseCurrencies([]byte(`{
+		"default": {"minor_units": 2},
+		"currencies": {
+			"JPY": {"name": "Japanese Yen", "symbol": "¥", "minor_units": 0},
+			"CHF": {"name": "Swiss Franc", "rounding": "half_up"}
+		}
+	}`))
+	assert.NilError(t, err)
+	assert.Equal(t, currencyInfo{MinorUnits: 2, Rounding: roundHalfEven}, table.info("USD"))
+	assert.Equal(t, currencyInfo{Name: "Japanese Yen", Symbol: "¥", MinorUnits: 0, Rounding: roundHalfEven}, table.info("JPY"))
+	assert.Equal(t, currencyInfo{Name: "Swiss Franc", MinorUnits: 2, Rounding: roundHalfUp}, table.info("CHF"))
+
+	for _, js := range []string{
+		`{"default": {"rounding": "half_down"}}`,
+		`{"currencies": {"JPY": {"minor_units": 10}}}`,
+		`{"currencies": {"yen": {"minor_units": 0}}}`,
+	} {
+		_, err := parseCurrencies([]byte(js))
+		assert.Assert(t, err != nil, js)
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// errRatesUnchanged is returned by providers whose rates did not change since
+// the last fetch.
+var errRatesUnchanged = errors.New("exchange rates are unchanged")
+
+// rateSnapshot is a version of the exchange rates. Snapshots are never
+// modified once published.
+type rateSnapshot struct {
+	// Version identifies the rates: snapshots with the same rates have the
+	// same version.
+	Version string `json:"version"`
+	// EffectiveAt is when the snapshot became current.
+	EffectiveAt time.Time `json:"effective_at"`
+	// Rates are the exchange rates per euro.
+	Rates map[string]*big.Rat `json:"rates"`
+}
+
+func newRateSnapshot(rates map[string]*big.Rat, effectiveAt time.Time) *rateSnapshot {
+	codes := make([]string, 0, len(rates))
+	for code := range rates {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	h := sha256.New()
+	for _, code := range codes {
+		fmt.Fprintf(h, "%s=%s\n", code, rates[code].RatString())
+	}
+	return &rateSnapshot{
+		Version:     hex.EncodeToString(h.Sum(nil))[:16],
+		EffectiveAt: effectiveAt,
+		Rates:       rates,
+	}
+}
+
+// validateRates checks that rates are usable as exchange rates per euro.
+func validateRates(rates map[string]*big.Rat) error {
+	eur, ok := rates["EUR"]
+	if !ok || eur.Cmp(big.NewRat(1, 1)) != 0 {
+		return errors.New("exchange rates must include EUR with a rate of 1")
+	}
+	for code, rate := range rates {
+		if rate == nil || rate.Sign() <= 0 {
+			return fmt.Errorf("currency %s: rate must be positive", code)
+		}
+	}
+	return nil
+}
+
+// rateBook holds the current exchange rates and a bounded history of past
+// ones. Conversions read the current snapshot without locking, and
+// publishing swaps it atomically.
+type rateBook struct {
+	current atomic.Value // *rateSnapshot
+
+	mu sync.Mutex
+	// history holds the published snapshots, oldest first.
+	history []*rateSnapshot
+	size    int
+	// dir, if set, is where snapshots are persisted so that the history
+	// survives restarts.
+	dir string
+}
+
+// newRateBook returns a rate book that remembers up to size snapshots. If dir
+// is set, the snapshots persisted there are loaded and new ones are saved.
+func newRateBook(size int, dir string) (*rateBook, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("rate history size must be positive, got %d", size)
+	}
+	b := &rateBook{size: size, dir: dir}
+	if dir == "" {
+		return b, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create rate history directory: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rate snapshot: %v", err)
+		}
+		var s rateSnapshot
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("failed to parse rate snapshot %s: %v", f, err)
+		}
+		b.history = append(b.history, &s)
+	}
+	sort.Slice(b.history, func(i, j int) bool {
+		return b.history[i].EffectiveAt.Before(b.history[j].EffectiveAt)
+	})
+	b.trim()
+	if n := len(b.history); n > 0 {
+		b.current.Store(b.history[n-1])
+	}
+	return b, nil
+}
+
+// latest returns the current snapshot, or nil if none was published yet.
+func (b *rateBook) latest() *rateSnapshot {
+	s, _ := b.current.Load().(*rateSnapshot)
+	return s
+}
+
+// publish makes rates the current exchange rates as of now. It returns the
+// current snapshot, and whether it is a new one.
+func (b *rateBook) publish(rates map[string]*big.Rat, now time.Time) (*rateSnapshot, bool, error) {
+	if err := validateRates(rates); err != nil {
+		return nil, false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := newRateSnapshot(rates, now)
+	if cur := b.latest(); cur != nil && cur.Version == s.Version {
+		return cur, false, nil
+	}
+	if b.dir != "" {
+		if err := b.save(s); err != nil {
+			return nil, false, err
+		}
+	}
+	b.history = append(b.history, s)
+	b.trim()
+	b.current.Store(s)
+	return s, true, nil
+}
+
+// trim forgets the oldest snapshots beyond the size of the history.
+func (b *rateBook) trim() {
+	for len(b.history) > b.size {
+		if b.dir != "" {
+			os.Remove(b.path(b.history[0]))
+		}
+		b.history[0] = nil
+		b.history = b.history[1:]
+	}
+}
+
+func (b *rateBook) path(s *rateSnapshot) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%d-%s.json", s.EffectiveAt.UnixNano(), s.Version))
+}
+
+func (b *rateBook) save(s *rateSnapshot) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := b.path(s) + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to save rate snapshot: %v", err)
+	}
+	return os.Rename(tmp, b.path(s))
+}
+
+// byVersion returns the most recent snapshot with a version, or nil if it is
+// not in the history.
+func (b *rateBook) byVersion(version string) *rateSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := len(b.history) - 1; i >= 0; i-- {
+		if b.history[i].Version == version {
+			return b.history[i]
+		}
+	}
+	return nil
+}
+
+// asOf returns the snapshot that was current at t, or nil if t is before the
+// oldest snapshot in the history.
+func (b *rateBook) asOf(t time.Time) *rateSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := sort.Search(len(b.history), func(i int) bool {
+		return b.history[i].EffectiveAt.After(t)
+	})
+	if i == 0 {
+		return nil
+	}
+	return b.history[i-1]
+}
+
+// rateProvider is a source of exchange rates.
+type rateProvider interface {
+	// fetch returns the exchange rates per euro, or errRatesUnchanged if
+	// they did not change since the last call.
+	fetch(ctx context.Context) (map[string]*big.Rat, error)
+}
+
+// fileProvider reads exchange rates from a JSON file that maps currency
+// codes to rates per euro. The file is only read again once it is modified.
+type fileProvider struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+func (p *fileProvider) fetch(ctx context.Context) (map[string]*big.Rat, error) {
+	fi, err := os.Stat(p.path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.ModTime().Equal(p.modTime) && fi.Size() == p.size {
+		return nil, errRatesUnchanged
+	}
+	data, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := parseRates(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", p.path, err)
+	}
+	p.modTime, p.size = fi.ModTime(), fi.Size()
+	return rates, nil
+}
+
+// httpProvider pulls exchange rates from a URL that serves either the XML
+// daily reference rates of the European Central Bank, or JSON in the format
+// of parseRates.
+type httpProvider struct {
+	url    string
+	client *http.Client
+}
+
+func (p *httpProvider) fetch(ctx context.Context) (map[string]*big.Rat, error) {
+	req, err := http.NewRequest(http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", p.url, res.Status)
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var rates map[string]*big.Rat
+	if strings.Contains(res.Header.Get("Content-Type"), "xml") || bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		rates, err = parseECBRates(data)
+	} else {
+		rates, err = parseRates(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rates from %s: %v", p.url, err)
+	}
+	return rates, nil
+}
+
+// parseRates parses exchange rates in JSON, either as an object that maps
+// currency codes to rates per euro, or as {"base": "USD", "rates": {...}}
+// with rates per unit of the base currency. The rates are read as exact
+// decimals rather than floats.
+func parseRates(data []byte) (map[string]*big.Rat, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	nested, ok := raw["rates"]
+	if !ok {
+		return parseRateObject(data)
+	}
+	rates, err := parseRateObject(nested)
+	if err != nil {
+		return nil, err
+	}
+	base := "EUR"
+	if b, ok := raw["base"]; ok {
+		if err := json.Unmarshal(b, &base); err != nil {
+			return nil, fmt.Errorf("base: %v", err)
+		}
+	}
+	if _, ok := rates[base]; !ok {
+		rates[base] = big.NewRat(1, 1)
+	}
+	eur, ok := rates["EUR"]
+	if !ok {
+		return nil, errors.New("rates do not include EUR")
+	}
+	for code, rate := range rates {
+		rates[code] = new(big.Rat).Quo(rate, eur)
+	}
+	return rates, nil
+}
+
+func parseRateObject(data []byte) (map[string]*big.Rat, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var raw map[string]json.Number
+	if err := d.Decode(&raw); err != nil {
+		return nil, err
+	}
+	rates := make(map[string]*big.Rat, len(raw))
+	for code, n := range raw {
+		rate, ok := new(big.Rat).SetString(n.String())
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("currency %s: rate %s is not a positive number", code, n)
+		}
+		rates[code] = rate
+	}
+	return rates, nil
+}
+
+// parseECBRates parses the XML daily reference rates of the European Central
+// Bank, which are per euro and do not include the euro itself.
+func parseECBRates(data []byte) (map[string]*big.Rat, error) {
+	var doc struct {
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube>Cube>Cube"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Rates) == 0 {
+		return nil, errors.New("no rates")
+	}
+	rates := map[string]*big.Rat{"EUR": big.NewRat(1, 1)}
+	for _, r := range doc.Rates {
+		rate, ok := new(big.Rat).SetString(r.Rate)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("currency %s: rate %q is not a positive number", r.Currency, r.Rate)
+		}
+		rates[r.Currency] = rate
+	}
+	return rates, nil
+}
+
+// refreshRates publishes the rates of p to b.
+func refreshRates(ctx context.Context, p rateProvider, b *rateBook) error {
+	rates, err := p.fetch(ctx)
+	if err == errRatesUnchanged {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s, changed, err := b.publish(rates, time.Now())
+	if err != nil {
+		return err
+	}
+	if changed {
+		sugar.Infof("exchange rates updated to version %s", s.Version)
+	}
+	return nil
+}
+
+// watchRates refreshes the rates of b from p every interval until ctx is
+// done. Failed refreshes keep the current rates.
+func watchRates(ctx context.Context, p rateProvider, b *rateBook, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := refreshRates(ctx, p, b); err != nil {
+				sugar.Warnf("failed to refresh exchange rates: %v", err)
+			}
+		}
+	}
+}
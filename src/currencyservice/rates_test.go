@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func rat(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic(s)
+	}
+	return r
+}
+
+func TestRateBook(t *testing.T) {
+	b, err := newRateBook(2, "")
+	assert.NilError(t, err)
+	assert.Assert(t, b.latest() == nil)
+
+	t1, t2, t3 := time.Unix(100, 0), time.Unix(200, 0), time.Unix(300, 0)
+	s1, changed, err := b.publish(map[string]*big.Rat{"EUR": rat("1"), "USD": rat("1.1")}, t1)
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+
+	// Publishing the same rates again keeps the current snapshot.
+	same, changed, err := b.publish(map[string]*big.Rat{"EUR": rat("1"), "USD": rat("1.10")}, t2)
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+	assert.Equal(t, s1, same)
+
+	s2, _, err := b.publish(map[string]*big.Rat{"EUR": rat("1"), "USD": rat("1.2")}, t2)
+	assert.NilError(t, err)
+	assert.Assert(t, s1.Version != s2.Version)
+	assert.Equal(t, s2, b.latest())
+	assert.Equal(t, s1, b.byVersion(s1.Version))
+	assert.Equal(t, s1, b.asOf(t1.Add(time.Second)))
+	assert.Equal(t, s2, b.asOf(t2))
+	assert.Assert(t, b.asOf(t1.Add(-time.Second)) == nil)
+
+	// The oldest snapshot is forgotten beyond the size of the history.
+	_, _, err = b.publish(map[string]*big.Rat{"EUR": rat("1"), "USD": rat("1.3")}, t3)
+	assert.NilError(t, err)
+	assert.Assert(t, b.byVersion(s1.Version) == nil)
+	assert.Equal(t, s2, b.byVersion(s2.Version))
+
+	_, _, err = b.publish(map[string]*big.Rat{"USD": rat("1.3")}, t3)
+	assert.ErrorContains(t, err, "EUR")
+}
+
+func TestRateBookPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rates")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	b, err := newRateBook(2, dir)
+	assert.NilError(t, err)
+	var snapshots []*rateSnapshot
+	for i, usd := range []string{"1.1", "1.2", "1.3"} {
+		s, _, err := b.publish(map[string]*big.Rat{"EUR": rat("1"), "USD": rat(usd)}, time.Unix(int64(i+1)*100, 0))
+		assert.NilError(t, err)
+		snapshots = append(snapshots, s)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(files))
+
+	reloaded, err := newRateBook(2, dir)
+	assert.NilError(t, err)
+	assert.Equal(t, snapshots[2].Version, reloaded.latest().Version)
+	s := reloaded.asOf(time.Unix(250, 0))
+	assert.Assert(t, s != nil)
+	assert.Equal(t, snapshots[1].Version, s.Version)
+	assert.Equal(t, 0, s.Rates["USD"].Cmp(rat("1.2")))
+}
+
+func TestFileProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rates")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rates.json")
+	assert.NilError(t, ioutil.WriteFile(path, []byte(`{"EUR": 1.0, "USD": 1.1305}`), 0644))
+
+	p := &fileProvider{path: path}
+	rates, err := p.fetch(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, 0, rates["USD"].Cmp(rat("1.1305")))
+	_, err = p.fetch(context.Background())
+	assert.Equal(t, errRatesUnchanged, err)
+
+	assert.NilError(t, ioutil.WriteFile(path, []byte(`{"EUR": 1.0, "USD": 1.2}`), 0644))
+	assert.NilError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	rates, err = p.fetch(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, 0, rates["USD"].Cmp(rat("1.2")))
+}
+
+const ecbRates = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2020-04-24">
+			<Cube currency="USD" rate="1.0784"/>
+			<Cube currency="JPY" rate="116.03"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestHTTPProvider(t *testing.T) {
+	body, contentType := ecbRates, "text/xml"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	p := &httpProvider{url: srv.URL, client: srv.Client()}
+
+	rates, err := p.fetch(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(rates))
+	assert.Equal(t, 0, rates["EUR"].Cmp(rat("1")))
+	assert.Equal(t, 0, rates["JPY"].Cmp(rat("116.03")))
+
+	// JSON rates per dollar are converted to rates per euro.
+	body, contentType = `{"base": "USD", "rates": {"EUR": 0.8, "GBP": 0.7}}`, "application/json"
+	rates, err = p.fetch(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, 0, rates["EUR"].Cmp(rat("1")))
+	assert.Equal(t, 0, rates["USD"].Cmp(rat("1.25")))
+	assert.Equal(t, 0, rates["GBP"].Cmp(rat("0.875")))
+
+	b, err := newRateBook(10, "")
+	assert.NilError(t, err)
+	assert.NilError(t, refreshRates(context.Background(), p, b))
+	assert.Equal(t, 0, b.latest().Rates["GBP"].Cmp(rat("0.875")))
+
+	// A failed refresh keeps the current rates.
+	body = `{"rates": {"GBP": "not a number"}}`
+	assert.Assert(t, refreshRates(context.Background(), p, b) != nil)
+	assert.Equal(t, 0, b.latest().Rates["GBP"].Cmp(rat("0.875")))
+}
@@ -16,21 +16,25 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"math"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
 
+	"github.com/triplewy/microservices-demo/src/currencyservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/currencyservice/genproto"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/status"
 )
 
@@ -40,7 +44,20 @@ var (
 	zLogger *zap.Logger
 	sugar   *zap.SugaredLogger
 
-	conversion map[string]float64
+	// exchangeRates holds the current and past exchange rates.
+	exchangeRates *rateBook
+	currencies    *currencyTable
+)
+
+const (
+	defaultRatesFile           = "data/currency_conversion.json"
+	defaultRateHistorySize     = 1000
+	defaultFileRefreshInterval = 30 * time.Second
+	defaultHTTPRefreshInterval = time.Hour
+
+	// maxBatchSize is the largest number of amounts ConvertBatch converts at
+	// once.
+	maxBatchSize = 1000
 )
 
 func init() {
@@ -53,14 +70,13 @@ func init() {
 	zLogger, _ = zap.NewProduction()
 	sugar = zLogger.Sugar()
 
-	data, err := ioutil.ReadFile(filepath.Join("data", "currency_conversion.json"))
+	data, err := ioutil.ReadFile(filepath.Join("data", "currencies.json"))
 	if err != nil {
 		panic(err)
 	}
-	if err := json.Unmarshal(data, &conversion); err != nil {
+	if currencies, err = parseCurrencies(data); err != nil {
 		panic(err)
 	}
-	fmt.Println(conversion)
 }
 
 func main() {
@@ -68,6 +84,27 @@ func main() {
 
 	defer zLogger.Sync()
 
+	provider, interval, err := rateProviderFromEnv()
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	historySize := defaultRateHistorySize
+	if v := os.Getenv("RATE_HISTORY_SIZE"); v != "" {
+		if historySize, err = strconv.Atoi(v); err != nil {
+			sugar.Fatalf("environment variable \"RATE_HISTORY_SIZE\" is not an integer: %q", v)
+		}
+	}
+	if exchangeRates, err = newRateBook(historySize, os.Getenv("RATE_HISTORY_DIR")); err != nil {
+		sugar.Fatal(err)
+	}
+	if err := refreshRates(context.Background(), provider, exchangeRates); err != nil {
+		if exchangeRates.latest() == nil {
+			sugar.Fatalf("failed to load exchange rates: %v", err)
+		}
+		sugar.Warnf("failed to load exchange rates, using the last saved ones: %v", err)
+	}
+	go watchRates(context.Background(), provider, exchangeRates, interval)
+
 	sugar.Infof("starting grpc server at :%s", port)
 	run(port)
 	select {}
@@ -78,81 +115,192 @@ func run(port string) string {
 	if err != nil {
 		sugar.Fatal(err)
 	}
-	srv := grpc.NewServer()
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
 	svc := &currency{}
 	pb.RegisterCurrencyServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, newHealthServer())
+	pb.RegisterFaultServiceServer(srv, faults)
 	go srv.Serve(l)
 	return l.Addr().String()
 }
 
-type currency struct{}
+// rateProviderFromEnv returns the source of exchange rates and how often to
+// refresh them. Rates are pulled from RATES_URL if it is set, and read from
+// RATES_FILE otherwise. RATES_REFRESH_INTERVAL overrides the refresh
+// interval.
+func rateProviderFromEnv() (rateProvider, time.Duration, error) {
+	var provider rateProvider
+	var interval time.Duration
+	if url := os.Getenv("RATES_URL"); url != "" {
+		provider = &httpProvider{url: url, client: &http.Client{Timeout: 30 * time.Second}}
+		interval = defaultHTTPRefreshInterval
+		sugar.Infof("pulling exchange rates from %s", url)
+	} else {
+		path := defaultRatesFile
+		if v := os.Getenv("RATES_FILE"); v != "" {
+			path = v
+		}
+		provider = &fileProvider{path: path}
+		interval = defaultFileRefreshInterval
+		sugar.Infof("reading exchange rates from %s", path)
+	}
+	if v := os.Getenv("RATES_REFRESH_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, 0, fmt.Errorf("environment variable \"RATES_REFRESH_INTERVAL\" is not a positive duration: %q", v)
+		}
+		interval = d
+	}
+	return provider, interval, nil
+}
 
-func convertToEuros(from *pb.Money) *pb.Money {
-	before := float64(from.GetUnits()) + float64(from.GetNanos())/math.Pow10(9)
-	fmt.Println("before", before)
+// newHealthServer returns a health server that reports the service as
+// serving, since it has no dependencies that could make it unable to.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("hipstershop.CurrencyService", healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
 
-	after := before / conversion[from.GetCurrencyCode()]
-	fmt.Println("after", after)
+type currency struct{}
 
-	euroUnits := math.Floor(after)
-	if euroUnits < 0 {
-		euroUnits += 1
+// convert converts from to the currency to with the rates of s. The
+// conversion is exact, and only the result is rounded to the minor unit of
+// to.
+func (s *rateSnapshot) convert(from *pb.Money, to string) (*pb.Money, error) {
+	if err := validateMoney(from); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %v", err)
 	}
-	fmt.Println("euroUnits", euroUnits)
-
-	euroNanos := (after - euroUnits) * math.Pow10(9)
-	fmt.Println("euroNanos", euroNanos)
-
-	return &pb.Money{
-		CurrencyCode: "EUR",
-		Units:        int64(euroUnits),
-		Nanos:        int32(euroNanos),
+	if err := s.checkCurrency("from.currency_code", from.GetCurrencyCode()); err != nil {
+		return nil, err
 	}
+	if err := s.checkCurrency("to_code", to); err != nil {
+		return nil, err
+	}
+	amount := moneyToRat(from)
+	amount.Quo(amount, s.Rates[from.GetCurrencyCode()])
+	amount.Mul(amount, s.Rates[to])
+	m, err := roundToMoney(amount, to, currencies.info(to))
+	if err != nil {
+		return nil, status.Errorf(codes.OutOfRange, "converting %d.%09d %s to %s: %v",
+			from.GetUnits(), from.GetNanos(), from.GetCurrencyCode(), to, err)
+	}
+	return m, nil
 }
 
-func convertFromEuros(euros *pb.Money, to string) *pb.Money {
-	before := float64(euros.GetUnits()) + float64(euros.GetNanos())/math.Pow10(9)
-	fmt.Println("before", before)
-
-	after := before * conversion[to]
-	fmt.Println("after", after)
-
-	units := math.Floor(after)
-	if units < 0 {
-		units += 1
+// checkCurrency returns an InvalidArgument error unless s has a rate for the
+// currency code in field.
+func (s *rateSnapshot) checkCurrency(field, code string) error {
+	switch {
+	case code == "":
+		return status.Errorf(codes.InvalidArgument, "%s must be set", field)
+	case !validCurrencyCode(code):
+		return status.Errorf(codes.InvalidArgument, "%s %q is not an ISO 4217 currency code", field, code)
+	case s.Rates[code] == nil:
+		return status.Errorf(codes.InvalidArgument, "unsupported currency %q", code)
 	}
-	fmt.Println("units", units)
+	return nil
+}
 
-	nanos := (after - units) * math.Pow10(9)
-	fmt.Println("nanos", nanos)
+// rateSelector is a request that can ask for past exchange rates.
+type rateSelector interface {
+	GetRateVersion() string
+	GetAsOf() int64
+}
 
-	return &pb.Money{
-		CurrencyCode: to,
-		Units:        int64(units),
-		Nanos:        int32(nanos),
+// snapshot returns the exchange rates that req asks for.
+func snapshot(req rateSelector) (*rateSnapshot, error) {
+	switch {
+	case req.GetRateVersion() != "" && req.GetAsOf() != 0:
+		return nil, status.Errorf(codes.InvalidArgument, "rate_version and as_of cannot both be set")
+	case req.GetRateVersion() != "":
+		if s := exchangeRates.byVersion(req.GetRateVersion()); s != nil {
+			return s, nil
+		}
+		return nil, status.Errorf(codes.NotFound, "unknown rate version %q", req.GetRateVersion())
+	case req.GetAsOf() != 0:
+		// as_of is in seconds, so it covers rates that became current at
+		// any time during that second.
+		if s := exchangeRates.asOf(time.Unix(req.GetAsOf()+1, 0).Add(-time.Nanosecond)); s != nil {
+			return s, nil
+		}
+		return nil, status.Errorf(codes.NotFound, "no exchange rates as of %v", time.Unix(req.GetAsOf(), 0).UTC())
 	}
+	if s := exchangeRates.latest(); s != nil {
+		return s, nil
+	}
+	return nil, status.Errorf(codes.Unavailable, "no exchange rates loaded")
 }
 
 func (c *currency) GetSupportedCurrencies(context.Context, *pb.Empty) (*pb.GetSupportedCurrenciesResponse, error) {
+	s := exchangeRates.latest()
+	if s == nil {
+		return nil, status.Errorf(codes.Unavailable, "no exchange rates loaded")
+	}
 	var supportedCodes []string
-	for code := range conversion {
+	for code := range s.Rates {
 		supportedCodes = append(supportedCodes, code)
 	}
+	sort.Strings(supportedCodes)
+	infos := make([]*pb.CurrencyInfo, len(supportedCodes))
+	for i, code := range supportedCodes {
+		info := currencies.info(code)
+		infos[i] = &pb.CurrencyInfo{
+			Code:       code,
+			Name:       info.Name,
+			Symbol:     info.Symbol,
+			MinorUnits: int32(info.MinorUnits),
+		}
+	}
 	return &pb.GetSupportedCurrenciesResponse{
 		CurrencyCodes: supportedCodes,
+		Currencies:    infos,
 	}, nil
 }
 
 func (c *currency) Convert(ctx context.Context, req *pb.CurrencyConversionRequest) (*pb.Money, error) {
-	euros := convertToEuros(req.GetFrom())
-	return convertFromEuros(euros, req.GetToCode()), nil
+	res, err := c.ConvertWithRates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
 }
 
-func (c *currency) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+func (c *currency) ConvertWithRates(ctx context.Context, req *pb.CurrencyConversionRequest) (*pb.CurrencyConversionResponse, error) {
+	s, err := snapshot(req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.convert(req.GetFrom(), req.GetToCode())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CurrencyConversionResponse{
+		Result:           m,
+		RateVersion:      s.Version,
+		RatesEffectiveAt: s.EffectiveAt.Unix(),
+	}, nil
 }
 
-func (c *currency) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+func (c *currency) ConvertBatch(ctx context.Context, req *pb.ConvertBatchRequest) (*pb.ConvertBatchResponse, error) {
+	if len(req.GetFrom()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d amounts can be converted at once, got %d", maxBatchSize, len(req.GetFrom()))
+	}
+	s, err := snapshot(req)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*pb.Money, len(req.GetFrom()))
+	for i, from := range req.GetFrom() {
+		if results[i], err = s.convert(from, req.GetToCode()); err != nil {
+			st := status.Convert(err)
+			return nil, status.Errorf(st.Code(), "from[%d]: %s", i, st.Message())
+		}
+	}
+	return &pb.ConvertBatchResponse{
+		Results:          results,
+		RateVersion:      s.Version,
+		RatesEffectiveAt: s.EffectiveAt.Unix(),
+	}, nil
 }
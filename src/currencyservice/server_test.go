@@ -1,11 +1,27 @@
 package main
 
 import (
+	"context"
 	pb "github.com/triplewy/microservices-demo/src/currencyservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gotest.tools/v3/assert"
+	"os"
 	"testing"
+	"time"
 )
 
+func TestMain(m *testing.M) {
+	var err error
+	if exchangeRates, err = newRateBook(defaultRateHistorySize, ""); err != nil {
+		panic(err)
+	}
+	if err := refreshRates(context.Background(), &fileProvider{path: defaultRatesFile}, exchangeRates); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
 func TestCovert(t *testing.T) {
 	// $2245
 	from := &pb.Money{
@@ -14,15 +30,136 @@ func TestCovert(t *testing.T) {
 		Nanos:        0,
 	}
 
-	// Convert $2245 to euros: 1985.84697037
-	euros := convertToEuros(from)
+	// Convert $2245 to euros: 1985.84697037, rounded to the cent
+	euros, err := exchangeRates.latest().convert(from, "EUR")
+	assert.NilError(t, err)
 	assert.Equal(t, "EUR", euros.GetCurrencyCode())
 	assert.Equal(t, int64(1985), euros.GetUnits())
-	assert.Equal(t, int32(846970367), euros.GetNanos())
+	assert.Equal(t, int32(850000000), euros.GetNanos())
 
-	// Convert euros back to USD
-	usd := convertFromEuros(euros, "USD")
+	// Convert euros back to USD: 2245.003425
+	usd, err := exchangeRates.latest().convert(euros, "USD")
+	assert.NilError(t, err)
 	assert.Equal(t, "USD", usd.GetCurrencyCode())
-	assert.Equal(t, int64(2244), usd.GetUnits())
-	assert.Equal(t, int32(999999999), usd.GetNanos())
+	assert.Equal(t, int64(2245), usd.GetUnits())
+	assert.Equal(t, int32(0), usd.GetNanos())
+}
+
+func TestConvertNegative(t *testing.T) {
+	// -$2245 converts like $2245
+	euros, err := exchangeRates.latest().convert(&pb.Money{CurrencyCode: "USD", Units: -2245}, "EUR")
+	assert.NilError(t, err)
+	assert.Equal(t, int64(-1985), euros.GetUnits())
+	assert.Equal(t, int32(-850000000), euros.GetNanos())
+
+	// -€0.50 is -$0.565250, rounded to -$0.57
+	usd, err := exchangeRates.latest().convert(&pb.Money{CurrencyCode: "EUR", Nanos: -500000000}, "USD")
+	assert.NilError(t, err)
+	assert.Equal(t, int64(0), usd.GetUnits())
+	assert.Equal(t, int32(-570000000), usd.GetNanos())
+}
+
+func TestConvertToYen(t *testing.T) {
+	// $19.99 is ¥2235.145510, and yen have no minor unit
+	yen, err := exchangeRates.latest().convert(&pb.Money{CurrencyCode: "USD", Units: 19, Nanos: 990000000}, "JPY")
+	assert.NilError(t, err)
+	assert.Equal(t, "JPY", yen.GetCurrencyCode())
+	assert.Equal(t, int64(2235), yen.GetUnits())
+	assert.Equal(t, int32(0), yen.GetNanos())
+}
+
+func TestConvertInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		from *pb.Money
+		to   string
+	}{
+		{&pb.Money{CurrencyCode: "XXX", Units: 1}, "EUR"},
+		{&pb.Money{CurrencyCode: "EUR", Units: 1}, "XXX"},
+		{&pb.Money{CurrencyCode: "EUR", Units: 1}, ""},
+		{&pb.Money{Units: 1}, "EUR"},
+		{&pb.Money{CurrencyCode: "usd", Units: 1}, "EUR"},
+		{&pb.Money{CurrencyCode: "EUR", Units: 1}, "DOLLARS"},
+		{&pb.Money{CurrencyCode: "EUR", Units: 1, Nanos: -1}, "USD"},
+		{&pb.Money{CurrencyCode: "EUR", Nanos: 1000000000}, "USD"},
+	} {
+		_, err := (&currency{}).Convert(context.Background(), &pb.CurrencyConversionRequest{From: tc.from, ToCode: tc.to})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "converting %v to %s", tc.from, tc.to)
+	}
+}
+
+func TestConvertWithRates(t *testing.T) {
+	c := &currency{}
+	ctx := context.Background()
+	from := &pb.Money{CurrencyCode: "EUR", Units: 10}
+	res, err := c.ConvertWithRates(ctx, &pb.CurrencyConversionRequest{From: from, ToCode: "USD"})
+	assert.NilError(t, err)
+	assert.Equal(t, exchangeRates.latest().Version, res.GetRateVersion())
+	// $11.305 rounds to the even cent
+	assert.Equal(t, int64(11), res.GetResult().GetUnits())
+	assert.Equal(t, int32(300000000), res.GetResult().GetNanos())
+
+	// Converting with the same version or as of now gives the same result.
+	for _, req := range []*pb.CurrencyConversionRequest{
+		{From: from, ToCode: "USD", RateVersion: res.GetRateVersion()},
+		{From: from, ToCode: "USD", AsOf: time.Now().Unix()},
+	} {
+		again, err := c.ConvertWithRates(ctx, req)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, res.GetResult(), again.GetResult())
+	}
+
+	_, err = c.Convert(ctx, &pb.CurrencyConversionRequest{From: from, ToCode: "USD", RateVersion: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = c.Convert(ctx, &pb.CurrencyConversionRequest{From: from, ToCode: "USD", AsOf: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = c.Convert(ctx, &pb.CurrencyConversionRequest{From: from, ToCode: "USD", RateVersion: res.GetRateVersion(), AsOf: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetSupportedCurrencies(t *testing.T) {
+	res, err := (&currency{}).GetSupportedCurrencies(context.Background(), &pb.Empty{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(exchangeRates.latest().Rates), len(res.GetCurrencyCodes()))
+	assert.Equal(t, len(res.GetCurrencyCodes()), len(res.GetCurrencies()))
+	infos := make(map[string]*pb.CurrencyInfo)
+	for i, info := range res.GetCurrencies() {
+		assert.Equal(t, res.GetCurrencyCodes()[i], info.GetCode())
+		assert.Assert(t, info.GetName() != "", "%s has no name", info.GetCode())
+		assert.Assert(t, info.GetSymbol() != "", "%s has no symbol", info.GetCode())
+		infos[info.GetCode()] = info
+	}
+	assert.Equal(t, "$", infos["USD"].GetSymbol())
+	assert.Equal(t, int32(2), infos["USD"].GetMinorUnits())
+	assert.Equal(t, "Japanese Yen", infos["JPY"].GetName())
+	assert.Equal(t, int32(0), infos["JPY"].GetMinorUnits())
+}
+
+func TestConvertBatch(t *testing.T) {
+	c := &currency{}
+	ctx := context.Background()
+	from := []*pb.Money{
+		{CurrencyCode: "USD", Units: 2245},
+		{CurrencyCode: "EUR", Units: 10},
+		{CurrencyCode: "JPY", Units: 1000},
+	}
+	res, err := c.ConvertBatch(ctx, &pb.ConvertBatchRequest{From: from, ToCode: "EUR"})
+	assert.NilError(t, err)
+	assert.Equal(t, exchangeRates.latest().Version, res.GetRateVersion())
+	assert.Equal(t, len(from), len(res.GetResults()))
+	for i, m := range from {
+		want, err := c.Convert(ctx, &pb.CurrencyConversionRequest{From: m, ToCode: "EUR"})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, want, res.GetResults()[i])
+	}
+
+	res, err = c.ConvertBatch(ctx, &pb.ConvertBatchRequest{ToCode: "EUR"})
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(res.GetResults()))
+
+	_, err = c.ConvertBatch(ctx, &pb.ConvertBatchRequest{From: append(from, &pb.Money{CurrencyCode: "XXX"}), ToCode: "EUR"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "from[3]")
+
+	_, err = c.ConvertBatch(ctx, &pb.ConvertBatchRequest{From: make([]*pb.Money, maxBatchSize+1), ToCode: "EUR"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
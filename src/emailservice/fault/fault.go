@@ -0,0 +1,156 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fault implements the FaultService API: a registry of faults that
+// is applied to incoming calls by a unary server interceptor.
+package fault
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/emailservice/genproto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// faultServicePrefix is never subject to faults, so that faults can
+	// always be removed again.
+	faultServicePrefix = "/hipstershop.FaultService/"
+
+	// maxCode is the highest gRPC status code defined by the spec.
+	maxCode = codes.Unauthenticated
+)
+
+type fault struct {
+	percent float64
+	code    codes.Code
+	delay   time.Duration
+}
+
+// Injector holds the active faults of a server. It implements
+// pb.FaultServiceServer and is installed with UnaryServerInterceptor.
+type Injector struct {
+	mu sync.RWMutex
+	// faults maps a service name to its faults keyed by method URI.
+	faults map[string]map[string]fault
+
+	// roll returns a number in [0, 100) that is compared to a fault's
+	// percentage.
+	roll func() float64
+}
+
+// NewInjector returns an Injector without any faults.
+func NewInjector() *Injector {
+	return &Injector{
+		faults: make(map[string]map[string]fault),
+		roll:   func() float64 { return rand.Float64() * 100 },
+	}
+}
+
+// Create registers a fault for req.Svc and req.Uri, replacing any fault
+// previously registered for the same pair.
+func (in *Injector) Create(ctx context.Context, req *pb.CreateRequest) (*pb.Empty, error) {
+	if req.GetSvc() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "svc must be set")
+	}
+	if req.GetPercent() < 0 || req.GetPercent() > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "percent %v is not between 0 and 100", req.GetPercent())
+	}
+	if req.GetCode() < 0 || codes.Code(req.GetCode()) > maxCode {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status code %d", req.GetCode())
+	}
+	if req.GetDelayMs() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "delay_ms must not be negative")
+	}
+
+	uri := req.GetUri()
+	if uri == "" {
+		uri = "*"
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.faults[req.GetSvc()] == nil {
+		in.faults[req.GetSvc()] = make(map[string]fault)
+	}
+	in.faults[req.GetSvc()][uri] = fault{
+		percent: req.GetPercent(),
+		code:    codes.Code(req.GetCode()),
+		delay:   time.Duration(req.GetDelayMs()) * time.Millisecond,
+	}
+	return &pb.Empty{}, nil
+}
+
+// Delete removes every fault registered for req.Svc.
+func (in *Injector) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.Empty, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	delete(in.faults, req.GetSvc())
+	return &pb.Empty{}, nil
+}
+
+// lookup returns the fault that applies to fullMethod, if any. A fault
+// registered for the exact method wins over a service-wide one.
+func (in *Injector) lookup(fullMethod string) (fault, bool) {
+	if strings.HasPrefix(fullMethod, faultServicePrefix) {
+		return fault{}, false
+	}
+	// fullMethod has the form "/package.Service/Method".
+	parts := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2)
+
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	byURI, ok := in.faults[parts[0]]
+	if !ok {
+		return fault{}, false
+	}
+	if f, ok := byURI[fullMethod]; ok {
+		return f, true
+	}
+	f, ok := byURI["*"]
+	return f, ok
+}
+
+// UnaryServerInterceptor delays and fails the configured percentage of calls
+// matching a registered fault.
+func (in *Injector) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	f, ok := in.lookup(info.FullMethod)
+	if !ok || in.roll() >= f.percent {
+		return handler(ctx, req)
+	}
+
+	if f.delay > 0 {
+		t := time.NewTimer(f.delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+			}
+			return nil, status.Error(codes.Canceled, ctx.Err().Error())
+		}
+	}
+	if f.code != codes.OK {
+		return nil, status.Errorf(f.code, "fault injected into %s", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
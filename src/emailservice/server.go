@@ -19,15 +19,16 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 
+	"github.com/triplewy/microservices-demo/src/emailservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/emailservice/genproto"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/health"
 )
 
 var (
@@ -63,14 +64,24 @@ func run(port string) string {
 	if err != nil {
 		sugar.Fatal(err)
 	}
-	srv := grpc.NewServer()
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
 	svc := &email{}
 	pb.RegisterEmailServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, newHealthServer())
+	pb.RegisterFaultServiceServer(srv, faults)
 	go srv.Serve(l)
 	return l.Addr().String()
 }
 
+// newHealthServer returns a health server that reports the service as
+// serving, since it has no dependencies that could make it unable to.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("hipstershop.EmailService", healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
+
 type email struct{}
 
 func (e *email) SendOrderConfirmation(ctx context.Context, req *pb.SendOrderConfirmationRequest) (*pb.Empty, error) {
@@ -78,10 +89,31 @@ func (e *email) SendOrderConfirmation(ctx context.Context, req *pb.SendOrderConf
 	return &pb.Empty{}, nil
 }
 
-func (e *email) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+func (e *email) SendCartReminder(ctx context.Context, req *pb.SendCartReminderRequest) (*pb.Empty, error) {
+	sugar.Infof("A request to remind user %v of a cart with %d items has been received.", req.GetUserId(), len(req.GetItems()))
+	return &pb.Empty{}, nil
 }
 
-func (e *email) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+// SendLoginLink logs a request to send a sign-in link. The link carries a
+// token that signs in whoever opens it, so only its address is logged.
+func (e *email) SendLoginLink(ctx context.Context, req *pb.SendLoginLinkRequest) (*pb.Empty, error) {
+	sugar.Infof("A request to send a sign-in link to %v has been received: %v", req.GetEmail(), redactLink(req.GetLink()))
+	return &pb.Empty{}, nil
+}
+
+// redactLink returns link with the values of its query and its fragment
+// removed.
+func redactLink(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "(invalid link)"
+	}
+	q := u.Query()
+	for k := range q {
+		q.Set(k, "REDACTED")
+	}
+	u.RawQuery = q.Encode()
+	u.Fragment = ""
+	u.User = nil
+	return u.String()
 }
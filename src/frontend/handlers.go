@@ -16,17 +16,24 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"html/template"
 	"math/rand"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/triplewy/microservices-demo/src/frontend/genproto"
 	"github.com/triplewy/microservices-demo/src/frontend/money"
@@ -36,6 +43,7 @@ var (
 	templates = template.Must(template.New("").
 		Funcs(template.FuncMap{
 			"renderMoney": renderMoney,
+			"csrfToken":   csrfToken,
 		}).ParseGlob("templates/*.html"))
 )
 
@@ -52,7 +60,7 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve products"), http.StatusInternalServerError)
 		return
 	}
-	cart, err := fe.getCart(r.Context(), sessionID(r))
+	cart, err := fe.getCart(r.Context(), userID(r))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
 		return
@@ -62,18 +70,23 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 		Item  *pb.Product
 		Price *pb.Money
 	}
+	pricesUSD := make([]*pb.Money, len(products))
+	for i, p := range products {
+		pricesUSD[i] = p.GetPriceUsd()
+	}
+	prices, err := fe.convertCurrencies(r.Context(), pricesUSD, currentCurrency(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "failed to do currency conversion for products"), http.StatusInternalServerError)
+		return
+	}
 	ps := make([]productView, len(products))
 	for i, p := range products {
-		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currentCurrency(r))
-		if err != nil {
-			renderHTTPError(log, r, w, errors.Wrapf(err, "failed to do currency conversion for product %s", p.GetId()), http.StatusInternalServerError)
-			return
-		}
-		ps[i] = productView{p, price}
+		ps[i] = productView{p, prices[i]}
 	}
 
 	if err := templates.ExecuteTemplate(w, "home", map[string]interface{}{
 		"session_id":    sessionID(r),
+		"signed_in":     signedIn(r),
 		"request_id":    r.Context().Value(ctxKeyRequestID{}),
 		"user_currency": currentCurrency(r),
 		"currencies":    currencies,
@@ -106,7 +119,7 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	cart, err := fe.getCart(r.Context(), sessionID(r))
+	cart, err := fe.getCart(r.Context(), userID(r))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
 		return
@@ -118,7 +131,7 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	recommendations, err := fe.getRecommendations(r.Context(), sessionID(r), []string{id})
+	recommendations, err := fe.getRecommendations(r.Context(), userID(r), []string{id})
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to get product recommendations"), http.StatusInternalServerError)
 		return
@@ -131,6 +144,7 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 
 	if err := templates.ExecuteTemplate(w, "product", map[string]interface{}{
 		"session_id":      sessionID(r),
+		"signed_in":       signedIn(r),
 		"request_id":      r.Context().Value(ctxKeyRequestID{}),
 		"ad":              fe.chooseAd(r.Context(), p.Categories, log),
 		"user_currency":   currentCurrency(r),
@@ -159,7 +173,7 @@ func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := fe.insertCart(r.Context(), sessionID(r), p.GetId(), int32(quantity)); err != nil {
+	if err := fe.insertCart(r.Context(), userID(r), p.GetId(), int32(quantity)); err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to add to cart"), http.StatusInternalServerError)
 		return
 	}
@@ -167,11 +181,50 @@ func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusFound)
 }
 
+func (fe *frontendServer) updateCartHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	quantity, err := strconv.ParseUint(r.FormValue("quantity"), 10, 31)
+	productID := r.FormValue("product_id")
+	if productID == "" || err != nil {
+		renderHTTPError(log, r, w, errors.New("invalid form input"), http.StatusBadRequest)
+		return
+	}
+	log.WithField("product", productID).WithField("quantity", quantity).Debug("updating cart")
+
+	if err := fe.updateCartItem(r.Context(), userID(r), productID, int32(quantity)); err != nil {
+		code := http.StatusInternalServerError
+		if status.Code(err) == codes.NotFound {
+			code = http.StatusNotFound
+		}
+		renderHTTPError(log, r, w, errors.Wrap(err, "failed to update cart"), code)
+		return
+	}
+	w.Header().Set("location", "/cart")
+	w.WriteHeader(http.StatusFound)
+}
+
+func (fe *frontendServer) removeFromCartHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	productID := r.FormValue("product_id")
+	if productID == "" {
+		renderHTTPError(log, r, w, errors.New("invalid form input"), http.StatusBadRequest)
+		return
+	}
+	log.WithField("product", productID).Debug("removing from cart")
+
+	if err := fe.removeCartItem(r.Context(), userID(r), productID); err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "failed to remove from cart"), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("location", "/cart")
+	w.WriteHeader(http.StatusFound)
+}
+
 func (fe *frontendServer) emptyCartHandler(w http.ResponseWriter, r *http.Request) {
 	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
 	log.Debug("emptying cart")
 
-	if err := fe.emptyCart(r.Context(), sessionID(r)); err != nil {
+	if err := fe.emptyCart(r.Context(), userID(r)); err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to empty cart"), http.StatusInternalServerError)
 		return
 	}
@@ -187,13 +240,13 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
 		return
 	}
-	cart, err := fe.getCart(r.Context(), sessionID(r))
+	cart, err := fe.getCart(r.Context(), userID(r))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
 		return
 	}
 
-	recommendations, err := fe.getRecommendations(r.Context(), sessionID(r), cartIDs(cart))
+	recommendations, err := fe.getRecommendations(r.Context(), userID(r), cartIDs(cart))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to get product recommendations"), http.StatusInternalServerError)
 		return
@@ -210,32 +263,42 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 		Quantity int32
 		Price    *pb.Money
 	}
-	items := make([]cartItemView, len(cart))
-	totalPrice := pb.Money{CurrencyCode: currentCurrency(r)}
+	products := make([]*pb.Product, len(cart))
+	pricesUSD := make([]*pb.Money, len(cart))
 	for i, item := range cart {
 		p, err := fe.getProduct(r.Context(), item.GetProductId())
 		if err != nil {
 			renderHTTPError(log, r, w, errors.Wrapf(err, "could not retrieve product #%s", item.GetProductId()), http.StatusInternalServerError)
 			return
 		}
-		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currentCurrency(r))
-		if err != nil {
-			renderHTTPError(log, r, w, errors.Wrapf(err, "could not convert currency for product #%s", item.GetProductId()), http.StatusInternalServerError)
-			return
-		}
+		products[i], pricesUSD[i] = p, p.GetPriceUsd()
+	}
+	prices, err := fe.convertCurrencies(r.Context(), pricesUSD, currentCurrency(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not convert currency for cart items"), http.StatusInternalServerError)
+		return
+	}
 
-		multPrice := money.MultiplySlow(*price, uint32(item.GetQuantity()))
+	items := make([]cartItemView, len(cart))
+	totalPrice := pb.Money{CurrencyCode: currentCurrency(r)}
+	for i, item := range cart {
+		multPrice := money.MultiplySlow(*prices[i], uint32(item.GetQuantity()))
 		items[i] = cartItemView{
-			Item:     p,
+			Item:     products[i],
 			Quantity: item.GetQuantity(),
 			Price:    &multPrice}
 		totalPrice = money.Must(money.Sum(totalPrice, multPrice))
 	}
 	totalPrice = money.Must(money.Sum(totalPrice, *shippingCost))
 
+	// A new key per rendered checkout form makes resubmitting the form
+	// return the order that was already placed.
+	idempotencyKey, _ := uuid.NewRandom()
+
 	year := time.Now().Year()
 	if err := templates.ExecuteTemplate(w, "cart", map[string]interface{}{
 		"session_id":       sessionID(r),
+		"signed_in":        signedIn(r),
 		"request_id":       r.Context().Value(ctxKeyRequestID{}),
 		"user_currency":    currentCurrency(r),
 		"currencies":       currencies,
@@ -245,6 +308,7 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 		"total_cost":       totalPrice,
 		"items":            items,
 		"expiration_years": []int{year, year + 1, year + 2, year + 3, year + 4},
+		"idempotency_key":  idempotencyKey.String(),
 	}); err != nil {
 		log.Println(err)
 	}
@@ -265,17 +329,30 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 		ccMonth, _    = strconv.ParseInt(r.FormValue("credit_card_expiration_month"), 10, 32)
 		ccYear, _     = strconv.ParseInt(r.FormValue("credit_card_expiration_year"), 10, 32)
 		ccCVV, _      = strconv.ParseInt(r.FormValue("credit_card_cvv"), 10, 32)
+		key           = r.FormValue("idempotency_key")
 	)
 
+	// The card goes to the vault of the payment service, and only its token
+	// to the checkout.
+	cardToken, err := fe.tokenizeCard(r.Context(), &pb.CreditCardInfo{
+		CreditCardNumber:          ccNumber,
+		CreditCardExpirationMonth: int32(ccMonth),
+		CreditCardExpirationYear:  int32(ccYear),
+		CreditCardCvv:             int32(ccCVV)})
+	if err != nil {
+		if st := status.Convert(err); paymentDeclined(st) {
+			renderPaymentDeclined(log, r, w, st)
+			return
+		}
+		renderHTTPError(log, r, w, errors.Wrap(err, "failed to tokenize the card"), http.StatusInternalServerError)
+		return
+	}
+
 	order, err := pb.NewCheckoutServiceClient(fe.checkoutSvcConn).
 		PlaceOrder(r.Context(), &pb.PlaceOrderRequest{
-			Email: email,
-			CreditCard: &pb.CreditCardInfo{
-				CreditCardNumber:          ccNumber,
-				CreditCardExpirationMonth: int32(ccMonth),
-				CreditCardExpirationYear:  int32(ccYear),
-				CreditCardCvv:             int32(ccCVV)},
-			UserId:       sessionID(r),
+			Email:        email,
+			CardToken:    cardToken,
+			UserId:       userID(r),
 			UserCurrency: currentCurrency(r),
 			Address: &pb.Address{
 				StreetAddress: streetAddress,
@@ -283,15 +360,20 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 				State:         state,
 				ZipCode:       int32(zipCode),
 				Country:       country},
+			IdempotencyKey: key,
 		})
 	if err != nil {
+		if st := status.Convert(err); paymentDeclined(st) {
+			renderPaymentDeclined(log, r, w, st)
+			return
+		}
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to complete the order"), http.StatusInternalServerError)
 		return
 	}
 	log.WithField("order", order.GetOrder().GetOrderId()).Info("order placed")
 
 	order.GetOrder().GetItems()
-	recommendations, _ := fe.getRecommendations(r.Context(), sessionID(r), nil)
+	recommendations, _ := fe.getRecommendations(r.Context(), userID(r), nil)
 
 	totalPaid := *order.GetOrder().GetShippingCost()
 	for _, v := range order.GetOrder().GetItems() {
@@ -300,6 +382,7 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 
 	if err := templates.ExecuteTemplate(w, "order", map[string]interface{}{
 		"session_id":      sessionID(r),
+		"signed_in":       signedIn(r),
 		"request_id":      r.Context().Value(ctxKeyRequestID{}),
 		"user_currency":   currentCurrency(r),
 		"order":           order.GetOrder(),
@@ -310,9 +393,245 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+func (fe *frontendServer) ordersHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	log.Debug("view user orders")
+	currencies, err := fe.getCurrencies(r.Context())
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	cart, err := fe.getCart(r.Context(), userID(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+	orders, err := fe.getOrders(r.Context(), userID(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve orders"), http.StatusInternalServerError)
+		return
+	}
+
+	type orderView struct {
+		Order    *pb.Order
+		PlacedAt time.Time
+		Items    int32
+	}
+	views := make([]orderView, len(orders))
+	for i, o := range orders {
+		views[i] = orderView{Order: o, PlacedAt: time.Unix(o.GetPlacedAt(), 0).UTC()}
+		for _, item := range o.GetResult().GetItems() {
+			views[i].Items += item.GetItem().GetQuantity()
+		}
+	}
+
+	if err := templates.ExecuteTemplate(w, "orders", map[string]interface{}{
+		"session_id":    sessionID(r),
+		"signed_in":     signedIn(r),
+		"request_id":    r.Context().Value(ctxKeyRequestID{}),
+		"user_currency": currentCurrency(r),
+		"currencies":    currencies,
+		"cart_size":     len(cart),
+		"orders":        views,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (fe *frontendServer) orderHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		renderHTTPError(log, r, w, errors.New("order id not specified"), http.StatusBadRequest)
+		return
+	}
+	log.WithField("id", id).Debug("serving order page")
+
+	order, err := fe.getOrder(r.Context(), id)
+	if status.Code(errors.Cause(err)) == codes.NotFound || (err == nil && order.GetUserId() != userID(r)) {
+		// Orders of other users are reported as missing, so that order
+		// IDs cannot be probed.
+		renderHTTPError(log, r, w, errors.Errorf("order %s not found", id), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve order"), http.StatusInternalServerError)
+		return
+	}
+	currencies, err := fe.getCurrencies(r.Context())
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	cart, err := fe.getCart(r.Context(), userID(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+
+	type orderItemView struct {
+		Item     *pb.Product
+		Quantity int32
+		Cost     *pb.Money
+	}
+	items := make([]orderItemView, len(order.GetResult().GetItems()))
+	for i, item := range order.GetResult().GetItems() {
+		p, err := fe.getProduct(r.Context(), item.GetItem().GetProductId())
+		if err != nil {
+			renderHTTPError(log, r, w, errors.Wrapf(err, "could not retrieve product #%s", item.GetItem().GetProductId()), http.StatusInternalServerError)
+			return
+		}
+		items[i] = orderItemView{
+			Item:     p,
+			Quantity: item.GetItem().GetQuantity(),
+			Cost:     item.GetCost(),
+		}
+	}
+
+	if err := templates.ExecuteTemplate(w, "order_details", map[string]interface{}{
+		"session_id":    sessionID(r),
+		"signed_in":     signedIn(r),
+		"request_id":    r.Context().Value(ctxKeyRequestID{}),
+		"user_currency": currentCurrency(r),
+		"currencies":    currencies,
+		"cart_size":     len(cart),
+		"order":         order,
+		"placed_at":     time.Unix(order.GetPlacedAt(), 0).UTC(),
+		"items":         items,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (fe *frontendServer) trackingHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		renderHTTPError(log, r, w, errors.New("tracking id not specified"), http.StatusBadRequest)
+		return
+	}
+	log.WithField("id", id).Debug("serving tracking page")
+
+	shipment, err := fe.getShipmentStatus(r.Context(), id)
+	if status.Code(err) == codes.NotFound {
+		renderHTTPError(log, r, w, errors.Errorf("shipment %s not found", id), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve shipment status"), http.StatusInternalServerError)
+		return
+	}
+	currencies, err := fe.getCurrencies(r.Context())
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	cart, err := fe.getCart(r.Context(), userID(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+
+	// The stages of the progress bar, in order.
+	stages := []struct {
+		State pb.ShipmentStatus_State
+		Label string
+	}{
+		{pb.ShipmentStatus_LABEL_CREATED, "Label created"},
+		{pb.ShipmentStatus_IN_TRANSIT, "In transit"},
+		{pb.ShipmentStatus_OUT_FOR_DELIVERY, "Out for delivery"},
+		{pb.ShipmentStatus_DELIVERED, "Delivered"},
+	}
+	var estimatedDelivery time.Time
+	if shipment.GetEstimatedDelivery() != 0 {
+		estimatedDelivery = time.Unix(shipment.GetEstimatedDelivery(), 0).UTC()
+	}
+
+	if err := templates.ExecuteTemplate(w, "tracking", map[string]interface{}{
+		"session_id":         sessionID(r),
+		"signed_in":          signedIn(r),
+		"request_id":         r.Context().Value(ctxKeyRequestID{}),
+		"user_currency":      currentCurrency(r),
+		"currencies":         currencies,
+		"cart_size":          len(cart),
+		"shipment":           shipment,
+		"stages":             stages,
+		"cancelled":          shipment.GetState() == pb.ShipmentStatus_CANCELLED,
+		"updated_at":         time.Unix(shipment.GetUpdatedAt(), 0).UTC(),
+		"estimated_delivery": estimatedDelivery,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+// loginHandler emails a shopper the link that signs them in. There are no
+// passwords: the email only gives the shopper an identity that outlives their
+// session, so that their cart and orders follow them to other sessions and
+// devices, and the link proves that the email is theirs.
+func (fe *frontendServer) loginHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	if !validCSRFToken(r) {
+		renderHTTPError(log, r, w, errors.New("invalid CSRF token"), http.StatusForbidden)
+		return
+	}
+	email := strings.ToLower(strings.TrimSpace(r.PostFormValue("email")))
+	if !strings.Contains(email, "@") {
+		renderHTTPError(log, r, w, errors.Errorf("invalid email %q", email), http.StatusBadRequest)
+		return
+	}
+	id := userIDForEmail(email)
+	log.WithField("user", id).Debug("sending sign-in link")
+
+	link := fe.publicURL + "/login?token=" + fe.sessions.startLogin(id)
+	if err := fe.sendLoginLink(r.Context(), email, link); err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not sign in"), http.StatusInternalServerError)
+		return
+	}
+	if err := templates.ExecuteTemplate(w, "login", map[string]interface{}{
+		"session_id": sessionID(r),
+		"signed_in":  signedIn(r),
+		"request_id": r.Context().Value(ctxKeyRequestID{}),
+		"email":      email,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+// loginLinkHandler signs in the shopper of a sign-in link with a new session.
+// The cart of the anonymous session is merged into theirs.
+func (fe *frontendServer) loginLinkHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	id, ok := fe.sessions.finishLogin(r.FormValue("token"))
+	if !ok {
+		renderHTTPError(log, r, w, errors.New("the sign-in link is invalid or has expired"), http.StatusBadRequest)
+		return
+	}
+	log.WithField("user", id).Debug("logging in")
+
+	if !signedIn(r) {
+		if err := fe.mergeCarts(r.Context(), sessionID(r), id); err != nil {
+			renderHTTPError(log, r, w, errors.Wrap(err, "failed to merge carts"), http.StatusInternalServerError)
+			return
+		}
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieUserSession,
+		Value:    fe.sessions.newSession(id),
+		Path:     "/",
+		MaxAge:   cookieUserMaxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set("Location", "/")
+	w.WriteHeader(http.StatusFound)
+}
+
 func (fe *frontendServer) logoutHandler(w http.ResponseWriter, r *http.Request) {
 	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
 	log.Debug("logging out")
+	if c, err := r.Cookie(cookieUserSession); err == nil {
+		fe.sessions.endSession(c.Value)
+	}
 	for _, c := range r.Cookies() {
 		c.Expires = time.Now().Add(-time.Hour * 24 * 365)
 		c.MaxAge = -1
@@ -328,6 +647,20 @@ func (fe *frontendServer) setCurrencyHandler(w http.ResponseWriter, r *http.Requ
 	log.WithField("curr.new", cur).WithField("curr.old", currentCurrency(r)).
 		Debug("setting currency")
 
+	currencies, err := fe.getCurrencies(r.Context())
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	supported := false
+	for _, c := range currencies {
+		supported = supported || c.GetCode() == cur
+	}
+	if cur != "" && !supported {
+		renderHTTPError(log, r, w, errors.Errorf("unsupported currency %q", cur), http.StatusBadRequest)
+		return
+	}
+
 	if cur != "" {
 		http.SetCookie(w, &http.Cookie{
 			Name:   cookieCurrency,
@@ -361,12 +694,77 @@ func renderHTTPError(log logrus.FieldLogger, r *http.Request, w http.ResponseWri
 	w.WriteHeader(code)
 	templates.ExecuteTemplate(w, "error", map[string]interface{}{
 		"session_id":  sessionID(r),
+		"signed_in":   signedIn(r),
 		"request_id":  r.Context().Value(ctxKeyRequestID{}),
 		"error":       errMsg,
 		"status_code": code,
 		"status":      http.StatusText(code)})
 }
 
+// paymentDeclined reports whether st is the status of a payment that the
+// payment service declined: one with the violations of an invalid card, or
+// one that the card issuer or the fraud checks declined. Other errors with the
+// same codes are failures of the frontend or of the checkout.
+func paymentDeclined(st *status.Status) bool {
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				if strings.HasPrefix(v.GetField(), "credit_card.") {
+					return true
+				}
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				if v.GetType() == cardDeclinedType {
+					return true
+				}
+			}
+		case *pb.FraudAssessment:
+			return true
+		}
+	}
+	return false
+}
+
+// cardDeclinedType is the type of the precondition violation of a card that
+// the payment gateway declined.
+const cardDeclinedType = "CARD_DECLINED"
+
+// renderPaymentDeclined tells the shopper that the payment of their order was
+// declined, and what is wrong with the card if it is invalid. Why a payment
+// was declined, such as the reasons of the fraud checks, is only logged with
+// the request, so that it cannot be used to get past the checks.
+func renderPaymentDeclined(log logrus.FieldLogger, r *http.Request, w http.ResponseWriter, st *status.Status) {
+	var violations, reasons []string
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				violations = append(violations, v.GetDescription())
+			}
+		case *pb.FraudAssessment:
+			reasons = append(reasons, d.GetReasons()...)
+		}
+	}
+	log.WithFields(logrus.Fields{"error": st.Message(), "reasons": reasons}).Info("payment declined")
+
+	code, msg := http.StatusPaymentRequired, "The payment could not be authorized."
+	if st.Code() == codes.InvalidArgument {
+		code, msg = http.StatusBadRequest, "The card is invalid."
+	}
+	w.WriteHeader(code)
+	templates.ExecuteTemplate(w, "error", map[string]interface{}{
+		"session_id":  sessionID(r),
+		"signed_in":   signedIn(r),
+		"request_id":  r.Context().Value(ctxKeyRequestID{}),
+		"error":       msg,
+		"declined":    true,
+		"violations":  violations,
+		"status_code": code,
+		"status":      http.StatusText(code)})
+}
+
 func currentCurrency(r *http.Request) string {
 	c, _ := r.Cookie(cookieCurrency)
 	if c != nil {
@@ -383,6 +781,28 @@ func sessionID(r *http.Request) string {
 	return ""
 }
 
+// userID returns the ID of the signed in shopper, or the session ID of an
+// anonymous one. Carts, orders and recommendations are keyed by it.
+func userID(r *http.Request) string {
+	if v, ok := r.Context().Value(ctxKeyUserID{}).(string); ok {
+		return v
+	}
+	return sessionID(r)
+}
+
+func signedIn(r *http.Request) bool {
+	return userID(r) != sessionID(r)
+}
+
+// userIDForEmail returns the ID of the shopper with an email. It is derived
+// from the email so that it is the same in every session, without putting the
+// email in logs. It is never put in cookies: shoppers are signed in by the
+// random token of their session.
+func userIDForEmail(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return "user-" + hex.EncodeToString(sum[:16])
+}
+
 func cartIDs(c []*pb.CartItem) []string {
 	out := make([]string, len(c))
 	for i, v := range c {
@@ -391,6 +811,12 @@ func cartIDs(c []*pb.CartItem) []string {
 	return out
 }
 
-func renderMoney(money pb.Money) string {
-	return fmt.Sprintf("%s %d.%02d", money.GetCurrencyCode(), money.GetUnits(), money.GetNanos()/10000000)
+// renderMoney formats an amount with the symbol and minor units of its
+// currency, falling back to the currency code and two decimals for unknown
+// currencies.
+func renderMoney(m pb.Money) string {
+	if info, ok := currencyInfos[m.GetCurrencyCode()]; ok {
+		return money.Format(m, info.GetSymbol(), int(info.GetMinorUnits()))
+	}
+	return money.Format(m, "", 2)
 }
@@ -19,12 +19,15 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+
+	pb "github.com/triplewy/microservices-demo/src/frontend/genproto"
 )
 
 const (
@@ -35,16 +38,22 @@ const (
 	cookiePrefix    = "shop_"
 	cookieSessionID = cookiePrefix + "session-id"
 	cookieCurrency  = cookiePrefix + "currency"
+	// cookieUserSession holds the session of a signed in shopper.
+	cookieUserSession = cookiePrefix + "user-session"
+
+	// cookieUserMaxAge is how long a shopper stays signed in.
+	cookieUserMaxAge = 60 * 60 * 24 * 30
+
+	// defaultPublicURL is where shoppers reach the frontend, for the links
+	// that are emailed to them, unless PUBLIC_URL is set.
+	defaultPublicURL = "http://localhost:8080"
 )
 
 var (
-	whitelistedCurrencies = map[string]bool{
-		"USD": true,
-		"EUR": true,
-		"CAD": true,
-		"JPY": true,
-		"GBP": true,
-		"TRY": true}
+	// currencyInfos holds the metadata of the supported currencies by code.
+	// It is loaded from the currency service before the server starts and
+	// not changed after, and renderMoney uses it to format amounts.
+	currencyInfos map[string]*pb.CurrencyInfo
 
 	ignoreAPIs = map[string]bool{
 		"/_healthz": true,
@@ -74,6 +83,18 @@ type frontendServer struct {
 
 	adSvcAddr string
 	adSvcConn *grpc.ClientConn
+
+	orderSvcAddr string
+	orderSvcConn *grpc.ClientConn
+
+	paymentSvcAddr string
+	paymentSvcConn *grpc.ClientConn
+
+	emailSvcAddr string
+	emailSvcConn *grpc.ClientConn
+
+	publicURL string
+	sessions  *sessionStore
 }
 
 func main() {
@@ -103,6 +124,14 @@ func main() {
 	mustMapEnv(&svc.checkoutSvcAddr, "CHECKOUT_SERVICE_ADDR")
 	mustMapEnv(&svc.shippingSvcAddr, "SHIPPING_SERVICE_ADDR")
 	mustMapEnv(&svc.adSvcAddr, "AD_SERVICE_ADDR")
+	mustMapEnv(&svc.orderSvcAddr, "ORDER_SERVICE_ADDR")
+	mustMapEnv(&svc.paymentSvcAddr, "PAYMENT_SERVICE_ADDR")
+	mustMapEnv(&svc.emailSvcAddr, "EMAIL_SERVICE_ADDR")
+	svc.publicURL = strings.TrimSuffix(os.Getenv("PUBLIC_URL"), "/")
+	if svc.publicURL == "" {
+		svc.publicURL = defaultPublicURL
+	}
+	svc.sessions = newSessionStore()
 
 	mustConnGRPC(ctx, &svc.currencySvcConn, svc.currencySvcAddr)
 	mustConnGRPC(ctx, &svc.productCatalogSvcConn, svc.productCatalogSvcAddr)
@@ -111,6 +140,11 @@ func main() {
 	mustConnGRPC(ctx, &svc.shippingSvcConn, svc.shippingSvcAddr)
 	mustConnGRPC(ctx, &svc.checkoutSvcConn, svc.checkoutSvcAddr)
 	mustConnGRPC(ctx, &svc.adSvcConn, svc.adSvcAddr)
+	mustConnGRPC(ctx, &svc.orderSvcConn, svc.orderSvcAddr)
+	mustConnGRPC(ctx, &svc.paymentSvcConn, svc.paymentSvcAddr)
+	mustConnGRPC(ctx, &svc.emailSvcConn, svc.emailSvcAddr)
+
+	currencyInfos = svc.mustLoadCurrencyInfos(ctx, log)
 
 	r := mux.NewRouter()
 	r.HandleFunc("/", svc.homeHandler).Methods(http.MethodGet, http.MethodHead)
@@ -118,15 +152,23 @@ func main() {
 	r.HandleFunc("/cart", svc.viewCartHandler).Methods(http.MethodGet, http.MethodHead)
 	r.HandleFunc("/cart", svc.addToCartHandler).Methods(http.MethodPost)
 	r.HandleFunc("/cart/empty", svc.emptyCartHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/update", svc.updateCartHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/remove", svc.removeFromCartHandler).Methods(http.MethodPost)
 	r.HandleFunc("/setCurrency", svc.setCurrencyHandler).Methods(http.MethodPost)
+	r.HandleFunc("/login", svc.loginHandler).Methods(http.MethodPost)
+	r.HandleFunc("/login", svc.loginLinkHandler).Methods(http.MethodGet)
 	r.HandleFunc("/logout", svc.logoutHandler).Methods(http.MethodGet)
 	r.HandleFunc("/cart/checkout", svc.placeOrderHandler).Methods(http.MethodPost)
+	r.HandleFunc("/orders", svc.ordersHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/orders/{id}", svc.orderHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/tracking/{id}", svc.trackingHandler).Methods(http.MethodGet, http.MethodHead)
 	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("./static/"))))
 	r.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "User-agent: *\nDisallow: /") })
 	r.HandleFunc("/_healthz", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "ok") })
 
 	var handler http.Handler = r
 	handler = &logHandler{log: log, next: handler} // add logging
+	handler = svc.sessions.resolveUser(handler)    // add user ID
 	handler = ensureSessionID(handler)             // add session ID
 	handler = tracingMiddleware(handler)
 	log.Infof("starting server on " + addr + ":" + srvPort)
@@ -141,6 +183,27 @@ func mustMapEnv(target *string, envKey string) {
 	*target = v
 }
 
+// mustLoadCurrencyInfos returns the metadata of the supported currencies by
+// code. It retries until the currency service answers, since it may still be
+// starting.
+func (fe *frontendServer) mustLoadCurrencyInfos(ctx context.Context, log logrus.FieldLogger) map[string]*pb.CurrencyInfo {
+	for backoff := time.Second; ; backoff *= 2 {
+		currs, err := fe.getCurrencies(ctx)
+		if err == nil {
+			infos := make(map[string]*pb.CurrencyInfo, len(currs))
+			for _, c := range currs {
+				infos[c.GetCode()] = c
+			}
+			return infos
+		}
+		if backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+		log.Warnf("failed to load the supported currencies, retrying in %v: %v", backoff, err)
+		time.Sleep(backoff)
+	}
+}
+
 func mustConnGRPC(ctx context.Context, conn **grpc.ClientConn, addr string) {
 	var err error
 
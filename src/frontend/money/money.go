@@ -16,6 +16,10 @@ package money
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
 
 	pb "github.com/triplewy/microservices-demo/src/frontend/genproto"
 )
@@ -130,3 +134,28 @@ func MultiplySlow(m pb.Money, n uint32) pb.Money {
 	}
 	return out
 }
+
+// Format formats m with a currency symbol and minorUnits decimals, such as
+// "$19.99", "¥2235" or "CHF 10.00". Digits beyond minorUnits are dropped. The
+// currency code of m is used if symbol is empty.
+func Format(m pb.Money, symbol string, minorUnits int) string {
+	if symbol == "" {
+		symbol = m.GetCurrencyCode()
+	}
+	if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) {
+		symbol += " "
+	}
+	units, nanos := m.GetUnits(), m.GetNanos()
+	sign := ""
+	if IsNegative(m) {
+		sign, units, nanos = "-", -units, -nanos
+	}
+	out := sign + symbol + strconv.FormatInt(units, 10)
+	if minorUnits > 9 {
+		minorUnits = 9
+	}
+	if minorUnits > 0 {
+		out += "." + fmt.Sprintf("%09d", nanos)[:minorUnits]
+	}
+	return out
+}
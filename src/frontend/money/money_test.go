@@ -243,3 +243,33 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestFormat(t *testing.T) {
+	type args struct {
+		m          pb.Money
+		symbol     string
+		minorUnits int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"cents", args{mmc(19, 990000000, "USD"), "$", 2}, "$19.99"},
+		{"drops extra digits", args{mmc(19, 999999999, "USD"), "$", 2}, "$19.99"},
+		{"leading zero cents", args{mmc(3, 50000000, "EUR"), "€", 2}, "€3.05"},
+		{"no minor units", args{mmc(2235, 0, "JPY"), "¥", 0}, "¥2235"},
+		{"three minor units", args{mmc(1, 5000000, "XXX"), "X", 3}, "X 1.005"},
+		{"letter symbol", args{mmc(10, 0, "CHF"), "CHF", 2}, "CHF 10.00"},
+		{"no symbol", args{mmc(10, 0, "XXX"), "", 2}, "XXX 10.00"},
+		{"negative", args{mmc(-1, -500000000, "USD"), "$", 2}, "-$1.50"},
+		{"negative nanos only", args{mmc(0, -500000000, "USD"), "$", 2}, "-$0.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.args.m, tt.args.symbol, tt.args.minorUnits); got != tt.want {
+				t.Errorf("Format([%v], %q, %d) = %q, want %q", tt.args.m, tt.args.symbol, tt.args.minorUnits, got, tt.want)
+			}
+		})
+	}
+}
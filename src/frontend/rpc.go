@@ -27,19 +27,13 @@ const (
 	avoidNoopCurrencyConversionRPC = false
 )
 
-func (fe *frontendServer) getCurrencies(ctx context.Context) ([]string, error) {
+func (fe *frontendServer) getCurrencies(ctx context.Context) ([]*pb.CurrencyInfo, error) {
 	currs, err := pb.NewCurrencyServiceClient(fe.currencySvcConn).
 		GetSupportedCurrencies(ctx, &pb.Empty{})
 	if err != nil {
 		return nil, err
 	}
-	var out []string
-	for _, c := range currs.CurrencyCodes {
-		if _, ok := whitelistedCurrencies[c]; ok {
-			out = append(out, c)
-		}
-	}
-	return out, nil
+	return currs.GetCurrencies(), nil
 }
 
 func (fe *frontendServer) getProducts(ctx context.Context) ([]*pb.Product, error) {
@@ -64,6 +58,38 @@ func (fe *frontendServer) emptyCart(ctx context.Context, userID string) error {
 	return err
 }
 
+func (fe *frontendServer) updateCartItem(ctx context.Context, userID, productID string, quantity int32) error {
+	_, err := pb.NewCartServiceClient(fe.cartSvcConn).UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{
+		UserId:    userID,
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	return err
+}
+
+func (fe *frontendServer) removeCartItem(ctx context.Context, userID, productID string) error {
+	_, err := pb.NewCartServiceClient(fe.cartSvcConn).RemoveItem(ctx, &pb.RemoveItemRequest{
+		UserId:    userID,
+		ProductId: productID,
+	})
+	return err
+}
+
+func (fe *frontendServer) mergeCarts(ctx context.Context, fromUserID, toUserID string) error {
+	_, err := pb.NewCartServiceClient(fe.cartSvcConn).MergeCarts(ctx, &pb.MergeCartsRequest{
+		FromUserId: fromUserID,
+		ToUserId:   toUserID,
+	})
+	return err
+}
+
+// tokenizeCard stores card in the vault of the payment service, and returns
+// its token.
+func (fe *frontendServer) tokenizeCard(ctx context.Context, card *pb.CreditCardInfo) (string, error) {
+	resp, err := pb.NewPaymentServiceClient(fe.paymentSvcConn).Tokenize(ctx, &pb.TokenizeRequest{CreditCard: card})
+	return resp.GetToken(), err
+}
+
 func (fe *frontendServer) insertCart(ctx context.Context, userID, productID string, quantity int32) error {
 	_, err := pb.NewCartServiceClient(fe.cartSvcConn).AddItem(ctx, &pb.AddItemRequest{
 		UserId: userID,
@@ -84,6 +110,30 @@ func (fe *frontendServer) convertCurrency(ctx context.Context, money *pb.Money,
 			ToCode: currency})
 }
 
+// convertCurrencies converts many amounts to currency in one round trip.
+func (fe *frontendServer) convertCurrencies(ctx context.Context, amounts []*pb.Money, currency string) ([]*pb.Money, error) {
+	if avoidNoopCurrencyConversionRPC {
+		noop := true
+		for _, m := range amounts {
+			noop = noop && m.GetCurrencyCode() == currency
+		}
+		if noop {
+			return amounts, nil
+		}
+	}
+	res, err := pb.NewCurrencyServiceClient(fe.currencySvcConn).
+		ConvertBatch(ctx, &pb.ConvertBatchRequest{
+			From:   amounts,
+			ToCode: currency})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.GetResults()) != len(amounts) {
+		return nil, errors.Errorf("currency service converted %d of %d amounts", len(res.GetResults()), len(amounts))
+	}
+	return res.GetResults(), nil
+}
+
 func (fe *frontendServer) getShippingQuote(ctx context.Context, items []*pb.CartItem, currency string) (*pb.Money, error) {
 	quote, err := pb.NewShippingServiceClient(fe.shippingSvcConn).GetQuote(ctx,
 		&pb.GetQuoteRequest{
@@ -125,3 +175,26 @@ func (fe *frontendServer) getAd(ctx context.Context, ctxKeys []string) ([]*pb.Ad
 	})
 	return resp.GetAds(), errors.Wrap(err, "failed to get ads")
 }
+
+func (fe *frontendServer) getShipmentStatus(ctx context.Context, trackingID string) (*pb.ShipmentStatus, error) {
+	return pb.NewShippingServiceClient(fe.shippingSvcConn).
+		GetShipmentStatus(ctx, &pb.GetShipmentStatusRequest{TrackingId: trackingID})
+}
+
+func (fe *frontendServer) sendLoginLink(ctx context.Context, email, link string) error {
+	_, err := pb.NewEmailServiceClient(fe.emailSvcConn).
+		SendLoginLink(ctx, &pb.SendLoginLinkRequest{Email: email, Link: link})
+	return errors.Wrap(err, "failed to send sign-in link")
+}
+
+func (fe *frontendServer) getOrders(ctx context.Context, userID string) ([]*pb.Order, error) {
+	resp, err := pb.NewOrderServiceClient(fe.orderSvcConn).
+		ListOrdersByUser(ctx, &pb.ListOrdersByUserRequest{UserId: userID})
+	return resp.GetOrders(), errors.Wrap(err, "failed to list orders")
+}
+
+func (fe *frontendServer) getOrder(ctx context.Context, id string) (*pb.Order, error) {
+	resp, err := pb.NewOrderServiceClient(fe.orderSvcConn).
+		GetOrder(ctx, &pb.GetOrderRequest{OrderId: id})
+	return resp, errors.Wrapf(err, "failed to get order %s", id)
+}
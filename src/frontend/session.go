@@ -0,0 +1,154 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// loginLinkTTL is how long a sign-in link can be used.
+	loginLinkTTL = 15 * time.Minute
+
+	// sweepEvery is how many grants the store makes between sweeps of the
+	// expired ones.
+	sweepEvery = 100
+)
+
+// csrfKey signs the CSRF tokens of the forms. It is random, so tokens are
+// only valid until the frontend restarts, like the sessions.
+var csrfKey = randomBytes(32)
+
+type ctxKeyUserID struct{}
+
+// grant is a pending sign-in or a session of a shopper.
+type grant struct {
+	userID  string
+	expires time.Time
+}
+
+// sessionStore keeps the pending sign-ins and the sessions of the signed in
+// shoppers in memory, both keyed by random tokens that are unrelated to the
+// user ID, so that they cannot be forged.
+type sessionStore struct {
+	mu       sync.Mutex
+	logins   map[string]grant
+	sessions map[string]grant
+	granted  int
+}
+
+func newSessionStore() *sessionStore {
+	return &sessionStore{logins: make(map[string]grant), sessions: make(map[string]grant)}
+}
+
+// startLogin returns the token of a sign-in link for userID.
+func (s *sessionStore) startLogin(userID string) string {
+	return s.grant(s.logins, userID, loginLinkTTL)
+}
+
+// finishLogin returns the user ID of the sign-in link with token, and makes
+// the link unusable.
+func (s *sessionStore) finishLogin(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.logins[token]
+	delete(s.logins, token)
+	if !ok || time.Now().After(g.expires) {
+		return "", false
+	}
+	return g.userID, true
+}
+
+// newSession returns the token of a new session of userID.
+func (s *sessionStore) newSession(userID string) string {
+	return s.grant(s.sessions, userID, cookieUserMaxAge*time.Second)
+}
+
+// user returns the user ID of the session with token.
+func (s *sessionStore) user(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.sessions[token]
+	if !ok || time.Now().After(g.expires) {
+		return "", false
+	}
+	return g.userID, true
+}
+
+// endSession signs the shopper of the session with token out.
+func (s *sessionStore) endSession(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, token)
+}
+
+func (s *sessionStore) grant(grants map[string]grant, userID string, ttl time.Duration) string {
+	token := hex.EncodeToString(randomBytes(32))
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grants[token] = grant{userID: userID, expires: now.Add(ttl)}
+	if s.granted++; s.granted%sweepEvery == 0 {
+		for _, m := range []map[string]grant{s.logins, s.sessions} {
+			for t, g := range m {
+				if now.After(g.expires) {
+					delete(m, t)
+				}
+			}
+		}
+	}
+	return token
+}
+
+// resolveUser adds the user ID of the session of a signed in shopper to the
+// context of requests.
+func (s *sessionStore) resolveUser(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie(cookieUserSession); err == nil {
+			if id, ok := s.user(c.Value); ok {
+				r = r.WithContext(context.WithValue(r.Context(), ctxKeyUserID{}, id))
+			}
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// csrfToken returns the token that forms must post in the session with
+// sessionID.
+func csrfToken(sessionID string) string {
+	mac := hmac.New(sha256.New, csrfKey)
+	mac.Write([]byte(sessionID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validCSRFToken reports whether r posts the CSRF token of its session.
+func validCSRFToken(r *http.Request) bool {
+	return hmac.Equal([]byte(r.PostFormValue("csrf_token")), []byte(csrfToken(sessionID(r))))
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
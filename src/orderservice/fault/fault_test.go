@@ -0,0 +1,115 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/orderservice/genproto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func call(in *Injector, ctx context.Context, method string) error {
+	_, err := in.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return &pb.Empty{}, nil })
+	return err
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	in := NewInjector()
+	in.roll = func() float64 { return 50 }
+
+	if _, err := in.Create(ctx, &pb.CreateRequest{
+		Svc:     "hipstershop.TestService",
+		Uri:     "/hipstershop.TestService/Test",
+		Percent: 60,
+		Code:    int32(codes.Unavailable),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := status.Code(call(in, ctx, "/hipstershop.TestService/Test")), codes.Unavailable; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if err := call(in, ctx, "/hipstershop.TestService/Other"); err != nil {
+		t.Errorf("unexpected error for unmatched method: %v", err)
+	}
+	if err := call(in, ctx, "/hipstershop.FaultService/Delete"); err != nil {
+		t.Errorf("unexpected error for fault service: %v", err)
+	}
+
+	// A roll above the percentage leaves the call untouched.
+	in.roll = func() float64 { return 60 }
+	if err := call(in, ctx, "/hipstershop.TestService/Test"); err != nil {
+		t.Errorf("unexpected error above percentage: %v", err)
+	}
+
+	in.roll = func() float64 { return 0 }
+	if _, err := in.Delete(ctx, &pb.DeleteRequest{Svc: "hipstershop.TestService"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(in, ctx, "/hipstershop.TestService/Test"); err != nil {
+		t.Errorf("unexpected error after delete: %v", err)
+	}
+}
+
+func TestServiceWideDelay(t *testing.T) {
+	ctx := context.Background()
+	in := NewInjector()
+
+	if _, err := in.Create(ctx, &pb.CreateRequest{
+		Svc:     "hipstershop.TestService",
+		Percent: 100,
+		DelayMs: 20,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := call(in, ctx, "/hipstershop.TestService/Test"); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took < 20*time.Millisecond {
+		t.Errorf("call took %v, want at least 20ms", took)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if got, want := status.Code(call(in, ctx, "/hipstershop.TestService/Test")), codes.DeadlineExceeded; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestCreateValidation(t *testing.T) {
+	in := NewInjector()
+	for _, req := range []*pb.CreateRequest{
+		{Percent: 10},
+		{Svc: "hipstershop.TestService", Percent: 101},
+		{Svc: "hipstershop.TestService", Percent: -1},
+		{Svc: "hipstershop.TestService", Percent: 10, Code: 17},
+		{Svc: "hipstershop.TestService", Percent: 10, DelayMs: -5},
+	} {
+		_, err := in.Create(context.Background(), req)
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("Create(%v): got %s, want %s", req, got, want)
+		}
+	}
+}
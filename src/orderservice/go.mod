@@ -0,0 +1,9 @@
+module github.com/triplewy/microservices-demo/src/orderservice
+
+go 1.14
+
+require (
+	github.com/golang/protobuf v1.3.5
+	go.uber.org/zap v1.14.1
+	google.golang.org/grpc v1.28.0
+)
@@ -0,0 +1,140 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/triplewy/microservices-demo/src/orderservice/fault"
+	pb "github.com/triplewy/microservices-demo/src/orderservice/genproto"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	port string
+
+	zLogger *zap.Logger
+	sugar   *zap.SugaredLogger
+)
+
+func init() {
+	port = "5060"
+
+	if os.Getenv("PORT") != "" {
+		port = os.Getenv("PORT")
+	}
+
+	zLogger, _ = zap.NewProduction()
+	sugar = zLogger.Sugar()
+}
+
+func main() {
+	flag.Parse()
+
+	defer zLogger.Sync()
+
+	var store orderStore = newMemoryStore()
+	if path := os.Getenv("ORDER_STORE_FILE"); path != "" {
+		fs, err := newFileStore(path)
+		if err != nil {
+			sugar.Fatal(err)
+		}
+		defer fs.close()
+		store = fs
+		sugar.Infof("storing orders in %s", path)
+	} else {
+		sugar.Info("storing orders in memory")
+	}
+
+	sugar.Infof("starting grpc server at :%s", port)
+	run(port, store)
+	select {}
+}
+
+func run(port string, store orderStore) string {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
+	svc := &orderService{store: store}
+	pb.RegisterOrderServiceServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, newHealthServer())
+	pb.RegisterFaultServiceServer(srv, faults)
+	go srv.Serve(l)
+	return l.Addr().String()
+}
+
+// newHealthServer returns a health server that reports the service as
+// serving, since it has no dependencies that could make it unable to.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("hipstershop.OrderService", healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
+
+type orderService struct {
+	store orderStore
+}
+
+// AddOrder stores an order. Adding an order again replaces it.
+func (s *orderService) AddOrder(ctx context.Context, req *pb.AddOrderRequest) (*pb.Empty, error) {
+	order := req.GetOrder()
+	if order.GetResult().GetOrderId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "order_id must be set")
+	}
+	if order.GetUserId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id must be set")
+	}
+	if err := s.store.put(order); err != nil {
+		sugar.Errorf("failed to store order %s: %v", order.GetResult().GetOrderId(), err)
+		return nil, status.Errorf(codes.Internal, "failed to store order: %v", err)
+	}
+	sugar.Infof("stored order %s of user %s", order.GetResult().GetOrderId(), order.GetUserId())
+	return &pb.Empty{}, nil
+}
+
+func (s *orderService) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	order, err := s.store.get(req.GetOrderId())
+	if err == errNotFound {
+		return nil, status.Errorf(codes.NotFound, "no order with ID %s", req.GetOrderId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+	return order, nil
+}
+
+func (s *orderService) ListOrdersByUser(ctx context.Context, req *pb.ListOrdersByUserRequest) (*pb.ListOrdersByUserResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id must be set")
+	}
+	orders, err := s.store.listByUser(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+	}
+	return &pb.ListOrdersByUserResponse{Orders: orders}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/triplewy/microservices-demo/src/orderservice/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	addr := run("0", newMemoryStore())
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewOrderServiceClient(conn)
+
+	for _, o := range []*pb.Order{testOrder("a", "alice", 1), testOrder("b", "alice", 2)} {
+		if _, err := client.AddOrder(ctx, &pb.AddOrderRequest{Order: o}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.AddOrder(ctx, &pb.AddOrderRequest{Order: testOrder("", "alice", 3)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddOrder without ID: got %v, want InvalidArgument", err)
+	}
+
+	order, err := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.GetResult().GetShippingCost().GetNanos() != 990000000 {
+		t.Errorf("GetOrder returned %v", order)
+	}
+	if _, err := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetOrder of a missing order: got %v, want NotFound", err)
+	}
+
+	res, err := client.ListOrdersByUser(ctx, &pb.ListOrdersByUserRequest{UserId: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := orderIDs(res.GetOrders()); len(ids) != 2 || ids[0] != "b" {
+		t.Errorf("ListOrdersByUser = %v, want [b a]", ids)
+	}
+	res, err = client.ListOrdersByUser(ctx, &pb.ListOrdersByUserRequest{UserId: "bob"})
+	if err != nil || len(res.GetOrders()) != 0 {
+		t.Errorf("ListOrdersByUser(bob) = %v, %v, want no orders", res, err)
+	}
+}
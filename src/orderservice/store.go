@@ -0,0 +1,157 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/orderservice/genproto"
+)
+
+var errNotFound = errors.New("order not found")
+
+// orderStore stores placed orders.
+type orderStore interface {
+	// put stores order, replacing a stored order with the same ID.
+	put(order *pb.Order) error
+	// get returns the order with id, or errNotFound.
+	get(id string) (*pb.Order, error)
+	// listByUser returns the orders of userID, most recent first.
+	listByUser(userID string) ([]*pb.Order, error)
+}
+
+// memoryStore keeps orders in memory only.
+type memoryStore struct {
+	mu     sync.RWMutex
+	orders map[string]*pb.Order
+	byUser map[string][]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		orders: make(map[string]*pb.Order),
+		byUser: make(map[string][]string),
+	}
+}
+
+func (s *memoryStore) put(order *pb.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := order.GetResult().GetOrderId()
+	if prev, ok := s.orders[id]; ok {
+		s.unindex(prev.GetUserId(), id)
+	}
+	s.orders[id] = proto.Clone(order).(*pb.Order)
+	s.byUser[order.GetUserId()] = append(s.byUser[order.GetUserId()], id)
+	return nil
+}
+
+func (s *memoryStore) unindex(userID, id string) {
+	ids := s.byUser[userID]
+	for i, v := range ids {
+		if v == id {
+			s.byUser[userID] = append(ids[:i:i], ids[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *memoryStore) get(id string) (*pb.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	order, ok := s.orders[id]
+	if !ok {
+		return nil, errNotFound
+	}
+	return proto.Clone(order).(*pb.Order), nil
+}
+
+func (s *memoryStore) listByUser(userID string) ([]*pb.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := s.byUser[userID]
+	out := make([]*pb.Order, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, proto.Clone(s.orders[id]).(*pb.Order))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].GetPlacedAt() > out[j].GetPlacedAt() })
+	return out, nil
+}
+
+// fileStore keeps orders in memory and appends every stored order to a file
+// of JSON lines, which is replayed when the store is opened.
+type fileStore struct {
+	*memoryStore
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+func newFileStore(path string) (*fileStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open order file: %+v", err)
+	}
+	s := &fileStore{memoryStore: newMemoryStore(), f: f}
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var order pb.Order
+		if err := jsonpb.Unmarshal(bytes.NewReader(sc.Bytes()), &order); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to parse order file line %d: %+v", n, err)
+		}
+		s.memoryStore.put(&order)
+	}
+	if err := sc.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read order file: %+v", err)
+	}
+	return s, nil
+}
+
+func (s *fileStore) put(order *pb.Order) error {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, order); err != nil {
+		return fmt.Errorf("failed to encode order: %+v", err)
+	}
+	buf.WriteByte('\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write order: %+v", err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("failed to write order: %+v", err)
+	}
+	return s.memoryStore.put(order)
+}
+
+func (s *fileStore) close() error {
+	return s.f.Close()
+}
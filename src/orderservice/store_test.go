@@ -0,0 +1,116 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/triplewy/microservices-demo/src/orderservice/genproto"
+)
+
+func testOrder(id, userID string, placedAt int64) *pb.Order {
+	return &pb.Order{
+		Result: &pb.OrderResult{
+			OrderId:      id,
+			ShippingCost: &pb.Money{CurrencyCode: "USD", Units: 8, Nanos: 990000000},
+		},
+		UserId:   userID,
+		Total:    &pb.Money{CurrencyCode: "USD", Units: 42},
+		PlacedAt: placedAt,
+	}
+}
+
+func orderIDs(orders []*pb.Order) []string {
+	var ids []string
+	for _, o := range orders {
+		ids = append(ids, o.GetResult().GetOrderId())
+	}
+	return ids
+}
+
+func testStore(t *testing.T, s orderStore) {
+	t.Helper()
+	for _, o := range []*pb.Order{
+		testOrder("a", "alice", 1),
+		testOrder("b", "bob", 2),
+		testOrder("c", "alice", 3),
+	} {
+		if err := s.put(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Adding an order again replaces it.
+	if err := s.put(testOrder("a", "alice", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.get("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetUserId() != "bob" {
+		t.Errorf("get(b) returned order of %q, want bob", got.GetUserId())
+	}
+	if _, err := s.get("missing"); err != errNotFound {
+		t.Errorf("get(missing) error = %v, want errNotFound", err)
+	}
+
+	orders, err := s.listByUser("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := orderIDs(orders); len(ids) != 2 || ids[0] != "c" || ids[1] != "a" {
+		t.Errorf("listByUser(alice) = %v, want [c a]", ids)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, newMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "orders.jsonl")
+
+	s, err := newFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, s)
+	s.close()
+
+	reopened, err := newFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.close()
+	orders, err := reopened.listByUser("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := orderIDs(orders); len(ids) != 2 || ids[0] != "c" || ids[1] != "a" {
+		t.Errorf("listByUser(alice) after reopening = %v, want [c a]", ids)
+	}
+	if got, err := reopened.get("a"); err != nil || got.GetTotal().GetUnits() != 42 {
+		t.Errorf("get(a) after reopening = %v, %v", got, err)
+	}
+}
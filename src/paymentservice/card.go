@@ -0,0 +1,179 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cardValidator "github.com/sgumirov/go-cards-validation"
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultCardBrands are the brands accepted unless ACCEPTED_CARD_BRANDS is
+// set.
+const defaultCardBrands = "visa,mastercard"
+
+// knownCardBrands are the codes of the brands that cards are recognized as.
+var knownCardBrands = map[string]bool{
+	"visa": true, "visa electron": true, "mastercard": true, "maestro": true,
+	"amex": true, "discover": true, "jcb": true, "china unionpay": true,
+	"diners club carte blanche": true, "diners club enroute": true, "diners club international": true,
+	"elo": true, "hipercard": true, "cabal": true, "aura": true, "bankcard": true,
+	"dankort": true, "interpayment": true, "instapayment": true,
+}
+
+// parseCardBrands parses a comma-separated list of brand codes, such as
+// "visa,mastercard,amex".
+func parseCardBrands(s string) (map[string]bool, error) {
+	brands := make(map[string]bool)
+	for _, b := range strings.Split(s, ",") {
+		b = strings.ToLower(strings.TrimSpace(b))
+		if b == "" {
+			continue
+		}
+		if !knownCardBrands[b] {
+			known := make([]string, 0, len(knownCardBrands))
+			for k := range knownCardBrands {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown card brand %q, want one of %s", b, strings.Join(known, ", "))
+		}
+		brands[b] = true
+	}
+	if len(brands) == 0 {
+		return nil, fmt.Errorf("no card brands are accepted")
+	}
+	return brands, nil
+}
+
+// cardBrandsFromEnv returns the brands accepted by the ACCEPTED_CARD_BRANDS
+// environment variable.
+func cardBrandsFromEnv() (map[string]bool, error) {
+	v := os.Getenv("ACCEPTED_CARD_BRANDS")
+	if v == "" {
+		v = defaultCardBrands
+	}
+	brands, err := parseCardBrands(v)
+	if err != nil {
+		return nil, fmt.Errorf("environment variable \"ACCEPTED_CARD_BRANDS\": %v", err)
+	}
+	return brands, nil
+}
+
+// validCard is a card that passed validation.
+type validCard struct {
+	gatewayCard
+	brand    string
+	lastFour string
+}
+
+// cardViolations collects what is wrong with a card.
+type cardViolations struct {
+	code       codes.Code
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+// add records a violation of field. Malformed fields fail with
+// INVALID_ARGUMENT, which takes precedence over FAILED_PRECONDITION for cards
+// that are well-formed but cannot be charged.
+func (v *cardViolations) add(code codes.Code, field, description string) {
+	if v.code != codes.InvalidArgument {
+		v.code = code
+	}
+	v.violations = append(v.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       "credit_card." + field,
+		Description: description,
+	})
+}
+
+// err returns the violations as a status with a BadRequest in its details, or
+// nil if there are none.
+func (v *cardViolations) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(v.violations))
+	for i, fv := range v.violations {
+		descriptions[i] = fv.GetDescription()
+	}
+	st := status.New(v.code, "invalid credit card: "+strings.Join(descriptions, "; "))
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v.violations}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// validateCard checks that creditCard is a valid card of an accepted brand
+// that has not expired. Every violation is reported at once.
+func (p *payment) validateCard(creditCard *pb.CreditCardInfo) (*validCard, error) {
+	if creditCard == nil {
+		return nil, status.Error(codes.InvalidArgument, "credit_card or card_token must be set")
+	}
+	var v cardViolations
+
+	card := cardValidator.Card{Number: strings.NewReplacer("-", "", " ", "").Replace(creditCard.GetCreditCardNumber())}
+	brand := ""
+	if strings.Trim(card.Number, "0123456789") != "" || !card.ValidateNumber() {
+		v.add(codes.InvalidArgument, "credit_card_number", "credit card number is invalid")
+	} else if err := card.Brand(); err != nil {
+		v.add(codes.FailedPrecondition, "credit_card_number", "credit card brand is unknown")
+	} else if brand = card.Company.Code; !p.brands[brand] {
+		v.add(codes.FailedPrecondition, "credit_card_number", fmt.Sprintf("%s cards are not accepted", card.Company.Name))
+	}
+
+	// Security codes are sent as numbers, so they must have exactly
+	// cvvDigits digits, the first of which is not zero.
+	cvv, cvvDigits := strconv.Itoa(int(creditCard.GetCreditCardCvv())), 3
+	if brand == "amex" {
+		cvvDigits = 4
+	}
+	if creditCard.GetCreditCardCvv() <= 0 || len(cvv) != cvvDigits {
+		v.add(codes.InvalidArgument, "credit_card_cvv", fmt.Sprintf("security code must have %d digits", cvvDigits))
+	}
+
+	month, year := int(creditCard.GetCreditCardExpirationMonth()), int(creditCard.GetCreditCardExpirationYear())
+	validDate := true
+	if month < 1 || month > 12 {
+		validDate = false
+		v.add(codes.InvalidArgument, "credit_card_expiration_month", "expiration month must be between 1 and 12")
+	}
+	if year < 1000 || year > 9999 {
+		validDate = false
+		v.add(codes.InvalidArgument, "credit_card_expiration_year", "expiration year must have 4 digits")
+	}
+	// Cards expire at the end of their expiration month.
+	if validDate && !time.Now().Before(time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)) {
+		v.add(codes.FailedPrecondition, "credit_card_expiration_year", "credit card has expired")
+	}
+
+	if err := v.err(); err != nil {
+		sugar.Infof("card rejected: %v", err)
+		return nil, err
+	}
+	return &validCard{
+		gatewayCard: gatewayCard{Number: card.Number, Cvv: cvv, ExpMonth: month, ExpYear: year},
+		brand:       card.Company.Name,
+		lastFour:    card.Number[len(card.Number)-4:],
+	}, nil
+}
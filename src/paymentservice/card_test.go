@@ -0,0 +1,127 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// violatedFields returns the fields of the BadRequest in the details of err.
+func violatedFields(err error) []string {
+	var fields []string
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	return fields
+}
+
+func TestCardValidation(t *testing.T) {
+	p := newTestPayment(t)
+	ctx := context.Background()
+	card := func(number string, cvv, month, year int32) *pb.CreditCardInfo {
+		return &pb.CreditCardInfo{
+			CreditCardNumber:          number,
+			CreditCardCvv:             cvv,
+			CreditCardExpirationMonth: month,
+			CreditCardExpirationYear:  year,
+		}
+	}
+	for _, tc := range []struct {
+		name   string
+		card   *pb.CreditCardInfo
+		code   codes.Code
+		fields []string
+	}{
+		{"valid", card("4432-8015-6152-0454", 672, 1, 2039), codes.OK, nil},
+		{"valid with spaces", card("4432 8015 6152 0454", 720, 12, 2039), codes.OK, nil},
+		{"bad luhn", card("4432-8015-6152-0455", 672, 1, 2039), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_number"}},
+		{"not a number", card("4432-8015-abcd-0454", 672, 1, 2039), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_number"}},
+		{"bad cvv", card("4432-8015-6152-0454", 6720, 1, 2039), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_cvv"}},
+		{"short cvv", card("4432-8015-6152-0454", 72, 1, 2039), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_cvv"}},
+		{"zero cvv", card("4432-8015-6152-0454", 0, 1, 2039), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_cvv"}},
+		{"bad month", card("4432-8015-6152-0454", 672, 13, 2039), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_expiration_month"}},
+		{"expired", card("4432-8015-6152-0454", 672, 1, 2001), codes.FailedPrecondition,
+			[]string{"credit_card.credit_card_expiration_year"}},
+		{"unsupported brand", card("378282246310005", 1234, 1, 2039), codes.FailedPrecondition,
+			[]string{"credit_card.credit_card_number"}},
+		{"expired with a bad cvv", card("4432-8015-6152-0454", -1, 1, 2001), codes.InvalidArgument,
+			[]string{"credit_card.credit_card_cvv", "credit_card.credit_card_expiration_year"}},
+	} {
+		_, err := p.Tokenize(ctx, &pb.TokenizeRequest{CreditCard: tc.card})
+		if status.Code(err) != tc.code || !reflect.DeepEqual(violatedFields(err), tc.fields) {
+			t.Errorf("%s: got %v with violations of %v, want %v with violations of %v",
+				tc.name, err, violatedFields(err), tc.code, tc.fields)
+		}
+	}
+	if _, err := p.Authorize(ctx, &pb.AuthorizeRequest{Amount: usd(42, 0)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("authorizing without a card: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestAcceptedCardBrands(t *testing.T) {
+	p := newTestPayment(t)
+	var err error
+	if p.brands, err = parseCardBrands("visa, AMEX,discover,jcb"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, tc := range []struct {
+		number string
+		cvv    int32
+		brand  string
+		code   codes.Code
+	}{
+		{"378282246310005", 1234, "American Express", codes.OK},
+		{"378282246310005", 12345, "", codes.InvalidArgument},
+		{"378282246310005", 123, "", codes.InvalidArgument},
+		{"6011111111111117", 123, "Discover", codes.OK},
+		{"3566002020360505", 123, "JCB", codes.OK},
+		{"4242424242424242", 123, "Visa", codes.OK},
+		{"5555555555554444", 123, "", codes.FailedPrecondition},
+	} {
+		resp, err := p.Tokenize(ctx, &pb.TokenizeRequest{CreditCard: &pb.CreditCardInfo{
+			CreditCardNumber:          tc.number,
+			CreditCardCvv:             tc.cvv,
+			CreditCardExpirationMonth: 1,
+			CreditCardExpirationYear:  2039,
+		}})
+		if status.Code(err) != tc.code || resp.GetCardBrand() != tc.brand {
+			t.Errorf("tokenizing %s: got %v, %v, want %v, %v", tc.number, resp.GetCardBrand(), err, tc.brand, tc.code)
+		}
+	}
+
+	for _, brands := range []string{"", " , ", "visa,diners"} {
+		if _, err := parseCardBrands(brands); err == nil {
+			t.Errorf("parseCardBrands(%q) succeeded", brands)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReviewScore  = 50
+	defaultDeclineScore = 100
+
+	// sweepEvery is how many assessments the scorer makes between sweeps of
+	// the payments that no velocity rule looks at anymore.
+	sweepEvery = 1000
+)
+
+// fraudRules are the rules that payments are scored with. They are read from
+// a JSON file such as:
+//
+//	{
+//	  "review_score": 50,
+//	  "decline_score": 100,
+//	  "velocity": [{"by": "card", "window": "1h", "max": 5, "score": 60}],
+//	  "amount_thresholds": [{"currency": "USD", "above": 1000, "score": 40}],
+//	  "country_mismatch_score": 30,
+//	  "bin_countries": {"4432": "United States"},
+//	  "blocked_bins": ["400000"]
+//	}
+type fraudRules struct {
+	// Payments scoring at least ReviewScore are approved for review, and
+	// those scoring at least DeclineScore are declined.
+	ReviewScore  int32 `json:"review_score"`
+	DeclineScore int32 `json:"decline_score"`
+
+	Velocity         []velocityRule `json:"velocity"`
+	AmountThresholds []amountRule   `json:"amount_thresholds"`
+
+	// CountryMismatchScore scores payments with a card issued in another
+	// country than the billing country. BinCountries maps prefixes of card
+	// numbers to the country that issues them.
+	CountryMismatchScore int32             `json:"country_mismatch_score"`
+	BinCountries         map[string]string `json:"bin_countries"`
+
+	// BlockedBins are prefixes of card numbers that are always declined.
+	BlockedBins []string `json:"blocked_bins"`
+}
+
+// velocityRule scores payments with a card or in a session that already made
+// Max payments in the last Window.
+type velocityRule struct {
+	By     string `json:"by"`
+	Window string `json:"window"`
+	Max    int    `json:"max"`
+	Score  int32  `json:"score"`
+
+	window time.Duration
+}
+
+// amountRule scores payments of more than Above units of Currency.
+type amountRule struct {
+	Currency string `json:"currency"`
+	Above    int64  `json:"above"`
+	Score    int32  `json:"score"`
+}
+
+// loadFraudRules reads the fraud rules from path, or returns no rules if path
+// is empty.
+func loadFraudRules(path string) (*fraudRules, error) {
+	rules := &fraudRules{}
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fraud rules: %v", err)
+		}
+		if err := json.Unmarshal(data, rules); err != nil {
+			return nil, fmt.Errorf("fraud rules %s: %v", path, err)
+		}
+	}
+	if err := rules.init(); err != nil {
+		return nil, fmt.Errorf("fraud rules %s: %v", path, err)
+	}
+	return rules, nil
+}
+
+// init checks the rules and sets their defaults.
+func (r *fraudRules) init() error {
+	if r.ReviewScore == 0 {
+		r.ReviewScore = defaultReviewScore
+	}
+	if r.DeclineScore == 0 {
+		r.DeclineScore = defaultDeclineScore
+	}
+	for i := range r.Velocity {
+		v := &r.Velocity[i]
+		if v.By != "card" && v.By != "session" {
+			return fmt.Errorf("velocity rule %d: by is %q, want card or session", i, v.By)
+		}
+		d, err := time.ParseDuration(v.Window)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("velocity rule %d: window is not a positive duration: %q", i, v.Window)
+		}
+		v.window = d
+	}
+	return nil
+}
+
+// fraudPayment is what the rules know of a payment.
+type fraudPayment struct {
+	card           *validCard
+	amount         *pb.Money
+	sessionID      string
+	billingCountry string
+}
+
+// fraudScorer scores payments with rules, remembering recent payments for
+// the velocity rules.
+type fraudScorer struct {
+	rules *fraudRules
+	now   func() time.Time
+
+	mu          sync.Mutex
+	recent      map[string][]time.Time
+	assessments int
+}
+
+func newFraudScorer(rules *fraudRules) *fraudScorer {
+	return &fraudScorer{rules: rules, now: time.Now, recent: make(map[string][]time.Time)}
+}
+
+// assess scores p, and remembers it for the velocity rules.
+func (s *fraudScorer) assess(p fraudPayment) *pb.FraudAssessment {
+	a := &pb.FraudAssessment{}
+	match := func(score int32, format string, args ...interface{}) {
+		a.Score += score
+		a.Reasons = append(a.Reasons, fmt.Sprintf(format, args...))
+	}
+	number := p.card.Number
+	for _, bin := range s.rules.BlockedBins {
+		if strings.HasPrefix(number, bin) {
+			match(s.rules.DeclineScore, "card BIN %s is blocked", bin)
+			break
+		}
+	}
+
+	for _, rule := range s.rules.AmountThresholds {
+		if strings.EqualFold(rule.Currency, p.amount.GetCurrencyCode()) &&
+			(p.amount.GetUnits() > rule.Above || p.amount.GetUnits() == rule.Above && p.amount.GetNanos() > 0) {
+			match(rule.Score, "amount is over %d %s", rule.Above, rule.Currency)
+		}
+	}
+
+	if country := s.cardCountry(number); country != "" && p.billingCountry != "" &&
+		!strings.EqualFold(country, strings.TrimSpace(p.billingCountry)) {
+		match(s.rules.CountryMismatchScore, "card is issued in %s but billing country is %s", country, p.billingCountry)
+	}
+
+	if len(s.rules.Velocity) > 0 {
+		s.velocity(p, match)
+	}
+
+	switch {
+	case a.Score >= s.rules.DeclineScore:
+		a.Decision = pb.FraudAssessment_DECLINE
+	case a.Score >= s.rules.ReviewScore:
+		a.Decision = pb.FraudAssessment_REVIEW
+	default:
+		a.Decision = pb.FraudAssessment_APPROVE
+	}
+	return a
+}
+
+// velocity applies the velocity rules to p, and remembers it.
+func (s *fraudScorer) velocity(p fraudPayment, match func(score int32, format string, args ...interface{})) {
+	keys := map[string]string{"card": s.cardKey(p.card.Number)}
+	if p.sessionID != "" {
+		keys["session"] = "session:" + p.sessionID
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rule := range s.rules.Velocity {
+		key, ok := keys[rule.By]
+		if !ok {
+			continue
+		}
+		n := 0
+		for _, t := range s.recent[key] {
+			if now.Sub(t) < rule.window {
+				n++
+			}
+		}
+		if n >= rule.Max {
+			match(rule.Score, "%d payments with this %s in the last %v", n, rule.By, rule.window)
+		}
+	}
+	for _, key := range keys {
+		s.recent[key] = append(s.prune(s.recent[key], now), now)
+	}
+	if s.assessments++; s.assessments%sweepEvery == 0 {
+		for key, times := range s.recent {
+			if times = s.prune(times, now); len(times) == 0 {
+				delete(s.recent, key)
+			} else {
+				s.recent[key] = times
+			}
+		}
+	}
+}
+
+// prune drops the times that no velocity rule looks at anymore.
+func (s *fraudScorer) prune(times []time.Time, now time.Time) []time.Time {
+	var longest time.Duration
+	for _, rule := range s.rules.Velocity {
+		if rule.window > longest {
+			longest = rule.window
+		}
+	}
+	i := sort.Search(len(times), func(i int) bool { return now.Sub(times[i]) < longest })
+	return append(times[:0], times[i:]...)
+}
+
+// cardCountry returns the country of the longest BIN of the rules that
+// number starts with.
+func (s *fraudScorer) cardCountry(number string) string {
+	var bin, country string
+	for b, c := range s.rules.BinCountries {
+		if len(b) > len(bin) && strings.HasPrefix(number, b) {
+			bin, country = b, c
+		}
+	}
+	return country
+}
+
+// cardKey identifies a card in the recent payments without keeping its
+// number in memory.
+func (s *fraudScorer) cardKey(number string) string {
+	return fmt.Sprintf("card:%x", sha256.Sum256([]byte(number)))
+}
+
+// fraudDeclined returns the error of a payment declined by the fraud checks,
+// with the assessment in its details.
+func fraudDeclined(a *pb.FraudAssessment) error {
+	st := status.Newf(codes.FailedPrecondition, "payment declined by fraud checks: %s", strings.Join(a.GetReasons(), "; "))
+	if withDetails, err := st.WithDetails(a); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testFraudRules = `{
+  "review_score": 50,
+  "decline_score": 100,
+  "velocity": [
+    {"by": "card", "window": "1h", "max": 3, "score": 100},
+    {"by": "session", "window": "10m", "max": 2, "score": 50}
+  ],
+  "amount_thresholds": [{"currency": "USD", "above": 1000, "score": 30}],
+  "country_mismatch_score": 30,
+  "bin_countries": {"44": "France", "4432": "United States"},
+  "blocked_bins": ["400000"]
+}`
+
+// newFraudTestPayment returns a payment service with the test fraud rules,
+// and a function to move its clock.
+func newFraudTestPayment(t *testing.T) (*payment, func(time.Duration)) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := ioutil.WriteFile(path, []byte(testFraudRules), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadFraudRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newTestPayment(t)
+	p.fraud = newFraudScorer(rules)
+	now := time.Now()
+	p.fraud.now = func() time.Time { return now }
+	return p, func(d time.Duration) { now = now.Add(d) }
+}
+
+// fraudAssessment returns the assessment in the details of err.
+func fraudAssessment(err error) *pb.FraudAssessment {
+	for _, d := range status.Convert(err).Details() {
+		if a, ok := d.(*pb.FraudAssessment); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+func TestFraudDecisions(t *testing.T) {
+	p, _ := newFraudTestPayment(t)
+	ctx := context.Background()
+
+	// A card from a blocked BIN is declined, with the assessment in the
+	// details of the status.
+	_, err := p.Authorize(ctx, authorizeRequest("4000000000000077", usd(42, 0)))
+	a := fraudAssessment(err)
+	if status.Code(err) != codes.FailedPrecondition || a == nil {
+		t.Fatalf("authorizing a blocked BIN: got %v, want FailedPrecondition with an assessment", err)
+	}
+	if a.GetDecision() != pb.FraudAssessment_DECLINE || a.GetScore() != 100 || len(a.GetReasons()) != 1 {
+		t.Errorf("assessment of a blocked BIN = %v, want a decline with one reason", a)
+	}
+
+	// A large payment billed abroad is approved for review, and the
+	// transaction records why.
+	req := authorizeRequest("4432-8015-6152-0454", usd(1500, 0))
+	req.BillingCountry = "Canada"
+	tx, err := p.Authorize(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := tx.GetFraudAssessment(); a.GetDecision() != pb.FraudAssessment_REVIEW || a.GetScore() != 60 || len(a.GetReasons()) != 2 {
+		t.Errorf("assessment of a large payment billed abroad = %v, want a review with two reasons", a)
+	}
+
+	// The longest BIN decides the country of the card.
+	req = authorizeRequest("4432-8015-6152-0454", usd(42, 0))
+	req.BillingCountry = "united states"
+	if tx, err = p.Authorize(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if a := tx.GetFraudAssessment(); a.GetDecision() != pb.FraudAssessment_APPROVE || a.GetScore() != 0 {
+		t.Errorf("assessment of a payment billed in the country of the card = %v, want an approval", a)
+	}
+}
+
+func TestFraudVelocity(t *testing.T) {
+	p, advance := newFraudTestPayment(t)
+	ctx := context.Background()
+	charge := func(number, session string) error {
+		req := testChargeRequest("")
+		req.CreditCard.CreditCardNumber = number
+		req.SessionId = session
+		_, err := p.Charge(ctx, req)
+		return err
+	}
+
+	// The third payment of a session in 10 minutes is to be reviewed, and
+	// the fourth payment with a card in an hour is declined.
+	for i, session := range []string{"a", "b", "b"} {
+		if err := charge("4432-8015-6152-0454", session); err != nil {
+			t.Fatalf("payment %d: %v", i+1, err)
+		}
+	}
+	if err := charge("4432-8015-6152-0454", "c"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("fourth payment with a card: got %v, want FailedPrecondition", err)
+	}
+	if err := charge("4242424242424242", "b"); err != nil {
+		t.Errorf("third payment of a session: %v, want it approved for review", err)
+	}
+
+	// Payments are forgotten once they are out of the window.
+	advance(time.Hour)
+	if err := charge("4432-8015-6152-0454", "c"); err != nil {
+		t.Errorf("payment with a card an hour later: %v", err)
+	}
+}
+
+func TestLoadFraudRulesErrors(t *testing.T) {
+	for _, rules := range []string{
+		`{"velocity": [{"by": "ip", "window": "1h", "max": 1, "score": 10}]}`,
+		`{"velocity": [{"by": "card", "window": "soon", "max": 1, "score": 10}]}`,
+		`{"blocked_bins": "400000"}`,
+	} {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		if err := ioutil.WriteFile(path, []byte(rules), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadFraudRules(path); err == nil {
+			t.Errorf("loadFraudRules(%s) succeeded", rules)
+		}
+	}
+}
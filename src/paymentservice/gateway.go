@@ -0,0 +1,149 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultGatewayTimeout is how long a call to the payment gateway may take.
+const defaultGatewayTimeout = 10 * time.Second
+
+// Gateway is a payment processor, which moves the money of the transactions
+// that the ledger records.
+type Gateway interface {
+	// Authorize holds amount on card, and returns a reference to the
+	// authorization.
+	Authorize(ctx context.Context, card gatewayCard, amount *pb.Money) (string, error)
+	// Capture captures amount of an authorization.
+	Capture(ctx context.Context, ref string, amount *pb.Money) error
+	// Void releases an authorization.
+	Void(ctx context.Context, ref string) error
+	// Refund refunds amount of a captured authorization.
+	Refund(ctx context.Context, ref string, amount *pb.Money) error
+}
+
+// gatewayCard is a card, as sent to a gateway.
+type gatewayCard struct {
+	Number   string `json:"number"`
+	Cvv      string `json:"cvv"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+}
+
+// cardDeclinedType is the type of the precondition violation of a card that
+// the gateway declined.
+const cardDeclinedType = "CARD_DECLINED"
+
+// declineError is returned by gateways that decline a transaction, with a
+// code such as "insufficient_funds".
+type declineError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *declineError) Error() string {
+	return fmt.Sprintf("card declined (%s): %s", e.Code, e.Message)
+}
+
+// GRPCStatus makes declines fail with FAILED_PRECONDITION, with a
+// PreconditionFailure of type cardDeclinedType in the details, so that
+// clients can tell declines from other failed preconditions.
+func (e *declineError) GRPCStatus() *status.Status {
+	st := status.New(codes.FailedPrecondition, e.Error())
+	if withDetails, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{Type: cardDeclinedType, Subject: "credit_card", Description: e.Code}},
+	}); err == nil {
+		st = withDetails
+	}
+	return st
+}
+
+// rejectedError is returned by gateways that reject a request as malformed,
+// without making it.
+type rejectedError struct {
+	msg string
+}
+
+func (e *rejectedError) Error() string {
+	return "payment gateway rejected the request: " + e.msg
+}
+
+func (e *rejectedError) GRPCStatus() *status.Status {
+	return status.New(codes.Internal, e.Error())
+}
+
+// gatewayRefused reports whether err is a gateway declining or rejecting a
+// change, which it then did not make. After other errors, such as time-outs,
+// the change may or may not have been made.
+func gatewayRefused(err error) bool {
+	switch err.(type) {
+	case *declineError, *rejectedError:
+		return true
+	}
+	return false
+}
+
+// errGatewayTimeout is returned when the gateway does not respond in time.
+// The transaction may or may not have been made.
+var errGatewayTimeout = status.Error(codes.Unavailable, "payment gateway timed out")
+
+// gatewayFromEnv returns the gateway selected by the PAYMENT_GATEWAY
+// environment variable: "simulated" (default), configured by the file
+// PAYMENT_GATEWAY_SIMULATOR_CONFIG if it is set, or "http" at
+// PAYMENT_GATEWAY_URL.
+func gatewayFromEnv() (Gateway, error) {
+	switch kind := os.Getenv("PAYMENT_GATEWAY"); kind {
+	case "", "simulated":
+		cfg, err := loadSimulatorConfig(os.Getenv("PAYMENT_GATEWAY_SIMULATOR_CONFIG"))
+		if err != nil {
+			return nil, err
+		}
+		sugar.Infof("using a simulated payment gateway")
+		return newSimulatedGateway(cfg), nil
+	case "http":
+		url := os.Getenv("PAYMENT_GATEWAY_URL")
+		if url == "" {
+			return nil, fmt.Errorf("environment variable \"PAYMENT_GATEWAY_URL\" not set")
+		}
+		sugar.Infof("using the payment gateway at %s", url)
+		return &httpGateway{url: url, client: http.DefaultClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown PAYMENT_GATEWAY %q, want simulated or http", kind)
+	}
+}
+
+// gatewayTimeout returns how long a call to the gateway may take, from the
+// PAYMENT_GATEWAY_TIMEOUT environment variable.
+func gatewayTimeout() (time.Duration, error) {
+	v := os.Getenv("PAYMENT_GATEWAY_TIMEOUT")
+	if v == "" {
+		return defaultGatewayTimeout, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("environment variable \"PAYMENT_GATEWAY_TIMEOUT\" is not a positive duration: %q", v)
+	}
+	return d, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func authorizeRequest(number string, amount *pb.Money) *pb.AuthorizeRequest {
+	req := testAuthorizeRequest()
+	req.CreditCard.CreditCardNumber = number
+	req.Amount = amount
+	return req
+}
+
+// testDeclines checks that p declines the magic cards of the simulated
+// gateway.
+func testDeclines(t *testing.T, p *payment) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		number string
+		code   codes.Code
+		reason string
+	}{
+		{"4242424242424242", codes.OK, ""},
+		{"4000000000000002", codes.FailedPrecondition, "card_declined"},
+		{"4000000000009995", codes.FailedPrecondition, "insufficient_funds"},
+		{"4000000000000127", codes.FailedPrecondition, "incorrect_cvc"},
+		{"4000000000006975", codes.Unavailable, "timed out"},
+	} {
+		tx, err := p.Authorize(ctx, authorizeRequest(tc.number, usd(42, 0)))
+		if status.Code(err) != tc.code || !strings.Contains(status.Convert(err).Message(), tc.reason) {
+			t.Errorf("authorizing %s: got %v, want %v with %q", tc.number, err, tc.code, tc.reason)
+			continue
+		}
+		if tc.code == codes.FailedPrecondition {
+			var f *errdetails.PreconditionFailure
+			details := status.Convert(err).Details()
+			if len(details) == 1 {
+				f, _ = details[0].(*errdetails.PreconditionFailure)
+			}
+			if len(f.GetViolations()) != 1 || f.GetViolations()[0].GetType() != cardDeclinedType {
+				t.Errorf("authorizing %s: details = %v, want a %s violation", tc.number, details, cardDeclinedType)
+			}
+		}
+		if err == nil && tx.GetGatewayReference() == "" {
+			t.Errorf("authorizing %s: transaction %v has no gateway reference", tc.number, tx)
+		}
+	}
+
+	// Charges that fail to capture release their authorization.
+	req := testChargeRequest("")
+	req.CreditCard.CreditCardNumber = "4000000000000341"
+	if _, err := p.Charge(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("charging a card that declines captures: got %v, want FailedPrecondition", err)
+	}
+	tx, err := p.Authorize(ctx, authorizeRequest("4000000000000341", usd(42, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Capture(ctx, &pb.CaptureRequest{TransactionId: tx.GetTransactionId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("capturing on a card that declines captures: got %v, want FailedPrecondition", err)
+	}
+	if tx, err = p.Void(ctx, &pb.VoidRequest{TransactionId: tx.GetTransactionId()}); err != nil || tx.GetState() != pb.Transaction_VOIDED {
+		t.Errorf("voiding after a declined capture: got %v, %v, want VOIDED", tx, err)
+	}
+}
+
+func TestSimulatedGateway(t *testing.T) {
+	p := newTestPayment(t)
+	p.gatewayTimeout = 50 * time.Millisecond
+	testDeclines(t, p)
+}
+
+func TestSimulatorConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.json")
+	config := `{"cards": {"4242424242424242": {"decline": "do_not_honor"}}, "insufficient_funds_above": 100}`
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadSimulatorConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newTestPayment(t)
+	p.gateway = newSimulatedGateway(cfg)
+	ctx := context.Background()
+
+	if _, err := p.Authorize(ctx, authorizeRequest("4242424242424242", usd(42, 0))); !strings.Contains(status.Convert(err).Message(), "do_not_honor") {
+		t.Errorf("authorizing a configured card: got %v, want do_not_honor", err)
+	}
+	if _, err := p.Authorize(ctx, authorizeRequest("4000000000000002", usd(42, 0))); !strings.Contains(status.Convert(err).Message(), "card_declined") {
+		t.Errorf("authorizing a default card: got %v, want card_declined", err)
+	}
+	if _, err := p.Authorize(ctx, authorizeRequest("4432-8015-6152-0454", usd(100, 0))); err != nil {
+		t.Errorf("authorizing the limit: %v", err)
+	}
+	if _, err := p.Authorize(ctx, authorizeRequest("4432-8015-6152-0454", usd(100, 1))); !strings.Contains(status.Convert(err).Message(), "insufficient_funds") {
+		t.Errorf("authorizing over the limit: got %v, want insufficient_funds", err)
+	}
+}
+
+func TestHTTPGateway(t *testing.T) {
+	srv := httptest.NewServer(&gatewayStub{gateway: newSimulatedGateway(simulatorConfig{})})
+	defer srv.Close()
+	p := newTestPayment(t)
+	p.gateway = &httpGateway{url: srv.URL, client: srv.Client()}
+	p.gatewayTimeout = 50 * time.Millisecond
+	ctx := context.Background()
+
+	testDeclines(t, p)
+
+	tx, err := p.Authorize(ctx, testAuthorizeRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := tx.GetTransactionId()
+	if _, err := p.Capture(ctx, &pb.CaptureRequest{TransactionId: id}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.Refund(ctx, &pb.RefundRequest{TransactionId: id, Amount: usd(2, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := resp.GetTransaction().GetState(); state != pb.Transaction_PARTIALLY_REFUNDED {
+		t.Errorf("after a refund through the HTTP gateway: state = %v, want PARTIALLY_REFUNDED", state)
+	}
+}
+
+func TestHTTPGatewayErrors(t *testing.T) {
+	var handler http.HandlerFunc
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { handler(w, r) }))
+	defer srv.Close()
+	p := newTestPayment(t)
+	p.gateway = &httpGateway{url: srv.URL, client: srv.Client()}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+		code    codes.Code
+	}{
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, codes.Unavailable},
+		{"bad request", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"message": "missing card"}}`))
+		}, codes.Internal},
+		{"no authorization id", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{}`))
+		}, codes.Internal},
+		{"malformed response", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<html>`))
+		}, codes.Internal},
+	} {
+		handler = tc.handler
+		if _, err := p.Authorize(ctx, testAuthorizeRequest()); status.Code(err) != tc.code {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.code)
+		}
+	}
+}
@@ -10,6 +10,7 @@ require (
 	go.uber.org/zap v1.14.1
 	golang.org/x/sys v0.0.0-20190502145724-3ef323f4f1fd // indirect
 	golang.org/x/text v0.3.2 // indirect
+	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55
 	google.golang.org/grpc v1.28.0
 	gotest.tools/v3 v3.0.2
 )
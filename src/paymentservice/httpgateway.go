@@ -0,0 +1,205 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The HTTP gateway API takes and returns JSON:
+//
+//	POST /authorizations             {"card": ..., "amount": ...} -> {"id": ...}
+//	POST /authorizations/{id}/capture {"amount": ...}
+//	POST /authorizations/{id}/void
+//	POST /authorizations/{id}/refunds {"amount": ...}
+//
+// Declined transactions fail with 402 Payment Required and
+// {"error": {"code": ..., "message": ...}}; other errors have a message only.
+
+type gatewayAmount struct {
+	Currency string `json:"currency"`
+	Units    int64  `json:"units"`
+	Nanos    int32  `json:"nanos"`
+}
+
+func newGatewayAmount(m *pb.Money) *gatewayAmount {
+	if m == nil {
+		return nil
+	}
+	return &gatewayAmount{Currency: m.GetCurrencyCode(), Units: m.GetUnits(), Nanos: m.GetNanos()}
+}
+
+func (a *gatewayAmount) money() *pb.Money {
+	if a == nil {
+		return nil
+	}
+	return &pb.Money{CurrencyCode: a.Currency, Units: a.Units, Nanos: a.Nanos}
+}
+
+type gatewayRequest struct {
+	Card   *gatewayCard   `json:"card,omitempty"`
+	Amount *gatewayAmount `json:"amount,omitempty"`
+}
+
+type gatewayResponse struct {
+	Id    string        `json:"id,omitempty"`
+	Error *declineError `json:"error,omitempty"`
+}
+
+// httpGateway is a payment processor behind the HTTP gateway API at url.
+type httpGateway struct {
+	url    string
+	client *http.Client
+}
+
+// post posts req to path, and returns the response.
+func (g *httpGateway) post(ctx context.Context, path string, req gatewayRequest) (*gatewayResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(g.url, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	res, err := g.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errGatewayTimeout
+		}
+		return nil, status.Errorf(codes.Unavailable, "payment gateway unreachable: %v", err)
+	}
+	defer res.Body.Close()
+	var resp gatewayResponse
+	decodeErr := json.NewDecoder(res.Body).Decode(&resp)
+	switch {
+	case res.StatusCode == http.StatusPaymentRequired && resp.Error != nil:
+		return nil, resp.Error
+	case res.StatusCode >= 500:
+		return nil, status.Errorf(codes.Unavailable, "payment gateway returned %s", res.Status)
+	case res.StatusCode/100 != 2:
+		msg := res.Status
+		if resp.Error != nil {
+			msg = resp.Error.Message
+		}
+		return nil, &rejectedError{msg: msg}
+	case decodeErr != nil:
+		return nil, status.Errorf(codes.Internal, "malformed payment gateway response: %v", decodeErr)
+	}
+	return &resp, nil
+}
+
+func (g *httpGateway) Authorize(ctx context.Context, card gatewayCard, amount *pb.Money) (string, error) {
+	resp, err := g.post(ctx, "/authorizations", gatewayRequest{Card: &card, Amount: newGatewayAmount(amount)})
+	if err != nil {
+		return "", err
+	}
+	if resp.Id == "" {
+		return "", status.Errorf(codes.Internal, "payment gateway returned no authorization id")
+	}
+	return resp.Id, nil
+}
+
+func (g *httpGateway) Capture(ctx context.Context, ref string, amount *pb.Money) error {
+	_, err := g.post(ctx, "/authorizations/"+url.PathEscape(ref)+"/capture", gatewayRequest{Amount: newGatewayAmount(amount)})
+	return err
+}
+
+func (g *httpGateway) Void(ctx context.Context, ref string) error {
+	_, err := g.post(ctx, "/authorizations/"+url.PathEscape(ref)+"/void", gatewayRequest{})
+	return err
+}
+
+func (g *httpGateway) Refund(ctx context.Context, ref string, amount *pb.Money) error {
+	_, err := g.post(ctx, "/authorizations/"+url.PathEscape(ref)+"/refunds", gatewayRequest{Amount: newGatewayAmount(amount)})
+	return err
+}
+
+// gatewayStub serves the HTTP gateway API with a gateway, so that the HTTP
+// gateway can be pointed at a simulated gateway.
+type gatewayStub struct {
+	gateway Gateway
+}
+
+func (s *gatewayStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeGatewayResponse(w, http.StatusMethodNotAllowed, gatewayResponse{Error: &declineError{Message: "method not allowed"}})
+		return
+	}
+	var req gatewayRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeGatewayResponse(w, http.StatusBadRequest, gatewayResponse{Error: &declineError{Message: err.Error()}})
+			return
+		}
+	}
+	ctx := r.Context()
+	var resp gatewayResponse
+	var err error
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/authorizations"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "" && req.Card != nil:
+		resp.Id, err = s.gateway.Authorize(ctx, *req.Card, req.Amount.money())
+	case len(parts) == 3 && parts[0] == "" && parts[2] == "capture":
+		err = s.gateway.Capture(ctx, parts[1], req.Amount.money())
+	case len(parts) == 3 && parts[0] == "" && parts[2] == "void":
+		err = s.gateway.Void(ctx, parts[1])
+	case len(parts) == 3 && parts[0] == "" && parts[2] == "refunds":
+		err = s.gateway.Refund(ctx, parts[1], req.Amount.money())
+	default:
+		writeGatewayResponse(w, http.StatusNotFound, gatewayResponse{Error: &declineError{Message: "not found"}})
+		return
+	}
+	if d, ok := err.(*declineError); ok {
+		writeGatewayResponse(w, http.StatusPaymentRequired, gatewayResponse{Error: d})
+		return
+	}
+	if err != nil {
+		writeGatewayResponse(w, http.StatusBadGateway, gatewayResponse{Error: &declineError{Message: err.Error()}})
+		return
+	}
+	writeGatewayResponse(w, http.StatusOK, resp)
+}
+
+func writeGatewayResponse(w http.ResponseWriter, code int, resp gatewayResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sugar.Warnf("failed to write gateway response: %v", err)
+	}
+}
+
+// serveGatewayStub serves the simulated gateway configured by the
+// environment at addr, until it fails.
+func serveGatewayStub(addr string) error {
+	cfg, err := loadSimulatorConfig(os.Getenv("PAYMENT_GATEWAY_SIMULATOR_CONFIG"))
+	if err != nil {
+		return err
+	}
+	sugar.Infof("serving a simulated payment gateway at %s", addr)
+	return fmt.Errorf("gateway stub: %v", http.ListenAndServe(addr, &gatewayStub{gateway: newSimulatedGateway(cfg)}))
+}
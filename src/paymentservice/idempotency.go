@@ -0,0 +1,150 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// idempotencyKeyHeader is the gRPC metadata key that carries the
+	// idempotency key of requests that do not set it in the message.
+	idempotencyKeyHeader = "idempotency-key"
+
+	defaultChargeCacheSize = 10000
+)
+
+// idempotencyKey returns the idempotency key of a request: field if it is
+// set, otherwise the idempotency-key metadata of ctx.
+func idempotencyKey(ctx context.Context, field string) string {
+	if field != "" {
+		return field
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(idempotencyKeyHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// chargeFingerprint identifies the card and amount of a charge without
+// keeping the card number in memory.
+func chargeFingerprint(req *pb.ChargeRequest) string {
+	amount := req.GetAmount()
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d|%d",
+		req.GetCreditCard().GetCreditCardNumber(), req.GetCardToken(),
+		amount.GetCurrencyCode(), amount.GetUnits(), amount.GetNanos())))
+	return fmt.Sprintf("%x", h)
+}
+
+type chargeEntry struct {
+	key         string
+	fingerprint string
+	done        chan struct{}
+	resp        *pb.ChargeResponse
+	err         error
+}
+
+// chargeCache remembers the responses of the most recent successful charges
+// by idempotency key. Failed charges are forgotten so that they can be
+// retried.
+type chargeCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // of *chargeEntry, most recently used first
+}
+
+func newChargeCache(size int) *chargeCache {
+	return &chargeCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// do returns the response of the charge with key, calling charge if there is
+// none. Concurrent calls with the same key wait for the first one. Reusing a
+// key for a different card or amount is an InvalidArgument error.
+func (c *chargeCache) do(ctx context.Context, key, fingerprint string, charge func() (*pb.ChargeResponse, error)) (*pb.ChargeResponse, error) {
+	for {
+		c.mu.Lock()
+		el, ok := c.entries[key]
+		if !ok {
+			break
+		}
+		e := el.Value.(*chargeEntry)
+		if e.fingerprint != fingerprint {
+			c.mu.Unlock()
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key %q was used for a different charge", key)
+		}
+		c.lru.MoveToFront(el)
+		c.mu.Unlock()
+
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		if e.err == nil {
+			sugar.Infof("replaying charge with idempotency key %q: transaction %v", key, e.resp.GetTransactionId())
+			return e.resp, nil
+		}
+		// The first charge failed and was removed; try again.
+	}
+
+	e := &chargeEntry{key: key, fingerprint: fingerprint, done: make(chan struct{})}
+	c.entries[key] = c.lru.PushFront(e)
+	c.evict()
+	c.mu.Unlock()
+
+	e.resp, e.err = charge()
+
+	c.mu.Lock()
+	if e.err != nil {
+		if el, ok := c.entries[key]; ok && el.Value == e {
+			c.lru.Remove(el)
+			delete(c.entries, key)
+		}
+	}
+	c.mu.Unlock()
+	close(e.done)
+	return e.resp, e.err
+}
+
+// evict drops the least recently used completed entries beyond the size of
+// the cache. Entries that are in flight are kept.
+func (c *chargeCache) evict() {
+	for el := c.lru.Back(); el != nil && c.lru.Len() > c.size; {
+		prev := el.Prev()
+		e := el.Value.(*chargeEntry)
+		select {
+		case <-e.done:
+			c.lru.Remove(el)
+			delete(c.entries, e.key)
+		default:
+		}
+		el = prev
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func testChargeRequest(key string) *pb.ChargeRequest {
+	return &pb.ChargeRequest{
+		Amount: &pb.Money{CurrencyCode: "USD", Units: 42},
+		CreditCard: &pb.CreditCardInfo{
+			CreditCardNumber:          "4432-8015-6152-0454",
+			CreditCardCvv:             672,
+			CreditCardExpirationYear:  2039,
+			CreditCardExpirationMonth: 1,
+		},
+		IdempotencyKey: key,
+	}
+}
+
+func TestChargeIdempotency(t *testing.T) {
+	p := newTestPayment(t)
+	ctx := context.Background()
+
+	first, err := p.Charge(ctx, testChargeRequest("order-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay, err := p.Charge(ctx, testChargeRequest("order-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.GetTransactionId() != first.GetTransactionId() {
+		t.Errorf("replayed transaction = %q, want %q", replay.GetTransactionId(), first.GetTransactionId())
+	}
+
+	mdCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(idempotencyKeyHeader, "order-1"))
+	viaMetadata, err := p.Charge(mdCtx, testChargeRequest(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viaMetadata.GetTransactionId() != first.GetTransactionId() {
+		t.Errorf("transaction with metadata key = %q, want %q", viaMetadata.GetTransactionId(), first.GetTransactionId())
+	}
+
+	other, err := p.Charge(ctx, testChargeRequest("order-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.GetTransactionId() == first.GetTransactionId() {
+		t.Error("charges with different keys share a transaction")
+	}
+
+	changed := testChargeRequest("order-1")
+	changed.Amount.Units = 43
+	if _, err := p.Charge(ctx, changed); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("reusing a key for another amount: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestChargeCacheConcurrent(t *testing.T) {
+	c := newChargeCache(10)
+	var calls int32
+	release := make(chan struct{})
+	charge := func() (*pb.ChargeResponse, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &pb.ChargeResponse{TransactionId: "tx"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.do(context.Background(), "key", "fp", charge)
+			if err != nil || resp.GetTransactionId() != "tx" {
+				t.Errorf("do() = %v, %v", resp, err)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("charged %d times, want 1", calls)
+	}
+}
+
+func TestChargeCacheRetriesFailures(t *testing.T) {
+	c := newChargeCache(10)
+	ctx := context.Background()
+	if _, err := c.do(ctx, "key", "fp", func() (*pb.ChargeResponse, error) {
+		return nil, errors.New("declined")
+	}); err == nil {
+		t.Fatal("do() succeeded, want error")
+	}
+	resp, err := c.do(ctx, "key", "fp", func() (*pb.ChargeResponse, error) {
+		return &pb.ChargeResponse{TransactionId: "tx"}, nil
+	})
+	if err != nil || resp.GetTransactionId() != "tx" {
+		t.Errorf("retry after failure: do() = %v, %v", resp, err)
+	}
+}
+
+func TestChargeCacheEviction(t *testing.T) {
+	c := newChargeCache(2)
+	ctx := context.Background()
+	var calls int
+	charge := func() (*pb.ChargeResponse, error) {
+		calls++
+		return &pb.ChargeResponse{}, nil
+	}
+	for _, key := range []string{"a", "b", "c", "a"} {
+		if _, err := c.do(ctx, key, "fp", charge); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("charged %d times, want 4 since %q was evicted", calls, "a")
+	}
+	if c.lru.Len() != 2 {
+		t.Errorf("cache holds %d entries, want 2", c.lru.Len())
+	}
+}
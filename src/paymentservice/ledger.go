@@ -0,0 +1,436 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	uuid "github.com/satori/go.uuid"
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"github.com/triplewy/microservices-demo/src/paymentservice/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ledger records every transaction and enforces the transitions between
+// their states:
+//
+//	AUTHORIZED -> CAPTURED -> PARTIALLY_REFUNDED -> REFUNDED
+//	AUTHORIZED -> VOIDED
+//
+// Changes of a transaction are serialized. A change is recorded as pending
+// before it is made to the gateway and as done after, so that a change the
+// gateway made is never lost: a transaction whose change has an unknown
+// outcome, or could not be recorded as done, keeps it pending, and cannot
+// change again until it is reconciled. If the ledger has a journal, every change of
+// a transaction is appended to it, and the ledger is rebuilt from it on
+// startup.
+type ledger struct {
+	mu      sync.Mutex // guards entries, keys and journal
+	entries map[string]*ledgerEntry
+	// keys maps the idempotency keys of authorizations to their
+	// transactions.
+	keys    map[string]string
+	journal *os.File
+}
+
+type ledgerEntry struct {
+	// mu is held while the transaction changes, including while the change
+	// is made to the gateway.
+	mu sync.Mutex
+	tx *pb.Transaction
+}
+
+// newLedger returns a ledger journaled to path, or kept only in memory if
+// path is empty.
+func newLedger(path string) (*ledger, error) {
+	l := &ledger{entries: make(map[string]*ledgerEntry), keys: make(map[string]string)}
+	if path == "" {
+		return l, nil
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger: %v", err)
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var tx pb.Transaction
+		if err := jsonpb.Unmarshal(bytes.NewReader(scanner.Bytes()), &tx); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("ledger %s, line %d: %v", path, line, err)
+		}
+		l.entries[tx.GetTransactionId()] = &ledgerEntry{tx: &tx}
+		if key := tx.GetIdempotencyKey(); key != "" {
+			l.keys[key] = tx.GetTransactionId()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read ledger: %v", err)
+	}
+	l.journal = f
+	return l, nil
+}
+
+// record stores tx, and returns a copy of it.
+func (l *ledger) record(tx *pb.Transaction) (*pb.Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tx.UpdatedAt = time.Now().Unix()
+	if l.journal != nil {
+		var buf bytes.Buffer
+		if err := (&jsonpb.Marshaler{}).Marshal(&buf, tx); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		if _, err := l.journal.Write(buf.Bytes()); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to record transaction: %v", err)
+		}
+		if err := l.journal.Sync(); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to record transaction: %v", err)
+		}
+	}
+	if e, ok := l.entries[tx.GetTransactionId()]; ok {
+		e.tx = tx
+	} else {
+		l.entries[tx.GetTransactionId()] = &ledgerEntry{tx: tx}
+	}
+	if key := tx.GetIdempotencyKey(); key != "" {
+		l.keys[key] = tx.GetTransactionId()
+	}
+	return proto.Clone(tx).(*pb.Transaction), nil
+}
+
+func (l *ledger) entry(id string) (*ledgerEntry, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id must be set")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown transaction %q", id)
+	}
+	return e, nil
+}
+
+func (l *ledger) get(id string) (*pb.Transaction, error) {
+	e, err := l.entry(id)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return proto.Clone(e.tx).(*pb.Transaction), nil
+}
+
+// authorization returns the last authorization with the idempotency key.
+func (l *ledger) authorization(key string) (*pb.Transaction, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[l.keys[key]]
+	if key == "" || !ok {
+		return nil, false
+	}
+	return proto.Clone(e.tx).(*pb.Transaction), true
+}
+
+// update applies change to a copy of the transaction with id, and records
+// the copy if change returns true. Otherwise it returns the transaction as
+// it is. A transaction with a pending change cannot change until the pending
+// change is reconciled.
+func (l *ledger) update(id string, change func(tx *pb.Transaction) (bool, error)) (*pb.Transaction, error) {
+	return l.modify(id, func(tx *pb.Transaction) (bool, error) {
+		if p := tx.GetPendingChange(); p != nil {
+			return false, status.Errorf(codes.FailedPrecondition,
+				"transaction %s has a change to %v of unknown outcome with the gateway, and must be reconciled", id, p.GetState())
+		}
+		return change(tx)
+	})
+}
+
+// modify is update, for transactions with a pending change too.
+func (l *ledger) modify(id string, change func(tx *pb.Transaction) (bool, error)) (*pb.Transaction, error) {
+	e, err := l.entry(id)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	l.mu.Lock()
+	// Changes are made to a copy, so that a change that fails leaves the
+	// transaction as it was.
+	tx := proto.Clone(e.tx).(*pb.Transaction)
+	l.mu.Unlock()
+	changed, err := change(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return tx, nil
+	}
+	return l.record(tx)
+}
+
+// withGateway records change as pending on tx, calls process to make it with
+// the gateway, and applies it to tx if the gateway made it. A change that the
+// gateway declined or rejected is no longer pending, but one whose outcome
+// is unknown, e.g. because the gateway timed out, stays pending until it is
+// reconciled.
+func (l *ledger) withGateway(tx *pb.Transaction, change *pb.PendingChange, process func() error) (*pb.TransactionRefund, error) {
+	change.StartedAt = time.Now().Unix()
+	pending := proto.Clone(tx).(*pb.Transaction)
+	pending.PendingChange = change
+	if _, err := l.record(pending); err != nil {
+		return nil, err
+	}
+	if err := process(); err != nil {
+		if !gatewayRefused(err) {
+			sugar.Errorf("change of transaction %v to %v has an unknown outcome, and must be reconciled: %v", tx.GetTransactionId(), change.GetState(), err)
+			return nil, err
+		}
+		if _, recErr := l.record(proto.Clone(tx).(*pb.Transaction)); recErr != nil {
+			sugar.Errorf("failed to clear the pending change of transaction %v: %v", tx.GetTransactionId(), recErr)
+		}
+		return nil, err
+	}
+	return applyChange(tx, change), nil
+}
+
+// applyChange applies change, which the gateway made, to tx, and returns the
+// refund that it made, if any.
+func applyChange(tx *pb.Transaction, change *pb.PendingChange) *pb.TransactionRefund {
+	switch change.GetState() {
+	case pb.Transaction_AUTHORIZED:
+		tx.State = pb.Transaction_AUTHORIZED
+	case pb.Transaction_CAPTURED:
+		tx.State = pb.Transaction_CAPTURED
+		tx.Captured = change.GetAmount()
+	case pb.Transaction_VOIDED:
+		tx.State = pb.Transaction_VOIDED
+	case pb.Transaction_PARTIALLY_REFUNDED:
+		refund := &pb.TransactionRefund{
+			RefundId:       uuid.NewV4().String(),
+			Amount:         change.GetAmount(),
+			CreatedAt:      time.Now().Unix(),
+			IdempotencyKey: change.GetIdempotencyKey(),
+		}
+		refunded := tx.GetRefunded()
+		if refunded == nil {
+			refunded = &pb.Money{CurrencyCode: tx.GetCaptured().GetCurrencyCode()}
+		}
+		total := money.Must(money.Sum(*refunded, *change.GetAmount()))
+		tx.Refunded = &total
+		tx.Refunds = append(tx.Refunds, refund)
+		tx.State = pb.Transaction_PARTIALLY_REFUNDED
+		if money.AreEquals(total, *tx.GetCaptured()) {
+			tx.State = pb.Transaction_REFUNDED
+		}
+		return refund
+	}
+	return nil
+}
+
+// reconcile settles the pending change of the transaction with id, applying
+// it if the gateway made it and dropping it otherwise. An authorization that
+// the gateway made needs its reference with the gateway, ref, and one that
+// it did not make fails.
+func (l *ledger) reconcile(id string, applied bool, ref string) (*pb.Transaction, error) {
+	return l.modify(id, func(tx *pb.Transaction) (bool, error) {
+		change := tx.GetPendingChange()
+		switch {
+		case change == nil:
+			return false, status.Errorf(codes.FailedPrecondition, "transaction %s has no pending change", id)
+		case change.GetState() != pb.Transaction_AUTHORIZED:
+		case !applied:
+			tx.State = pb.Transaction_FAILED
+		case ref == "":
+			return false, status.Error(codes.InvalidArgument, "gateway_reference must be set for an authorization that the gateway made")
+		default:
+			tx.GatewayReference = ref
+		}
+		tx.PendingChange = nil
+		if applied {
+			applyChange(tx, change)
+		}
+		return true, nil
+	})
+}
+
+// beginAuthorization records an authorization of amount on a card, which the
+// fraud checks assessed, as pending before it is made with the gateway.
+func (l *ledger) beginAuthorization(key string, amount *pb.Money, brand, lastFour string, fraud *pb.FraudAssessment) (*pb.Transaction, error) {
+	now := time.Now().Unix()
+	return l.record(&pb.Transaction{
+		TransactionId:   uuid.NewV4().String(),
+		Authorized:      amount,
+		CardBrand:       brand,
+		CardLastFour:    lastFour,
+		FraudAssessment: fraud,
+		CreatedAt:       now,
+		PendingChange:   &pb.PendingChange{State: pb.Transaction_AUTHORIZED, Amount: amount, StartedAt: now},
+		IdempotencyKey:  key,
+	})
+}
+
+// finishAuthorization records the outcome of the pending authorization with
+// id: made with the gateway as ref if gatewayErr is nil, or failed if the
+// gateway refused it. After other errors the outcome is unknown, and the
+// authorization stays pending until it is reconciled.
+func (l *ledger) finishAuthorization(id, ref string, gatewayErr error) (*pb.Transaction, error) {
+	if gatewayErr != nil && !gatewayRefused(gatewayErr) {
+		sugar.Errorf("authorization of transaction %v has an unknown outcome, and must be reconciled: %v", id, gatewayErr)
+		return nil, gatewayErr
+	}
+	tx, err := l.modify(id, func(tx *pb.Transaction) (bool, error) {
+		change := tx.GetPendingChange()
+		tx.PendingChange = nil
+		if gatewayErr != nil {
+			tx.State = pb.Transaction_FAILED
+			return true, nil
+		}
+		tx.GatewayReference = ref
+		applyChange(tx, change)
+		return true, nil
+	})
+	if gatewayErr != nil {
+		if err != nil {
+			sugar.Errorf("failed to record the failed authorization of transaction %v: %v", id, err)
+		}
+		return nil, gatewayErr
+	}
+	return tx, err
+}
+
+// capture captures amount of an authorization, or all of it if amount is
+// nil, calling process to capture it with the gateway. Capturing a captured
+// transaction again with the same amount returns it.
+func (l *ledger) capture(id string, amount *pb.Money, process func(tx *pb.Transaction, amount *pb.Money) error) (*pb.Transaction, error) {
+	return l.update(id, func(tx *pb.Transaction) (bool, error) {
+		if amount == nil {
+			amount = tx.GetAuthorized()
+		}
+		if err := checkAmount(amount, tx.GetAuthorized(), "the authorized amount"); err != nil {
+			return false, err
+		}
+		switch tx.GetState() {
+		case pb.Transaction_AUTHORIZED:
+		case pb.Transaction_CAPTURED:
+			if money.AreEquals(*tx.GetCaptured(), *amount) {
+				return false, nil
+			}
+			fallthrough
+		default:
+			return false, status.Errorf(codes.FailedPrecondition, "transaction %s is %v and cannot be captured", id, tx.GetState())
+		}
+		change := &pb.PendingChange{State: pb.Transaction_CAPTURED, Amount: amount}
+		if _, err := l.withGateway(tx, change, func() error { return process(tx, amount) }); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// void releases an authorization, calling process to release it with the
+// gateway. Voiding a voided transaction returns it.
+func (l *ledger) void(id string, process func(tx *pb.Transaction) error) (*pb.Transaction, error) {
+	return l.update(id, func(tx *pb.Transaction) (bool, error) {
+		switch tx.GetState() {
+		case pb.Transaction_AUTHORIZED:
+		case pb.Transaction_VOIDED:
+			return false, nil
+		default:
+			return false, status.Errorf(codes.FailedPrecondition, "transaction %s is %v and cannot be voided", id, tx.GetState())
+		}
+		change := &pb.PendingChange{State: pb.Transaction_VOIDED}
+		if _, err := l.withGateway(tx, change, func() error { return process(tx) }); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// refund refunds amount of a captured transaction, or all that is left if
+// amount is nil, calling process to refund it with the gateway. A refund with
+// the idempotency key of an earlier refund of the transaction returns that
+// refund.
+func (l *ledger) refund(id string, amount *pb.Money, key string, process func(tx *pb.Transaction, amount *pb.Money) error) (*pb.TransactionRefund, *pb.Transaction, error) {
+	var refund *pb.TransactionRefund
+	tx, err := l.update(id, func(tx *pb.Transaction) (bool, error) {
+		if key != "" {
+			for _, r := range tx.GetRefunds() {
+				if r.GetIdempotencyKey() != key {
+					continue
+				}
+				if amount != nil && !money.AreEquals(*r.GetAmount(), *amount) {
+					return false, status.Errorf(codes.InvalidArgument, "idempotency key %q was used for a different refund", key)
+				}
+				refund = r
+				return false, nil
+			}
+		}
+		switch tx.GetState() {
+		case pb.Transaction_CAPTURED, pb.Transaction_PARTIALLY_REFUNDED:
+		default:
+			return false, status.Errorf(codes.FailedPrecondition, "transaction %s is %v and cannot be refunded", id, tx.GetState())
+		}
+
+		refunded := tx.GetRefunded()
+		if refunded == nil {
+			refunded = &pb.Money{CurrencyCode: tx.GetCaptured().GetCurrencyCode()}
+		}
+		left := money.Must(money.Sum(*tx.GetCaptured(), money.Negate(*refunded)))
+		if amount == nil {
+			amount = &left
+		}
+		if err := checkAmount(amount, &left, "the amount left to refund"); err != nil {
+			return false, err
+		}
+		change := &pb.PendingChange{State: pb.Transaction_PARTIALLY_REFUNDED, Amount: amount, IdempotencyKey: key}
+		var err error
+		if refund, err = l.withGateway(tx, change, func() error { return process(tx, amount) }); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return refund, tx, nil
+}
+
+// checkAmount returns an InvalidArgument error unless amount is positive and
+// at most max, in the same currency.
+func checkAmount(amount, max *pb.Money, maxName string) error {
+	if !money.IsPositive(*amount) {
+		return status.Errorf(codes.InvalidArgument, "amount must be positive")
+	}
+	if !money.AreSameCurrency(*amount, *max) {
+		return status.Errorf(codes.InvalidArgument, "amount is in %s, want %s", amount.GetCurrencyCode(), max.GetCurrencyCode())
+	}
+	if money.IsNegative(money.Must(money.Sum(*max, money.Negate(*amount)))) {
+		return status.Errorf(codes.InvalidArgument, "amount %d.%09d exceeds %s, %d.%09d %s",
+			amount.GetUnits(), amount.GetNanos(), maxName, max.GetUnits(), max.GetNanos(), max.GetCurrencyCode())
+	}
+	return nil
+}
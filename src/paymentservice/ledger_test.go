@@ -0,0 +1,337 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestPayment returns a payment service with a ledger and a card vault in
+// memory, a simulated gateway and no fraud rules.
+func newTestPayment(t *testing.T) *payment {
+	l, err := newLedger("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := newCardVault("", "", defaultCardTokenTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadFraudRules("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	brands, err := parseCardBrands(defaultCardBrands)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &payment{
+		charges:        newChargeCache(defaultChargeCacheSize),
+		ledger:         l,
+		vault:          v,
+		brands:         brands,
+		fraud:          newFraudScorer(rules),
+		gateway:        newSimulatedGateway(simulatorConfig{}),
+		gatewayTimeout: defaultGatewayTimeout,
+	}
+}
+
+// noGateway makes changes of the ledger without a gateway.
+func noGateway(*pb.Transaction, *pb.Money) error { return nil }
+
+// testAuthorization records an authorization of 42 USD that the gateway made
+// as "ref".
+func testAuthorization(t *testing.T, l *ledger) *pb.Transaction {
+	tx, err := l.beginAuthorization("", usd(42, 0), "Visa", "0454", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx, err = l.finishAuthorization(tx.GetTransactionId(), "ref", nil); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func usd(units int64, nanos int32) *pb.Money {
+	return &pb.Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+}
+
+func testAuthorizeRequest() *pb.AuthorizeRequest {
+	req := testChargeRequest("")
+	return &pb.AuthorizeRequest{Amount: usd(42, 0), CreditCard: req.GetCreditCard()}
+}
+
+func TestCaptureAndRefund(t *testing.T) {
+	p := newTestPayment(t)
+	ctx := context.Background()
+
+	tx, err := p.Authorize(ctx, testAuthorizeRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetState() != pb.Transaction_AUTHORIZED || tx.GetCardLastFour() != "0454" {
+		t.Fatalf("Authorize = %v, want AUTHORIZED ending 0454", tx)
+	}
+	id := tx.GetTransactionId()
+
+	if _, err := p.Refund(ctx, &pb.RefundRequest{TransactionId: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("refunding an authorization: got %v, want FailedPrecondition", err)
+	}
+	if _, err := p.Capture(ctx, &pb.CaptureRequest{TransactionId: id, Amount: usd(43, 0)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("capturing more than authorized: got %v, want InvalidArgument", err)
+	}
+	if tx, err = p.Capture(ctx, &pb.CaptureRequest{TransactionId: id, Amount: usd(40, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetState() != pb.Transaction_CAPTURED || !proto.Equal(tx.GetCaptured(), usd(40, 0)) {
+		t.Errorf("Capture = %v, want 40 USD captured", tx)
+	}
+	if _, err := p.Capture(ctx, &pb.CaptureRequest{TransactionId: id, Amount: usd(40, 0)}); err != nil {
+		t.Errorf("capturing again: %v", err)
+	}
+	if _, err := p.Void(ctx, &pb.VoidRequest{TransactionId: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("voiding a capture: got %v, want FailedPrecondition", err)
+	}
+
+	first, err := p.Refund(ctx, &pb.RefundRequest{TransactionId: id, Amount: usd(10, 500000000), IdempotencyKey: "r1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.GetTransaction().GetState() != pb.Transaction_PARTIALLY_REFUNDED {
+		t.Errorf("after a partial refund: state = %v, want PARTIALLY_REFUNDED", first.GetTransaction().GetState())
+	}
+	replay, err := p.Refund(ctx, &pb.RefundRequest{TransactionId: id, Amount: usd(10, 500000000), IdempotencyKey: "r1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.GetRefundId() != first.GetRefundId() {
+		t.Errorf("replayed refund = %q, want %q", replay.GetRefundId(), first.GetRefundId())
+	}
+	if _, err := p.Refund(ctx, &pb.RefundRequest{TransactionId: id, Amount: usd(30, 0)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("refunding more than is left: got %v, want InvalidArgument", err)
+	}
+	rest, err := p.Refund(ctx, &pb.RefundRequest{TransactionId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx = rest.GetTransaction()
+	if tx.GetState() != pb.Transaction_REFUNDED || !proto.Equal(tx.GetRefunded(), usd(40, 0)) || len(tx.GetRefunds()) != 2 {
+		t.Errorf("after refunding the rest: %v, want 40 USD refunded in 2 refunds", tx)
+	}
+	if !proto.Equal(tx.GetRefunds()[1].GetAmount(), usd(29, 500000000)) {
+		t.Errorf("second refund = %v, want 29.50 USD", tx.GetRefunds()[1].GetAmount())
+	}
+}
+
+func TestVoid(t *testing.T) {
+	p := newTestPayment(t)
+	ctx := context.Background()
+	tx, err := p.Authorize(ctx, testAuthorizeRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := tx.GetTransactionId()
+	for i := 0; i < 2; i++ {
+		if tx, err = p.Void(ctx, &pb.VoidRequest{TransactionId: id}); err != nil || tx.GetState() != pb.Transaction_VOIDED {
+			t.Fatalf("Void = %v, %v, want VOIDED", tx, err)
+		}
+	}
+	if _, err := p.Capture(ctx, &pb.CaptureRequest{TransactionId: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("capturing a voided transaction: got %v, want FailedPrecondition", err)
+	}
+	if _, err := p.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("getting an unknown transaction: got %v, want NotFound", err)
+	}
+}
+
+func TestChargeCaptures(t *testing.T) {
+	p := newTestPayment(t)
+	ctx := context.Background()
+	resp, err := p.Charge(ctx, testChargeRequest(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := p.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: resp.GetTransactionId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetState() != pb.Transaction_CAPTURED || !proto.Equal(tx.GetCaptured(), usd(42, 0)) {
+		t.Errorf("charged transaction = %v, want 42 USD captured", tx)
+	}
+}
+
+func TestLedgerJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	l, err := newLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testAuthorization(t, l)
+	if _, err := l.capture(tx.GetTransactionId(), nil, noGateway); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l.refund(tx.GetTransactionId(), usd(2, 0), "", noGateway); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := l.get(tx.GetTransactionId())
+	l.journal.Close()
+
+	restarted, err := newLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.journal.Close()
+	got, err := restarted.get(tx.GetTransactionId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("after a restart: transaction = %v, want %v", got, want)
+	}
+}
+
+func TestLedgerPendingChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	l, err := newLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testAuthorization(t, l)
+	id := tx.GetTransactionId()
+
+	// A change that the gateway declines leaves the transaction as it was.
+	declined := func(*pb.Transaction, *pb.Money) error { return decline("card_declined") }
+	if _, err := l.capture(id, nil, declined); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("capture declined by the gateway: got %v, want FailedPrecondition", err)
+	}
+	if tx, _ := l.get(id); tx.GetState() != pb.Transaction_AUTHORIZED || tx.GetPendingChange() != nil {
+		t.Fatalf("after a declined capture: transaction = %v, want AUTHORIZED without a pending change", tx)
+	}
+
+	// A change that times out may have been made, so it stays pending.
+	timedOut := func(*pb.Transaction, *pb.Money) error { return errGatewayTimeout }
+	if _, err := l.capture(id, nil, timedOut); status.Code(err) != codes.Unavailable {
+		t.Fatalf("capture timing out: got %v, want Unavailable", err)
+	}
+	if tx, _ := l.get(id); tx.GetPendingChange().GetState() != pb.Transaction_CAPTURED {
+		t.Fatalf("after a capture timed out: transaction = %v, want a pending capture", tx)
+	}
+	if _, err := l.reconcile(id, false, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// A change that the gateway made but that cannot be recorded stays
+	// pending, even after a restart, until it is reconciled.
+	lost := func(*pb.Transaction, *pb.Money) error { return l.journal.Close() }
+	if _, err := l.capture(id, nil, lost); err == nil {
+		t.Fatal("capture that cannot be recorded succeeded, want error")
+	}
+	restarted, err := newLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.journal.Close()
+	tx, err = restarted.get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetState() != pb.Transaction_AUTHORIZED || tx.GetPendingChange().GetState() != pb.Transaction_CAPTURED {
+		t.Errorf("after a restart: transaction = %v, want AUTHORIZED with a pending capture", tx)
+	}
+	if _, err := restarted.void(id, func(*pb.Transaction) error { return nil }); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("voiding a transaction with a pending change: got %v, want FailedPrecondition", err)
+	}
+	if tx, err = restarted.reconcile(id, true, ""); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetState() != pb.Transaction_CAPTURED || !proto.Equal(tx.GetCaptured(), usd(42, 0)) || tx.GetPendingChange() != nil {
+		t.Errorf("after reconciling the capture: transaction = %v, want 42 USD captured", tx)
+	}
+	if _, err := restarted.reconcile(id, true, ""); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("reconciling a transaction without a pending change: got %v, want FailedPrecondition", err)
+	}
+}
+
+func TestPendingAuthorization(t *testing.T) {
+	p := newTestPayment(t)
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	var err error
+	if p.ledger, err = newLedger(path); err != nil {
+		t.Fatal(err)
+	}
+	p.gatewayTimeout = time.Millisecond
+	ctx := context.Background()
+
+	// An authorization that times out stays pending, and one with the same
+	// idempotency key is not made again, even after a restart.
+	req := authorizeRequest("4000000000006975", usd(42, 0))
+	req.IdempotencyKey = "order-1"
+	if _, err := p.Authorize(ctx, req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("authorizing a card that times out: got %v, want Unavailable", err)
+	}
+	p.ledger.journal.Close()
+	if p.ledger, err = newLedger(path); err != nil {
+		t.Fatal(err)
+	}
+	defer p.ledger.journal.Close()
+	p.charges = newChargeCache(defaultChargeCacheSize)
+	if _, err := p.Authorize(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("authorizing again with the key of a pending authorization: got %v, want FailedPrecondition", err)
+	}
+
+	tx, err := p.GetTransaction(ctx, &pb.GetTransactionRequest{IdempotencyKey: "order-1"})
+	if err != nil || tx.GetPendingChange().GetState() != pb.Transaction_AUTHORIZED {
+		t.Fatalf("authorization of order-1 = %v, %v, want a pending authorization", tx, err)
+	}
+	if _, err := p.GetTransaction(ctx, &pb.GetTransactionRequest{IdempotencyKey: "order-3"}); status.Code(err) != codes.NotFound {
+		t.Errorf("getting an unknown authorization: got %v, want NotFound", err)
+	}
+	reconcile := &pb.ReconcileTransactionRequest{TransactionId: tx.GetTransactionId(), Applied: true}
+	if _, err := p.ReconcileTransaction(ctx, reconcile); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("reconciling an authorization without its reference: got %v, want InvalidArgument", err)
+	}
+	reconcile.GatewayReference = "sim_1"
+	if tx, err = p.ReconcileTransaction(ctx, reconcile); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetState() != pb.Transaction_AUTHORIZED || tx.GetGatewayReference() != "sim_1" {
+		t.Errorf("after reconciling the authorization: transaction = %v, want AUTHORIZED as sim_1", tx)
+	}
+	got, err := p.Authorize(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetTransactionId() != tx.GetTransactionId() {
+		t.Errorf("authorizing again with the key of a reconciled authorization = %v, want %v", got, tx)
+	}
+
+	// A declined authorization fails, and its key can be used again.
+	declined := authorizeRequest("4000000000000002", usd(42, 0))
+	declined.IdempotencyKey = "order-2"
+	if _, err := p.Authorize(ctx, declined); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("authorizing a card that declines: got %v, want FailedPrecondition", err)
+	}
+	if tx, _ := p.ledger.authorization("order-2"); tx.GetState() != pb.Transaction_FAILED || tx.GetPendingChange() != nil {
+		t.Errorf("declined authorization = %v, want FAILED", tx)
+	}
+}
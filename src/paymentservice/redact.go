@@ -0,0 +1,118 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// panPattern matches runs of 13 to 19 digits, which may be grouped by
+	// spaces or dashes, like card numbers.
+	panPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// cvvPattern matches security codes after their name, as in the text of
+	// a card message.
+	cvvPattern = regexp.MustCompile(`(?i)(cvv|cvc)(\W{0,3})\d{3,4}\b`)
+)
+
+// sensitiveKeys are the parts of the keys of log fields whose values are
+// never logged.
+var sensitiveKeys = []string{"cvv", "cvc", "card_number", "cardnumber"}
+
+const redacted = "[REDACTED]"
+
+// redact masks the card numbers in s but for their last four digits, and
+// removes security codes.
+func redact(s string) string {
+	s = panPattern.ReplaceAllStringFunc(s, func(pan string) string {
+		b := []byte(pan)
+		digits := 0
+		for i := len(b) - 1; i >= 0; i-- {
+			if b[i] < '0' || b[i] > '9' {
+				continue
+			}
+			if digits++; digits > 4 {
+				b[i] = '*'
+			}
+		}
+		return string(b)
+	})
+	return cvvPattern.ReplaceAllString(s, "$1$2"+redacted)
+}
+
+// redactingCore is a zap core that redacts card numbers and security codes
+// from messages and fields before they are written.
+type redactingCore struct {
+	zapcore.Core
+}
+
+func newRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{core}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = redact(ent.Message)
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = redactField(f)
+	}
+	return out
+}
+
+func redactField(f zapcore.Field) zapcore.Field {
+	key := strings.ToLower(f.Key)
+	for _, k := range sensitiveKeys {
+		if strings.Contains(key, k) {
+			return zap.String(f.Key, redacted)
+		}
+	}
+	switch f.Type {
+	case zapcore.StringType:
+		return zap.String(f.Key, redact(f.String))
+	case zapcore.ErrorType:
+		return zap.String(f.Key, redact(f.Interface.(error).Error()))
+	case zapcore.StringerType:
+		return zap.String(f.Key, redact(f.Interface.(fmt.Stringer).String()))
+	case zapcore.ReflectType, zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		// Structured values are logged as their redacted JSON.
+		b, err := json.Marshal(f.Interface)
+		if err != nil {
+			return zap.String(f.Key, redacted)
+		}
+		return zap.String(f.Key, redact(string(b)))
+	}
+	return f
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRedact(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"card 4432801561520454 declined", "card ************0454 declined"},
+		{"card 4432-8015-6152-0454", "card ****-****-****-0454"},
+		{"card 4432 8015 6152 0454", "card **** **** **** 0454"},
+		{`credit_card_number:"4432801561520454" credit_card_cvv:672`, `credit_card_number:"************0454" credit_card_cvv:[REDACTED]`},
+		{`{"cvv":"672"}`, `{"cvv":"[REDACTED]"}`},
+		{"order 42 of 1200 USD", "order 42 of 1200 USD"},
+	} {
+		if got := redact(tc.in); got != tc.want {
+			t.Errorf("redact(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRedactingLogger(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	logger := zap.New(core, zap.WrapCore(newRedactingCore)).Sugar()
+
+	card := testChargeRequest("").GetCreditCard()
+	logger.Infof("charging %v", card)
+	logger.Errorw("declined", "card", card, "error", errors.New("card 4432801561520454 declined"), "cvv", 672)
+	logger.With("number", "4432801561520454").Warn("retrying")
+	logger.Sync()
+
+	out := buf.String()
+	for _, secret := range []string{"4432801561520454", "4432-8015-6152-0454", "672"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "0454") {
+		t.Errorf("log output lost the last four digits:\n%s", out)
+	}
+}
@@ -16,23 +16,22 @@ package main
 
 import (
 	"context"
-	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"os"
 	"strconv"
-	"strings"
 	"time"
 
+	"github.com/triplewy/microservices-demo/src/paymentservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"github.com/triplewy/microservices-demo/src/paymentservice/money"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
-	uuid "github.com/satori/go.uuid"
-	cardValidator "github.com/sgumirov/go-cards-validation"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/status"
 )
 
@@ -50,15 +49,21 @@ func init() {
 		port = os.Getenv("PORT")
 	}
 
-	zLogger, _ = zap.NewProduction()
+	// Card numbers and security codes never reach the logs.
+	zLogger, _ = zap.NewProduction(zap.WrapCore(newRedactingCore))
 	sugar = zLogger.Sugar()
 }
 
 func main() {
+	stubAddr := flag.String("gateway-stub", "", "serve a simulated payment gateway over HTTP at this address instead of the payment service")
 	flag.Parse()
 
 	defer zLogger.Sync()
 
+	if *stubAddr != "" {
+		sugar.Fatal(serveGatewayStub(*stubAddr))
+	}
+
 	sugar.Infof("starting grpc server at :%s", port)
 	run(port)
 	select {}
@@ -69,73 +74,305 @@ func run(port string) string {
 	if err != nil {
 		sugar.Fatal(err)
 	}
-	srv := grpc.NewServer()
-	svc := &payment{}
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
+	svc := &payment{charges: newChargeCache(chargeCacheSize())}
+	if svc.ledger, err = newLedger(os.Getenv("LEDGER_FILE")); err != nil {
+		sugar.Fatal(err)
+	}
+	tokenTTL, err := cardTokenTTL()
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	if svc.vault, err = newCardVault(os.Getenv("CARD_VAULT_KEY_FILE"), os.Getenv("CARD_VAULT_FILE"), tokenTTL); err != nil {
+		sugar.Fatal(err)
+	}
+	go svc.vault.pruneEvery(context.Background(), vaultPruneInterval)
+	if svc.brands, err = cardBrandsFromEnv(); err != nil {
+		sugar.Fatal(err)
+	}
+	rules, err := loadFraudRules(os.Getenv("FRAUD_RULES_FILE"))
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	svc.fraud = newFraudScorer(rules)
+	if svc.gateway, err = gatewayFromEnv(); err != nil {
+		sugar.Fatal(err)
+	}
+	if svc.gatewayTimeout, err = gatewayTimeout(); err != nil {
+		sugar.Fatal(err)
+	}
 	pb.RegisterPaymentServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, newHealthServer())
+	pb.RegisterFaultServiceServer(srv, faults)
 	go srv.Serve(l)
 	return l.Addr().String()
 }
 
-type payment struct{}
+// newHealthServer returns a health server that reports the service as
+// serving, since it has no dependencies that could make it unable to.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("hipstershop.PaymentService", healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
+
+// chargeCacheSize returns the number of idempotent charges to remember, from
+// the IDEMPOTENCY_CACHE_SIZE environment variable.
+func chargeCacheSize() int {
+	if v := os.Getenv("IDEMPOTENCY_CACHE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			sugar.Fatalf("invalid IDEMPOTENCY_CACHE_SIZE %q", v)
+		}
+		return n
+	}
+	return defaultChargeCacheSize
+}
+
+type payment struct {
+	charges        *chargeCache
+	ledger         *ledger
+	vault          *cardVault
+	brands         map[string]bool
+	fraud          *fraudScorer
+	gateway        Gateway
+	gatewayTimeout time.Duration
+}
+
+// gatewayContext returns the context of a call to the gateway.
+func (p *payment) gatewayContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, p.gatewayTimeout)
+}
 
+// Charge charges the card of req. Requests with an idempotency key are
+// charged once; replays return the response of the first charge.
 func (p *payment) Charge(ctx context.Context, req *pb.ChargeRequest) (*pb.ChargeResponse, error) {
+	key := idempotencyKey(ctx, req.GetIdempotencyKey())
+	if key == "" {
+		return p.charge(ctx, req)
+	}
+	return p.charges.do(ctx, key, chargeFingerprint(req), func() (*pb.ChargeResponse, error) {
+		return p.charge(ctx, req)
+	})
+}
+
+func (p *payment) charge(ctx context.Context, req *pb.ChargeRequest) (*pb.ChargeResponse, error) {
+	tx, err := p.authorize(ctx, &pb.AuthorizeRequest{
+		Amount:         req.GetAmount(),
+		CreditCard:     req.GetCreditCard(),
+		CardToken:      req.GetCardToken(),
+		SessionId:      req.GetSessionId(),
+		BillingCountry: req.GetBillingCountry(),
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+	id := tx.GetTransactionId()
+	if tx, err = p.ledger.capture(id, nil, p.captureWithGateway(ctx)); err != nil {
+		// Release the authorization of a charge that failed.
+		if _, voidErr := p.ledger.void(id, p.voidWithGateway(ctx)); voidErr != nil {
+			sugar.Warnf("failed to void transaction %v of a failed charge: %v", id, voidErr)
+		}
+		return nil, err
+	}
+	return &pb.ChargeResponse{
+		TransactionId: tx.GetTransactionId(),
+	}, nil
+}
+
+// cardOf returns the card of a request, which has either the card or a
+// token of it.
+func (p *payment) cardOf(creditCard *pb.CreditCardInfo, token string) (*validCard, error) {
+	switch {
+	case creditCard != nil && token != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of credit_card and card_token may be set")
+	case token != "":
+		var err error
+		if creditCard, err = p.vault.card(token); err != nil {
+			return nil, err
+		}
+	}
+	return p.validateCard(creditCard)
+}
+
+// authorize checks the payment of req for fraud, and authorizes its amount
+// on its card with the gateway. An authorization with the idempotency key of
+// an earlier one that did not fail returns the earlier one, also after a
+// restart.
+func (p *payment) authorize(ctx context.Context, req *pb.AuthorizeRequest, key string) (*pb.Transaction, error) {
 	amount := req.GetAmount()
-	creditCard := req.GetCreditCard()
+	if amount == nil || !money.IsPositive(*amount) || amount.GetCurrencyCode() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "amount must be positive and have a currency code")
+	}
+	if tx, ok := p.ledger.authorization(key); ok && tx.GetState() != pb.Transaction_FAILED {
+		switch {
+		case !money.AreEquals(*tx.GetAuthorized(), *amount):
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key %q was used for a different authorization", key)
+		case tx.GetPendingChange() != nil:
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"authorization %s with idempotency key %q has an unknown outcome with the gateway, and must be reconciled", tx.GetTransactionId(), key)
+		}
+		return tx, nil
+	}
+	card, err := p.cardOf(req.GetCreditCard(), req.GetCardToken())
+	if err != nil {
+		return nil, err
+	}
 
-	card := cardValidator.Card{
-		Number: strings.ReplaceAll(creditCard.CreditCardNumber, "-", ""),
-		Cvv:    fmt.Sprint(creditCard.CreditCardCvv),
-		Month:  fmt.Sprint(creditCard.CreditCardExpirationMonth),
-		Year:   fmt.Sprint(creditCard.CreditCardExpirationYear),
+	fraud := p.fraud.assess(fraudPayment{card: card, amount: amount, sessionID: req.GetSessionId(), billingCountry: req.GetBillingCountry()})
+	switch fraud.GetDecision() {
+	case pb.FraudAssessment_DECLINE:
+		sugar.Warnw("payment declined by fraud checks", "card", card.brand+" ending "+card.lastFour, "score", fraud.GetScore(), "reasons", fraud.GetReasons())
+		return nil, fraudDeclined(fraud)
+	case pb.FraudAssessment_REVIEW:
+		sugar.Warnw("payment to review", "card", card.brand+" ending "+card.lastFour, "score", fraud.GetScore(), "reasons", fraud.GetReasons())
 	}
 
-	if err := card.Validate(true); err != nil {
-		sugar.Error("invalid card number: %v", card)
+	// The authorization is journaled before it is made, so that one of
+	// unknown outcome is never missing from the ledger.
+	tx, err := p.ledger.beginAuthorization(key, amount, card.brand, card.lastFour, fraud)
+	if err != nil {
 		return nil, err
 	}
+	gctx, cancel := p.gatewayContext(ctx)
+	defer cancel()
+	ref, gatewayErr := p.gateway.Authorize(gctx, card.gatewayCard, amount)
+	tx, err = p.ledger.finishAuthorization(tx.GetTransactionId(), ref, gatewayErr)
+	if gatewayErr != nil {
+		sugar.Infof("Authorization failed: %v ending %v Amount: %v%v.%v: %v", card.brand, card.lastFour, amount.CurrencyCode, amount.Units, amount.Nanos, gatewayErr)
+		return nil, gatewayErr
+	}
+	if err != nil {
+		// Release an authorization that the ledger could not record as
+		// made. It stays pending, to be reconciled as not made.
+		if voidErr := p.gateway.Void(gctx, ref); voidErr != nil {
+			sugar.Warnf("failed to void unrecorded authorization %v: %v", ref, voidErr)
+		}
+		return nil, err
+	}
+	sugar.Infof("Transaction %v authorized: %v ending %v Amount: %v%v.%v", tx.GetTransactionId(), card.brand, card.lastFour, amount.CurrencyCode, amount.Units, amount.Nanos)
+	return tx, nil
+}
 
-	if err := card.Brand(); err != nil {
-		sugar.Error("invalid card brand: %v", card)
+// Authorize holds amount on the card of req until it is captured or voided.
+// Requests with an idempotency key are authorized once, like charges.
+func (p *payment) Authorize(ctx context.Context, req *pb.AuthorizeRequest) (*pb.Transaction, error) {
+	key := idempotencyKey(ctx, req.GetIdempotencyKey())
+	if key == "" {
+		return p.authorize(ctx, req, "")
+	}
+	fingerprint := chargeFingerprint(&pb.ChargeRequest{Amount: req.GetAmount(), CreditCard: req.GetCreditCard(), CardToken: req.GetCardToken()})
+	// Authorizations share the cache of charges, under keys of their own.
+	resp, err := p.charges.do(ctx, "authorize:"+key, fingerprint, func() (*pb.ChargeResponse, error) {
+		tx, err := p.authorize(ctx, req, key)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ChargeResponse{TransactionId: tx.GetTransactionId()}, nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	return p.ledger.get(resp.GetTransactionId())
+}
+
+// The following functions return how the ledger makes its changes with the
+// gateway.
+
+func (p *payment) captureWithGateway(ctx context.Context) func(tx *pb.Transaction, amount *pb.Money) error {
+	return func(tx *pb.Transaction, amount *pb.Money) error {
+		ctx, cancel := p.gatewayContext(ctx)
+		defer cancel()
+		return p.gateway.Capture(ctx, tx.GetGatewayReference(), amount)
+	}
+}
 
-	if !(strings.ToLower(card.Company.Name) == "visa" || strings.ToLower(card.Company.Name) == "mastercard") {
-		sugar.Error("invalid card company: %v", card)
-		return nil, errors.New("unaccepted credit card")
+func (p *payment) voidWithGateway(ctx context.Context) func(tx *pb.Transaction) error {
+	return func(tx *pb.Transaction) error {
+		ctx, cancel := p.gatewayContext(ctx)
+		defer cancel()
+		return p.gateway.Void(ctx, tx.GetGatewayReference())
 	}
+}
 
-	var cardYear, cardMonth int
-	var err error
+func (p *payment) refundWithGateway(ctx context.Context) func(tx *pb.Transaction, amount *pb.Money) error {
+	return func(tx *pb.Transaction, amount *pb.Money) error {
+		ctx, cancel := p.gatewayContext(ctx)
+		defer cancel()
+		return p.gateway.Refund(ctx, tx.GetGatewayReference(), amount)
+	}
+}
 
-	if cardYear, err = strconv.Atoi(card.Year); err != nil {
+func (p *payment) Capture(ctx context.Context, req *pb.CaptureRequest) (*pb.Transaction, error) {
+	tx, err := p.ledger.capture(req.GetTransactionId(), req.GetAmount(), p.captureWithGateway(ctx))
+	if err != nil {
 		return nil, err
 	}
-	if cardMonth, err = strconv.Atoi(card.Month); err != nil {
+	sugar.Infof("Transaction %v captured: %v%v.%v", tx.GetTransactionId(), tx.Captured.CurrencyCode, tx.Captured.Units, tx.Captured.Nanos)
+	return tx, nil
+}
+
+func (p *payment) Void(ctx context.Context, req *pb.VoidRequest) (*pb.Transaction, error) {
+	tx, err := p.ledger.void(req.GetTransactionId(), p.voidWithGateway(ctx))
+	if err != nil {
 		return nil, err
 	}
-	cardExpDate := time.Date(cardYear, time.Month(cardMonth), 0, 0, 0, 0, 0, time.UTC)
+	sugar.Infof("Transaction %v voided", tx.GetTransactionId())
+	return tx, nil
+}
+
+// GetTransaction returns a transaction by its ID, or the last authorization
+// with an idempotency key, so that a caller that lost the outcome of an
+// authorization can find it.
+func (p *payment) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.Transaction, error) {
+	if req.GetTransactionId() == "" && req.GetIdempotencyKey() != "" {
+		tx, ok := p.ledger.authorization(req.GetIdempotencyKey())
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "no authorization with idempotency key %q", req.GetIdempotencyKey())
+		}
+		return tx, nil
+	}
+	return p.ledger.get(req.GetTransactionId())
+}
 
-	if time.Now().Unix() > cardExpDate.Unix() {
-		return nil, errors.New("expired credit card")
+// ReconcileTransaction settles the pending change of a transaction, applying
+// it if the gateway made it and dropping it otherwise.
+func (p *payment) ReconcileTransaction(ctx context.Context, req *pb.ReconcileTransactionRequest) (*pb.Transaction, error) {
+	tx, err := p.ledger.reconcile(req.GetTransactionId(), req.GetApplied(), req.GetGatewayReference())
+	if err != nil {
+		return nil, err
 	}
+	sugar.Infof("Transaction %v reconciled: %v", tx.GetTransactionId(), tx.GetState())
+	return tx, nil
+}
 
-	var lastFour string
-	if lastFour, err = card.LastFour(); err != nil {
+// Refund returns amount of a captured transaction to the card.
+func (p *payment) Refund(ctx context.Context, req *pb.RefundRequest) (*pb.RefundResponse, error) {
+	refund, tx, err := p.ledger.refund(req.GetTransactionId(), req.GetAmount(), req.GetIdempotencyKey(), p.refundWithGateway(ctx))
+	if err != nil {
 		return nil, err
 	}
+	amount := refund.GetAmount()
 
-	sugar.Infof("Transaction processed: %v ending %v Amount: %v%v.%v", card.Company.Name, lastFour, amount.CurrencyCode, amount.Units, amount.Nanos)
+	sugar.Infof("Refund processed: transaction %v Amount: %v%v.%v", req.GetTransactionId(), amount.CurrencyCode, amount.Units, amount.Nanos)
 
-	return &pb.ChargeResponse{
-		TransactionId: uuid.NewV4().String(),
+	return &pb.RefundResponse{
+		RefundId:    refund.GetRefundId(),
+		Transaction: tx,
 	}, nil
 }
 
-func (p *payment) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
-}
-
-func (p *payment) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+// Tokenize stores a valid card in the vault, and returns a token of it.
+func (p *payment) Tokenize(ctx context.Context, req *pb.TokenizeRequest) (*pb.TokenizeResponse, error) {
+	card, err := p.validateCard(req.GetCreditCard())
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.vault.tokenize(req.GetCreditCard())
+	if err != nil {
+		return nil, err
+	}
+	sugar.Infof("Card tokenized: %v ending %v", card.brand, card.lastFour)
+	return &pb.TokenizeResponse{Token: token, CardBrand: card.brand, CardLastFour: card.lastFour}, nil
 }
@@ -0,0 +1,197 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+)
+
+// simulatedCard is how the simulated gateway treats a card number.
+type simulatedCard struct {
+	// Decline is the code that authorizations are declined with.
+	Decline string `json:"decline,omitempty"`
+	// CaptureDecline is the code that captures are declined with, after the
+	// authorization succeeded.
+	CaptureDecline string `json:"capture_decline,omitempty"`
+	// Timeout makes authorizations hang until the caller gives up.
+	Timeout bool `json:"timeout,omitempty"`
+}
+
+// defaultSimulatedCards are the card numbers with a behavior of their own,
+// after the test cards of Stripe. Other cards are approved.
+var defaultSimulatedCards = map[string]simulatedCard{
+	"4000000000000002": {Decline: "card_declined"},
+	"4000000000009995": {Decline: "insufficient_funds"},
+	"4000000000009987": {Decline: "lost_card"},
+	"4000000000009979": {Decline: "stolen_card"},
+	"4000000000000069": {Decline: "expired_card"},
+	"4000000000000127": {Decline: "incorrect_cvc"},
+	"4000000000000119": {Decline: "processing_error"},
+	"4000000000000341": {CaptureDecline: "card_declined"},
+	"4000000000006975": {Timeout: true},
+}
+
+// declineMessages are the messages of the decline codes that the simulated
+// gateway knows.
+var declineMessages = map[string]string{
+	"card_declined":      "Your card was declined.",
+	"insufficient_funds": "Your card has insufficient funds.",
+	"lost_card":          "Your card was declined.",
+	"stolen_card":        "Your card was declined.",
+	"expired_card":       "Your card has expired.",
+	"incorrect_cvc":      "Your card's security code is incorrect.",
+	"processing_error":   "An error occurred while processing your card. Try again in a little bit.",
+}
+
+// simulatorConfig configures the simulated gateway. It is read from a JSON
+// file such as:
+//
+//	{
+//	  "cards": {"4111111111111111": {"decline": "do_not_honor"}},
+//	  "insufficient_funds_above": 1000,
+//	  "latency": "200ms"
+//	}
+type simulatorConfig struct {
+	// Cards are added to, or replace, the default card behaviors.
+	Cards map[string]simulatedCard `json:"cards"`
+	// InsufficientFundsAbove, if positive, declines authorizations of more
+	// than that many units of any currency with "insufficient_funds".
+	InsufficientFundsAbove int64 `json:"insufficient_funds_above"`
+	// Latency is how long every call takes, as a Go duration.
+	Latency string `json:"latency"`
+
+	latency time.Duration
+}
+
+// loadSimulatorConfig reads the config of the simulated gateway from path,
+// or returns the default config if path is empty.
+func loadSimulatorConfig(path string) (simulatorConfig, error) {
+	var cfg simulatorConfig
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read simulated gateway config: %v", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("simulated gateway config %s: %v", path, err)
+	}
+	if cfg.Latency != "" {
+		if cfg.latency, err = time.ParseDuration(cfg.Latency); err != nil || cfg.latency < 0 {
+			return cfg, fmt.Errorf("simulated gateway config %s: latency is not a non-negative duration: %q", path, cfg.Latency)
+		}
+	}
+	return cfg, nil
+}
+
+// simulatedGateway is a payment processor in memory, which treats cards as
+// configured and approves everything else.
+type simulatedGateway struct {
+	cfg   simulatorConfig
+	cards map[string]simulatedCard
+
+	mu sync.Mutex
+	// auths are the cards of the authorizations that the gateway made.
+	auths map[string]simulatedCard
+}
+
+func newSimulatedGateway(cfg simulatorConfig) *simulatedGateway {
+	cards := make(map[string]simulatedCard, len(defaultSimulatedCards)+len(cfg.Cards))
+	for number, c := range defaultSimulatedCards {
+		cards[number] = c
+	}
+	for number, c := range cfg.Cards {
+		cards[number] = c
+	}
+	return &simulatedGateway{cfg: cfg, cards: cards, auths: make(map[string]simulatedCard)}
+}
+
+// wait simulates the latency of a call.
+func (g *simulatedGateway) wait(ctx context.Context) error {
+	if g.cfg.latency == 0 {
+		return nil
+	}
+	t := time.NewTimer(g.cfg.latency)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return errGatewayTimeout
+	case <-t.C:
+		return nil
+	}
+}
+
+func decline(code string) *declineError {
+	msg, ok := declineMessages[code]
+	if !ok {
+		msg = declineMessages["card_declined"]
+	}
+	return &declineError{Code: code, Message: msg}
+}
+
+func (g *simulatedGateway) Authorize(ctx context.Context, card gatewayCard, amount *pb.Money) (string, error) {
+	if err := g.wait(ctx); err != nil {
+		return "", err
+	}
+	c := g.cards[card.Number]
+	switch {
+	case c.Timeout:
+		<-ctx.Done()
+		return "", errGatewayTimeout
+	case c.Decline != "":
+		return "", decline(c.Decline)
+	case g.cfg.InsufficientFundsAbove > 0 && (amount.GetUnits() > g.cfg.InsufficientFundsAbove ||
+		amount.GetUnits() == g.cfg.InsufficientFundsAbove && amount.GetNanos() > 0):
+		return "", decline("insufficient_funds")
+	}
+	ref := "sim_" + uuid.NewV4().String()
+	g.mu.Lock()
+	g.auths[ref] = c
+	g.mu.Unlock()
+	return ref, nil
+}
+
+// Capture, Void and Refund approve authorizations that the gateway does not
+// know, such as those made before a restart.
+
+func (g *simulatedGateway) Capture(ctx context.Context, ref string, amount *pb.Money) error {
+	if err := g.wait(ctx); err != nil {
+		return err
+	}
+	g.mu.Lock()
+	c := g.auths[ref]
+	g.mu.Unlock()
+	if c.CaptureDecline != "" {
+		return decline(c.CaptureDecline)
+	}
+	return nil
+}
+
+func (g *simulatedGateway) Void(ctx context.Context, ref string) error {
+	return g.wait(ctx)
+}
+
+func (g *simulatedGateway) Refund(ctx context.Context, ref string, amount *pb.Money) error {
+	return g.wait(ctx)
+}
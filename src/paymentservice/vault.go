@@ -0,0 +1,314 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokenPrefix starts every card token.
+const tokenPrefix = "tok_"
+
+const (
+	// defaultCardTokenTTL is how long a card token can be used.
+	defaultCardTokenTTL = 15 * time.Minute
+
+	// vaultPruneInterval is how often the vault drops the used and expired
+	// tokens.
+	vaultPruneInterval = time.Minute
+)
+
+// cardVault stores cards under opaque tokens. Cards are sealed with AES-GCM,
+// bound to their token, both in memory and in the vault file, and are only
+// opened to be sent to the gateway. A token expires after its TTL and is
+// used up by the first payment made with it, so that the security code of
+// its card serves a single authorization. The used and expired tokens are
+// dropped from the vault file on startup and every vaultPruneInterval.
+type cardVault struct {
+	aead cipher.AEAD
+	ttl  time.Duration
+
+	mu      sync.Mutex // guards entries and file
+	entries map[string]vaultEntry
+	path    string
+	file    *os.File
+}
+
+type vaultEntry struct {
+	// sealed is the nonce followed by the sealed card.
+	sealed  []byte
+	expires time.Time
+}
+
+// vaultRecord is a line of the vault file.
+type vaultRecord struct {
+	Token string `json:"token"`
+	// Card is the nonce followed by the sealed card, or empty if the token
+	// was used.
+	Card []byte `json:"card,omitempty"`
+	// Expires is when the token expires, in seconds since the epoch.
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// newCardVault returns a vault with the key in keyFile, stored in path, whose
+// tokens expire after ttl. The key file is created with a random key if it
+// does not exist. Without a key file the vault has a random key, and without
+// a path it is kept in memory only; a vault file needs a key file, since its
+// cards could not be opened after a restart otherwise.
+func newCardVault(keyFile, path string, ttl time.Duration) (*cardVault, error) {
+	if path != "" && keyFile == "" {
+		return nil, fmt.Errorf("a card vault file needs a key file")
+	}
+	key, err := loadVaultKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	v := &cardVault{aead: aead, ttl: ttl, entries: make(map[string]vaultEntry), path: path}
+	if path == "" {
+		return v, nil
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return v, v.prune()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open card vault: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		var r vaultRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("card vault %s, line %d: %v", path, line, err)
+		}
+		if len(r.Card) == 0 {
+			delete(v.entries, r.Token)
+			continue
+		}
+		v.entries[r.Token] = vaultEntry{sealed: r.Card, expires: time.Unix(r.Expires, 0)}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read card vault: %v", err)
+	}
+	if err := v.prune(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// loadVaultKey returns the 256-bit key written in hex in path, creating the
+// file with a random key if it does not exist, or a random key if path is
+// empty.
+func loadVaultKey(path string) ([]byte, error) {
+	key := make([]byte, 32)
+	if path == "" {
+		_, err := rand.Read(key)
+		return key, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+			return nil, fmt.Errorf("failed to create card vault key: %v", err)
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read card vault key: %v", err)
+	}
+	if key, err = hex.DecodeString(strings.TrimSpace(string(data))); err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("card vault key %s is not 64 hex digits", path)
+	}
+	return key, nil
+}
+
+// tokenize stores card, and returns its token.
+func (v *cardVault) tokenize(card *pb.CreditCardInfo) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := tokenPrefix + hex.EncodeToString(b)
+	plain, err := proto.Marshal(card)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, v.aead.NonceSize(), v.aead.NonceSize()+len(plain)+v.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	e := vaultEntry{sealed: v.aead.Seal(nonce, nonce, plain, []byte(token)), expires: time.Now().Add(v.ttl)}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err := v.write(vaultRecord{Token: token, Card: e.sealed, Expires: e.expires.Unix()}); err != nil {
+		return "", status.Errorf(codes.Unavailable, "failed to store card: %v", err)
+	}
+	v.entries[token] = e
+	return token, nil
+}
+
+// card returns the card of token, and uses the token up.
+func (v *cardVault) card(token string) (*pb.CreditCardInfo, error) {
+	v.mu.Lock()
+	e, ok := v.entries[token]
+	if ok {
+		if err := v.write(vaultRecord{Token: token}); err != nil {
+			v.mu.Unlock()
+			return nil, status.Errorf(codes.Unavailable, "failed to use card token: %v", err)
+		}
+		delete(v.entries, token)
+	}
+	v.mu.Unlock()
+	if !ok || !time.Now().Before(e.expires) {
+		return nil, status.Error(codes.InvalidArgument, "unknown, used or expired card token")
+	}
+	n := v.aead.NonceSize()
+	if len(e.sealed) < n {
+		return nil, status.Error(codes.Internal, "corrupt card in the vault")
+	}
+	plain, err := v.aead.Open(nil, e.sealed[:n], e.sealed[n:], []byte(token))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to open card in the vault")
+	}
+	var card pb.CreditCardInfo
+	if err := proto.Unmarshal(plain, &card); err != nil {
+		return nil, status.Error(codes.Internal, "corrupt card in the vault")
+	}
+	return &card, nil
+}
+
+// write appends r to the vault file, if there is one. v.mu must be held.
+func (v *cardVault) write(r vaultRecord) error {
+	if v.file == nil {
+		return nil
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := v.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return v.file.Sync()
+}
+
+// prune drops the expired tokens, and rewrites the vault file with the
+// tokens that are left, so that the cards of the used and expired tokens no
+// longer are in it.
+func (v *cardVault) prune() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := time.Now()
+	for token, e := range v.entries {
+		if !now.Before(e.expires) {
+			delete(v.entries, token)
+		}
+	}
+	if v.path == "" {
+		return nil
+	}
+
+	tmp := v.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to rewrite card vault: %v", err)
+	}
+	w := bufio.NewWriter(f)
+	for token, e := range v.entries {
+		line, err := json.Marshal(vaultRecord{Token: token, Card: e.sealed, Expires: e.expires.Unix()})
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to rewrite card vault: %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to rewrite card vault: %v", err)
+	}
+	f.Close()
+	if err := os.Rename(tmp, v.path); err != nil {
+		return fmt.Errorf("failed to rewrite card vault: %v", err)
+	}
+	if v.file != nil {
+		v.file.Close()
+	}
+	if v.file, err = os.OpenFile(v.path, os.O_WRONLY|os.O_APPEND, 0600); err != nil {
+		return fmt.Errorf("failed to open card vault: %v", err)
+	}
+	return nil
+}
+
+// pruneEvery prunes the vault every interval until ctx is done.
+func (v *cardVault) pruneEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := v.prune(); err != nil {
+			sugar.Warnf("failed to prune the card vault: %v", err)
+		}
+	}
+}
+
+// cardTokenTTL returns how long card tokens can be used, from the
+// CARD_TOKEN_TTL environment variable.
+func cardTokenTTL() (time.Duration, error) {
+	v := os.Getenv("CARD_TOKEN_TTL")
+	if v == "" {
+		return defaultCardTokenTTL, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("environment variable \"CARD_TOKEN_TTL\" is not a positive duration: %q", v)
+	}
+	return d, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/triplewy/microservices-demo/src/paymentservice/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenize(t *testing.T) {
+	p := newTestPayment(t)
+	ctx := context.Background()
+	card := testChargeRequest("").GetCreditCard()
+
+	resp, err := p.Tokenize(ctx, &pb.TokenizeRequest{CreditCard: card})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetCardLastFour() != "0454" || resp.GetCardBrand() != "Visa" {
+		t.Errorf("Tokenize = %v, want a Visa ending 0454", resp)
+	}
+
+	tx, err := p.Authorize(ctx, &pb.AuthorizeRequest{Amount: usd(42, 0), CardToken: resp.GetToken()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.GetCardLastFour() != "0454" {
+		t.Errorf("authorized with a token: %v, want the card ending 0454", tx)
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.ChargeRequest
+	}{
+		{"unknown token", &pb.ChargeRequest{Amount: usd(42, 0), CardToken: tokenPrefix + "0123"}},
+		{"used token", &pb.ChargeRequest{Amount: usd(42, 0), CardToken: resp.GetToken()}},
+		{"card and token", &pb.ChargeRequest{Amount: usd(42, 0), CreditCard: card, CardToken: resp.GetToken()}},
+	} {
+		if _, err := p.Charge(ctx, tc.req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("charging with %s: got %v, want InvalidArgument", tc.name, err)
+		}
+	}
+
+	expired := proto.Clone(card).(*pb.CreditCardInfo)
+	expired.CreditCardExpirationYear = 2001
+	if _, err := p.Tokenize(ctx, &pb.TokenizeRequest{CreditCard: expired}); err == nil {
+		t.Error("tokenizing an expired card succeeded")
+	}
+}
+
+func TestCardVaultFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, path := filepath.Join(dir, "vault.key"), filepath.Join(dir, "vault.jsonl")
+	card := testChargeRequest("").GetCreditCard()
+
+	v, err := newCardVault(keyFile, path, defaultCardTokenTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := v.tokenize(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spare, err := v.tokenize(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.file.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(card.GetCreditCardNumber())) || bytes.Contains(data, []byte("4432801561520454")) {
+		t.Errorf("vault file has the card number in the clear: %s", data)
+	}
+
+	restarted, err := newCardVault(keyFile, path, defaultCardTokenTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := restarted.card(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, card) {
+		t.Errorf("after a restart: card = %v, want %v", got, card)
+	}
+	if _, err := restarted.card(token); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("using a token twice: got %v, want InvalidArgument", err)
+	}
+	restarted.file.Close()
+
+	// A vault with another key cannot open the card, and drops the used
+	// token from the file.
+	otherKey := filepath.Join(dir, "other.key")
+	other, err := newCardVault(otherKey, path, defaultCardTokenTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.file.Close()
+	if data, err = ioutil.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(token)) || !bytes.Contains(data, []byte(spare)) {
+		t.Errorf("vault file after a restart = %s, want only the unused token %s", data, spare)
+	}
+	if _, err := other.card(spare); status.Code(err) != codes.Internal {
+		t.Errorf("opening a card with another key: got %v, want Internal", err)
+	}
+
+	if _, err := newCardVault("", path, defaultCardTokenTTL); err == nil {
+		t.Error("a vault file without a key file was accepted")
+	}
+}
+
+func TestCardTokenExpires(t *testing.T) {
+	dir := t.TempDir()
+	v, err := newCardVault(filepath.Join(dir, "vault.key"), filepath.Join(dir, "vault.jsonl"), time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.file.Close()
+	token, err := v.tokenize(testChargeRequest("").GetCreditCard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := v.prune(); err != nil {
+		t.Fatal(err)
+	}
+	if len(v.entries) != 0 {
+		t.Errorf("after pruning: vault has %d tokens, want 0", len(v.entries))
+	}
+	if _, err := v.card(token); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("using an expired token: got %v, want InvalidArgument", err)
+	}
+}
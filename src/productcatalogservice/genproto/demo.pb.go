@@ -4,14 +4,13 @@
 package hipstershop
 
 import (
+	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
-	math "math"
-)
-
-import (
-	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -23,7 +22,44 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ShipmentStatus_State int32
+
+const (
+	ShipmentStatus_UNKNOWN          ShipmentStatus_State = 0
+	ShipmentStatus_LABEL_CREATED    ShipmentStatus_State = 1
+	ShipmentStatus_IN_TRANSIT       ShipmentStatus_State = 2
+	ShipmentStatus_OUT_FOR_DELIVERY ShipmentStatus_State = 3
+	ShipmentStatus_DELIVERED        ShipmentStatus_State = 4
+	ShipmentStatus_CANCELLED        ShipmentStatus_State = 5
+)
+
+var ShipmentStatus_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "LABEL_CREATED",
+	2: "IN_TRANSIT",
+	3: "OUT_FOR_DELIVERY",
+	4: "DELIVERED",
+	5: "CANCELLED",
+}
+
+var ShipmentStatus_State_value = map[string]int32{
+	"UNKNOWN":          0,
+	"LABEL_CREATED":    1,
+	"IN_TRANSIT":       2,
+	"OUT_FOR_DELIVERY": 3,
+	"DELIVERED":        4,
+	"CANCELLED":        5,
+}
+
+func (x ShipmentStatus_State) String() string {
+	return proto.EnumName(ShipmentStatus_State_name, int32(x))
+}
+
+func (ShipmentStatus_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{21, 0}
+}
 
 type CartItem struct {
 	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -369,10 +405,14 @@ type Product struct {
 	PriceUsd    *Money `protobuf:"bytes,5,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
 	// Categories such as "vintage" or "gardening" that can be used to look up
 	// other related products.
-	Categories           []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Categories []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Shipping weight in kilograms and size of the package, used to quote
+	// shipping. Unset if unknown.
+	WeightKg             float64     `protobuf:"fixed64,7,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	Dimensions           *Dimensions `protobuf:"bytes,8,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *Product) Reset()         { *m = Product{} }
@@ -442,6 +482,75 @@ func (m *Product) GetCategories() []string {
 	return nil
 }
 
+func (m *Product) GetWeightKg() float64 {
+	if m != nil {
+		return m.WeightKg
+	}
+	return 0
+}
+
+func (m *Product) GetDimensions() *Dimensions {
+	if m != nil {
+		return m.Dimensions
+	}
+	return nil
+}
+
+type Dimensions struct {
+	LengthCm             float64  `protobuf:"fixed64,1,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm              float64  `protobuf:"fixed64,2,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm             float64  `protobuf:"fixed64,3,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dimensions) Reset()         { *m = Dimensions{} }
+func (m *Dimensions) String() string { return proto.CompactTextString(m) }
+func (*Dimensions) ProtoMessage()    {}
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{9}
+}
+
+func (m *Dimensions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dimensions.Unmarshal(m, b)
+}
+func (m *Dimensions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dimensions.Marshal(b, m, deterministic)
+}
+func (m *Dimensions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dimensions.Merge(m, src)
+}
+func (m *Dimensions) XXX_Size() int {
+	return xxx_messageInfo_Dimensions.Size(m)
+}
+func (m *Dimensions) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dimensions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dimensions proto.InternalMessageInfo
+
+func (m *Dimensions) GetLengthCm() float64 {
+	if m != nil {
+		return m.LengthCm
+	}
+	return 0
+}
+
+func (m *Dimensions) GetWidthCm() float64 {
+	if m != nil {
+		return m.WidthCm
+	}
+	return 0
+}
+
+func (m *Dimensions) GetHeightCm() float64 {
+	if m != nil {
+		return m.HeightCm
+	}
+	return 0
+}
+
 type ListProductsResponse struct {
 	Products             []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -453,7 +562,7 @@ func (m *ListProductsResponse) Reset()         { *m = ListProductsResponse{} }
 func (m *ListProductsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListProductsResponse) ProtoMessage()    {}
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{9}
+	return fileDescriptor_ca53982754088a9d, []int{10}
 }
 
 func (m *ListProductsResponse) XXX_Unmarshal(b []byte) error {
@@ -492,7 +601,7 @@ func (m *GetProductRequest) Reset()         { *m = GetProductRequest{} }
 func (m *GetProductRequest) String() string { return proto.CompactTextString(m) }
 func (*GetProductRequest) ProtoMessage()    {}
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{10}
+	return fileDescriptor_ca53982754088a9d, []int{11}
 }
 
 func (m *GetProductRequest) XXX_Unmarshal(b []byte) error {
@@ -531,7 +640,7 @@ func (m *SearchProductsRequest) Reset()         { *m = SearchProductsRequest{} }
 func (m *SearchProductsRequest) String() string { return proto.CompactTextString(m) }
 func (*SearchProductsRequest) ProtoMessage()    {}
 func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{11}
+	return fileDescriptor_ca53982754088a9d, []int{12}
 }
 
 func (m *SearchProductsRequest) XXX_Unmarshal(b []byte) error {
@@ -570,7 +679,7 @@ func (m *SearchProductsResponse) Reset()         { *m = SearchProductsResponse{}
 func (m *SearchProductsResponse) String() string { return proto.CompactTextString(m) }
 func (*SearchProductsResponse) ProtoMessage()    {}
 func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{12}
+	return fileDescriptor_ca53982754088a9d, []int{13}
 }
 
 func (m *SearchProductsResponse) XXX_Unmarshal(b []byte) error {
@@ -610,7 +719,7 @@ func (m *GetQuoteRequest) Reset()         { *m = GetQuoteRequest{} }
 func (m *GetQuoteRequest) String() string { return proto.CompactTextString(m) }
 func (*GetQuoteRequest) ProtoMessage()    {}
 func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{13}
+	return fileDescriptor_ca53982754088a9d, []int{14}
 }
 
 func (m *GetQuoteRequest) XXX_Unmarshal(b []byte) error {
@@ -646,17 +755,20 @@ func (m *GetQuoteRequest) GetItems() []*CartItem {
 }
 
 type GetQuoteResponse struct {
-	CostUsd              *Money   `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// The cost of the cheapest option.
+	CostUsd *Money `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The service levels available for the address, cheapest first.
+	Options              []*QuoteOption `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GetQuoteResponse) Reset()         { *m = GetQuoteResponse{} }
 func (m *GetQuoteResponse) String() string { return proto.CompactTextString(m) }
 func (*GetQuoteResponse) ProtoMessage()    {}
 func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{14}
+	return fileDescriptor_ca53982754088a9d, []int{15}
 }
 
 func (m *GetQuoteResponse) XXX_Unmarshal(b []byte) error {
@@ -684,6 +796,70 @@ func (m *GetQuoteResponse) GetCostUsd() *Money {
 	return nil
 }
 
+func (m *GetQuoteResponse) GetOptions() []*QuoteOption {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type QuoteOption struct {
+	// The name of the service level, such as "standard" or "overnight".
+	ServiceLevel string `protobuf:"bytes,1,opt,name=service_level,json=serviceLevel,proto3" json:"service_level,omitempty"`
+	CostUsd      *Money `protobuf:"bytes,2,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The number of business days until delivery.
+	TransitDays          int32    `protobuf:"varint,3,opt,name=transit_days,json=transitDays,proto3" json:"transit_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteOption) Reset()         { *m = QuoteOption{} }
+func (m *QuoteOption) String() string { return proto.CompactTextString(m) }
+func (*QuoteOption) ProtoMessage()    {}
+func (*QuoteOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{16}
+}
+
+func (m *QuoteOption) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteOption.Unmarshal(m, b)
+}
+func (m *QuoteOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteOption.Marshal(b, m, deterministic)
+}
+func (m *QuoteOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteOption.Merge(m, src)
+}
+func (m *QuoteOption) XXX_Size() int {
+	return xxx_messageInfo_QuoteOption.Size(m)
+}
+func (m *QuoteOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteOption.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteOption proto.InternalMessageInfo
+
+func (m *QuoteOption) GetServiceLevel() string {
+	if m != nil {
+		return m.ServiceLevel
+	}
+	return ""
+}
+
+func (m *QuoteOption) GetCostUsd() *Money {
+	if m != nil {
+		return m.CostUsd
+	}
+	return nil
+}
+
+func (m *QuoteOption) GetTransitDays() int32 {
+	if m != nil {
+		return m.TransitDays
+	}
+	return 0
+}
+
 type ShipOrderRequest struct {
 	Address              *Address    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Items                []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
@@ -696,7 +872,7 @@ func (m *ShipOrderRequest) Reset()         { *m = ShipOrderRequest{} }
 func (m *ShipOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*ShipOrderRequest) ProtoMessage()    {}
 func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{15}
+	return fileDescriptor_ca53982754088a9d, []int{17}
 }
 
 func (m *ShipOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -742,7 +918,7 @@ func (m *ShipOrderResponse) Reset()         { *m = ShipOrderResponse{} }
 func (m *ShipOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*ShipOrderResponse) ProtoMessage()    {}
 func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{16}
+	return fileDescriptor_ca53982754088a9d, []int{18}
 }
 
 func (m *ShipOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -770,6 +946,158 @@ func (m *ShipOrderResponse) GetTrackingId() string {
 	return ""
 }
 
+type CancelShipmentRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelShipmentRequest) Reset()         { *m = CancelShipmentRequest{} }
+func (m *CancelShipmentRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelShipmentRequest) ProtoMessage()    {}
+func (*CancelShipmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{19}
+}
+
+func (m *CancelShipmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelShipmentRequest.Unmarshal(m, b)
+}
+func (m *CancelShipmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelShipmentRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelShipmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelShipmentRequest.Merge(m, src)
+}
+func (m *CancelShipmentRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelShipmentRequest.Size(m)
+}
+func (m *CancelShipmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelShipmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelShipmentRequest proto.InternalMessageInfo
+
+func (m *CancelShipmentRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type GetShipmentStatusRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetShipmentStatusRequest) Reset()         { *m = GetShipmentStatusRequest{} }
+func (m *GetShipmentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShipmentStatusRequest) ProtoMessage()    {}
+func (*GetShipmentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{20}
+}
+
+func (m *GetShipmentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShipmentStatusRequest.Unmarshal(m, b)
+}
+func (m *GetShipmentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShipmentStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShipmentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShipmentStatusRequest.Merge(m, src)
+}
+func (m *GetShipmentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShipmentStatusRequest.Size(m)
+}
+func (m *GetShipmentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShipmentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShipmentStatusRequest proto.InternalMessageInfo
+
+func (m *GetShipmentStatusRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type ShipmentStatus struct {
+	TrackingId string               `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	State      ShipmentStatus_State `protobuf:"varint,2,opt,name=state,proto3,enum=hipstershop.ShipmentStatus_State" json:"state,omitempty"`
+	// Unix time in seconds at which the shipment entered state.
+	UpdatedAt int64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Unix time in seconds at which the shipment is expected to be delivered,
+	// or 0 once it is delivered or cancelled.
+	EstimatedDelivery    int64    `protobuf:"varint,4,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	Address              *Address `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShipmentStatus) Reset()         { *m = ShipmentStatus{} }
+func (m *ShipmentStatus) String() string { return proto.CompactTextString(m) }
+func (*ShipmentStatus) ProtoMessage()    {}
+func (*ShipmentStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{21}
+}
+
+func (m *ShipmentStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShipmentStatus.Unmarshal(m, b)
+}
+func (m *ShipmentStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShipmentStatus.Marshal(b, m, deterministic)
+}
+func (m *ShipmentStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShipmentStatus.Merge(m, src)
+}
+func (m *ShipmentStatus) XXX_Size() int {
+	return xxx_messageInfo_ShipmentStatus.Size(m)
+}
+func (m *ShipmentStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShipmentStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShipmentStatus proto.InternalMessageInfo
+
+func (m *ShipmentStatus) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+func (m *ShipmentStatus) GetState() ShipmentStatus_State {
+	if m != nil {
+		return m.State
+	}
+	return ShipmentStatus_UNKNOWN
+}
+
+func (m *ShipmentStatus) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *ShipmentStatus) GetEstimatedDelivery() int64 {
+	if m != nil {
+		return m.EstimatedDelivery
+	}
+	return 0
+}
+
+func (m *ShipmentStatus) GetAddress() *Address {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
 type Address struct {
 	StreetAddress        string   `protobuf:"bytes,1,opt,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
 	City                 string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
@@ -785,7 +1113,7 @@ func (m *Address) Reset()         { *m = Address{} }
 func (m *Address) String() string { return proto.CompactTextString(m) }
 func (*Address) ProtoMessage()    {}
 func (*Address) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{17}
+	return fileDescriptor_ca53982754088a9d, []int{22}
 }
 
 func (m *Address) XXX_Unmarshal(b []byte) error {
@@ -864,7 +1192,7 @@ func (m *Money) Reset()         { *m = Money{} }
 func (m *Money) String() string { return proto.CompactTextString(m) }
 func (*Money) ProtoMessage()    {}
 func (*Money) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{18}
+	return fileDescriptor_ca53982754088a9d, []int{23}
 }
 
 func (m *Money) XXX_Unmarshal(b []byte) error {
@@ -918,7 +1246,7 @@ func (m *GetSupportedCurrenciesResponse) Reset()         { *m = GetSupportedCurr
 func (m *GetSupportedCurrenciesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetSupportedCurrenciesResponse) ProtoMessage()    {}
 func (*GetSupportedCurrenciesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{19}
+	return fileDescriptor_ca53982754088a9d, []int{24}
 }
 
 func (m *GetSupportedCurrenciesResponse) XXX_Unmarshal(b []byte) error {
@@ -959,7 +1287,7 @@ func (m *CurrencyConversionRequest) Reset()         { *m = CurrencyConversionReq
 func (m *CurrencyConversionRequest) String() string { return proto.CompactTextString(m) }
 func (*CurrencyConversionRequest) ProtoMessage()    {}
 func (*CurrencyConversionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{20}
+	return fileDescriptor_ca53982754088a9d, []int{25}
 }
 
 func (m *CurrencyConversionRequest) XXX_Unmarshal(b []byte) error {
@@ -1008,7 +1336,7 @@ func (m *CreditCardInfo) Reset()         { *m = CreditCardInfo{} }
 func (m *CreditCardInfo) String() string { return proto.CompactTextString(m) }
 func (*CreditCardInfo) ProtoMessage()    {}
 func (*CreditCardInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{21}
+	return fileDescriptor_ca53982754088a9d, []int{26}
 }
 
 func (m *CreditCardInfo) XXX_Unmarshal(b []byte) error {
@@ -1058,18 +1386,22 @@ func (m *CreditCardInfo) GetCreditCardExpirationMonth() int32 {
 }
 
 type ChargeRequest struct {
-	Amount               *Money          `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	CreditCard           *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Amount     *Money          `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreditCard *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Requests with the same idempotency_key charge the card only once and
+	// return the response of the first request. May also be set with the
+	// "idempotency-key" gRPC metadata.
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChargeRequest) Reset()         { *m = ChargeRequest{} }
 func (m *ChargeRequest) String() string { return proto.CompactTextString(m) }
 func (*ChargeRequest) ProtoMessage()    {}
 func (*ChargeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{22}
+	return fileDescriptor_ca53982754088a9d, []int{27}
 }
 
 func (m *ChargeRequest) XXX_Unmarshal(b []byte) error {
@@ -1104,6 +1436,13 @@ func (m *ChargeRequest) GetCreditCard() *CreditCardInfo {
 	return nil
 }
 
+func (m *ChargeRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type ChargeResponse struct {
 	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1115,7 +1454,7 @@ func (m *ChargeResponse) Reset()         { *m = ChargeResponse{} }
 func (m *ChargeResponse) String() string { return proto.CompactTextString(m) }
 func (*ChargeResponse) ProtoMessage()    {}
 func (*ChargeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{23}
+	return fileDescriptor_ca53982754088a9d, []int{28}
 }
 
 func (m *ChargeResponse) XXX_Unmarshal(b []byte) error {
@@ -1143,44 +1482,131 @@ func (m *ChargeResponse) GetTransactionId() string {
 	return ""
 }
 
-type OrderItem struct {
-	Item                 *CartItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	Cost                 *Money    `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type RefundRequest struct {
+	// The transaction_id returned by Charge.
+	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount               *Money   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OrderItem) Reset()         { *m = OrderItem{} }
-func (m *OrderItem) String() string { return proto.CompactTextString(m) }
-func (*OrderItem) ProtoMessage()    {}
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{24}
+func (m *RefundRequest) Reset()         { *m = RefundRequest{} }
+func (m *RefundRequest) String() string { return proto.CompactTextString(m) }
+func (*RefundRequest) ProtoMessage()    {}
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{29}
 }
 
-func (m *OrderItem) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+func (m *RefundRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundRequest.Unmarshal(m, b)
 }
-func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+func (m *RefundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundRequest.Marshal(b, m, deterministic)
 }
-func (m *OrderItem) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderItem.Merge(m, src)
+func (m *RefundRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundRequest.Merge(m, src)
 }
-func (m *OrderItem) XXX_Size() int {
-	return xxx_messageInfo_OrderItem.Size(m)
+func (m *RefundRequest) XXX_Size() int {
+	return xxx_messageInfo_RefundRequest.Size(m)
 }
-func (m *OrderItem) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+func (m *RefundRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+var xxx_messageInfo_RefundRequest proto.InternalMessageInfo
 
-func (m *OrderItem) GetItem() *CartItem {
+func (m *RefundRequest) GetTransactionId() string {
 	if m != nil {
-		return m.Item
+		return m.TransactionId
 	}
-	return nil
+	return ""
+}
+
+func (m *RefundRequest) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+type RefundResponse struct {
+	RefundId             string   `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundResponse) Reset()         { *m = RefundResponse{} }
+func (m *RefundResponse) String() string { return proto.CompactTextString(m) }
+func (*RefundResponse) ProtoMessage()    {}
+func (*RefundResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{30}
+}
+
+func (m *RefundResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundResponse.Unmarshal(m, b)
+}
+func (m *RefundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundResponse.Marshal(b, m, deterministic)
+}
+func (m *RefundResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundResponse.Merge(m, src)
+}
+func (m *RefundResponse) XXX_Size() int {
+	return xxx_messageInfo_RefundResponse.Size(m)
+}
+func (m *RefundResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundResponse proto.InternalMessageInfo
+
+func (m *RefundResponse) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+type OrderItem struct {
+	Item                 *CartItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Cost                 *Money    `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *OrderItem) Reset()         { *m = OrderItem{} }
+func (m *OrderItem) String() string { return proto.CompactTextString(m) }
+func (*OrderItem) ProtoMessage()    {}
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{31}
+}
+
+func (m *OrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+}
+func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+}
+func (m *OrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderItem.Merge(m, src)
+}
+func (m *OrderItem) XXX_Size() int {
+	return xxx_messageInfo_OrderItem.Size(m)
+}
+func (m *OrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+
+func (m *OrderItem) GetItem() *CartItem {
+	if m != nil {
+		return m.Item
+	}
+	return nil
 }
 
 func (m *OrderItem) GetCost() *Money {
@@ -1205,7 +1631,7 @@ func (m *OrderResult) Reset()         { *m = OrderResult{} }
 func (m *OrderResult) String() string { return proto.CompactTextString(m) }
 func (*OrderResult) ProtoMessage()    {}
 func (*OrderResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{25}
+	return fileDescriptor_ca53982754088a9d, []int{32}
 }
 
 func (m *OrderResult) XXX_Unmarshal(b []byte) error {
@@ -1273,7 +1699,7 @@ func (m *SendOrderConfirmationRequest) Reset()         { *m = SendOrderConfirmat
 func (m *SendOrderConfirmationRequest) String() string { return proto.CompactTextString(m) }
 func (*SendOrderConfirmationRequest) ProtoMessage()    {}
 func (*SendOrderConfirmationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{26}
+	return fileDescriptor_ca53982754088a9d, []int{33}
 }
 
 func (m *SendOrderConfirmationRequest) XXX_Unmarshal(b []byte) error {
@@ -1309,21 +1735,25 @@ func (m *SendOrderConfirmationRequest) GetOrder() *OrderResult {
 }
 
 type PlaceOrderRequest struct {
-	UserId               string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserCurrency         string          `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
-	Address              *Address        `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Email                string          `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	CreditCard           *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	UserId       string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserCurrency string          `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
+	Address      *Address        `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Email        string          `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	CreditCard   *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Requests of a user with the same idempotency_key place the order only
+	// once and return the result of the first request. May also be set with
+	// the "idempotency-key" gRPC metadata.
+	IdempotencyKey       string   `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PlaceOrderRequest) Reset()         { *m = PlaceOrderRequest{} }
 func (m *PlaceOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*PlaceOrderRequest) ProtoMessage()    {}
 func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{27}
+	return fileDescriptor_ca53982754088a9d, []int{34}
 }
 
 func (m *PlaceOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -1379,6 +1809,13 @@ func (m *PlaceOrderRequest) GetCreditCard() *CreditCardInfo {
 	return nil
 }
 
+func (m *PlaceOrderRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type PlaceOrderResponse struct {
 	Order                *OrderResult `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
@@ -1390,7 +1827,7 @@ func (m *PlaceOrderResponse) Reset()         { *m = PlaceOrderResponse{} }
 func (m *PlaceOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*PlaceOrderResponse) ProtoMessage()    {}
 func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{28}
+	return fileDescriptor_ca53982754088a9d, []int{35}
 }
 
 func (m *PlaceOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -1418,6 +1855,235 @@ func (m *PlaceOrderResponse) GetOrder() *OrderResult {
 	return nil
 }
 
+type Order struct {
+	Result *OrderResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	UserId string       `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email  string       `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Total  *Money       `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Unix time in seconds at which the order was placed.
+	PlacedAt             int64    `protobuf:"varint,5,opt,name=placed_at,json=placedAt,proto3" json:"placed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+func (*Order) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{36}
+}
+
+func (m *Order) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Order.Unmarshal(m, b)
+}
+func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+}
+func (m *Order) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Order.Merge(m, src)
+}
+func (m *Order) XXX_Size() int {
+	return xxx_messageInfo_Order.Size(m)
+}
+func (m *Order) XXX_DiscardUnknown() {
+	xxx_messageInfo_Order.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Order proto.InternalMessageInfo
+
+func (m *Order) GetResult() *OrderResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *Order) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Order) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Order) GetTotal() *Money {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *Order) GetPlacedAt() int64 {
+	if m != nil {
+		return m.PlacedAt
+	}
+	return 0
+}
+
+type AddOrderRequest struct {
+	Order                *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddOrderRequest) Reset()         { *m = AddOrderRequest{} }
+func (m *AddOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*AddOrderRequest) ProtoMessage()    {}
+func (*AddOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{37}
+}
+
+func (m *AddOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddOrderRequest.Unmarshal(m, b)
+}
+func (m *AddOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *AddOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddOrderRequest.Merge(m, src)
+}
+func (m *AddOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_AddOrderRequest.Size(m)
+}
+func (m *AddOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddOrderRequest proto.InternalMessageInfo
+
+func (m *AddOrderRequest) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
+func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderRequest) ProtoMessage()    {}
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{38}
+}
+
+func (m *GetOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderRequest.Unmarshal(m, b)
+}
+func (m *GetOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderRequest.Merge(m, src)
+}
+func (m *GetOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderRequest.Size(m)
+}
+func (m *GetOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderRequest proto.InternalMessageInfo
+
+func (m *GetOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type ListOrdersByUserRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersByUserRequest) Reset()         { *m = ListOrdersByUserRequest{} }
+func (m *ListOrdersByUserRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersByUserRequest) ProtoMessage()    {}
+func (*ListOrdersByUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{39}
+}
+
+func (m *ListOrdersByUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersByUserRequest.Unmarshal(m, b)
+}
+func (m *ListOrdersByUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersByUserRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersByUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersByUserRequest.Merge(m, src)
+}
+func (m *ListOrdersByUserRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersByUserRequest.Size(m)
+}
+func (m *ListOrdersByUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersByUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersByUserRequest proto.InternalMessageInfo
+
+func (m *ListOrdersByUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+// Orders are sorted by placed_at, most recent first.
+type ListOrdersByUserResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersByUserResponse) Reset()         { *m = ListOrdersByUserResponse{} }
+func (m *ListOrdersByUserResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersByUserResponse) ProtoMessage()    {}
+func (*ListOrdersByUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{40}
+}
+
+func (m *ListOrdersByUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersByUserResponse.Unmarshal(m, b)
+}
+func (m *ListOrdersByUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersByUserResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersByUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersByUserResponse.Merge(m, src)
+}
+func (m *ListOrdersByUserResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersByUserResponse.Size(m)
+}
+func (m *ListOrdersByUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersByUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersByUserResponse proto.InternalMessageInfo
+
+func (m *ListOrdersByUserResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
 type AdRequest struct {
 	// List of important key words from the current page describing the context.
 	ContextKeys          []string `protobuf:"bytes,1,rep,name=context_keys,json=contextKeys,proto3" json:"context_keys,omitempty"`
@@ -1430,7 +2096,7 @@ func (m *AdRequest) Reset()         { *m = AdRequest{} }
 func (m *AdRequest) String() string { return proto.CompactTextString(m) }
 func (*AdRequest) ProtoMessage()    {}
 func (*AdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{29}
+	return fileDescriptor_ca53982754088a9d, []int{41}
 }
 
 func (m *AdRequest) XXX_Unmarshal(b []byte) error {
@@ -1469,7 +2135,7 @@ func (m *AdResponse) Reset()         { *m = AdResponse{} }
 func (m *AdResponse) String() string { return proto.CompactTextString(m) }
 func (*AdResponse) ProtoMessage()    {}
 func (*AdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{30}
+	return fileDescriptor_ca53982754088a9d, []int{42}
 }
 
 func (m *AdResponse) XXX_Unmarshal(b []byte) error {
@@ -1511,7 +2177,7 @@ func (m *Ad) Reset()         { *m = Ad{} }
 func (m *Ad) String() string { return proto.CompactTextString(m) }
 func (*Ad) ProtoMessage()    {}
 func (*Ad) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{31}
+	return fileDescriptor_ca53982754088a9d, []int{43}
 }
 
 func (m *Ad) XXX_Unmarshal(b []byte) error {
@@ -1546,7 +2212,126 @@ func (m *Ad) GetText() string {
 	return ""
 }
 
+type CreateRequest struct {
+	// Fully-qualified gRPC service the fault applies to, e.g.
+	// "hipstershop.CartService".
+	Svc string `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	// Full method name to fail, e.g. "/hipstershop.CartService/GetCart".
+	// Empty or "*" matches every method of svc.
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// Percentage of matching calls to affect, between 0 and 100.
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// gRPC status code returned by affected calls. OK (0) only delays them.
+	Code int32 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	// Delay in milliseconds added to affected calls.
+	DelayMs              int64    `protobuf:"varint,5,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{44}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetSvc() string {
+	if m != nil {
+		return m.Svc
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetDelayMs() int64 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
+}
+
+// Removes every fault registered for svc.
+type DeleteRequest struct {
+	Svc                  string   `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{45}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetSvc() string {
+	if m != nil {
+		return m.Svc
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("hipstershop.ShipmentStatus_State", ShipmentStatus_State_name, ShipmentStatus_State_value)
 	proto.RegisterType((*CartItem)(nil), "hipstershop.CartItem")
 	proto.RegisterType((*AddItemRequest)(nil), "hipstershop.AddItemRequest")
 	proto.RegisterType((*EmptyCartRequest)(nil), "hipstershop.EmptyCartRequest")
@@ -1556,14 +2341,19 @@ func init() {
 	proto.RegisterType((*ListRecommendationsRequest)(nil), "hipstershop.ListRecommendationsRequest")
 	proto.RegisterType((*ListRecommendationsResponse)(nil), "hipstershop.ListRecommendationsResponse")
 	proto.RegisterType((*Product)(nil), "hipstershop.Product")
+	proto.RegisterType((*Dimensions)(nil), "hipstershop.Dimensions")
 	proto.RegisterType((*ListProductsResponse)(nil), "hipstershop.ListProductsResponse")
 	proto.RegisterType((*GetProductRequest)(nil), "hipstershop.GetProductRequest")
 	proto.RegisterType((*SearchProductsRequest)(nil), "hipstershop.SearchProductsRequest")
 	proto.RegisterType((*SearchProductsResponse)(nil), "hipstershop.SearchProductsResponse")
 	proto.RegisterType((*GetQuoteRequest)(nil), "hipstershop.GetQuoteRequest")
 	proto.RegisterType((*GetQuoteResponse)(nil), "hipstershop.GetQuoteResponse")
+	proto.RegisterType((*QuoteOption)(nil), "hipstershop.QuoteOption")
 	proto.RegisterType((*ShipOrderRequest)(nil), "hipstershop.ShipOrderRequest")
 	proto.RegisterType((*ShipOrderResponse)(nil), "hipstershop.ShipOrderResponse")
+	proto.RegisterType((*CancelShipmentRequest)(nil), "hipstershop.CancelShipmentRequest")
+	proto.RegisterType((*GetShipmentStatusRequest)(nil), "hipstershop.GetShipmentStatusRequest")
+	proto.RegisterType((*ShipmentStatus)(nil), "hipstershop.ShipmentStatus")
 	proto.RegisterType((*Address)(nil), "hipstershop.Address")
 	proto.RegisterType((*Money)(nil), "hipstershop.Money")
 	proto.RegisterType((*GetSupportedCurrenciesResponse)(nil), "hipstershop.GetSupportedCurrenciesResponse")
@@ -1571,14 +2361,171 @@ func init() {
 	proto.RegisterType((*CreditCardInfo)(nil), "hipstershop.CreditCardInfo")
 	proto.RegisterType((*ChargeRequest)(nil), "hipstershop.ChargeRequest")
 	proto.RegisterType((*ChargeResponse)(nil), "hipstershop.ChargeResponse")
+	proto.RegisterType((*RefundRequest)(nil), "hipstershop.RefundRequest")
+	proto.RegisterType((*RefundResponse)(nil), "hipstershop.RefundResponse")
 	proto.RegisterType((*OrderItem)(nil), "hipstershop.OrderItem")
 	proto.RegisterType((*OrderResult)(nil), "hipstershop.OrderResult")
 	proto.RegisterType((*SendOrderConfirmationRequest)(nil), "hipstershop.SendOrderConfirmationRequest")
 	proto.RegisterType((*PlaceOrderRequest)(nil), "hipstershop.PlaceOrderRequest")
 	proto.RegisterType((*PlaceOrderResponse)(nil), "hipstershop.PlaceOrderResponse")
+	proto.RegisterType((*Order)(nil), "hipstershop.Order")
+	proto.RegisterType((*AddOrderRequest)(nil), "hipstershop.AddOrderRequest")
+	proto.RegisterType((*GetOrderRequest)(nil), "hipstershop.GetOrderRequest")
+	proto.RegisterType((*ListOrdersByUserRequest)(nil), "hipstershop.ListOrdersByUserRequest")
+	proto.RegisterType((*ListOrdersByUserResponse)(nil), "hipstershop.ListOrdersByUserResponse")
 	proto.RegisterType((*AdRequest)(nil), "hipstershop.AdRequest")
 	proto.RegisterType((*AdResponse)(nil), "hipstershop.AdResponse")
 	proto.RegisterType((*Ad)(nil), "hipstershop.Ad")
+	proto.RegisterType((*CreateRequest)(nil), "hipstershop.CreateRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "hipstershop.DeleteRequest")
+}
+
+func init() { proto.RegisterFile("demo.proto", fileDescriptor_ca53982754088a9d) }
+
+var fileDescriptor_ca53982754088a9d = []byte{
+	// 2257 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4d, 0x73, 0x1b, 0x49,
+	0xd5, 0xa3, 0x6f, 0x3d, 0x59, 0xb2, 0xdc, 0xc4, 0x89, 0x22, 0x27, 0xd9, 0xb8, 0x43, 0xb2, 0xce,
+	0x26, 0xf1, 0xa6, 0x0c, 0x55, 0xa1, 0x36, 0x4b, 0x82, 0x76, 0xac, 0x38, 0x22, 0x8e, 0x1d, 0xc6,
+	0xce, 0x66, 0x53, 0x4b, 0xa1, 0x9a, 0xcc, 0x74, 0xac, 0xc1, 0x9a, 0x19, 0x65, 0xa6, 0xc7, 0xbb,
+	0xda, 0xe2, 0x04, 0x14, 0x57, 0x38, 0x73, 0xe4, 0xc8, 0x85, 0x23, 0xff, 0x81, 0x3b, 0x7f, 0x81,
+	0x3b, 0x7f, 0x80, 0xa2, 0xfa, 0x6b, 0x34, 0x33, 0x1e, 0x59, 0xa6, 0xa0, 0x38, 0x69, 0xfa, 0xf5,
+	0xfb, 0xea, 0xd7, 0xef, 0xb3, 0x05, 0x60, 0x13, 0xd7, 0xdf, 0x9a, 0x04, 0x3e, 0xf5, 0x51, 0x63,
+	0xe4, 0x4c, 0x42, 0x4a, 0x82, 0x70, 0xe4, 0x4f, 0x70, 0x1f, 0x6a, 0xba, 0x19, 0xd0, 0x01, 0x25,
+	0x2e, 0xba, 0x0e, 0x30, 0x09, 0x7c, 0x3b, 0xb2, 0xe8, 0xd0, 0xb1, 0x3b, 0xda, 0x4d, 0x6d, 0xb3,
+	0x6e, 0xd4, 0x25, 0x64, 0x60, 0xa3, 0x2e, 0xd4, 0x3e, 0x44, 0xa6, 0x47, 0x1d, 0x3a, 0xed, 0x14,
+	0x6e, 0x6a, 0x9b, 0x65, 0x23, 0x5e, 0xe3, 0x23, 0x68, 0xf5, 0x6c, 0x9b, 0x71, 0x31, 0xc8, 0x87,
+	0x88, 0x84, 0x14, 0x5d, 0x81, 0x6a, 0x14, 0x92, 0x60, 0xc6, 0xa9, 0xc2, 0x96, 0x03, 0x1b, 0xdd,
+	0x85, 0x92, 0x43, 0x89, 0xcb, 0x59, 0x34, 0xb6, 0xd7, 0xb6, 0x12, 0xda, 0x6c, 0x29, 0x55, 0x0c,
+	0x8e, 0x82, 0xef, 0x41, 0xbb, 0xef, 0x4e, 0xe8, 0x94, 0x81, 0x17, 0xf1, 0xc5, 0x77, 0xa1, 0xb5,
+	0x4b, 0xe8, 0x85, 0x50, 0xf7, 0xa0, 0xc4, 0xf0, 0xe6, 0xeb, 0x78, 0x0f, 0xca, 0x4c, 0x81, 0xb0,
+	0x53, 0xb8, 0x59, 0x9c, 0xaf, 0xa4, 0xc0, 0xc1, 0x55, 0x28, 0x73, 0x2d, 0xf1, 0x97, 0xd0, 0xdd,
+	0x73, 0x42, 0x6a, 0x10, 0xcb, 0x77, 0x5d, 0xe2, 0xd9, 0x26, 0x75, 0x7c, 0x2f, 0x5c, 0x68, 0x90,
+	0x8f, 0xa0, 0x31, 0x33, 0xbb, 0x10, 0x59, 0x37, 0x20, 0xb6, 0x7b, 0x88, 0x9f, 0xc0, 0x7a, 0x2e,
+	0xdf, 0x70, 0xe2, 0x7b, 0x21, 0xc9, 0xd2, 0x6b, 0x67, 0xe8, 0xff, 0x50, 0x80, 0xea, 0x2b, 0xb1,
+	0x44, 0x2d, 0x28, 0xc4, 0x0a, 0x14, 0x1c, 0x1b, 0x21, 0x28, 0x79, 0xa6, 0x4b, 0xf8, 0x6d, 0xd4,
+	0x0d, 0xfe, 0x8d, 0x6e, 0x42, 0xc3, 0x26, 0xa1, 0x15, 0x38, 0x13, 0x26, 0xa8, 0x53, 0xe4, 0x5b,
+	0x49, 0x10, 0xea, 0x40, 0x75, 0xe2, 0x58, 0x34, 0x0a, 0x48, 0xa7, 0xc4, 0x77, 0xd5, 0x12, 0x7d,
+	0x0a, 0xf5, 0x49, 0xe0, 0x58, 0x64, 0x18, 0x85, 0x76, 0xa7, 0xcc, 0xaf, 0x18, 0xa5, 0xac, 0xf7,
+	0xd2, 0xf7, 0xc8, 0xd4, 0xa8, 0x71, 0xa4, 0xd7, 0xa1, 0x8d, 0x6e, 0x00, 0x58, 0x26, 0x25, 0xc7,
+	0x7e, 0xe0, 0x90, 0xb0, 0x53, 0x11, 0xca, 0xcf, 0x20, 0x68, 0x1d, 0xea, 0xdf, 0x10, 0xe7, 0x78,
+	0x44, 0x87, 0x27, 0xc7, 0x9d, 0xea, 0x4d, 0x6d, 0x53, 0x33, 0x6a, 0x02, 0xf0, 0xe2, 0x18, 0x3d,
+	0x02, 0xb0, 0x1d, 0x97, 0x78, 0x21, 0x33, 0x48, 0xa7, 0xc6, 0xc5, 0x5d, 0x49, 0x89, 0xdb, 0x89,
+	0xb7, 0x8d, 0x04, 0x2a, 0x36, 0x01, 0x66, 0x3b, 0x4c, 0xc6, 0x98, 0x78, 0xc7, 0x74, 0x34, 0xb4,
+	0x5c, 0x6e, 0x1b, 0xcd, 0xa8, 0x09, 0x80, 0xee, 0xa2, 0xab, 0x50, 0xfb, 0xc6, 0xb1, 0xc5, 0x5e,
+	0x81, 0xef, 0x55, 0xf9, 0x5a, 0x77, 0x19, 0xdd, 0x48, 0xe8, 0x66, 0xb9, 0xdc, 0x4c, 0x9a, 0x51,
+	0x13, 0x00, 0xdd, 0xc5, 0xcf, 0xe1, 0x12, 0xbb, 0x35, 0x69, 0xf8, 0xd9, 0x75, 0x3d, 0x84, 0x9a,
+	0xbc, 0x1b, 0x71, 0x57, 0x8d, 0xed, 0x4b, 0x29, 0x8d, 0x25, 0x81, 0x11, 0x63, 0xe1, 0x5b, 0xb0,
+	0xba, 0x4b, 0x14, 0x23, 0xe5, 0x4e, 0x99, 0x8b, 0xc4, 0x0f, 0x60, 0xed, 0x90, 0x98, 0x81, 0x35,
+	0x9a, 0x09, 0x14, 0x88, 0x97, 0xa0, 0xfc, 0x21, 0x22, 0xc1, 0x54, 0xe2, 0x8a, 0x05, 0x7e, 0x0e,
+	0x97, 0xb3, 0xe8, 0x52, 0xbf, 0x2d, 0xa8, 0x06, 0x24, 0x8c, 0xc6, 0x0b, 0xd4, 0x53, 0x48, 0xd8,
+	0x83, 0x95, 0x5d, 0x42, 0x7f, 0x16, 0xf9, 0x94, 0x28, 0x91, 0x5b, 0x50, 0x35, 0x6d, 0x3b, 0x20,
+	0x61, 0xc8, 0x85, 0x66, 0x59, 0xf4, 0xc4, 0x9e, 0xa1, 0x90, 0xfe, 0xb3, 0x70, 0x8b, 0xa0, 0x3d,
+	0x93, 0x27, 0x75, 0x7e, 0x00, 0x35, 0xcb, 0x0f, 0x29, 0x77, 0x3a, 0x6d, 0xae, 0xd3, 0x55, 0x19,
+	0x0e, 0xf3, 0xb9, 0x6d, 0xa8, 0xfa, 0xdc, 0x91, 0x95, 0xc4, 0x4e, 0x0a, 0x9b, 0xf3, 0x3e, 0xe0,
+	0x08, 0x86, 0x42, 0xc4, 0xbf, 0xd5, 0xa0, 0x91, 0xd8, 0x40, 0xb7, 0xa0, 0x19, 0x92, 0xe0, 0x94,
+	0xb9, 0xfa, 0x98, 0x9c, 0x92, 0xb1, 0x34, 0xef, 0xb2, 0x04, 0xee, 0x31, 0x58, 0x4a, 0xaf, 0xc2,
+	0x62, 0xbd, 0x36, 0x60, 0x99, 0x06, 0xa6, 0x17, 0x3a, 0x74, 0x68, 0x9b, 0xd3, 0x90, 0xbb, 0x54,
+	0xd9, 0x68, 0x48, 0xd8, 0x8e, 0x39, 0x0d, 0xb1, 0x0f, 0xed, 0xc3, 0x91, 0x33, 0x39, 0x08, 0x6c,
+	0x12, 0xfc, 0x5f, 0xcc, 0xfd, 0x43, 0x58, 0x4d, 0x08, 0x9c, 0xa5, 0x1c, 0x1a, 0x98, 0xd6, 0x89,
+	0xe3, 0x1d, 0xcf, 0xf2, 0x19, 0x28, 0xd0, 0xc0, 0xc6, 0x3f, 0x82, 0x35, 0xdd, 0xf4, 0x2c, 0x32,
+	0x66, 0xb4, 0x2e, 0xf1, 0x62, 0xb7, 0x5d, 0x48, 0xf9, 0x18, 0x3a, 0xbb, 0x84, 0x2a, 0xb2, 0x43,
+	0x6a, 0xd2, 0x28, 0xbc, 0x30, 0xf1, 0xdf, 0x0b, 0xd0, 0x4a, 0x93, 0x2e, 0xa4, 0x41, 0x8f, 0xa0,
+	0x1c, 0x52, 0x93, 0x8a, 0x14, 0xd8, 0xda, 0xde, 0x48, 0x59, 0x23, 0xcd, 0x6c, 0x8b, 0xfd, 0x10,
+	0x43, 0xe0, 0xb3, 0x72, 0x19, 0x4d, 0x6c, 0x93, 0x12, 0x7b, 0x68, 0x52, 0x7e, 0x57, 0x45, 0xa3,
+	0x2e, 0x21, 0x3d, 0x8a, 0x1e, 0x00, 0x22, 0x21, 0x75, 0x5c, 0x8e, 0x60, 0x93, 0xb1, 0x73, 0xca,
+	0x82, 0xb0, 0xc4, 0xd1, 0x56, 0xe3, 0x9d, 0x1d, 0xb9, 0x91, 0xbc, 0xc4, 0xf2, 0x05, 0x2e, 0x11,
+	0x9f, 0x40, 0x99, 0x6b, 0x83, 0x1a, 0x50, 0x7d, 0xbd, 0xff, 0x62, 0xff, 0xe0, 0xcd, 0x7e, 0x7b,
+	0x09, 0xad, 0x42, 0x73, 0xaf, 0xf7, 0x45, 0x7f, 0x6f, 0xa8, 0x1b, 0xfd, 0xde, 0x51, 0x7f, 0xa7,
+	0xad, 0xa1, 0x16, 0xc0, 0x60, 0x7f, 0x78, 0x64, 0xf4, 0xf6, 0x0f, 0x07, 0x47, 0xed, 0x02, 0xba,
+	0x04, 0xed, 0x83, 0xd7, 0x47, 0xc3, 0x67, 0x07, 0xc6, 0x70, 0xa7, 0xbf, 0x37, 0xf8, 0xb2, 0x6f,
+	0xbc, 0x6d, 0x17, 0x51, 0x13, 0xea, 0x72, 0xd5, 0xdf, 0x69, 0x97, 0xd8, 0x52, 0xef, 0xed, 0xeb,
+	0xfd, 0xbd, 0xbd, 0xfe, 0x4e, 0xbb, 0x8c, 0x7f, 0xaf, 0x41, 0x55, 0x6a, 0x80, 0x6e, 0x43, 0x2b,
+	0xa4, 0x01, 0x21, 0x74, 0x98, 0x74, 0xba, 0xba, 0xd1, 0x14, 0x50, 0x85, 0x86, 0xa0, 0x64, 0xa9,
+	0x4e, 0xa1, 0x6e, 0xf0, 0x6f, 0x96, 0x8a, 0x84, 0xa9, 0x45, 0x49, 0x11, 0x0b, 0x56, 0x4c, 0x2c,
+	0x3f, 0xf2, 0xa8, 0xb4, 0x4e, 0xdd, 0x50, 0x4b, 0x96, 0x7a, 0xbf, 0x73, 0x26, 0x43, 0xcb, 0xb7,
+	0x09, 0x37, 0x4a, 0xd9, 0xa8, 0x7e, 0xe7, 0x4c, 0x74, 0xdf, 0x26, 0xf8, 0x2b, 0x28, 0xf3, 0xe0,
+	0x61, 0x71, 0x68, 0x45, 0x41, 0x40, 0x3c, 0x6b, 0x2a, 0x10, 0x65, 0x1c, 0x2a, 0x20, 0xc3, 0x66,
+	0x82, 0x23, 0xcf, 0xa1, 0x21, 0xd7, 0xa6, 0x68, 0x88, 0x05, 0x83, 0x7a, 0xa6, 0xe7, 0xab, 0x38,
+	0x13, 0x0b, 0xbc, 0x0b, 0x37, 0x98, 0x03, 0x46, 0x93, 0x89, 0x1f, 0x50, 0x62, 0xeb, 0x82, 0x8f,
+	0x43, 0x66, 0x19, 0xf2, 0x36, 0xb4, 0x52, 0x22, 0x55, 0xcd, 0x6d, 0x26, 0x65, 0x86, 0xf8, 0xe7,
+	0x70, 0x55, 0x8f, 0x01, 0xde, 0x29, 0x09, 0x58, 0xb1, 0x51, 0xae, 0x7c, 0x07, 0x4a, 0xef, 0x03,
+	0xdf, 0x3d, 0x27, 0x5b, 0xf1, 0x7d, 0xd6, 0x35, 0x50, 0x5f, 0x1c, 0x4c, 0x58, 0xb2, 0x42, 0x7d,
+	0x6e, 0x80, 0x7f, 0x68, 0xd0, 0xd2, 0x03, 0x62, 0x3b, 0xac, 0xe5, 0xb1, 0x07, 0xde, 0x7b, 0x1f,
+	0xdd, 0x07, 0x64, 0x71, 0xc8, 0xd0, 0x32, 0x03, 0x7b, 0xe8, 0x45, 0xee, 0x3b, 0x12, 0x48, 0x7b,
+	0xb4, 0xad, 0x18, 0x77, 0x9f, 0xc3, 0xd1, 0x1d, 0x58, 0x49, 0x62, 0x5b, 0xa7, 0xa7, 0xb2, 0xab,
+	0x6b, 0xce, 0x50, 0xf5, 0xd3, 0x53, 0xf4, 0x63, 0x58, 0x4f, 0xe2, 0x91, 0x6f, 0x27, 0x4e, 0xc0,
+	0x3b, 0x90, 0xe1, 0x94, 0x98, 0x81, 0xb4, 0x5d, 0x67, 0x46, 0xd3, 0x8f, 0x11, 0xde, 0x12, 0x33,
+	0x40, 0x4f, 0xe1, 0xda, 0x1c, 0x72, 0xd7, 0xf7, 0xe8, 0x88, 0x5f, 0x79, 0xd9, 0xb8, 0x9a, 0x47,
+	0xff, 0x92, 0x21, 0xe0, 0x3f, 0x69, 0xd0, 0xd4, 0x47, 0x66, 0x70, 0x1c, 0x97, 0x97, 0x4f, 0xa0,
+	0x62, 0xba, 0xcc, 0x45, 0xce, 0xb1, 0x9e, 0xc4, 0x40, 0x9f, 0x43, 0x23, 0x21, 0x5e, 0x26, 0xe1,
+	0xf5, 0x74, 0xc6, 0x4b, 0x59, 0xd1, 0x80, 0x99, 0x2a, 0xe8, 0x63, 0x58, 0x71, 0x6c, 0xe2, 0x4e,
+	0x7c, 0xca, 0x2f, 0xfb, 0x84, 0x4c, 0xa5, 0xeb, 0xb6, 0x12, 0xe0, 0x17, 0x64, 0x8a, 0x1f, 0x41,
+	0x4b, 0xe9, 0x38, 0x73, 0x12, 0x9e, 0xb7, 0x4d, 0x8b, 0x1f, 0x36, 0x4e, 0x3d, 0xcd, 0x04, 0x74,
+	0x60, 0xe3, 0x77, 0xd0, 0x34, 0xc8, 0xfb, 0xc8, 0xb3, 0xd5, 0xe1, 0x2e, 0x46, 0x97, 0xb0, 0x41,
+	0x61, 0x91, 0x0d, 0xf0, 0x03, 0x68, 0x29, 0x19, 0x52, 0xb9, 0x75, 0xa8, 0x07, 0x1c, 0x32, 0xe3,
+	0x5f, 0x13, 0x80, 0x81, 0x8d, 0x7f, 0x01, 0x75, 0x9e, 0xed, 0xf9, 0x4c, 0xa0, 0xba, 0x75, 0x6d,
+	0x61, 0xb7, 0xce, 0x5c, 0x9a, 0x15, 0xb2, 0x73, 0x14, 0xe2, 0xfb, 0xf8, 0xd7, 0x05, 0x68, 0xa8,
+	0x72, 0x12, 0x8d, 0x29, 0x8b, 0x72, 0x9f, 0x2d, 0x67, 0xba, 0x54, 0xf9, 0x7a, 0x60, 0xa3, 0x87,
+	0x70, 0x29, 0x1c, 0x39, 0x93, 0x09, 0x4b, 0xde, 0xc9, 0x2c, 0x2e, 0x42, 0x01, 0xa9, 0xbd, 0xa3,
+	0x64, 0x36, 0x6f, 0xc6, 0x14, 0x5c, 0x9b, 0xe2, 0x5c, 0x6d, 0x96, 0x15, 0xa2, 0xee, 0x87, 0x14,
+	0x3d, 0x85, 0x76, 0x4c, 0xa8, 0x12, 0x5b, 0xe9, 0x9c, 0x44, 0xbc, 0xa2, 0xb0, 0x25, 0x00, 0xdd,
+	0x57, 0x55, 0xb5, 0xcc, 0xab, 0xea, 0xe5, 0x14, 0x55, 0x6c, 0x50, 0x55, 0x56, 0x6d, 0xb8, 0x76,
+	0x48, 0x3c, 0x9b, 0xc3, 0x75, 0xdf, 0x7b, 0xef, 0x04, 0x2e, 0xf7, 0xf9, 0x44, 0xd7, 0x46, 0x5c,
+	0xd3, 0x51, 0x6d, 0x85, 0x58, 0xa0, 0x2d, 0x28, 0x73, 0xd3, 0x48, 0x1b, 0x77, 0xce, 0xca, 0x10,
+	0x36, 0x35, 0x04, 0x1a, 0xfe, 0x97, 0x06, 0xab, 0xaf, 0xc6, 0xa6, 0x45, 0x52, 0xfd, 0xc2, 0xdc,
+	0x49, 0xe4, 0x16, 0x34, 0xf9, 0x86, 0xca, 0x63, 0xd2, 0xce, 0xcb, 0x0c, 0xa8, 0x52, 0x59, 0xb2,
+	0x50, 0x15, 0x2f, 0xd2, 0x6d, 0xc4, 0x27, 0x29, 0x27, 0x4f, 0x92, 0x89, 0xcb, 0xca, 0x7f, 0x1d,
+	0x97, 0xd5, 0xdc, 0xb8, 0xdc, 0x01, 0x94, 0x3c, 0x7f, 0xdc, 0xe2, 0x4a, 0x33, 0x6a, 0x17, 0x33,
+	0xe3, 0x5f, 0x34, 0x28, 0x73, 0x30, 0x7a, 0x08, 0x15, 0xd1, 0xf7, 0x2e, 0x24, 0x95, 0x78, 0x49,
+	0x63, 0x17, 0x52, 0xc6, 0x8e, 0xed, 0x52, 0x4c, 0xda, 0x65, 0x13, 0xca, 0xd4, 0xa7, 0xe6, 0xb8,
+	0x53, 0x9a, 0xeb, 0xb7, 0x02, 0x81, 0xc5, 0xf0, 0x84, 0x1d, 0x8d, 0x77, 0x1f, 0x65, 0x5e, 0xd7,
+	0x6a, 0x02, 0xd0, 0xa3, 0xf8, 0x31, 0xac, 0xf4, 0x6c, 0x3b, 0x75, 0xeb, 0x9b, 0xe9, 0x43, 0xa3,
+	0x1c, 0xcd, 0xe5, 0x71, 0xef, 0xf3, 0x8e, 0x3e, 0x45, 0x3c, 0x3f, 0x46, 0xf1, 0x36, 0x5c, 0x61,
+	0x73, 0x0e, 0x47, 0x0f, 0xbf, 0x98, 0xbe, 0x0e, 0x17, 0x3b, 0x1a, 0x7e, 0x06, 0x9d, 0xb3, 0x34,
+	0xf2, 0x72, 0x3e, 0x81, 0x0a, 0x67, 0xad, 0xc6, 0x8f, 0x3c, 0x45, 0x25, 0x06, 0xde, 0x82, 0x7a,
+	0x2f, 0xce, 0x9c, 0x1b, 0xb0, 0x6c, 0xf9, 0x1e, 0x25, 0xdf, 0x52, 0xe6, 0x10, 0xaa, 0x28, 0x37,
+	0x24, 0xec, 0x05, 0x99, 0x86, 0xf8, 0x53, 0x80, 0xde, 0x2c, 0x0b, 0x6e, 0x40, 0xd1, 0xb4, 0x95,
+	0x98, 0x95, 0x8c, 0x17, 0x1b, 0x6c, 0x0f, 0x3f, 0x86, 0x42, 0x8f, 0xf7, 0xe5, 0xcc, 0xf7, 0x02,
+	0x62, 0xd1, 0x61, 0x14, 0xa8, 0x98, 0x6c, 0x28, 0xd8, 0xeb, 0x60, 0xcc, 0xda, 0x1d, 0x26, 0x45,
+	0xb5, 0x3b, 0xec, 0x1b, 0xff, 0x0a, 0x9a, 0x7a, 0x40, 0xcc, 0xd9, 0x5c, 0xd4, 0x86, 0x62, 0x78,
+	0x6a, 0x49, 0x72, 0xf6, 0xc9, 0x20, 0x51, 0xe0, 0x48, 0x2a, 0xf6, 0xc9, 0x47, 0x6b, 0x12, 0x58,
+	0xc4, 0xa3, 0x72, 0xa2, 0x54, 0x4b, 0xde, 0x51, 0xb1, 0x3e, 0x40, 0x54, 0x4c, 0xfe, 0xcd, 0xee,
+	0xc5, 0x26, 0x63, 0x73, 0x3a, 0x74, 0x43, 0xe9, 0x03, 0x55, 0xbe, 0x7e, 0x19, 0xe2, 0x0d, 0x68,
+	0xee, 0x90, 0x31, 0x39, 0x47, 0xfa, 0xf6, 0xdf, 0x34, 0x68, 0xb0, 0x24, 0x7e, 0x28, 0x66, 0x16,
+	0xf4, 0x39, 0xef, 0xf2, 0x78, 0xde, 0x5f, 0xcf, 0x06, 0x75, 0xe2, 0x6d, 0xa7, 0x9b, 0xbe, 0x12,
+	0xf1, 0xf8, 0xb1, 0x84, 0x1e, 0x43, 0x55, 0x3e, 0xc0, 0x64, 0xa8, 0xd3, 0xcf, 0x32, 0xdd, 0xd5,
+	0x33, 0x45, 0x04, 0x2f, 0xa1, 0x9f, 0x40, 0x3d, 0x7e, 0xea, 0x41, 0xd7, 0xcf, 0xf2, 0x4f, 0x32,
+	0xc8, 0x15, 0xbf, 0xfd, 0x1b, 0x0d, 0xd6, 0xd2, 0x4f, 0x24, 0xea, 0x58, 0xbf, 0x84, 0xef, 0xe5,
+	0xbc, 0x9f, 0xa0, 0x8f, 0x53, 0x6c, 0xe6, 0xbf, 0xdc, 0x74, 0x37, 0x17, 0x23, 0x0a, 0x8f, 0x62,
+	0x5a, 0x14, 0x60, 0x4d, 0x8e, 0xc8, 0xba, 0x49, 0xcd, 0xb1, 0x7f, 0xac, 0xb4, 0xd8, 0x85, 0xe5,
+	0xe4, 0x7b, 0x00, 0xca, 0x39, 0x45, 0x77, 0xe3, 0x8c, 0xa4, 0xec, 0x78, 0x8e, 0x97, 0xd0, 0x0e,
+	0xc0, 0xec, 0x39, 0x00, 0xdd, 0xc8, 0x9a, 0x3a, 0xfd, 0x4e, 0xd0, 0xcd, 0x9d, 0xde, 0xf1, 0x12,
+	0xfa, 0x1a, 0x5a, 0xe9, 0x07, 0x00, 0x84, 0xd3, 0x93, 0x4f, 0xde, 0x63, 0x42, 0xf7, 0xd6, 0xb9,
+	0x38, 0xb1, 0x15, 0xfe, 0x5c, 0x84, 0x95, 0x43, 0x59, 0x1f, 0xd5, 0xf9, 0x07, 0x50, 0x53, 0x73,
+	0x3b, 0xba, 0x96, 0x55, 0x3a, 0xf9, 0x7c, 0xd0, 0xbd, 0x3e, 0x67, 0x37, 0xb6, 0xc0, 0x1e, 0xd4,
+	0xe3, 0x99, 0x34, 0xe3, 0x2c, 0xd9, 0xe1, 0xb8, 0x7b, 0x63, 0xde, 0x76, 0xcc, 0xed, 0xa7, 0xd0,
+	0x4a, 0xcf, 0xaa, 0x19, 0x4b, 0xe4, 0x0e, 0xb2, 0x73, 0x62, 0xe0, 0x2d, 0x7f, 0xaa, 0xc9, 0x8c,
+	0xa0, 0xb7, 0xb3, 0xe7, 0xc9, 0x9d, 0x6e, 0xbb, 0xeb, 0xe7, 0x4c, 0x9e, 0x78, 0x09, 0xbd, 0x81,
+	0xe6, 0x1b, 0x93, 0x5a, 0xa3, 0x58, 0xcb, 0xff, 0x09, 0xdb, 0x87, 0xda, 0xf6, 0x5f, 0x35, 0x58,
+	0x51, 0xd5, 0x5d, 0x5d, 0xd6, 0xd7, 0x70, 0x39, 0x7f, 0x08, 0xca, 0x75, 0xdb, 0x7b, 0x67, 0x34,
+	0x99, 0x3f, 0x3d, 0xe1, 0x25, 0xb4, 0x0b, 0x55, 0x31, 0x10, 0x51, 0x74, 0x27, 0x6d, 0xe9, 0x79,
+	0xe3, 0x52, 0x37, 0xa7, 0x0e, 0xe2, 0xa5, 0xed, 0x3f, 0x6a, 0xd0, 0x7a, 0x65, 0x4e, 0xf9, 0x79,
+	0xa4, 0xe2, 0x3a, 0x54, 0x44, 0x23, 0x8e, 0xba, 0x69, 0xd6, 0xc9, 0x09, 0xa2, 0xbb, 0x9e, 0xbb,
+	0x17, 0x2b, 0xa8, 0x43, 0x45, 0x34, 0xcc, 0x19, 0x26, 0xa9, 0x4e, 0xbd, 0xbb, 0x9e, 0xbb, 0x17,
+	0xc7, 0xc0, 0x08, 0x96, 0xfb, 0xac, 0xa4, 0x2b, 0xcd, 0xbe, 0x82, 0xb5, 0xdc, 0x8e, 0x0f, 0xdd,
+	0xcd, 0xc4, 0xd4, 0xfc, 0xae, 0x70, 0x4e, 0xe6, 0x7b, 0x07, 0x2b, 0xfa, 0x88, 0x58, 0x27, 0x7e,
+	0x14, 0x9b, 0xe1, 0x00, 0x60, 0xd6, 0xf7, 0x64, 0x72, 0xc4, 0x99, 0x86, 0xb0, 0xfb, 0xd1, 0xdc,
+	0xfd, 0xf8, 0x34, 0xff, 0xd4, 0x60, 0x99, 0xc3, 0x94, 0x84, 0x27, 0x50, 0x53, 0x1d, 0x46, 0x26,
+	0x9c, 0x33, 0x8d, 0xc7, 0x9c, 0x48, 0x79, 0xc2, 0xd3, 0x41, 0x1e, 0x7d, 0xa6, 0xf7, 0xe8, 0xe6,
+	0x34, 0x00, 0x78, 0x09, 0x99, 0xd0, 0xce, 0xb6, 0x10, 0xe8, 0xfb, 0x67, 0xd2, 0x67, 0x4e, 0x57,
+	0xd2, 0xbd, 0xbd, 0x00, 0x2b, 0x3e, 0xf3, 0x73, 0xd6, 0x5d, 0xa8, 0xf3, 0x3e, 0x86, 0xca, 0x2e,
+	0x7b, 0xdd, 0x08, 0xd1, 0xe5, 0x6c, 0xa7, 0x20, 0xf9, 0x5e, 0x39, 0x03, 0x8f, 0x39, 0xfd, 0x4e,
+	0x83, 0xe5, 0x67, 0x66, 0x34, 0x8e, 0xef, 0xe7, 0x33, 0xa8, 0x88, 0xd6, 0x20, 0xeb, 0xa6, 0xc9,
+	0x7e, 0x61, 0x8e, 0xe5, 0x3e, 0x83, 0x8a, 0x28, 0xec, 0x19, 0xda, 0x54, 0xb5, 0x9f, 0xe3, 0x2a,
+	0x4f, 0xa1, 0x71, 0x44, 0xc2, 0x58, 0x8d, 0x87, 0x50, 0x62, 0xcb, 0xdc, 0xa0, 0xce, 0x65, 0xf0,
+	0xae, 0xc2, 0xff, 0x43, 0xfa, 0xc1, 0xbf, 0x07, 0x00, 0x17, 0x46, 0xa4, 0xfe, 0x51, 0x1a, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1640,6 +2587,20 @@ type CartServiceServer interface {
 	EmptyCart(context.Context, *EmptyCartRequest) (*Empty, error)
 }
 
+// UnimplementedCartServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCartServiceServer struct {
+}
+
+func (*UnimplementedCartServiceServer) AddItem(ctx context.Context, req *AddItemRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (*UnimplementedCartServiceServer) GetCart(ctx context.Context, req *GetCartRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCart not implemented")
+}
+func (*UnimplementedCartServiceServer) EmptyCart(ctx context.Context, req *EmptyCartRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmptyCart not implemented")
+}
+
 func RegisterCartServiceServer(s *grpc.Server, srv CartServiceServer) {
 	s.RegisterService(&_CartService_serviceDesc, srv)
 }
@@ -1748,6 +2709,14 @@ type RecommendationServiceServer interface {
 	ListRecommendations(context.Context, *ListRecommendationsRequest) (*ListRecommendationsResponse, error)
 }
 
+// UnimplementedRecommendationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedRecommendationServiceServer struct {
+}
+
+func (*UnimplementedRecommendationServiceServer) ListRecommendations(ctx context.Context, req *ListRecommendationsRequest) (*ListRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecommendations not implemented")
+}
+
 func RegisterRecommendationServiceServer(s *grpc.Server, srv RecommendationServiceServer) {
 	s.RegisterService(&_RecommendationService_serviceDesc, srv)
 }
@@ -1834,6 +2803,20 @@ type ProductCatalogServiceServer interface {
 	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
 }
 
+// UnimplementedProductCatalogServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedProductCatalogServiceServer struct {
+}
+
+func (*UnimplementedProductCatalogServiceServer) ListProducts(ctx context.Context, req *Empty) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (*UnimplementedProductCatalogServiceServer) GetProduct(ctx context.Context, req *GetProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (*UnimplementedProductCatalogServiceServer) SearchProducts(ctx context.Context, req *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+
 func RegisterProductCatalogServiceServer(s *grpc.Server, srv ProductCatalogServiceServer) {
 	s.RegisterService(&_ProductCatalogService_serviceDesc, srv)
 }
@@ -1919,6 +2902,12 @@ var _ProductCatalogService_serviceDesc = grpc.ServiceDesc{
 type ShippingServiceClient interface {
 	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
 	ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error)
+	CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetShipmentStatus(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (*ShipmentStatus, error)
+	// Streams the status of a shipment whenever it changes, starting with the
+	// current status. The stream ends once the shipment is delivered or
+	// cancelled.
+	WatchShipment(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (ShippingService_WatchShipmentClient, error)
 }
 
 type shippingServiceClient struct {
@@ -1947,10 +2936,86 @@ func (c *shippingServiceClient) ShipOrder(ctx context.Context, in *ShipOrderRequ
 	return out, nil
 }
 
+func (c *shippingServiceClient) CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/CancelShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) GetShipmentStatus(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (*ShipmentStatus, error) {
+	out := new(ShipmentStatus)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/GetShipmentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) WatchShipment(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (ShippingService_WatchShipmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ShippingService_serviceDesc.Streams[0], "/hipstershop.ShippingService/WatchShipment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shippingServiceWatchShipmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShippingService_WatchShipmentClient interface {
+	Recv() (*ShipmentStatus, error)
+	grpc.ClientStream
+}
+
+type shippingServiceWatchShipmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *shippingServiceWatchShipmentClient) Recv() (*ShipmentStatus, error) {
+	m := new(ShipmentStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ShippingServiceServer is the server API for ShippingService service.
 type ShippingServiceServer interface {
 	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
 	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+	CancelShipment(context.Context, *CancelShipmentRequest) (*Empty, error)
+	GetShipmentStatus(context.Context, *GetShipmentStatusRequest) (*ShipmentStatus, error)
+	// Streams the status of a shipment whenever it changes, starting with the
+	// current status. The stream ends once the shipment is delivered or
+	// cancelled.
+	WatchShipment(*GetShipmentStatusRequest, ShippingService_WatchShipmentServer) error
+}
+
+// UnimplementedShippingServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedShippingServiceServer struct {
+}
+
+func (*UnimplementedShippingServiceServer) GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (*UnimplementedShippingServiceServer) ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipOrder not implemented")
+}
+func (*UnimplementedShippingServiceServer) CancelShipment(ctx context.Context, req *CancelShipmentRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelShipment not implemented")
+}
+func (*UnimplementedShippingServiceServer) GetShipmentStatus(ctx context.Context, req *GetShipmentStatusRequest) (*ShipmentStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShipmentStatus not implemented")
+}
+func (*UnimplementedShippingServiceServer) WatchShipment(req *GetShipmentStatusRequest, srv ShippingService_WatchShipmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchShipment not implemented")
 }
 
 func RegisterShippingServiceServer(s *grpc.Server, srv ShippingServiceServer) {
@@ -1993,6 +3058,63 @@ func _ShippingService_ShipOrder_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShippingService_CancelShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).CancelShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/CancelShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).CancelShipment(ctx, req.(*CancelShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_GetShipmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).GetShipmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/GetShipmentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).GetShipmentStatus(ctx, req.(*GetShipmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_WatchShipment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetShipmentStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShippingServiceServer).WatchShipment(m, &shippingServiceWatchShipmentServer{stream})
+}
+
+type ShippingService_WatchShipmentServer interface {
+	Send(*ShipmentStatus) error
+	grpc.ServerStream
+}
+
+type shippingServiceWatchShipmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *shippingServiceWatchShipmentServer) Send(m *ShipmentStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _ShippingService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hipstershop.ShippingService",
 	HandlerType: (*ShippingServiceServer)(nil),
@@ -2005,8 +3127,22 @@ var _ShippingService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ShipOrder",
 			Handler:    _ShippingService_ShipOrder_Handler,
 		},
+		{
+			MethodName: "CancelShipment",
+			Handler:    _ShippingService_CancelShipment_Handler,
+		},
+		{
+			MethodName: "GetShipmentStatus",
+			Handler:    _ShippingService_GetShipmentStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchShipment",
+			Handler:       _ShippingService_WatchShipment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
@@ -2050,6 +3186,17 @@ type CurrencyServiceServer interface {
 	Convert(context.Context, *CurrencyConversionRequest) (*Money, error)
 }
 
+// UnimplementedCurrencyServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCurrencyServiceServer struct {
+}
+
+func (*UnimplementedCurrencyServiceServer) GetSupportedCurrencies(ctx context.Context, req *Empty) (*GetSupportedCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportedCurrencies not implemented")
+}
+func (*UnimplementedCurrencyServiceServer) Convert(ctx context.Context, req *CurrencyConversionRequest) (*Money, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+
 func RegisterCurrencyServiceServer(s *grpc.Server, srv CurrencyServiceServer) {
 	s.RegisterService(&_CurrencyService_serviceDesc, srv)
 }
@@ -2112,6 +3259,7 @@ var _CurrencyService_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type PaymentServiceClient interface {
 	Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -2131,9 +3279,30 @@ func (c *paymentServiceClient) Charge(ctx context.Context, in *ChargeRequest, op
 	return out, nil
 }
 
+func (c *paymentServiceClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Refund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 type PaymentServiceServer interface {
 	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
+	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
+}
+
+// UnimplementedPaymentServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPaymentServiceServer struct {
+}
+
+func (*UnimplementedPaymentServiceServer) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Charge not implemented")
+}
+func (*UnimplementedPaymentServiceServer) Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
 }
 
 func RegisterPaymentServiceServer(s *grpc.Server, srv PaymentServiceServer) {
@@ -2158,6 +3327,24 @@ func _PaymentService_Charge_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.PaymentService/Refund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PaymentService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hipstershop.PaymentService",
 	HandlerType: (*PaymentServiceServer)(nil),
@@ -2166,6 +3353,10 @@ var _PaymentService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Charge",
 			Handler:    _PaymentService_Charge_Handler,
 		},
+		{
+			MethodName: "Refund",
+			Handler:    _PaymentService_Refund_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
@@ -2200,6 +3391,14 @@ type EmailServiceServer interface {
 	SendOrderConfirmation(context.Context, *SendOrderConfirmationRequest) (*Empty, error)
 }
 
+// UnimplementedEmailServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEmailServiceServer struct {
+}
+
+func (*UnimplementedEmailServiceServer) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOrderConfirmation not implemented")
+}
+
 func RegisterEmailServiceServer(s *grpc.Server, srv EmailServiceServer) {
 	s.RegisterService(&_EmailService_serviceDesc, srv)
 }
@@ -2264,6 +3463,14 @@ type CheckoutServiceServer interface {
 	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
 }
 
+// UnimplementedCheckoutServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCheckoutServiceServer struct {
+}
+
+func (*UnimplementedCheckoutServiceServer) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+
 func RegisterCheckoutServiceServer(s *grpc.Server, srv CheckoutServiceServer) {
 	s.RegisterService(&_CheckoutService_serviceDesc, srv)
 }
@@ -2299,6 +3506,150 @@ var _CheckoutService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "demo.proto",
 }
 
+// OrderServiceClient is the client API for OrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OrderServiceClient interface {
+	AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	ListOrdersByUser(ctx context.Context, in *ListOrdersByUserRequest, opts ...grpc.CallOption) (*ListOrdersByUserResponse, error)
+}
+
+type orderServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrderServiceClient(cc *grpc.ClientConn) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/AddOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListOrdersByUser(ctx context.Context, in *ListOrdersByUserRequest, opts ...grpc.CallOption) (*ListOrdersByUserResponse, error) {
+	out := new(ListOrdersByUserResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/ListOrdersByUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderServiceServer is the server API for OrderService service.
+type OrderServiceServer interface {
+	AddOrder(context.Context, *AddOrderRequest) (*Empty, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	ListOrdersByUser(context.Context, *ListOrdersByUserRequest) (*ListOrdersByUserResponse, error)
+}
+
+// UnimplementedOrderServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOrderServiceServer struct {
+}
+
+func (*UnimplementedOrderServiceServer) AddOrder(ctx context.Context, req *AddOrderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrder not implemented")
+}
+func (*UnimplementedOrderServiceServer) GetOrder(ctx context.Context, req *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedOrderServiceServer) ListOrdersByUser(ctx context.Context, req *ListOrdersByUserRequest) (*ListOrdersByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrdersByUser not implemented")
+}
+
+func RegisterOrderServiceServer(s *grpc.Server, srv OrderServiceServer) {
+	s.RegisterService(&_OrderService_serviceDesc, srv)
+}
+
+func _OrderService_AddOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/AddOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddOrder(ctx, req.(*AddOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListOrdersByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrdersByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/ListOrdersByUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrdersByUser(ctx, req.(*ListOrdersByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OrderService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddOrder",
+			Handler:    _OrderService_AddOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrdersByUser",
+			Handler:    _OrderService_ListOrdersByUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
 // AdServiceClient is the client API for AdService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
@@ -2328,6 +3679,14 @@ type AdServiceServer interface {
 	GetAds(context.Context, *AdRequest) (*AdResponse, error)
 }
 
+// UnimplementedAdServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdServiceServer struct {
+}
+
+func (*UnimplementedAdServiceServer) GetAds(ctx context.Context, req *AdRequest) (*AdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAds not implemented")
+}
+
 func RegisterAdServiceServer(s *grpc.Server, srv AdServiceServer) {
 	s.RegisterService(&_AdService_serviceDesc, srv)
 }
@@ -2363,102 +3722,182 @@ var _AdService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "demo.proto",
 }
 
-func init() { proto.RegisterFile("demo.proto", fileDescriptor_ca53982754088a9d) }
+// FaultServiceClient is the client API for FaultService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FaultServiceClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+}
 
-var fileDescriptor_ca53982754088a9d = []byte{
-	// 1500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xef, 0x72, 0x13, 0xb7,
-	0x16, 0xcf, 0x26, 0xb1, 0x1d, 0x1f, 0xc7, 0x4e, 0xa2, 0x9b, 0x04, 0xb3, 0x81, 0x10, 0x94, 0x81,
-	0x0b, 0x17, 0x08, 0x4c, 0xee, 0x9d, 0xe1, 0x03, 0xdc, 0xd2, 0x8c, 0xc9, 0x18, 0x4f, 0xa1, 0xd0,
-	0x0d, 0xe9, 0xd0, 0xa1, 0x53, 0xcf, 0xb2, 0x12, 0xf1, 0x96, 0xec, 0x6a, 0x91, 0xb4, 0x19, 0xcc,
-	0xc7, 0xf6, 0x01, 0xfa, 0x1e, 0x7d, 0x81, 0xce, 0xf4, 0x11, 0xfa, 0xbd, 0xaf, 0xd0, 0xe7, 0xe8,
-	0x48, 0xbb, 0xda, 0x7f, 0xb1, 0x13, 0xf8, 0xd2, 0x6f, 0xab, 0xa3, 0x9f, 0xce, 0xf9, 0xe9, 0xe8,
-	0xfc, 0xb3, 0x01, 0x08, 0x0d, 0xd8, 0x4e, 0xc4, 0x99, 0x64, 0xa8, 0x35, 0xf2, 0x23, 0x21, 0x29,
-	0x17, 0x23, 0x16, 0xe1, 0x7d, 0x58, 0xe8, 0xb9, 0x5c, 0x0e, 0x24, 0x0d, 0xd0, 0x65, 0x80, 0x88,
-	0x33, 0x12, 0x7b, 0x72, 0xe8, 0x93, 0xae, 0xb5, 0x65, 0xdd, 0x68, 0x3a, 0xcd, 0x54, 0x32, 0x20,
-	0xc8, 0x86, 0x85, 0xf7, 0xb1, 0x1b, 0x4a, 0x5f, 0x8e, 0xbb, 0xb3, 0x5b, 0xd6, 0x8d, 0x9a, 0x93,
-	0xad, 0xf1, 0x4b, 0xe8, 0xec, 0x11, 0xa2, 0xb4, 0x38, 0xf4, 0x7d, 0x4c, 0x85, 0x44, 0x17, 0xa0,
-	0x11, 0x0b, 0xca, 0x73, 0x4d, 0x75, 0xb5, 0x1c, 0x10, 0x74, 0x13, 0xe6, 0x7d, 0x49, 0x03, 0xad,
-	0xa2, 0xb5, 0xbb, 0xb6, 0x53, 0x60, 0xb3, 0x63, 0xa8, 0x38, 0x1a, 0x82, 0x6f, 0xc1, 0xf2, 0x7e,
-	0x10, 0xc9, 0xb1, 0x12, 0x9f, 0xa7, 0x17, 0xdf, 0x84, 0x4e, 0x9f, 0xca, 0x4f, 0x82, 0x3e, 0x85,
-	0x79, 0x85, 0x9b, 0xce, 0xf1, 0x16, 0xd4, 0x14, 0x01, 0xd1, 0x9d, 0xdd, 0x9a, 0x9b, 0x4e, 0x32,
-	0xc1, 0xe0, 0x06, 0xd4, 0x34, 0x4b, 0xfc, 0x2d, 0xd8, 0x4f, 0x7d, 0x21, 0x1d, 0xea, 0xb1, 0x20,
-	0xa0, 0x21, 0x71, 0xa5, 0xcf, 0x42, 0x71, 0xae, 0x43, 0xae, 0x40, 0x2b, 0x77, 0x7b, 0x62, 0xb2,
-	0xe9, 0x40, 0xe6, 0x77, 0x81, 0xbf, 0x80, 0x8d, 0x89, 0x7a, 0x45, 0xc4, 0x42, 0x41, 0xab, 0xe7,
-	0xad, 0x53, 0xe7, 0x7f, 0xb7, 0xa0, 0xf1, 0x22, 0x59, 0xa2, 0x0e, 0xcc, 0x66, 0x04, 0x66, 0x7d,
-	0x82, 0x10, 0xcc, 0x87, 0x6e, 0x40, 0xf5, 0x6b, 0x34, 0x1d, 0xfd, 0x8d, 0xb6, 0xa0, 0x45, 0xa8,
-	0xf0, 0xb8, 0x1f, 0x29, 0x43, 0xdd, 0x39, 0xbd, 0x55, 0x14, 0xa1, 0x2e, 0x34, 0x22, 0xdf, 0x93,
-	0x31, 0xa7, 0xdd, 0x79, 0xbd, 0x6b, 0x96, 0xe8, 0x2e, 0x34, 0x23, 0xee, 0x7b, 0x74, 0x18, 0x0b,
-	0xd2, 0xad, 0xe9, 0x27, 0x46, 0x25, 0xef, 0x3d, 0x63, 0x21, 0x1d, 0x3b, 0x0b, 0x1a, 0x74, 0x28,
-	0x08, 0xda, 0x04, 0xf0, 0x5c, 0x49, 0x8f, 0x18, 0xf7, 0xa9, 0xe8, 0xd6, 0x13, 0xf2, 0xb9, 0x04,
-	0x3f, 0x81, 0x55, 0x75, 0xf9, 0x94, 0x7f, 0x7e, 0xeb, 0x7b, 0xb0, 0x90, 0x5e, 0x31, 0xb9, 0x72,
-	0x6b, 0x77, 0xb5, 0x64, 0x27, 0x3d, 0xe0, 0x64, 0x28, 0xbc, 0x0d, 0x2b, 0x7d, 0x6a, 0x14, 0x99,
-	0x57, 0xa9, 0xf8, 0x03, 0xdf, 0x81, 0xb5, 0x03, 0xea, 0x72, 0x6f, 0x94, 0x1b, 0x4c, 0x80, 0xab,
-	0x50, 0x7b, 0x1f, 0x53, 0x3e, 0x4e, 0xb1, 0xc9, 0x02, 0x3f, 0x81, 0xf5, 0x2a, 0x3c, 0xe5, 0xb7,
-	0x03, 0x0d, 0x4e, 0x45, 0x7c, 0x7c, 0x0e, 0x3d, 0x03, 0xc2, 0x21, 0x2c, 0xf5, 0xa9, 0xfc, 0x26,
-	0x66, 0x92, 0x1a, 0x93, 0x3b, 0xd0, 0x70, 0x09, 0xe1, 0x54, 0x08, 0x6d, 0xb4, 0xaa, 0x62, 0x2f,
-	0xd9, 0x73, 0x0c, 0xe8, 0xf3, 0xa2, 0x76, 0x0f, 0x96, 0x73, 0x7b, 0x29, 0xe7, 0x3b, 0xb0, 0xe0,
-	0x31, 0x21, 0xf5, 0xdb, 0x59, 0x53, 0xdf, 0xae, 0xa1, 0x30, 0x87, 0x82, 0x60, 0x06, 0xcb, 0x07,
-	0x23, 0x3f, 0x7a, 0xce, 0x09, 0xe5, 0xff, 0x08, 0xe7, 0xff, 0xc1, 0x4a, 0xc1, 0x60, 0x1e, 0xfe,
-	0x92, 0xbb, 0xde, 0x3b, 0x3f, 0x3c, 0xca, 0x73, 0x0b, 0x8c, 0x68, 0x40, 0xf0, 0x2f, 0x16, 0x34,
-	0x52, 0xbb, 0xe8, 0x1a, 0x74, 0x84, 0xe4, 0x94, 0xca, 0x61, 0x91, 0x65, 0xd3, 0x69, 0x27, 0x52,
-	0x03, 0x43, 0x30, 0xef, 0x99, 0x32, 0xd7, 0x74, 0xf4, 0xb7, 0x0a, 0x00, 0x21, 0x5d, 0x49, 0xd3,
-	0x7c, 0x48, 0x16, 0x2a, 0x13, 0x3c, 0x16, 0x87, 0x92, 0x8f, 0x4d, 0x26, 0xa4, 0x4b, 0x74, 0x11,
-	0x16, 0x3e, 0xfa, 0xd1, 0xd0, 0x63, 0x84, 0xea, 0x44, 0xa8, 0x39, 0x8d, 0x8f, 0x7e, 0xd4, 0x63,
-	0x84, 0xe2, 0x57, 0x50, 0xd3, 0xae, 0x44, 0xdb, 0xd0, 0xf6, 0x62, 0xce, 0x69, 0xe8, 0x8d, 0x13,
-	0x60, 0xc2, 0x66, 0xd1, 0x08, 0x15, 0x5a, 0x19, 0x8e, 0x43, 0x5f, 0x0a, 0xcd, 0x66, 0xce, 0x49,
-	0x16, 0x4a, 0x1a, 0xba, 0x21, 0x13, 0x9a, 0x4e, 0xcd, 0x49, 0x16, 0xb8, 0x0f, 0x9b, 0x7d, 0x2a,
-	0x0f, 0xe2, 0x28, 0x62, 0x5c, 0x52, 0xd2, 0x4b, 0xf4, 0xf8, 0x34, 0x8f, 0xcb, 0x6b, 0xd0, 0x29,
-	0x99, 0x34, 0x05, 0xa3, 0x5d, 0xb4, 0x29, 0xf0, 0xf7, 0x70, 0xb1, 0x97, 0x09, 0xc2, 0x13, 0xca,
-	0x85, 0xcf, 0x42, 0xf3, 0xc8, 0xd7, 0x61, 0xfe, 0x2d, 0x67, 0xc1, 0x19, 0x31, 0xa2, 0xf7, 0x55,
-	0xc9, 0x93, 0x2c, 0xb9, 0x58, 0xe2, 0xc9, 0xba, 0x64, 0xda, 0x01, 0x7f, 0x59, 0xd0, 0xe9, 0x71,
-	0x4a, 0x7c, 0x55, 0xaf, 0xc9, 0x20, 0x7c, 0xcb, 0xd0, 0x6d, 0x40, 0x9e, 0x96, 0x0c, 0x3d, 0x97,
-	0x93, 0x61, 0x18, 0x07, 0x6f, 0x28, 0x4f, 0xfd, 0xb1, 0xec, 0x65, 0xd8, 0xaf, 0xb5, 0x1c, 0x5d,
-	0x87, 0xa5, 0x22, 0xda, 0x3b, 0x39, 0x49, 0x5b, 0x52, 0x3b, 0x87, 0xf6, 0x4e, 0x4e, 0xd0, 0xff,
-	0x61, 0xa3, 0x88, 0xa3, 0x1f, 0x22, 0x9f, 0xeb, 0xf2, 0x39, 0x1c, 0x53, 0x97, 0xa7, 0xbe, 0xeb,
-	0xe6, 0x67, 0xf6, 0x33, 0xc0, 0x77, 0xd4, 0xe5, 0xe8, 0x11, 0x5c, 0x9a, 0x72, 0x3c, 0x60, 0xa1,
-	0x1c, 0xe9, 0x27, 0xaf, 0x39, 0x17, 0x27, 0x9d, 0x7f, 0xa6, 0x00, 0x78, 0x0c, 0xed, 0xde, 0xc8,
-	0xe5, 0x47, 0x59, 0x4e, 0xff, 0x07, 0xea, 0x6e, 0xa0, 0x22, 0xe4, 0x0c, 0xe7, 0xa5, 0x08, 0xf4,
-	0x10, 0x5a, 0x05, 0xeb, 0x69, 0xc3, 0xdc, 0x28, 0x67, 0x48, 0xc9, 0x89, 0x0e, 0xe4, 0x4c, 0xf0,
-	0x7d, 0xe8, 0x18, 0xd3, 0xf9, 0xd3, 0x4b, 0xee, 0x86, 0xc2, 0xf5, 0xf4, 0x15, 0xb2, 0x64, 0x69,
-	0x17, 0xa4, 0x03, 0x82, 0x7f, 0x80, 0xa6, 0xce, 0x30, 0x3d, 0x13, 0x98, 0x6e, 0x6d, 0x9d, 0xdb,
-	0xad, 0x55, 0x54, 0xa8, 0xca, 0x90, 0xf2, 0x9c, 0x18, 0x15, 0x6a, 0x1f, 0xff, 0x34, 0x0b, 0x2d,
-	0x93, 0xc2, 0xf1, 0xb1, 0x54, 0x89, 0xc2, 0xd4, 0x32, 0x27, 0xd4, 0xd0, 0xeb, 0x01, 0x41, 0xf7,
-	0x60, 0x55, 0x8c, 0xfc, 0x28, 0x52, 0xb9, 0x5d, 0x4c, 0xf2, 0x24, 0x9a, 0x90, 0xd9, 0x7b, 0x99,
-	0x25, 0x3b, 0xba, 0x0f, 0xed, 0xec, 0x84, 0x66, 0x33, 0x37, 0x95, 0xcd, 0xa2, 0x01, 0xf6, 0x98,
-	0x90, 0xe8, 0x11, 0x2c, 0x67, 0x07, 0x4d, 0x6d, 0x98, 0x3f, 0xa3, 0x82, 0x2d, 0x19, 0xb4, 0xa9,
-	0x19, 0xb7, 0x4d, 0x25, 0xab, 0xe9, 0x4a, 0xb6, 0x5e, 0x3a, 0x95, 0x39, 0xd4, 0x94, 0x32, 0x02,
-	0x97, 0x0e, 0x68, 0x48, 0xb4, 0xbc, 0xc7, 0xc2, 0xb7, 0x3e, 0x0f, 0x74, 0xd8, 0x14, 0xda, 0x0d,
-	0x0d, 0x5c, 0xff, 0xd8, 0xb4, 0x1b, 0xbd, 0x40, 0x3b, 0x50, 0xd3, 0xae, 0x49, 0x7d, 0xdc, 0x3d,
-	0x6d, 0x23, 0xf1, 0xa9, 0x93, 0xc0, 0xf0, 0x9f, 0x16, 0xac, 0xbc, 0x38, 0x76, 0x3d, 0x5a, 0xaa,
-	0xd1, 0x53, 0x27, 0x91, 0x6d, 0x68, 0xeb, 0x0d, 0x53, 0x0a, 0x52, 0x3f, 0x2f, 0x2a, 0xa1, 0xa9,
-	0x06, 0xc5, 0x0a, 0x3f, 0xf7, 0x29, 0x15, 0x3e, 0xbb, 0x49, 0xad, 0x78, 0x93, 0x4a, 0x6c, 0xd7,
-	0x3f, 0x2f, 0xb6, 0x1f, 0x03, 0x2a, 0x5e, 0x2b, 0x6b, 0xb9, 0xa9, 0x77, 0xac, 0x4f, 0xf3, 0xce,
-	0x0e, 0x34, 0xf7, 0x88, 0x71, 0xca, 0x55, 0x58, 0xf4, 0x58, 0x28, 0xe9, 0x07, 0x39, 0x7c, 0x47,
-	0xc7, 0xa6, 0x2a, 0xb6, 0x52, 0xd9, 0x57, 0x74, 0x2c, 0xf0, 0x5d, 0x00, 0x85, 0x4f, 0xad, 0x5d,
-	0x85, 0x39, 0x97, 0x98, 0xe6, 0xbe, 0x54, 0xf1, 0x81, 0xa3, 0xf6, 0xf0, 0x03, 0x98, 0xdd, 0x23,
-	0x4a, 0xb3, 0x62, 0xce, 0xa9, 0x27, 0x87, 0x31, 0x37, 0x2f, 0xda, 0x32, 0xb2, 0x43, 0x7e, 0xac,
-	0xfa, 0x8d, 0xb2, 0x62, 0xfa, 0x8d, 0xfa, 0xde, 0xfd, 0xc3, 0x82, 0x96, 0xca, 0xb0, 0x03, 0xca,
-	0x4f, 0x7c, 0x8f, 0xa2, 0x87, 0xba, 0x8b, 0xe9, 0xa4, 0xdc, 0xa8, 0x7a, 0xbc, 0x30, 0x78, 0xdb,
-	0xe5, 0x50, 0x4f, 0x26, 0xd3, 0x19, 0xf4, 0x00, 0x1a, 0xe9, 0x74, 0x5c, 0x39, 0x5d, 0x9e, 0x99,
-	0xed, 0x95, 0x53, 0x19, 0x8e, 0x67, 0xd0, 0x97, 0xd0, 0xcc, 0xe6, 0x70, 0x74, 0xf9, 0xb4, 0xfe,
-	0xa2, 0x82, 0x89, 0xe6, 0x77, 0x7f, 0xb6, 0x60, 0xad, 0x3c, 0xbf, 0x9a, 0x6b, 0xfd, 0x08, 0xff,
-	0x9a, 0x30, 0xdc, 0xa2, 0x7f, 0x97, 0xd4, 0x4c, 0x1f, 0xab, 0xed, 0x1b, 0xe7, 0x03, 0x93, 0x07,
-	0x53, 0x2c, 0x66, 0x61, 0x2d, 0x1d, 0xbc, 0x7a, 0xae, 0x74, 0x8f, 0xd9, 0x91, 0x61, 0xd1, 0x87,
-	0xc5, 0xe2, 0x94, 0x89, 0x26, 0xdc, 0xc2, 0xbe, 0x7a, 0xca, 0x52, 0x75, 0xe8, 0xc3, 0x33, 0xe8,
-	0x31, 0x40, 0x3e, 0x64, 0xa2, 0xcd, 0xaa, 0xab, 0xcb, 0xd3, 0xa7, 0x3d, 0x71, 0x26, 0xc4, 0x33,
-	0xe8, 0x35, 0x74, 0xca, 0x63, 0x25, 0xc2, 0x25, 0xe4, 0xc4, 0x11, 0xd5, 0xde, 0x3e, 0x13, 0x93,
-	0x79, 0xe1, 0x57, 0x0b, 0x96, 0x0e, 0xd2, 0xe2, 0x65, 0xee, 0x3f, 0x80, 0x05, 0x33, 0x0d, 0xa2,
-	0x4b, 0x55, 0xd2, 0xc5, 0xa1, 0xd4, 0xbe, 0x3c, 0x65, 0x37, 0xf3, 0xc0, 0x53, 0x68, 0x66, 0x43,
-	0x5a, 0x25, 0x58, 0xaa, 0xd3, 0xa2, 0xbd, 0x39, 0x6d, 0x3b, 0x23, 0xfb, 0x9b, 0x05, 0x4b, 0xa6,
-	0xf4, 0x18, 0xb2, 0xaf, 0x61, 0x7d, 0xf2, 0x90, 0x33, 0xf1, 0xd9, 0x6e, 0x55, 0x09, 0x9f, 0x31,
-	0x1d, 0xe1, 0x19, 0xd4, 0x87, 0x46, 0x32, 0xf0, 0x48, 0x74, 0xbd, 0x9c, 0x0b, 0xd3, 0xc6, 0x21,
-	0x7b, 0x42, 0x73, 0xc1, 0x33, 0xbb, 0x87, 0xd0, 0x79, 0xe1, 0x8e, 0x03, 0x1a, 0x66, 0x19, 0xdc,
-	0x83, 0x7a, 0xd2, 0x91, 0x91, 0x5d, 0xd6, 0x5c, 0x9c, 0x10, 0xec, 0x8d, 0x89, 0x7b, 0x99, 0x43,
-	0x46, 0xb0, 0xb8, 0xaf, 0x2a, 0xa8, 0x51, 0xfa, 0x4a, 0xfd, 0x60, 0x99, 0xd0, 0x48, 0xd0, 0xcd,
-	0x4a, 0x34, 0x4c, 0x6f, 0x36, 0x53, 0x72, 0xf6, 0x0d, 0x2c, 0xf5, 0x46, 0xd4, 0x7b, 0xc7, 0xe2,
-	0xec, 0x06, 0xcf, 0x01, 0xf2, 0xba, 0x5b, 0x89, 0xee, 0x53, 0x7d, 0xc6, 0xbe, 0x32, 0x75, 0x3f,
-	0xbb, 0xcd, 0x13, 0x55, 0x82, 0x8d, 0xf6, 0x07, 0x50, 0xef, 0xab, 0x19, 0x5c, 0xa0, 0xf5, 0x6a,
-	0x39, 0x4d, 0x35, 0x5e, 0x38, 0x25, 0x37, 0x9a, 0xde, 0xd4, 0xf5, 0x9f, 0x1b, 0xff, 0xfd, 0x3b,
-	0x00, 0x00, 0xff, 0xff, 0xb2, 0xa0, 0x6e, 0x6c, 0xea, 0x10, 0x00, 0x00,
+type faultServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFaultServiceClient(cc *grpc.ClientConn) FaultServiceClient {
+	return &faultServiceClient{cc}
+}
+
+func (c *faultServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.FaultService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faultServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.FaultService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FaultServiceServer is the server API for FaultService service.
+type FaultServiceServer interface {
+	Create(context.Context, *CreateRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
+}
+
+// UnimplementedFaultServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFaultServiceServer struct {
+}
+
+func (*UnimplementedFaultServiceServer) Create(ctx context.Context, req *CreateRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedFaultServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+
+func RegisterFaultServiceServer(s *grpc.Server, srv FaultServiceServer) {
+	s.RegisterService(&_FaultService_serviceDesc, srv)
+}
+
+func _FaultService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.FaultService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaultService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.FaultService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _FaultService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.FaultService",
+	HandlerType: (*FaultServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _FaultService_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _FaultService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+// TestServiceClient is the client API for TestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TestServiceClient interface {
+	Test(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type testServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTestServiceClient(cc *grpc.ClientConn) TestServiceClient {
+	return &testServiceClient{cc}
+}
+
+func (c *testServiceClient) Test(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.TestService/Test", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestServiceServer is the server API for TestService service.
+type TestServiceServer interface {
+	Test(context.Context, *Empty) (*Empty, error)
+}
+
+// UnimplementedTestServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTestServiceServer struct {
+}
+
+func (*UnimplementedTestServiceServer) Test(ctx context.Context, req *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Test not implemented")
+}
+
+func RegisterTestServiceServer(s *grpc.Server, srv TestServiceServer) {
+	s.RegisterService(&_TestService_serviceDesc, srv)
+}
+
+func _TestService_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.TestService/Test",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).Test(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TestService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.TestService",
+	HandlerType: (*TestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Test",
+			Handler:    _TestService_Test_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
 }
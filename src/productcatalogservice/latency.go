@@ -0,0 +1,283 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	distFixed      = "fixed"
+	distUniform    = "uniform"
+	distNormal     = "normal"
+	distPercentile = "percentile"
+)
+
+// duration is a time.Duration that is written as a time.ParseDuration
+// string in JSON, e.g. "150ms".
+type duration time.Duration
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+// latencySpec describes the latency distribution and error rate of a method.
+// Which duration fields are used depends on Distribution:
+//   - fixed: Latency
+//   - uniform: Min and Max
+//   - normal: Mean and Stddev, truncated at zero
+//   - percentile: Percentiles, mapping a percentile such as "99.9" to the
+//     latency at that percentile; latencies in between are interpolated
+type latencySpec struct {
+	Distribution string              `json:"distribution,omitempty"`
+	Latency      duration            `json:"latency,omitempty"`
+	Min          duration            `json:"min,omitempty"`
+	Max          duration            `json:"max,omitempty"`
+	Mean         duration            `json:"mean,omitempty"`
+	Stddev       duration            `json:"stddev,omitempty"`
+	Percentiles  map[string]duration `json:"percentiles,omitempty"`
+
+	// ErrorRate is the fraction of calls, between 0 and 1, that fail with
+	// codes.Unavailable after the latency has been added.
+	ErrorRate float64 `json:"error_rate,omitempty"`
+}
+
+// latencyProfile assigns a latencySpec to each method of the service.
+// Methods, keyed by method name such as "GetProduct", override Default.
+type latencyProfile struct {
+	Default latencySpec            `json:"default"`
+	Methods map[string]latencySpec `json:"methods,omitempty"`
+}
+
+// percentilePoint is one point of the inverse CDF of a percentile spec.
+type percentilePoint struct {
+	p float64
+	d time.Duration
+}
+
+// sampler draws latencies from a validated latencySpec.
+type sampler struct {
+	spec   latencySpec
+	points []percentilePoint
+}
+
+func newSampler(spec latencySpec) (*sampler, error) {
+	s := &sampler{spec: spec}
+	if spec.ErrorRate < 0 || spec.ErrorRate > 1 {
+		return nil, fmt.Errorf("error_rate %v is not between 0 and 1", spec.ErrorRate)
+	}
+	switch spec.Distribution {
+	case "", distFixed:
+		if spec.Latency < 0 {
+			return nil, fmt.Errorf("latency must not be negative")
+		}
+	case distUniform:
+		if spec.Min < 0 || spec.Max < spec.Min {
+			return nil, fmt.Errorf("uniform distribution needs 0 <= min <= max")
+		}
+	case distNormal:
+		if spec.Mean < 0 || spec.Stddev < 0 {
+			return nil, fmt.Errorf("normal distribution needs non-negative mean and stddev")
+		}
+	case distPercentile:
+		if len(spec.Percentiles) == 0 {
+			return nil, fmt.Errorf("percentile distribution needs at least one percentile")
+		}
+		// The inverse CDF starts at zero latency for the 0th percentile.
+		s.points = []percentilePoint{{0, 0}}
+		for k, v := range spec.Percentiles {
+			p, err := strconv.ParseFloat(k, 64)
+			if err != nil || p <= 0 || p > 100 {
+				return nil, fmt.Errorf("invalid percentile %q", k)
+			}
+			s.points = append(s.points, percentilePoint{p, time.Duration(v)})
+		}
+		sort.Slice(s.points, func(i, j int) bool { return s.points[i].p < s.points[j].p })
+		for i := 1; i < len(s.points); i++ {
+			// Keys such as "99" and "99.0" are the same percentile.
+			if s.points[i].p == s.points[i-1].p {
+				return nil, fmt.Errorf("percentile %v is given more than once", s.points[i].p)
+			}
+			if s.points[i].d < s.points[i-1].d {
+				return nil, fmt.Errorf("latency at percentile %v is lower than at a smaller percentile", s.points[i].p)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown distribution %q", spec.Distribution)
+	}
+	return s, nil
+}
+
+// latency draws a latency from the distribution.
+func (s *sampler) latency() time.Duration {
+	switch s.spec.Distribution {
+	case distUniform:
+		return time.Duration(s.spec.Min) + time.Duration(rand.Int63n(int64(s.spec.Max-s.spec.Min)+1))
+	case distNormal:
+		d := time.Duration(rand.NormFloat64()*float64(s.spec.Stddev)) + time.Duration(s.spec.Mean)
+		if d < 0 {
+			return 0
+		}
+		return d
+	case distPercentile:
+		return s.percentile(rand.Float64() * 100)
+	default:
+		return time.Duration(s.spec.Latency)
+	}
+}
+
+// percentile returns the latency at percentile p by linear interpolation
+// between the configured percentiles. Beyond the highest configured
+// percentile the latency stays at its value.
+func (s *sampler) percentile(p float64) time.Duration {
+	for i := 1; i < len(s.points); i++ {
+		lo, hi := s.points[i-1], s.points[i]
+		if p <= hi.p {
+			frac := (p - lo.p) / (hi.p - lo.p)
+			return lo.d + time.Duration(frac*float64(hi.d-lo.d))
+		}
+	}
+	return s.points[len(s.points)-1].d
+}
+
+func (s *sampler) fail() bool {
+	return s.spec.ErrorRate > 0 && rand.Float64() < s.spec.ErrorRate
+}
+
+// latencyInjector applies a latencyProfile to incoming calls. The profile
+// can be swapped at any time through its HTTP handler.
+type latencyInjector struct {
+	mu       sync.RWMutex
+	profile  latencyProfile
+	def      *sampler
+	samplers map[string]*sampler
+}
+
+func newLatencyInjector() *latencyInjector {
+	l := &latencyInjector{}
+	if err := l.set(latencyProfile{}); err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// set validates profile and makes it the active one.
+func (l *latencyInjector) set(profile latencyProfile) error {
+	def, err := newSampler(profile.Default)
+	if err != nil {
+		return fmt.Errorf("default: %v", err)
+	}
+	samplers := make(map[string]*sampler, len(profile.Methods))
+	for method, spec := range profile.Methods {
+		s, err := newSampler(spec)
+		if err != nil {
+			return fmt.Errorf("%s: %v", method, err)
+		}
+		samplers[method] = s
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.profile = profile
+	l.def = def
+	l.samplers = samplers
+	return nil
+}
+
+func (l *latencyInjector) get() latencyProfile {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.profile
+}
+
+func (l *latencyInjector) sampler(method string) *sampler {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if s, ok := l.samplers[method]; ok {
+		return s
+	}
+	return l.def
+}
+
+// inject sleeps for a latency drawn from the profile of method and then
+// returns an error for the configured fraction of calls.
+func (l *latencyInjector) inject(ctx context.Context, method string) error {
+	s := l.sampler(method)
+	if d := s.latency(); d > 0 {
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return status.Errorf(status.FromContextError(ctx.Err()).Code(), "%s: %v", method, ctx.Err())
+		}
+	}
+	if s.fail() {
+		return status.Errorf(codes.Unavailable, "%s: injected error", method)
+	}
+	return nil
+}
+
+// ServeHTTP exposes the active profile: GET returns it, PUT replaces it and
+// DELETE resets it so that no latency or errors are injected.
+func (l *latencyInjector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var profile latencyProfile
+		if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode profile: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := l.set(profile); err != nil {
+			http.Error(w, fmt.Sprintf("invalid profile: %v", err), http.StatusBadRequest)
+			return
+		}
+		sugar.Infof("latency profile updated: %+v", profile)
+	case http.MethodDelete:
+		l.set(latencyProfile{})
+		sugar.Info("latency profile reset")
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(l.get())
+}
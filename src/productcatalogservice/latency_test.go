@@ -0,0 +1,137 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPercentileSampler(t *testing.T) {
+	s, err := newSampler(latencySpec{
+		Distribution: distPercentile,
+		Percentiles: map[string]duration{
+			"50": duration(10 * time.Millisecond),
+			"99": duration(1 * time.Second),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 0},
+		{25, 5 * time.Millisecond},
+		{50, 10 * time.Millisecond},
+		{99, time.Second},
+		{99.9, time.Second},
+	} {
+		if got := s.percentile(tc.p); got != tc.want {
+			t.Errorf("percentile(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestInvalidSpecs(t *testing.T) {
+	for _, spec := range []latencySpec{
+		{Distribution: "exponential"},
+		{Distribution: distUniform, Min: duration(time.Second), Max: duration(time.Millisecond)},
+		{Distribution: distPercentile},
+		{Distribution: distPercentile, Percentiles: map[string]duration{"101": 0}},
+		{Distribution: distPercentile, Percentiles: map[string]duration{"50": duration(time.Second), "90": 0}},
+		{Distribution: distPercentile, Percentiles: map[string]duration{"99": duration(time.Second), "99.0": duration(time.Second)}},
+		{ErrorRate: 1.5},
+	} {
+		if _, err := newSampler(spec); err == nil {
+			t.Errorf("newSampler(%+v) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestLatencyAdmin(t *testing.T) {
+	l := newLatencyInjector()
+	srv := httptest.NewServer(l)
+	defer srv.Close()
+
+	body := `{"default": {"distribution": "fixed", "latency": "5ms"},
+		"methods": {"GetProduct": {"error_rate": 1}}}`
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PUT returned %s", resp.Status)
+	}
+
+	start := time.Now()
+	if err := l.inject(context.Background(), "ListProducts"); err != nil {
+		t.Errorf("ListProducts: unexpected error %v", err)
+	}
+	if took := time.Since(start); took < 5*time.Millisecond {
+		t.Errorf("ListProducts took %v, want at least 5ms", took)
+	}
+	if got, want := status.Code(l.inject(context.Background(), "GetProduct")), codes.Unavailable; got != want {
+		t.Errorf("GetProduct: got %s, want %s", got, want)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got, want := status.Code(l.inject(ctx, "ListProducts")), codes.Canceled; got != want {
+		t.Errorf("ListProducts with a canceled context: got %s, want %s", got, want)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if got, want := status.Code(l.inject(ctx, "ListProducts")), codes.DeadlineExceeded; got != want {
+		t.Errorf("ListProducts past its deadline: got %s, want %s", got, want)
+	}
+
+	req, _ = http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(`{"default": {"distribution": "bogus"}}`))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("PUT of invalid profile returned %s, want 400", resp.Status)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, srv.URL, nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var got latencyProfile
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Methods) != 0 || got.Default.Latency != 0 {
+		t.Errorf("profile after DELETE = %+v, want empty", got)
+	}
+	if err := l.inject(context.Background(), "GetProduct"); err != nil {
+		t.Errorf("GetProduct after reset: unexpected error %v", err)
+	}
+}
@@ -17,17 +17,20 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"sync"
 	"syscall"
-	"time"
 
+	"github.com/triplewy/microservices-demo/src/productcatalogservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/productcatalogservice/genproto"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
@@ -35,16 +38,19 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/status"
 )
 
 var (
 	cat           pb.ListProductsResponse
 	catalogMutex  *sync.Mutex
-	extraLatency  time.Duration
+	catalogErr    error
 	reloadCatalog bool
+	latency       = newLatencyInjector()
 
-	port = "3550"
+	port      = "3550"
+	adminPort = "3551"
 
 	zLogger *zap.Logger
 	sugar   *zap.SugaredLogger
@@ -66,16 +72,16 @@ func main() {
 
 	defer zLogger.Sync()
 
-	// set injected latency
-	if s := os.Getenv("EXTRA_LATENCY"); s != "" {
-		v, err := time.ParseDuration(s)
-		if err != nil {
-			sugar.Fatalf("failed to parse EXTRA_LATENCY (%s) as time.Duration: %+v", v, err)
+	// set initial injected latency
+	if s := os.Getenv("LATENCY_PROFILE"); s != "" {
+		var profile latencyProfile
+		if err := json.Unmarshal([]byte(s), &profile); err != nil {
+			sugar.Fatalf("failed to parse LATENCY_PROFILE (%s): %+v", s, err)
+		}
+		if err := latency.set(profile); err != nil {
+			sugar.Fatalf("invalid LATENCY_PROFILE (%s): %+v", s, err)
 		}
-		extraLatency = v
-		sugar.Infof("extra latency enabled (duration: %v)", extraLatency)
-	} else {
-		extraLatency = time.Duration(0)
+		sugar.Infof("latency profile enabled: %+v", profile)
 	}
 
 	sigs := make(chan os.Signal, 1)
@@ -97,6 +103,10 @@ func main() {
 	if os.Getenv("PORT") != "" {
 		port = os.Getenv("PORT")
 	}
+	if os.Getenv("ADMIN_PORT") != "" {
+		adminPort = os.Getenv("ADMIN_PORT")
+	}
+	go runAdmin(adminPort)
 	sugar.Infof("starting grpc server at :%s", port)
 	run(port)
 	select {}
@@ -107,14 +117,29 @@ func run(port string) string {
 	if err != nil {
 		sugar.Fatal(err)
 	}
-	srv := grpc.NewServer()
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
 	svc := &productCatalog{}
+	hs := health.NewServer()
+	go watchHealth(hs, checkCatalog, "hipstershop.ProductCatalogService")
 	pb.RegisterProductCatalogServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, hs)
+	pb.RegisterFaultServiceServer(srv, faults)
 	go srv.Serve(l)
 	return l.Addr().String()
 }
 
+// runAdmin serves the HTTP endpoint used to change the latency profile at
+// runtime.
+func runAdmin(port string) {
+	mux := http.NewServeMux()
+	mux.Handle("/latency", latency)
+	sugar.Infof("starting admin server at :%s", port)
+	if err := http.ListenAndServe(fmt.Sprintf(":%s", port), mux); err != nil {
+		sugar.Errorf("admin server stopped: %v", err)
+	}
+}
+
 type productCatalog struct{}
 
 func readCatalogFile(catalog *pb.ListProductsResponse) error {
@@ -125,14 +150,28 @@ func readCatalogFile(catalog *pb.ListProductsResponse) error {
 		sugar.Fatalf("failed to open product catalog json file: %v", err)
 		return err
 	}
-	if err := jsonpb.Unmarshal(bytes.NewReader(catalogJSON), catalog); err != nil {
-		sugar.Warnf("failed to parse the catalog JSON: %v", err)
-		return err
+	if catalogErr = jsonpb.Unmarshal(bytes.NewReader(catalogJSON), catalog); catalogErr != nil {
+		sugar.Warnf("failed to parse the catalog JSON: %v", catalogErr)
+		return catalogErr
 	}
 	sugar.Info("successfully parsed product catalog json")
 	return nil
 }
 
+// checkCatalog reports whether the last attempt to parse the catalog
+// succeeded and yielded any products.
+func checkCatalog(context.Context) error {
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+	if catalogErr != nil {
+		return fmt.Errorf("failed to parse the catalog JSON: %v", catalogErr)
+	}
+	if len(cat.Products) == 0 {
+		return errors.New("product catalog is empty")
+	}
+	return nil
+}
+
 func parseCatalog() []*pb.Product {
 	if reloadCatalog || len(cat.Products) == 0 {
 		err := readCatalogFile(&cat)
@@ -143,21 +182,17 @@ func parseCatalog() []*pb.Product {
 	return cat.Products
 }
 
-func (p *productCatalog) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
-}
-
-func (p *productCatalog) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
-}
-
-func (p *productCatalog) ListProducts(context.Context, *pb.Empty) (*pb.ListProductsResponse, error) {
-	time.Sleep(extraLatency)
+func (p *productCatalog) ListProducts(ctx context.Context, _ *pb.Empty) (*pb.ListProductsResponse, error) {
+	if err := latency.inject(ctx, "ListProducts"); err != nil {
+		return nil, err
+	}
 	return &pb.ListProductsResponse{Products: parseCatalog()}, nil
 }
 
 func (p *productCatalog) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
-	time.Sleep(extraLatency)
+	if err := latency.inject(ctx, "GetProduct"); err != nil {
+		return nil, err
+	}
 	var found *pb.Product
 	for i := 0; i < len(parseCatalog()); i++ {
 		if req.Id == parseCatalog()[i].Id {
@@ -171,7 +206,9 @@ func (p *productCatalog) GetProduct(ctx context.Context, req *pb.GetProductReque
 }
 
 func (p *productCatalog) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
-	time.Sleep(extraLatency)
+	if err := latency.inject(ctx, "SearchProducts"); err != nil {
+		return nil, err
+	}
 	// Intepret query as a substring match in name or description.
 	var ps []*pb.Product
 	for _, p := range parseCatalog() {
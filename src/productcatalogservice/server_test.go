@@ -16,7 +16,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/go-cmp/cmp"
@@ -24,12 +26,14 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
 func TestServer(t *testing.T) {
 	ctx := context.Background()
-	addr := run(0)
+	addr := run("0")
 	conn, err := grpc.Dial(addr,
 		grpc.WithInsecure(),
 		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}))
@@ -53,6 +57,9 @@ func TestServer(t *testing.T) {
 	if want := parseCatalog()[0]; !proto.Equal(got, want) {
 		t.Errorf("got %v, want %v", got, want)
 	}
+	if got.GetWeightKg() == 0 || got.GetDimensions().GetLengthCm() == 0 {
+		t.Errorf("product %s has no shipping weight or dimensions", got.GetId())
+	}
 	_, err = client.GetProduct(ctx, &pb.GetProductRequest{Id: "N/A"})
 	if got, want := status.Code(err), codes.NotFound; got != want {
 		t.Errorf("got %s, want %s", got, want)
@@ -66,3 +73,51 @@ func TestServer(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestHealthWatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addr := run("0")
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{
+		Service: "hipstershop.ProductCatalogService",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetStatus() == healthpb.HealthCheckResponse_SERVING {
+			return
+		}
+	}
+}
+
+func TestWatchHealthKeepsServerServing(t *testing.T) {
+	hs := health.NewServer()
+	go watchHealth(hs, func(context.Context) error { return errors.New("catalog unavailable") }, "hipstershop.ProductCatalogService")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for {
+		resp, err := hs.Check(ctx, &healthpb.HealthCheckRequest{Service: "hipstershop.ProductCatalogService"})
+		if err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_NOT_SERVING {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatalf("service status = %v, %v, want NOT_SERVING", resp, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if resp, err := hs.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("server status with a failed dependency = %v, %v, want SERVING", resp, err)
+	}
+}
@@ -1545,6 +1545,124 @@ func (m *Ad) GetText() string {
 	return ""
 }
 
+type CreateRequest struct {
+	// Fully-qualified gRPC service the fault applies to, e.g.
+	// "hipstershop.CartService".
+	Svc string `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	// Full method name to fail, e.g. "/hipstershop.CartService/GetCart".
+	// Empty or "*" matches every method of svc.
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// Percentage of matching calls to affect, between 0 and 100.
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// gRPC status code returned by affected calls. OK (0) only delays them.
+	Code int32 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	// Delay in milliseconds added to affected calls.
+	DelayMs              int64    `protobuf:"varint,5,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{32}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetSvc() string {
+	if m != nil {
+		return m.Svc
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetDelayMs() int64 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
+}
+
+// Removes every fault registered for svc.
+type DeleteRequest struct {
+	Svc                  string   `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{33}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetSvc() string {
+	if m != nil {
+		return m.Svc
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CartItem)(nil), "hipstershop.CartItem")
 	proto.RegisterType((*AddItemRequest)(nil), "hipstershop.AddItemRequest")
@@ -1578,115 +1696,125 @@ func init() {
 	proto.RegisterType((*AdRequest)(nil), "hipstershop.AdRequest")
 	proto.RegisterType((*AdResponse)(nil), "hipstershop.AdResponse")
 	proto.RegisterType((*Ad)(nil), "hipstershop.Ad")
+	proto.RegisterType((*CreateRequest)(nil), "hipstershop.CreateRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "hipstershop.DeleteRequest")
 }
 
 func init() { proto.RegisterFile("demo.proto", fileDescriptor_ca53982754088a9d) }
 
 var fileDescriptor_ca53982754088a9d = []byte{
-	// 1500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xef, 0x72, 0x13, 0xb7,
-	0x16, 0xcf, 0x26, 0xb1, 0x1d, 0x1f, 0xc7, 0x4e, 0xa2, 0x9b, 0x04, 0xb3, 0x81, 0x10, 0x94, 0x81,
-	0x0b, 0x17, 0x08, 0x4c, 0xee, 0x9d, 0xe1, 0x03, 0xdc, 0xd2, 0x8c, 0xc9, 0x18, 0x4f, 0xa1, 0xd0,
-	0x0d, 0xe9, 0xd0, 0xa1, 0x53, 0xcf, 0xb2, 0x12, 0xf1, 0x96, 0xec, 0x6a, 0x91, 0xb4, 0x19, 0xcc,
-	0xc7, 0xf6, 0x01, 0xfa, 0x1e, 0x7d, 0x81, 0xce, 0xf4, 0x11, 0xfa, 0xbd, 0xaf, 0xd0, 0xe7, 0xe8,
-	0x48, 0xbb, 0xda, 0x7f, 0xb1, 0x13, 0xf8, 0xd2, 0x6f, 0xab, 0xa3, 0x9f, 0xce, 0xf9, 0xe9, 0xe8,
-	0xfc, 0xb3, 0x01, 0x08, 0x0d, 0xd8, 0x4e, 0xc4, 0x99, 0x64, 0xa8, 0x35, 0xf2, 0x23, 0x21, 0x29,
-	0x17, 0x23, 0x16, 0xe1, 0x7d, 0x58, 0xe8, 0xb9, 0x5c, 0x0e, 0x24, 0x0d, 0xd0, 0x65, 0x80, 0x88,
-	0x33, 0x12, 0x7b, 0x72, 0xe8, 0x93, 0xae, 0xb5, 0x65, 0xdd, 0x68, 0x3a, 0xcd, 0x54, 0x32, 0x20,
-	0xc8, 0x86, 0x85, 0xf7, 0xb1, 0x1b, 0x4a, 0x5f, 0x8e, 0xbb, 0xb3, 0x5b, 0xd6, 0x8d, 0x9a, 0x93,
-	0xad, 0xf1, 0x4b, 0xe8, 0xec, 0x11, 0xa2, 0xb4, 0x38, 0xf4, 0x7d, 0x4c, 0x85, 0x44, 0x17, 0xa0,
-	0x11, 0x0b, 0xca, 0x73, 0x4d, 0x75, 0xb5, 0x1c, 0x10, 0x74, 0x13, 0xe6, 0x7d, 0x49, 0x03, 0xad,
-	0xa2, 0xb5, 0xbb, 0xb6, 0x53, 0x60, 0xb3, 0x63, 0xa8, 0x38, 0x1a, 0x82, 0x6f, 0xc1, 0xf2, 0x7e,
-	0x10, 0xc9, 0xb1, 0x12, 0x9f, 0xa7, 0x17, 0xdf, 0x84, 0x4e, 0x9f, 0xca, 0x4f, 0x82, 0x3e, 0x85,
-	0x79, 0x85, 0x9b, 0xce, 0xf1, 0x16, 0xd4, 0x14, 0x01, 0xd1, 0x9d, 0xdd, 0x9a, 0x9b, 0x4e, 0x32,
-	0xc1, 0xe0, 0x06, 0xd4, 0x34, 0x4b, 0xfc, 0x2d, 0xd8, 0x4f, 0x7d, 0x21, 0x1d, 0xea, 0xb1, 0x20,
-	0xa0, 0x21, 0x71, 0xa5, 0xcf, 0x42, 0x71, 0xae, 0x43, 0xae, 0x40, 0x2b, 0x77, 0x7b, 0x62, 0xb2,
-	0xe9, 0x40, 0xe6, 0x77, 0x81, 0xbf, 0x80, 0x8d, 0x89, 0x7a, 0x45, 0xc4, 0x42, 0x41, 0xab, 0xe7,
-	0xad, 0x53, 0xe7, 0x7f, 0xb7, 0xa0, 0xf1, 0x22, 0x59, 0xa2, 0x0e, 0xcc, 0x66, 0x04, 0x66, 0x7d,
-	0x82, 0x10, 0xcc, 0x87, 0x6e, 0x40, 0xf5, 0x6b, 0x34, 0x1d, 0xfd, 0x8d, 0xb6, 0xa0, 0x45, 0xa8,
-	0xf0, 0xb8, 0x1f, 0x29, 0x43, 0xdd, 0x39, 0xbd, 0x55, 0x14, 0xa1, 0x2e, 0x34, 0x22, 0xdf, 0x93,
-	0x31, 0xa7, 0xdd, 0x79, 0xbd, 0x6b, 0x96, 0xe8, 0x2e, 0x34, 0x23, 0xee, 0x7b, 0x74, 0x18, 0x0b,
-	0xd2, 0xad, 0xe9, 0x27, 0x46, 0x25, 0xef, 0x3d, 0x63, 0x21, 0x1d, 0x3b, 0x0b, 0x1a, 0x74, 0x28,
-	0x08, 0xda, 0x04, 0xf0, 0x5c, 0x49, 0x8f, 0x18, 0xf7, 0xa9, 0xe8, 0xd6, 0x13, 0xf2, 0xb9, 0x04,
-	0x3f, 0x81, 0x55, 0x75, 0xf9, 0x94, 0x7f, 0x7e, 0xeb, 0x7b, 0xb0, 0x90, 0x5e, 0x31, 0xb9, 0x72,
-	0x6b, 0x77, 0xb5, 0x64, 0x27, 0x3d, 0xe0, 0x64, 0x28, 0xbc, 0x0d, 0x2b, 0x7d, 0x6a, 0x14, 0x99,
-	0x57, 0xa9, 0xf8, 0x03, 0xdf, 0x81, 0xb5, 0x03, 0xea, 0x72, 0x6f, 0x94, 0x1b, 0x4c, 0x80, 0xab,
-	0x50, 0x7b, 0x1f, 0x53, 0x3e, 0x4e, 0xb1, 0xc9, 0x02, 0x3f, 0x81, 0xf5, 0x2a, 0x3c, 0xe5, 0xb7,
-	0x03, 0x0d, 0x4e, 0x45, 0x7c, 0x7c, 0x0e, 0x3d, 0x03, 0xc2, 0x21, 0x2c, 0xf5, 0xa9, 0xfc, 0x26,
-	0x66, 0x92, 0x1a, 0x93, 0x3b, 0xd0, 0x70, 0x09, 0xe1, 0x54, 0x08, 0x6d, 0xb4, 0xaa, 0x62, 0x2f,
-	0xd9, 0x73, 0x0c, 0xe8, 0xf3, 0xa2, 0x76, 0x0f, 0x96, 0x73, 0x7b, 0x29, 0xe7, 0x3b, 0xb0, 0xe0,
-	0x31, 0x21, 0xf5, 0xdb, 0x59, 0x53, 0xdf, 0xae, 0xa1, 0x30, 0x87, 0x82, 0x60, 0x06, 0xcb, 0x07,
-	0x23, 0x3f, 0x7a, 0xce, 0x09, 0xe5, 0xff, 0x08, 0xe7, 0xff, 0xc1, 0x4a, 0xc1, 0x60, 0x1e, 0xfe,
-	0x92, 0xbb, 0xde, 0x3b, 0x3f, 0x3c, 0xca, 0x73, 0x0b, 0x8c, 0x68, 0x40, 0xf0, 0x2f, 0x16, 0x34,
-	0x52, 0xbb, 0xe8, 0x1a, 0x74, 0x84, 0xe4, 0x94, 0xca, 0x61, 0x91, 0x65, 0xd3, 0x69, 0x27, 0x52,
-	0x03, 0x43, 0x30, 0xef, 0x99, 0x32, 0xd7, 0x74, 0xf4, 0xb7, 0x0a, 0x00, 0x21, 0x5d, 0x49, 0xd3,
-	0x7c, 0x48, 0x16, 0x2a, 0x13, 0x3c, 0x16, 0x87, 0x92, 0x8f, 0x4d, 0x26, 0xa4, 0x4b, 0x74, 0x11,
-	0x16, 0x3e, 0xfa, 0xd1, 0xd0, 0x63, 0x84, 0xea, 0x44, 0xa8, 0x39, 0x8d, 0x8f, 0x7e, 0xd4, 0x63,
-	0x84, 0xe2, 0x57, 0x50, 0xd3, 0xae, 0x44, 0xdb, 0xd0, 0xf6, 0x62, 0xce, 0x69, 0xe8, 0x8d, 0x13,
-	0x60, 0xc2, 0x66, 0xd1, 0x08, 0x15, 0x5a, 0x19, 0x8e, 0x43, 0x5f, 0x0a, 0xcd, 0x66, 0xce, 0x49,
-	0x16, 0x4a, 0x1a, 0xba, 0x21, 0x13, 0x9a, 0x4e, 0xcd, 0x49, 0x16, 0xb8, 0x0f, 0x9b, 0x7d, 0x2a,
-	0x0f, 0xe2, 0x28, 0x62, 0x5c, 0x52, 0xd2, 0x4b, 0xf4, 0xf8, 0x34, 0x8f, 0xcb, 0x6b, 0xd0, 0x29,
-	0x99, 0x34, 0x05, 0xa3, 0x5d, 0xb4, 0x29, 0xf0, 0xf7, 0x70, 0xb1, 0x97, 0x09, 0xc2, 0x13, 0xca,
-	0x85, 0xcf, 0x42, 0xf3, 0xc8, 0xd7, 0x61, 0xfe, 0x2d, 0x67, 0xc1, 0x19, 0x31, 0xa2, 0xf7, 0x55,
-	0xc9, 0x93, 0x2c, 0xb9, 0x58, 0xe2, 0xc9, 0xba, 0x64, 0xda, 0x01, 0x7f, 0x59, 0xd0, 0xe9, 0x71,
-	0x4a, 0x7c, 0x55, 0xaf, 0xc9, 0x20, 0x7c, 0xcb, 0xd0, 0x6d, 0x40, 0x9e, 0x96, 0x0c, 0x3d, 0x97,
-	0x93, 0x61, 0x18, 0x07, 0x6f, 0x28, 0x4f, 0xfd, 0xb1, 0xec, 0x65, 0xd8, 0xaf, 0xb5, 0x1c, 0x5d,
-	0x87, 0xa5, 0x22, 0xda, 0x3b, 0x39, 0x49, 0x5b, 0x52, 0x3b, 0x87, 0xf6, 0x4e, 0x4e, 0xd0, 0xff,
-	0x61, 0xa3, 0x88, 0xa3, 0x1f, 0x22, 0x9f, 0xeb, 0xf2, 0x39, 0x1c, 0x53, 0x97, 0xa7, 0xbe, 0xeb,
-	0xe6, 0x67, 0xf6, 0x33, 0xc0, 0x77, 0xd4, 0xe5, 0xe8, 0x11, 0x5c, 0x9a, 0x72, 0x3c, 0x60, 0xa1,
-	0x1c, 0xe9, 0x27, 0xaf, 0x39, 0x17, 0x27, 0x9d, 0x7f, 0xa6, 0x00, 0x78, 0x0c, 0xed, 0xde, 0xc8,
-	0xe5, 0x47, 0x59, 0x4e, 0xff, 0x07, 0xea, 0x6e, 0xa0, 0x22, 0xe4, 0x0c, 0xe7, 0xa5, 0x08, 0xf4,
-	0x10, 0x5a, 0x05, 0xeb, 0x69, 0xc3, 0xdc, 0x28, 0x67, 0x48, 0xc9, 0x89, 0x0e, 0xe4, 0x4c, 0xf0,
-	0x7d, 0xe8, 0x18, 0xd3, 0xf9, 0xd3, 0x4b, 0xee, 0x86, 0xc2, 0xf5, 0xf4, 0x15, 0xb2, 0x64, 0x69,
-	0x17, 0xa4, 0x03, 0x82, 0x7f, 0x80, 0xa6, 0xce, 0x30, 0x3d, 0x13, 0x98, 0x6e, 0x6d, 0x9d, 0xdb,
-	0xad, 0x55, 0x54, 0xa8, 0xca, 0x90, 0xf2, 0x9c, 0x18, 0x15, 0x6a, 0x1f, 0xff, 0x34, 0x0b, 0x2d,
-	0x93, 0xc2, 0xf1, 0xb1, 0x54, 0x89, 0xc2, 0xd4, 0x32, 0x27, 0xd4, 0xd0, 0xeb, 0x01, 0x41, 0xf7,
-	0x60, 0x55, 0x8c, 0xfc, 0x28, 0x52, 0xb9, 0x5d, 0x4c, 0xf2, 0x24, 0x9a, 0x90, 0xd9, 0x7b, 0x99,
-	0x25, 0x3b, 0xba, 0x0f, 0xed, 0xec, 0x84, 0x66, 0x33, 0x37, 0x95, 0xcd, 0xa2, 0x01, 0xf6, 0x98,
-	0x90, 0xe8, 0x11, 0x2c, 0x67, 0x07, 0x4d, 0x6d, 0x98, 0x3f, 0xa3, 0x82, 0x2d, 0x19, 0xb4, 0xa9,
-	0x19, 0xb7, 0x4d, 0x25, 0xab, 0xe9, 0x4a, 0xb6, 0x5e, 0x3a, 0x95, 0x39, 0xd4, 0x94, 0x32, 0x02,
-	0x97, 0x0e, 0x68, 0x48, 0xb4, 0xbc, 0xc7, 0xc2, 0xb7, 0x3e, 0x0f, 0x74, 0xd8, 0x14, 0xda, 0x0d,
-	0x0d, 0x5c, 0xff, 0xd8, 0xb4, 0x1b, 0xbd, 0x40, 0x3b, 0x50, 0xd3, 0xae, 0x49, 0x7d, 0xdc, 0x3d,
-	0x6d, 0x23, 0xf1, 0xa9, 0x93, 0xc0, 0xf0, 0x9f, 0x16, 0xac, 0xbc, 0x38, 0x76, 0x3d, 0x5a, 0xaa,
-	0xd1, 0x53, 0x27, 0x91, 0x6d, 0x68, 0xeb, 0x0d, 0x53, 0x0a, 0x52, 0x3f, 0x2f, 0x2a, 0xa1, 0xa9,
-	0x06, 0xc5, 0x0a, 0x3f, 0xf7, 0x29, 0x15, 0x3e, 0xbb, 0x49, 0xad, 0x78, 0x93, 0x4a, 0x6c, 0xd7,
-	0x3f, 0x2f, 0xb6, 0x1f, 0x03, 0x2a, 0x5e, 0x2b, 0x6b, 0xb9, 0xa9, 0x77, 0xac, 0x4f, 0xf3, 0xce,
-	0x0e, 0x34, 0xf7, 0x88, 0x71, 0xca, 0x55, 0x58, 0xf4, 0x58, 0x28, 0xe9, 0x07, 0x39, 0x7c, 0x47,
-	0xc7, 0xa6, 0x2a, 0xb6, 0x52, 0xd9, 0x57, 0x74, 0x2c, 0xf0, 0x5d, 0x00, 0x85, 0x4f, 0xad, 0x5d,
-	0x85, 0x39, 0x97, 0x98, 0xe6, 0xbe, 0x54, 0xf1, 0x81, 0xa3, 0xf6, 0xf0, 0x03, 0x98, 0xdd, 0x23,
-	0x4a, 0xb3, 0x62, 0xce, 0xa9, 0x27, 0x87, 0x31, 0x37, 0x2f, 0xda, 0x32, 0xb2, 0x43, 0x7e, 0xac,
-	0xfa, 0x8d, 0xb2, 0x62, 0xfa, 0x8d, 0xfa, 0xde, 0xfd, 0xc3, 0x82, 0x96, 0xca, 0xb0, 0x03, 0xca,
-	0x4f, 0x7c, 0x8f, 0xa2, 0x87, 0xba, 0x8b, 0xe9, 0xa4, 0xdc, 0xa8, 0x7a, 0xbc, 0x30, 0x78, 0xdb,
-	0xe5, 0x50, 0x4f, 0x26, 0xd3, 0x19, 0xf4, 0x00, 0x1a, 0xe9, 0x74, 0x5c, 0x39, 0x5d, 0x9e, 0x99,
-	0xed, 0x95, 0x53, 0x19, 0x8e, 0x67, 0xd0, 0x97, 0xd0, 0xcc, 0xe6, 0x70, 0x74, 0xf9, 0xb4, 0xfe,
-	0xa2, 0x82, 0x89, 0xe6, 0x77, 0x7f, 0xb6, 0x60, 0xad, 0x3c, 0xbf, 0x9a, 0x6b, 0xfd, 0x08, 0xff,
-	0x9a, 0x30, 0xdc, 0xa2, 0x7f, 0x97, 0xd4, 0x4c, 0x1f, 0xab, 0xed, 0x1b, 0xe7, 0x03, 0x93, 0x07,
-	0x53, 0x2c, 0x66, 0x61, 0x2d, 0x1d, 0xbc, 0x7a, 0xae, 0x74, 0x8f, 0xd9, 0x91, 0x61, 0xd1, 0x87,
-	0xc5, 0xe2, 0x94, 0x89, 0x26, 0xdc, 0xc2, 0xbe, 0x7a, 0xca, 0x52, 0x75, 0xe8, 0xc3, 0x33, 0xe8,
-	0x31, 0x40, 0x3e, 0x64, 0xa2, 0xcd, 0xaa, 0xab, 0xcb, 0xd3, 0xa7, 0x3d, 0x71, 0x26, 0xc4, 0x33,
-	0xe8, 0x35, 0x74, 0xca, 0x63, 0x25, 0xc2, 0x25, 0xe4, 0xc4, 0x11, 0xd5, 0xde, 0x3e, 0x13, 0x93,
-	0x79, 0xe1, 0x57, 0x0b, 0x96, 0x0e, 0xd2, 0xe2, 0x65, 0xee, 0x3f, 0x80, 0x05, 0x33, 0x0d, 0xa2,
-	0x4b, 0x55, 0xd2, 0xc5, 0xa1, 0xd4, 0xbe, 0x3c, 0x65, 0x37, 0xf3, 0xc0, 0x53, 0x68, 0x66, 0x43,
-	0x5a, 0x25, 0x58, 0xaa, 0xd3, 0xa2, 0xbd, 0x39, 0x6d, 0x3b, 0x23, 0xfb, 0x9b, 0x05, 0x4b, 0xa6,
-	0xf4, 0x18, 0xb2, 0xaf, 0x61, 0x7d, 0xf2, 0x90, 0x33, 0xf1, 0xd9, 0x6e, 0x55, 0x09, 0x9f, 0x31,
-	0x1d, 0xe1, 0x19, 0xd4, 0x87, 0x46, 0x32, 0xf0, 0x48, 0x74, 0xbd, 0x9c, 0x0b, 0xd3, 0xc6, 0x21,
-	0x7b, 0x42, 0x73, 0xc1, 0x33, 0xbb, 0x87, 0xd0, 0x79, 0xe1, 0x8e, 0x03, 0x1a, 0x66, 0x19, 0xdc,
-	0x83, 0x7a, 0xd2, 0x91, 0x91, 0x5d, 0xd6, 0x5c, 0x9c, 0x10, 0xec, 0x8d, 0x89, 0x7b, 0x99, 0x43,
-	0x46, 0xb0, 0xb8, 0xaf, 0x2a, 0xa8, 0x51, 0xfa, 0x4a, 0xfd, 0x60, 0x99, 0xd0, 0x48, 0xd0, 0xcd,
-	0x4a, 0x34, 0x4c, 0x6f, 0x36, 0x53, 0x72, 0xf6, 0x0d, 0x2c, 0xf5, 0x46, 0xd4, 0x7b, 0xc7, 0xe2,
-	0xec, 0x06, 0xcf, 0x01, 0xf2, 0xba, 0x5b, 0x89, 0xee, 0x53, 0x7d, 0xc6, 0xbe, 0x32, 0x75, 0x3f,
-	0xbb, 0xcd, 0x13, 0x55, 0x82, 0x8d, 0xf6, 0x07, 0x50, 0xef, 0xab, 0x19, 0x5c, 0xa0, 0xf5, 0x6a,
-	0x39, 0x4d, 0x35, 0x5e, 0x38, 0x25, 0x37, 0x9a, 0xde, 0xd4, 0xf5, 0x9f, 0x1b, 0xff, 0xfd, 0x3b,
-	0x00, 0x00, 0xff, 0xff, 0xb2, 0xa0, 0x6e, 0x6c, 0xea, 0x10, 0x00, 0x00,
+	// 1617 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x6f, 0x6f, 0x13, 0x47,
+	0x13, 0xcf, 0x39, 0xfe, 0x13, 0x8f, 0x63, 0x27, 0xd9, 0x27, 0x09, 0xc6, 0x81, 0x90, 0x6c, 0x04,
+	0x0f, 0x3c, 0x40, 0x40, 0x79, 0x2a, 0x21, 0x01, 0x2d, 0x8d, 0x4c, 0x6a, 0xac, 0x42, 0xa1, 0x17,
+	0x52, 0x51, 0x51, 0xd5, 0x3a, 0x6e, 0x97, 0xf8, 0x8a, 0x7d, 0x77, 0xec, 0xee, 0x45, 0x18, 0xf5,
+	0x55, 0x2b, 0xf5, 0x6d, 0xbf, 0x47, 0xbf, 0x40, 0xa5, 0x7e, 0x84, 0xbe, 0xef, 0x57, 0xe8, 0xe7,
+	0xa8, 0x76, 0xef, 0xf6, 0xfe, 0xd9, 0x97, 0xc0, 0x9b, 0xbe, 0xbb, 0x9d, 0x9d, 0x9d, 0xf9, 0xed,
+	0xec, 0xcc, 0x6f, 0xc6, 0x06, 0x20, 0x74, 0xec, 0xed, 0xfa, 0xcc, 0x13, 0x1e, 0x6a, 0x0c, 0x1d,
+	0x9f, 0x0b, 0xca, 0xf8, 0xd0, 0xf3, 0xf1, 0x01, 0x2c, 0x74, 0x2d, 0x26, 0xfa, 0x82, 0x8e, 0xd1,
+	0x45, 0x00, 0x9f, 0x79, 0x24, 0xb0, 0xc5, 0xc0, 0x21, 0x6d, 0x63, 0xcb, 0xb8, 0x5a, 0x37, 0xeb,
+	0x91, 0xa4, 0x4f, 0x50, 0x07, 0x16, 0xde, 0x06, 0x96, 0x2b, 0x1c, 0x31, 0x69, 0x97, 0xb6, 0x8c,
+	0xab, 0x15, 0x33, 0x5e, 0xe3, 0xe7, 0xd0, 0xda, 0x27, 0x44, 0x5a, 0x31, 0xe9, 0xdb, 0x80, 0x72,
+	0x81, 0xce, 0x41, 0x2d, 0xe0, 0x94, 0x25, 0x96, 0xaa, 0x72, 0xd9, 0x27, 0xe8, 0x1a, 0x94, 0x1d,
+	0x41, 0xc7, 0xca, 0x44, 0x63, 0x6f, 0x6d, 0x37, 0x85, 0x66, 0x57, 0x43, 0x31, 0x95, 0x0a, 0xbe,
+	0x0e, 0xcb, 0x07, 0x63, 0x5f, 0x4c, 0xa4, 0xf8, 0x2c, 0xbb, 0xf8, 0x1a, 0xb4, 0x7a, 0x54, 0x7c,
+	0x90, 0xea, 0x63, 0x28, 0x4b, 0xbd, 0x62, 0x8c, 0xd7, 0xa1, 0x22, 0x01, 0xf0, 0x76, 0x69, 0x6b,
+	0xbe, 0x18, 0x64, 0xa8, 0x83, 0x6b, 0x50, 0x51, 0x28, 0xf1, 0x37, 0xd0, 0x79, 0xec, 0x70, 0x61,
+	0x52, 0xdb, 0x1b, 0x8f, 0xa9, 0x4b, 0x2c, 0xe1, 0x78, 0x2e, 0x3f, 0x33, 0x20, 0x97, 0xa0, 0x91,
+	0x84, 0x3d, 0x74, 0x59, 0x37, 0x21, 0x8e, 0x3b, 0xc7, 0x9f, 0xc1, 0xc6, 0x4c, 0xbb, 0xdc, 0xf7,
+	0x5c, 0x4e, 0xf3, 0xe7, 0x8d, 0xa9, 0xf3, 0x7f, 0x18, 0x50, 0x7b, 0x16, 0x2e, 0x51, 0x0b, 0x4a,
+	0x31, 0x80, 0x92, 0x43, 0x10, 0x82, 0xb2, 0x6b, 0x8d, 0xa9, 0x7a, 0x8d, 0xba, 0xa9, 0xbe, 0xd1,
+	0x16, 0x34, 0x08, 0xe5, 0x36, 0x73, 0x7c, 0xe9, 0xa8, 0x3d, 0xaf, 0xb6, 0xd2, 0x22, 0xd4, 0x86,
+	0x9a, 0xef, 0xd8, 0x22, 0x60, 0xb4, 0x5d, 0x56, 0xbb, 0x7a, 0x89, 0x6e, 0x41, 0xdd, 0x67, 0x8e,
+	0x4d, 0x07, 0x01, 0x27, 0xed, 0x8a, 0x7a, 0x62, 0x94, 0x89, 0xde, 0x13, 0xcf, 0xa5, 0x13, 0x73,
+	0x41, 0x29, 0x1d, 0x71, 0x82, 0x36, 0x01, 0x6c, 0x4b, 0xd0, 0x63, 0x8f, 0x39, 0x94, 0xb7, 0xab,
+	0x21, 0xf8, 0x44, 0x82, 0x1f, 0xc1, 0xaa, 0xbc, 0x7c, 0x84, 0x3f, 0xb9, 0xf5, 0x6d, 0x58, 0x88,
+	0xae, 0x18, 0x5e, 0xb9, 0xb1, 0xb7, 0x9a, 0xf1, 0x13, 0x1d, 0x30, 0x63, 0x2d, 0xbc, 0x03, 0x2b,
+	0x3d, 0xaa, 0x0d, 0xe9, 0x57, 0xc9, 0xc5, 0x03, 0xdf, 0x84, 0xb5, 0x43, 0x6a, 0x31, 0x7b, 0x98,
+	0x38, 0x0c, 0x15, 0x57, 0xa1, 0xf2, 0x36, 0xa0, 0x6c, 0x12, 0xe9, 0x86, 0x0b, 0xfc, 0x08, 0xd6,
+	0xf3, 0xea, 0x11, 0xbe, 0x5d, 0xa8, 0x31, 0xca, 0x83, 0xd1, 0x19, 0xf0, 0xb4, 0x12, 0x76, 0x61,
+	0xa9, 0x47, 0xc5, 0xd7, 0x81, 0x27, 0xa8, 0x76, 0xb9, 0x0b, 0x35, 0x8b, 0x10, 0x46, 0x39, 0x57,
+	0x4e, 0xf3, 0x26, 0xf6, 0xc3, 0x3d, 0x53, 0x2b, 0x7d, 0x5c, 0xd6, 0xee, 0xc3, 0x72, 0xe2, 0x2f,
+	0xc2, 0x7c, 0x13, 0x16, 0x6c, 0x8f, 0x0b, 0xf5, 0x76, 0x46, 0xe1, 0xdb, 0xd5, 0xa4, 0xce, 0x11,
+	0x27, 0xd8, 0x83, 0xe5, 0xc3, 0xa1, 0xe3, 0x3f, 0x65, 0x84, 0xb2, 0x7f, 0x05, 0xf3, 0x27, 0xb0,
+	0x92, 0x72, 0x98, 0xa4, 0xbf, 0x60, 0x96, 0xfd, 0xc6, 0x71, 0x8f, 0x93, 0xda, 0x02, 0x2d, 0xea,
+	0x13, 0xfc, 0xab, 0x01, 0xb5, 0xc8, 0x2f, 0xba, 0x0c, 0x2d, 0x2e, 0x18, 0xa5, 0x62, 0x90, 0x46,
+	0x59, 0x37, 0x9b, 0xa1, 0x54, 0xab, 0x21, 0x28, 0xdb, 0x9a, 0xe6, 0xea, 0xa6, 0xfa, 0x96, 0x09,
+	0xc0, 0x85, 0x25, 0x68, 0x54, 0x0f, 0xe1, 0x42, 0x56, 0x82, 0xed, 0x05, 0xae, 0x60, 0x13, 0x5d,
+	0x09, 0xd1, 0x12, 0x9d, 0x87, 0x85, 0xf7, 0x8e, 0x3f, 0xb0, 0x3d, 0x42, 0x55, 0x21, 0x54, 0xcc,
+	0xda, 0x7b, 0xc7, 0xef, 0x7a, 0x84, 0xe2, 0x17, 0x50, 0x51, 0xa1, 0x44, 0x3b, 0xd0, 0xb4, 0x03,
+	0xc6, 0xa8, 0x6b, 0x4f, 0x42, 0xc5, 0x10, 0xcd, 0xa2, 0x16, 0x4a, 0x6d, 0xe9, 0x38, 0x70, 0x1d,
+	0xc1, 0x15, 0x9a, 0x79, 0x33, 0x5c, 0x48, 0xa9, 0x6b, 0xb9, 0x1e, 0x57, 0x70, 0x2a, 0x66, 0xb8,
+	0xc0, 0x3d, 0xd8, 0xec, 0x51, 0x71, 0x18, 0xf8, 0xbe, 0xc7, 0x04, 0x25, 0xdd, 0xd0, 0x8e, 0x43,
+	0x93, 0xbc, 0xbc, 0x0c, 0xad, 0x8c, 0x4b, 0x4d, 0x18, 0xcd, 0xb4, 0x4f, 0x8e, 0xbf, 0x83, 0xf3,
+	0xdd, 0x58, 0xe0, 0x9e, 0x50, 0xc6, 0x1d, 0xcf, 0xd5, 0x8f, 0x7c, 0x05, 0xca, 0xaf, 0x99, 0x37,
+	0x3e, 0x25, 0x47, 0xd4, 0xbe, 0xa4, 0x3c, 0xe1, 0x85, 0x17, 0x0b, 0x23, 0x59, 0x15, 0x9e, 0x0a,
+	0xc0, 0xdf, 0x06, 0xb4, 0xba, 0x8c, 0x12, 0x47, 0xf2, 0x35, 0xe9, 0xbb, 0xaf, 0x3d, 0x74, 0x03,
+	0x90, 0xad, 0x24, 0x03, 0xdb, 0x62, 0x64, 0xe0, 0x06, 0xe3, 0x57, 0x94, 0x45, 0xf1, 0x58, 0xb6,
+	0x63, 0xdd, 0xaf, 0x94, 0x1c, 0x5d, 0x81, 0xa5, 0xb4, 0xb6, 0x7d, 0x72, 0x12, 0xb5, 0xa4, 0x66,
+	0xa2, 0xda, 0x3d, 0x39, 0x41, 0x9f, 0xc2, 0x46, 0x5a, 0x8f, 0xbe, 0xf3, 0x1d, 0xa6, 0xe8, 0x73,
+	0x30, 0xa1, 0x16, 0x8b, 0x62, 0xd7, 0x4e, 0xce, 0x1c, 0xc4, 0x0a, 0xdf, 0x52, 0x8b, 0xa1, 0x07,
+	0x70, 0xa1, 0xe0, 0xf8, 0xd8, 0x73, 0xc5, 0x50, 0x3d, 0x79, 0xc5, 0x3c, 0x3f, 0xeb, 0xfc, 0x13,
+	0xa9, 0x80, 0x27, 0xd0, 0xec, 0x0e, 0x2d, 0x76, 0x1c, 0xd7, 0xf4, 0xff, 0xa0, 0x6a, 0x8d, 0x65,
+	0x86, 0x9c, 0x12, 0xbc, 0x48, 0x03, 0xdd, 0x87, 0x46, 0xca, 0x7b, 0xd4, 0x30, 0x37, 0xb2, 0x15,
+	0x92, 0x09, 0xa2, 0x09, 0x09, 0x12, 0x7c, 0x07, 0x5a, 0xda, 0x75, 0xf2, 0xf4, 0x82, 0x59, 0x2e,
+	0xb7, 0x6c, 0x75, 0x85, 0xb8, 0x58, 0x9a, 0x29, 0x69, 0x9f, 0xe0, 0xef, 0xa1, 0xae, 0x2a, 0x4c,
+	0xcd, 0x04, 0xba, 0x5b, 0x1b, 0x67, 0x76, 0x6b, 0x99, 0x15, 0x92, 0x19, 0xda, 0xa5, 0xc2, 0x8b,
+	0xa9, 0x7d, 0xfc, 0x53, 0x09, 0x1a, 0xba, 0x84, 0x83, 0x91, 0x90, 0x85, 0xe2, 0xc9, 0x65, 0x02,
+	0xa8, 0xa6, 0xd6, 0x7d, 0x82, 0x6e, 0xc3, 0x2a, 0x1f, 0x3a, 0xbe, 0x2f, 0x6b, 0x3b, 0x5d, 0xe4,
+	0x61, 0x36, 0x21, 0xbd, 0xf7, 0x3c, 0x2e, 0x76, 0x74, 0x07, 0x9a, 0xf1, 0x09, 0x85, 0x66, 0xbe,
+	0x10, 0xcd, 0xa2, 0x56, 0xec, 0x7a, 0x5c, 0xa0, 0x07, 0xb0, 0x1c, 0x1f, 0xd4, 0xdc, 0x50, 0x3e,
+	0x85, 0xc1, 0x96, 0xb4, 0x76, 0x24, 0x40, 0x37, 0x34, 0x93, 0x55, 0x14, 0x93, 0xad, 0x67, 0x4e,
+	0xc5, 0x01, 0xd5, 0x54, 0x46, 0xe0, 0xc2, 0x21, 0x75, 0x89, 0x92, 0x77, 0x3d, 0xf7, 0xb5, 0xc3,
+	0xc6, 0x2a, 0x6d, 0x52, 0xed, 0x86, 0x8e, 0x2d, 0x67, 0xa4, 0xdb, 0x8d, 0x5a, 0xa0, 0x5d, 0xa8,
+	0xa8, 0xd0, 0x44, 0x31, 0x6e, 0x4f, 0xfb, 0x08, 0x63, 0x6a, 0x86, 0x6a, 0xf8, 0x2f, 0x03, 0x56,
+	0x9e, 0x8d, 0x2c, 0x9b, 0x66, 0x38, 0xba, 0x70, 0x12, 0xd9, 0x81, 0xa6, 0xda, 0xd0, 0x54, 0x10,
+	0xc5, 0x79, 0x51, 0x0a, 0x35, 0x1b, 0xa4, 0x19, 0x7e, 0xfe, 0x43, 0x18, 0x3e, 0xbe, 0x49, 0x25,
+	0x7d, 0x93, 0x5c, 0x6e, 0x57, 0x3f, 0x2e, 0xb7, 0x1f, 0x02, 0x4a, 0x5f, 0x2b, 0x6e, 0xb9, 0x51,
+	0x74, 0x8c, 0x0f, 0x8b, 0xce, 0x2e, 0xd4, 0xf7, 0x89, 0x0e, 0xca, 0x36, 0x2c, 0xda, 0x9e, 0x2b,
+	0xe8, 0x3b, 0x31, 0x78, 0x43, 0x27, 0x9a, 0x15, 0x1b, 0x91, 0xec, 0x4b, 0x3a, 0xe1, 0xf8, 0x16,
+	0xc0, 0x3e, 0x89, 0xbd, 0x6d, 0xc3, 0xbc, 0x45, 0x74, 0x73, 0x5f, 0xca, 0xc5, 0xc0, 0x94, 0x7b,
+	0xf8, 0x1e, 0x94, 0xf6, 0x89, 0xb4, 0x2c, 0x91, 0x33, 0x6a, 0x8b, 0x41, 0xc0, 0xf4, 0x8b, 0x36,
+	0xb4, 0xec, 0x88, 0x8d, 0x64, 0xbf, 0x91, 0x5e, 0x74, 0xbf, 0x91, 0xdf, 0xf8, 0x47, 0x68, 0x76,
+	0x19, 0xb5, 0x92, 0x71, 0x60, 0x19, 0xe6, 0xf9, 0x89, 0x1d, 0x1d, 0x97, 0x9f, 0x52, 0x12, 0x30,
+	0x27, 0x3a, 0x25, 0x3f, 0xd5, 0x60, 0x46, 0x99, 0x4d, 0xdd, 0x30, 0xf1, 0x0d, 0x53, 0x2f, 0x55,
+	0x4b, 0x93, 0x44, 0x1c, 0x52, 0x96, 0xfa, 0x96, 0x95, 0x47, 0xe8, 0xc8, 0x9a, 0x0c, 0x54, 0xd6,
+	0xca, 0xe6, 0x52, 0x53, 0xeb, 0x27, 0x1c, 0x6f, 0x43, 0xf3, 0x21, 0x1d, 0xd1, 0x53, 0xbc, 0xef,
+	0xfd, 0x69, 0x40, 0x43, 0x52, 0xc0, 0x21, 0x65, 0x27, 0x8e, 0x4d, 0xd1, 0x7d, 0xd5, 0x66, 0x15,
+	0x6b, 0x6c, 0xe4, 0x53, 0x22, 0xf5, 0xcb, 0xa0, 0x93, 0xad, 0xc5, 0x70, 0x74, 0x9e, 0x43, 0xf7,
+	0xa0, 0x16, 0x8d, 0xef, 0xb9, 0xd3, 0xd9, 0xa1, 0xbe, 0xb3, 0x32, 0x45, 0x41, 0x78, 0x0e, 0x7d,
+	0x0e, 0xf5, 0xf8, 0x87, 0x02, 0xba, 0x38, 0x6d, 0x3f, 0x6d, 0x60, 0xa6, 0xfb, 0xbd, 0x9f, 0x0d,
+	0x58, 0xcb, 0x0e, 0xd8, 0xfa, 0x5a, 0x3f, 0xc0, 0x7f, 0x66, 0x4c, 0xdf, 0xe8, 0xbf, 0x19, 0x33,
+	0xc5, 0x73, 0x7f, 0xe7, 0xea, 0xd9, 0x8a, 0x61, 0x46, 0x49, 0x14, 0x25, 0x58, 0x8b, 0x26, 0xc3,
+	0xae, 0x25, 0xac, 0x91, 0x77, 0xac, 0x51, 0xf4, 0x60, 0x31, 0x3d, 0x06, 0xa3, 0x19, 0xb7, 0xe8,
+	0x6c, 0x4f, 0x79, 0xca, 0x4f, 0xa5, 0x78, 0x0e, 0x3d, 0x04, 0x48, 0xa6, 0x60, 0xb4, 0x99, 0x0f,
+	0x75, 0x76, 0x3c, 0xee, 0xcc, 0x1c, 0x5a, 0xf1, 0x1c, 0x7a, 0x09, 0xad, 0xec, 0xdc, 0x8b, 0x70,
+	0x46, 0x73, 0xe6, 0x0c, 0xdd, 0xd9, 0x39, 0x55, 0x27, 0x8e, 0xc2, 0x6f, 0x06, 0x2c, 0x1d, 0x46,
+	0xec, 0xaa, 0xef, 0xdf, 0x87, 0x05, 0x3d, 0xae, 0xa2, 0x0b, 0x79, 0xd0, 0xe9, 0xa9, 0xb9, 0x73,
+	0xb1, 0x60, 0x37, 0x8e, 0xc0, 0x63, 0xa8, 0xc7, 0x53, 0x64, 0x2e, 0x59, 0xf2, 0xe3, 0x6c, 0x67,
+	0xb3, 0x68, 0x3b, 0x06, 0xfb, 0xbb, 0x01, 0x4b, 0x9a, 0x1b, 0x35, 0xd8, 0x97, 0xb0, 0x3e, 0x7b,
+	0x0a, 0x9b, 0xf9, 0x6c, 0xd7, 0xf3, 0x80, 0x4f, 0x19, 0xdf, 0xf0, 0x1c, 0xea, 0x41, 0x2d, 0x9c,
+	0xc8, 0x04, 0xba, 0x92, 0xad, 0x85, 0xa2, 0x79, 0xad, 0x33, 0xa3, 0xfb, 0xe1, 0xb9, 0xbd, 0x23,
+	0x68, 0x3d, 0xb3, 0x26, 0x63, 0xea, 0xc6, 0x15, 0xdc, 0x85, 0x6a, 0x38, 0x32, 0xa0, 0x4e, 0xd6,
+	0x72, 0x7a, 0x84, 0xe9, 0x6c, 0xcc, 0xdc, 0x8b, 0x03, 0x32, 0x84, 0xc5, 0x03, 0x49, 0xf1, 0xda,
+	0xe8, 0x0b, 0x58, 0x9b, 0xd9, 0xe9, 0xd0, 0xb5, 0x5c, 0x36, 0x14, 0x77, 0xc3, 0x82, 0x9a, 0x7d,
+	0x05, 0x4b, 0xdd, 0x21, 0xb5, 0xdf, 0x78, 0x41, 0x7c, 0x83, 0xa7, 0x00, 0x49, 0x63, 0xc8, 0x65,
+	0xf7, 0x54, 0x23, 0xec, 0x5c, 0x2a, 0xdc, 0x8f, 0x6f, 0xf3, 0x48, 0xf6, 0x08, 0x6d, 0xfd, 0x1e,
+	0x54, 0x7b, 0xf2, 0x47, 0x02, 0x47, 0xeb, 0x79, 0xbe, 0x8f, 0x2c, 0x9e, 0x9b, 0x92, 0xc7, 0x96,
+	0x7e, 0x31, 0x60, 0xf1, 0x0b, 0x2b, 0x18, 0xc5, 0x58, 0xef, 0x42, 0x35, 0x24, 0xf8, 0x7c, 0xb4,
+	0xd3, 0xac, 0x5f, 0xc0, 0x96, 0x77, 0xa1, 0x1a, 0xd2, 0x73, 0xee, 0x6c, 0x86, 0xb3, 0x0b, 0xc2,
+	0xf6, 0x00, 0x1a, 0xcf, 0x29, 0x8f, 0x61, 0xdc, 0x86, 0xb2, 0x5c, 0xce, 0x4c, 0xcd, 0x99, 0x06,
+	0x5e, 0x55, 0xd5, 0xff, 0x48, 0xff, 0xff, 0x67, 0x00, 0xf4, 0x5a, 0x6f, 0x3c, 0x55, 0x12, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConnInterface
+var _ grpc.ClientConn
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+const _ = grpc.SupportPackageIsVersion4
 
 // CartServiceClient is the client API for CartService service.
 //
@@ -1698,10 +1826,10 @@ type CartServiceClient interface {
 }
 
 type cartServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewCartServiceClient(cc grpc.ClientConnInterface) CartServiceClient {
+func NewCartServiceClient(cc *grpc.ClientConn) CartServiceClient {
 	return &cartServiceClient{cc}
 }
 
@@ -1840,10 +1968,10 @@ type RecommendationServiceClient interface {
 }
 
 type recommendationServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewRecommendationServiceClient(cc grpc.ClientConnInterface) RecommendationServiceClient {
+func NewRecommendationServiceClient(cc *grpc.ClientConn) RecommendationServiceClient {
 	return &recommendationServiceClient{cc}
 }
 
@@ -1914,10 +2042,10 @@ type ProductCatalogServiceClient interface {
 }
 
 type productCatalogServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewProductCatalogServiceClient(cc grpc.ClientConnInterface) ProductCatalogServiceClient {
+func NewProductCatalogServiceClient(cc *grpc.ClientConn) ProductCatalogServiceClient {
 	return &productCatalogServiceClient{cc}
 }
 
@@ -2057,10 +2185,10 @@ type ShippingServiceClient interface {
 }
 
 type shippingServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewShippingServiceClient(cc grpc.ClientConnInterface) ShippingServiceClient {
+func NewShippingServiceClient(cc *grpc.ClientConn) ShippingServiceClient {
 	return &shippingServiceClient{cc}
 }
 
@@ -2165,10 +2293,10 @@ type CurrencyServiceClient interface {
 }
 
 type currencyServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewCurrencyServiceClient(cc grpc.ClientConnInterface) CurrencyServiceClient {
+func NewCurrencyServiceClient(cc *grpc.ClientConn) CurrencyServiceClient {
 	return &currencyServiceClient{cc}
 }
 
@@ -2272,10 +2400,10 @@ type PaymentServiceClient interface {
 }
 
 type paymentServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewPaymentServiceClient(cc grpc.ClientConnInterface) PaymentServiceClient {
+func NewPaymentServiceClient(cc *grpc.ClientConn) PaymentServiceClient {
 	return &paymentServiceClient{cc}
 }
 
@@ -2344,10 +2472,10 @@ type EmailServiceClient interface {
 }
 
 type emailServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewEmailServiceClient(cc grpc.ClientConnInterface) EmailServiceClient {
+func NewEmailServiceClient(cc *grpc.ClientConn) EmailServiceClient {
 	return &emailServiceClient{cc}
 }
 
@@ -2416,10 +2544,10 @@ type CheckoutServiceClient interface {
 }
 
 type checkoutServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewCheckoutServiceClient(cc grpc.ClientConnInterface) CheckoutServiceClient {
+func NewCheckoutServiceClient(cc *grpc.ClientConn) CheckoutServiceClient {
 	return &checkoutServiceClient{cc}
 }
 
@@ -2488,10 +2616,10 @@ type AdServiceClient interface {
 }
 
 type adServiceClient struct {
-	cc grpc.ClientConnInterface
+	cc *grpc.ClientConn
 }
 
-func NewAdServiceClient(cc grpc.ClientConnInterface) AdServiceClient {
+func NewAdServiceClient(cc *grpc.ClientConn) AdServiceClient {
 	return &adServiceClient{cc}
 }
 
@@ -2551,3 +2679,183 @@ var _AdService_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
+
+// FaultServiceClient is the client API for FaultService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FaultServiceClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type faultServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFaultServiceClient(cc *grpc.ClientConn) FaultServiceClient {
+	return &faultServiceClient{cc}
+}
+
+func (c *faultServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.FaultService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faultServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.FaultService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FaultServiceServer is the server API for FaultService service.
+type FaultServiceServer interface {
+	Create(context.Context, *CreateRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
+}
+
+// UnimplementedFaultServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFaultServiceServer struct {
+}
+
+func (*UnimplementedFaultServiceServer) Create(ctx context.Context, req *CreateRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedFaultServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+
+func RegisterFaultServiceServer(s *grpc.Server, srv FaultServiceServer) {
+	s.RegisterService(&_FaultService_serviceDesc, srv)
+}
+
+func _FaultService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.FaultService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaultService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.FaultService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _FaultService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.FaultService",
+	HandlerType: (*FaultServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _FaultService_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _FaultService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+// TestServiceClient is the client API for TestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TestServiceClient interface {
+	Test(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type testServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTestServiceClient(cc *grpc.ClientConn) TestServiceClient {
+	return &testServiceClient{cc}
+}
+
+func (c *testServiceClient) Test(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.TestService/Test", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestServiceServer is the server API for TestService service.
+type TestServiceServer interface {
+	Test(context.Context, *Empty) (*Empty, error)
+}
+
+// UnimplementedTestServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTestServiceServer struct {
+}
+
+func (*UnimplementedTestServiceServer) Test(ctx context.Context, req *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Test not implemented")
+}
+
+func RegisterTestServiceServer(s *grpc.Server, srv TestServiceServer) {
+	s.RegisterService(&_TestService_serviceDesc, srv)
+}
+
+func _TestService_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.TestService/Test",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).Test(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TestService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.TestService",
+	HandlerType: (*TestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Test",
+			Handler:    _TestService_Test_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -24,14 +25,14 @@ import (
 
 	"google.golang.org/grpc/metadata"
 
+	"github.com/triplewy/microservices-demo/src/recommendationservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/recommendationservice/genproto"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	mapset "github.com/deckarep/golang-set"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/health"
 )
 
 var (
@@ -91,23 +92,35 @@ func run(port string) string {
 	if err != nil {
 		sugar.Fatal(err)
 	}
-	srv := grpc.NewServer()
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
 	svc := &recommendation{}
+	hs := health.NewServer()
+	go watchHealth(hs, checkProductCatalog, "hipstershop.RecommendationService")
 	pb.RegisterRecommendationServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, hs)
+	pb.RegisterFaultServiceServer(srv, faults)
 	go srv.Serve(l)
 	return l.Addr().String()
 }
 
-type recommendation struct{}
-
-func (r *recommendation) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+// checkProductCatalog reports whether the product catalog service, which
+// every recommendation depends on, is serving.
+func checkProductCatalog(ctx context.Context) error {
+	if cc == nil {
+		return errors.New("no connection to product catalog service")
+	}
+	resp, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("product catalog service health check failed: %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("product catalog service is %v", resp.GetStatus())
+	}
+	return nil
 }
 
-func (r *recommendation) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
-}
+type recommendation struct{}
 
 func (r *recommendation) ListRecommendations(ctx context.Context, req *pb.ListRecommendationsRequest) (*pb.ListRecommendationsResponse, error) {
 	maxResponses := 5
@@ -4,14 +4,13 @@
 package hipstershop
 
 import (
+	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
-	math "math"
-)
-
-import (
-	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -23,7 +22,117 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ShipmentStatus_State int32
+
+const (
+	ShipmentStatus_UNKNOWN          ShipmentStatus_State = 0
+	ShipmentStatus_LABEL_CREATED    ShipmentStatus_State = 1
+	ShipmentStatus_IN_TRANSIT       ShipmentStatus_State = 2
+	ShipmentStatus_OUT_FOR_DELIVERY ShipmentStatus_State = 3
+	ShipmentStatus_DELIVERED        ShipmentStatus_State = 4
+	ShipmentStatus_CANCELLED        ShipmentStatus_State = 5
+)
+
+var ShipmentStatus_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "LABEL_CREATED",
+	2: "IN_TRANSIT",
+	3: "OUT_FOR_DELIVERY",
+	4: "DELIVERED",
+	5: "CANCELLED",
+}
+
+var ShipmentStatus_State_value = map[string]int32{
+	"UNKNOWN":          0,
+	"LABEL_CREATED":    1,
+	"IN_TRANSIT":       2,
+	"OUT_FOR_DELIVERY": 3,
+	"DELIVERED":        4,
+	"CANCELLED":        5,
+}
+
+func (x ShipmentStatus_State) String() string {
+	return proto.EnumName(ShipmentStatus_State_name, int32(x))
+}
+
+func (ShipmentStatus_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{26, 0}
+}
+
+type FraudAssessment_Decision int32
+
+const (
+	FraudAssessment_DECISION_UNSPECIFIED FraudAssessment_Decision = 0
+	FraudAssessment_APPROVE              FraudAssessment_Decision = 1
+	// Approved, but to be reviewed.
+	FraudAssessment_REVIEW  FraudAssessment_Decision = 2
+	FraudAssessment_DECLINE FraudAssessment_Decision = 3
+)
+
+var FraudAssessment_Decision_name = map[int32]string{
+	0: "DECISION_UNSPECIFIED",
+	1: "APPROVE",
+	2: "REVIEW",
+	3: "DECLINE",
+}
+
+var FraudAssessment_Decision_value = map[string]int32{
+	"DECISION_UNSPECIFIED": 0,
+	"APPROVE":              1,
+	"REVIEW":               2,
+	"DECLINE":              3,
+}
+
+func (x FraudAssessment_Decision) String() string {
+	return proto.EnumName(FraudAssessment_Decision_name, int32(x))
+}
+
+func (FraudAssessment_Decision) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{41, 0}
+}
+
+type Transaction_State int32
+
+const (
+	Transaction_STATE_UNSPECIFIED  Transaction_State = 0
+	Transaction_AUTHORIZED         Transaction_State = 1
+	Transaction_CAPTURED           Transaction_State = 2
+	Transaction_VOIDED             Transaction_State = 3
+	Transaction_PARTIALLY_REFUNDED Transaction_State = 4
+	Transaction_REFUNDED           Transaction_State = 5
+	// The gateway did not make the authorization.
+	Transaction_FAILED Transaction_State = 6
+)
+
+var Transaction_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "AUTHORIZED",
+	2: "CAPTURED",
+	3: "VOIDED",
+	4: "PARTIALLY_REFUNDED",
+	5: "REFUNDED",
+	6: "FAILED",
+}
+
+var Transaction_State_value = map[string]int32{
+	"STATE_UNSPECIFIED":  0,
+	"AUTHORIZED":         1,
+	"CAPTURED":           2,
+	"VOIDED":             3,
+	"PARTIALLY_REFUNDED": 4,
+	"REFUNDED":           5,
+	"FAILED":             6,
+}
+
+func (x Transaction_State) String() string {
+	return proto.EnumName(Transaction_State_name, int32(x))
+}
+
+func (Transaction_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{48, 0}
+}
 
 type CartItem struct {
 	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -158,6 +267,155 @@ func (m *EmptyCartRequest) GetUserId() string {
 	return ""
 }
 
+type RemoveItemRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId            string   `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveItemRequest) Reset()         { *m = RemoveItemRequest{} }
+func (m *RemoveItemRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveItemRequest) ProtoMessage()    {}
+func (*RemoveItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{3}
+}
+
+func (m *RemoveItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveItemRequest.Unmarshal(m, b)
+}
+func (m *RemoveItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveItemRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveItemRequest.Merge(m, src)
+}
+func (m *RemoveItemRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveItemRequest.Size(m)
+}
+func (m *RemoveItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveItemRequest proto.InternalMessageInfo
+
+func (m *RemoveItemRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *RemoveItemRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+type UpdateItemQuantityRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId            string   `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity             int32    `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateItemQuantityRequest) Reset()         { *m = UpdateItemQuantityRequest{} }
+func (m *UpdateItemQuantityRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateItemQuantityRequest) ProtoMessage()    {}
+func (*UpdateItemQuantityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{4}
+}
+
+func (m *UpdateItemQuantityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateItemQuantityRequest.Unmarshal(m, b)
+}
+func (m *UpdateItemQuantityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateItemQuantityRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateItemQuantityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateItemQuantityRequest.Merge(m, src)
+}
+func (m *UpdateItemQuantityRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateItemQuantityRequest.Size(m)
+}
+func (m *UpdateItemQuantityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateItemQuantityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateItemQuantityRequest proto.InternalMessageInfo
+
+func (m *UpdateItemQuantityRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *UpdateItemQuantityRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *UpdateItemQuantityRequest) GetQuantity() int32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+type MergeCartsRequest struct {
+	FromUserId           string   `protobuf:"bytes,1,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId             string   `protobuf:"bytes,2,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MergeCartsRequest) Reset()         { *m = MergeCartsRequest{} }
+func (m *MergeCartsRequest) String() string { return proto.CompactTextString(m) }
+func (*MergeCartsRequest) ProtoMessage()    {}
+func (*MergeCartsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{5}
+}
+
+func (m *MergeCartsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MergeCartsRequest.Unmarshal(m, b)
+}
+func (m *MergeCartsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MergeCartsRequest.Marshal(b, m, deterministic)
+}
+func (m *MergeCartsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeCartsRequest.Merge(m, src)
+}
+func (m *MergeCartsRequest) XXX_Size() int {
+	return xxx_messageInfo_MergeCartsRequest.Size(m)
+}
+func (m *MergeCartsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeCartsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeCartsRequest proto.InternalMessageInfo
+
+func (m *MergeCartsRequest) GetFromUserId() string {
+	if m != nil {
+		return m.FromUserId
+	}
+	return ""
+}
+
+func (m *MergeCartsRequest) GetToUserId() string {
+	if m != nil {
+		return m.ToUserId
+	}
+	return ""
+}
+
 type GetCartRequest struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -169,7 +427,7 @@ func (m *GetCartRequest) Reset()         { *m = GetCartRequest{} }
 func (m *GetCartRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCartRequest) ProtoMessage()    {}
 func (*GetCartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{3}
+	return fileDescriptor_ca53982754088a9d, []int{6}
 }
 
 func (m *GetCartRequest) XXX_Unmarshal(b []byte) error {
@@ -209,7 +467,7 @@ func (m *Cart) Reset()         { *m = Cart{} }
 func (m *Cart) String() string { return proto.CompactTextString(m) }
 func (*Cart) ProtoMessage()    {}
 func (*Cart) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{4}
+	return fileDescriptor_ca53982754088a9d, []int{7}
 }
 
 func (m *Cart) XXX_Unmarshal(b []byte) error {
@@ -254,7 +512,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{5}
+	return fileDescriptor_ca53982754088a9d, []int{8}
 }
 
 func (m *Empty) XXX_Unmarshal(b []byte) error {
@@ -287,7 +545,7 @@ func (m *ListRecommendationsRequest) Reset()         { *m = ListRecommendationsR
 func (m *ListRecommendationsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRecommendationsRequest) ProtoMessage()    {}
 func (*ListRecommendationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{6}
+	return fileDescriptor_ca53982754088a9d, []int{9}
 }
 
 func (m *ListRecommendationsRequest) XXX_Unmarshal(b []byte) error {
@@ -333,7 +591,7 @@ func (m *ListRecommendationsResponse) Reset()         { *m = ListRecommendations
 func (m *ListRecommendationsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListRecommendationsResponse) ProtoMessage()    {}
 func (*ListRecommendationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{7}
+	return fileDescriptor_ca53982754088a9d, []int{10}
 }
 
 func (m *ListRecommendationsResponse) XXX_Unmarshal(b []byte) error {
@@ -369,17 +627,21 @@ type Product struct {
 	PriceUsd    *Money `protobuf:"bytes,5,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
 	// Categories such as "vintage" or "gardening" that can be used to look up
 	// other related products.
-	Categories           []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Categories []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Shipping weight in kilograms and size of the package, used to quote
+	// shipping. Unset if unknown.
+	WeightKg             float64     `protobuf:"fixed64,7,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	Dimensions           *Dimensions `protobuf:"bytes,8,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *Product) Reset()         { *m = Product{} }
 func (m *Product) String() string { return proto.CompactTextString(m) }
 func (*Product) ProtoMessage()    {}
 func (*Product) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{8}
+	return fileDescriptor_ca53982754088a9d, []int{11}
 }
 
 func (m *Product) XXX_Unmarshal(b []byte) error {
@@ -442,6 +704,75 @@ func (m *Product) GetCategories() []string {
 	return nil
 }
 
+func (m *Product) GetWeightKg() float64 {
+	if m != nil {
+		return m.WeightKg
+	}
+	return 0
+}
+
+func (m *Product) GetDimensions() *Dimensions {
+	if m != nil {
+		return m.Dimensions
+	}
+	return nil
+}
+
+type Dimensions struct {
+	LengthCm             float64  `protobuf:"fixed64,1,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm              float64  `protobuf:"fixed64,2,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm             float64  `protobuf:"fixed64,3,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dimensions) Reset()         { *m = Dimensions{} }
+func (m *Dimensions) String() string { return proto.CompactTextString(m) }
+func (*Dimensions) ProtoMessage()    {}
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{12}
+}
+
+func (m *Dimensions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dimensions.Unmarshal(m, b)
+}
+func (m *Dimensions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dimensions.Marshal(b, m, deterministic)
+}
+func (m *Dimensions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dimensions.Merge(m, src)
+}
+func (m *Dimensions) XXX_Size() int {
+	return xxx_messageInfo_Dimensions.Size(m)
+}
+func (m *Dimensions) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dimensions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dimensions proto.InternalMessageInfo
+
+func (m *Dimensions) GetLengthCm() float64 {
+	if m != nil {
+		return m.LengthCm
+	}
+	return 0
+}
+
+func (m *Dimensions) GetWidthCm() float64 {
+	if m != nil {
+		return m.WidthCm
+	}
+	return 0
+}
+
+func (m *Dimensions) GetHeightCm() float64 {
+	if m != nil {
+		return m.HeightCm
+	}
+	return 0
+}
+
 type ListProductsResponse struct {
 	Products             []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -453,7 +784,7 @@ func (m *ListProductsResponse) Reset()         { *m = ListProductsResponse{} }
 func (m *ListProductsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListProductsResponse) ProtoMessage()    {}
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{9}
+	return fileDescriptor_ca53982754088a9d, []int{13}
 }
 
 func (m *ListProductsResponse) XXX_Unmarshal(b []byte) error {
@@ -492,7 +823,7 @@ func (m *GetProductRequest) Reset()         { *m = GetProductRequest{} }
 func (m *GetProductRequest) String() string { return proto.CompactTextString(m) }
 func (*GetProductRequest) ProtoMessage()    {}
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{10}
+	return fileDescriptor_ca53982754088a9d, []int{14}
 }
 
 func (m *GetProductRequest) XXX_Unmarshal(b []byte) error {
@@ -531,7 +862,7 @@ func (m *SearchProductsRequest) Reset()         { *m = SearchProductsRequest{} }
 func (m *SearchProductsRequest) String() string { return proto.CompactTextString(m) }
 func (*SearchProductsRequest) ProtoMessage()    {}
 func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{11}
+	return fileDescriptor_ca53982754088a9d, []int{15}
 }
 
 func (m *SearchProductsRequest) XXX_Unmarshal(b []byte) error {
@@ -570,7 +901,7 @@ func (m *SearchProductsResponse) Reset()         { *m = SearchProductsResponse{}
 func (m *SearchProductsResponse) String() string { return proto.CompactTextString(m) }
 func (*SearchProductsResponse) ProtoMessage()    {}
 func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{12}
+	return fileDescriptor_ca53982754088a9d, []int{16}
 }
 
 func (m *SearchProductsResponse) XXX_Unmarshal(b []byte) error {
@@ -610,7 +941,7 @@ func (m *GetQuoteRequest) Reset()         { *m = GetQuoteRequest{} }
 func (m *GetQuoteRequest) String() string { return proto.CompactTextString(m) }
 func (*GetQuoteRequest) ProtoMessage()    {}
 func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{13}
+	return fileDescriptor_ca53982754088a9d, []int{17}
 }
 
 func (m *GetQuoteRequest) XXX_Unmarshal(b []byte) error {
@@ -646,17 +977,20 @@ func (m *GetQuoteRequest) GetItems() []*CartItem {
 }
 
 type GetQuoteResponse struct {
-	CostUsd              *Money   `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// The cost of the cheapest option.
+	CostUsd *Money `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The service levels available for the address, cheapest first.
+	Options              []*QuoteOption `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GetQuoteResponse) Reset()         { *m = GetQuoteResponse{} }
 func (m *GetQuoteResponse) String() string { return proto.CompactTextString(m) }
 func (*GetQuoteResponse) ProtoMessage()    {}
 func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{14}
+	return fileDescriptor_ca53982754088a9d, []int{18}
 }
 
 func (m *GetQuoteResponse) XXX_Unmarshal(b []byte) error {
@@ -684,19 +1018,86 @@ func (m *GetQuoteResponse) GetCostUsd() *Money {
 	return nil
 }
 
+func (m *GetQuoteResponse) GetOptions() []*QuoteOption {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type QuoteOption struct {
+	// The name of the service level, such as "standard" or "overnight".
+	ServiceLevel string `protobuf:"bytes,1,opt,name=service_level,json=serviceLevel,proto3" json:"service_level,omitempty"`
+	CostUsd      *Money `protobuf:"bytes,2,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The number of business days until delivery.
+	TransitDays          int32    `protobuf:"varint,3,opt,name=transit_days,json=transitDays,proto3" json:"transit_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteOption) Reset()         { *m = QuoteOption{} }
+func (m *QuoteOption) String() string { return proto.CompactTextString(m) }
+func (*QuoteOption) ProtoMessage()    {}
+func (*QuoteOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{19}
+}
+
+func (m *QuoteOption) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteOption.Unmarshal(m, b)
+}
+func (m *QuoteOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteOption.Marshal(b, m, deterministic)
+}
+func (m *QuoteOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteOption.Merge(m, src)
+}
+func (m *QuoteOption) XXX_Size() int {
+	return xxx_messageInfo_QuoteOption.Size(m)
+}
+func (m *QuoteOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteOption.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteOption proto.InternalMessageInfo
+
+func (m *QuoteOption) GetServiceLevel() string {
+	if m != nil {
+		return m.ServiceLevel
+	}
+	return ""
+}
+
+func (m *QuoteOption) GetCostUsd() *Money {
+	if m != nil {
+		return m.CostUsd
+	}
+	return nil
+}
+
+func (m *QuoteOption) GetTransitDays() int32 {
+	if m != nil {
+		return m.TransitDays
+	}
+	return 0
+}
+
 type ShipOrderRequest struct {
-	Address              *Address    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Items                []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Address *Address    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Items   []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// The ID of the order, if any. Shipping an order again returns its
+	// shipment.
+	OrderId              string   `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ShipOrderRequest) Reset()         { *m = ShipOrderRequest{} }
 func (m *ShipOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*ShipOrderRequest) ProtoMessage()    {}
 func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{15}
+	return fileDescriptor_ca53982754088a9d, []int{20}
 }
 
 func (m *ShipOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -731,6 +1132,13 @@ func (m *ShipOrderRequest) GetItems() []*CartItem {
 	return nil
 }
 
+func (m *ShipOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
 type ShipOrderResponse struct {
 	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -742,7 +1150,7 @@ func (m *ShipOrderResponse) Reset()         { *m = ShipOrderResponse{} }
 func (m *ShipOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*ShipOrderResponse) ProtoMessage()    {}
 func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{16}
+	return fileDescriptor_ca53982754088a9d, []int{21}
 }
 
 func (m *ShipOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -770,10 +1178,259 @@ func (m *ShipOrderResponse) GetTrackingId() string {
 	return ""
 }
 
-type Address struct {
-	StreetAddress        string   `protobuf:"bytes,1,opt,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
-	City                 string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
-	State                string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+type CancelShipmentRequest struct {
+	TrackingId string `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	// Cancels the shipment of the order with this ID if tracking_id is not
+	// set.
+	OrderId              string   `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelShipmentRequest) Reset()         { *m = CancelShipmentRequest{} }
+func (m *CancelShipmentRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelShipmentRequest) ProtoMessage()    {}
+func (*CancelShipmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{22}
+}
+
+func (m *CancelShipmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelShipmentRequest.Unmarshal(m, b)
+}
+func (m *CancelShipmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelShipmentRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelShipmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelShipmentRequest.Merge(m, src)
+}
+func (m *CancelShipmentRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelShipmentRequest.Size(m)
+}
+func (m *CancelShipmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelShipmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelShipmentRequest proto.InternalMessageInfo
+
+func (m *CancelShipmentRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+func (m *CancelShipmentRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type GetShipmentStatusRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetShipmentStatusRequest) Reset()         { *m = GetShipmentStatusRequest{} }
+func (m *GetShipmentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShipmentStatusRequest) ProtoMessage()    {}
+func (*GetShipmentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{23}
+}
+
+func (m *GetShipmentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShipmentStatusRequest.Unmarshal(m, b)
+}
+func (m *GetShipmentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShipmentStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShipmentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShipmentStatusRequest.Merge(m, src)
+}
+func (m *GetShipmentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShipmentStatusRequest.Size(m)
+}
+func (m *GetShipmentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShipmentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShipmentStatusRequest proto.InternalMessageInfo
+
+func (m *GetShipmentStatusRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type ValidateTrackingIdRequest struct {
+	TrackingId           string   `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateTrackingIdRequest) Reset()         { *m = ValidateTrackingIdRequest{} }
+func (m *ValidateTrackingIdRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateTrackingIdRequest) ProtoMessage()    {}
+func (*ValidateTrackingIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{24}
+}
+
+func (m *ValidateTrackingIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateTrackingIdRequest.Unmarshal(m, b)
+}
+func (m *ValidateTrackingIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateTrackingIdRequest.Marshal(b, m, deterministic)
+}
+func (m *ValidateTrackingIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateTrackingIdRequest.Merge(m, src)
+}
+func (m *ValidateTrackingIdRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateTrackingIdRequest.Size(m)
+}
+func (m *ValidateTrackingIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateTrackingIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateTrackingIdRequest proto.InternalMessageInfo
+
+func (m *ValidateTrackingIdRequest) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+type ValidateTrackingIdResponse struct {
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Why the tracking ID is invalid, if it is.
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateTrackingIdResponse) Reset()         { *m = ValidateTrackingIdResponse{} }
+func (m *ValidateTrackingIdResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateTrackingIdResponse) ProtoMessage()    {}
+func (*ValidateTrackingIdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{25}
+}
+
+func (m *ValidateTrackingIdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateTrackingIdResponse.Unmarshal(m, b)
+}
+func (m *ValidateTrackingIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateTrackingIdResponse.Marshal(b, m, deterministic)
+}
+func (m *ValidateTrackingIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateTrackingIdResponse.Merge(m, src)
+}
+func (m *ValidateTrackingIdResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateTrackingIdResponse.Size(m)
+}
+func (m *ValidateTrackingIdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateTrackingIdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateTrackingIdResponse proto.InternalMessageInfo
+
+func (m *ValidateTrackingIdResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ValidateTrackingIdResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ShipmentStatus struct {
+	TrackingId string               `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	State      ShipmentStatus_State `protobuf:"varint,2,opt,name=state,proto3,enum=hipstershop.ShipmentStatus_State" json:"state,omitempty"`
+	// Unix time in seconds at which the shipment entered state.
+	UpdatedAt int64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Unix time in seconds at which the shipment is expected to be delivered,
+	// or 0 once it is delivered or cancelled.
+	EstimatedDelivery    int64    `protobuf:"varint,4,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	Address              *Address `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShipmentStatus) Reset()         { *m = ShipmentStatus{} }
+func (m *ShipmentStatus) String() string { return proto.CompactTextString(m) }
+func (*ShipmentStatus) ProtoMessage()    {}
+func (*ShipmentStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{26}
+}
+
+func (m *ShipmentStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShipmentStatus.Unmarshal(m, b)
+}
+func (m *ShipmentStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShipmentStatus.Marshal(b, m, deterministic)
+}
+func (m *ShipmentStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShipmentStatus.Merge(m, src)
+}
+func (m *ShipmentStatus) XXX_Size() int {
+	return xxx_messageInfo_ShipmentStatus.Size(m)
+}
+func (m *ShipmentStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShipmentStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShipmentStatus proto.InternalMessageInfo
+
+func (m *ShipmentStatus) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+func (m *ShipmentStatus) GetState() ShipmentStatus_State {
+	if m != nil {
+		return m.State
+	}
+	return ShipmentStatus_UNKNOWN
+}
+
+func (m *ShipmentStatus) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *ShipmentStatus) GetEstimatedDelivery() int64 {
+	if m != nil {
+		return m.EstimatedDelivery
+	}
+	return 0
+}
+
+func (m *ShipmentStatus) GetAddress() *Address {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+type Address struct {
+	StreetAddress        string   `protobuf:"bytes,1,opt,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
+	City                 string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	State                string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
 	Country              string   `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
 	ZipCode              int32    `protobuf:"varint,5,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -785,7 +1442,7 @@ func (m *Address) Reset()         { *m = Address{} }
 func (m *Address) String() string { return proto.CompactTextString(m) }
 func (*Address) ProtoMessage()    {}
 func (*Address) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{17}
+	return fileDescriptor_ca53982754088a9d, []int{27}
 }
 
 func (m *Address) XXX_Unmarshal(b []byte) error {
@@ -864,7 +1521,7 @@ func (m *Money) Reset()         { *m = Money{} }
 func (m *Money) String() string { return proto.CompactTextString(m) }
 func (*Money) ProtoMessage()    {}
 func (*Money) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{18}
+	return fileDescriptor_ca53982754088a9d, []int{28}
 }
 
 func (m *Money) XXX_Unmarshal(b []byte) error {
@@ -908,17 +1565,20 @@ func (m *Money) GetNanos() int32 {
 
 type GetSupportedCurrenciesResponse struct {
 	// The 3-letter currency code defined in ISO 4217.
-	CurrencyCodes        []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CurrencyCodes []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
+	// The metadata of the supported currencies, in the order of
+	// currency_codes.
+	Currencies           []*CurrencyInfo `protobuf:"bytes,2,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *GetSupportedCurrenciesResponse) Reset()         { *m = GetSupportedCurrenciesResponse{} }
 func (m *GetSupportedCurrenciesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetSupportedCurrenciesResponse) ProtoMessage()    {}
 func (*GetSupportedCurrenciesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{19}
+	return fileDescriptor_ca53982754088a9d, []int{29}
 }
 
 func (m *GetSupportedCurrenciesResponse) XXX_Unmarshal(b []byte) error {
@@ -946,10 +1606,92 @@ func (m *GetSupportedCurrenciesResponse) GetCurrencyCodes() []string {
 	return nil
 }
 
+func (m *GetSupportedCurrenciesResponse) GetCurrencies() []*CurrencyInfo {
+	if m != nil {
+		return m.Currencies
+	}
+	return nil
+}
+
+// The ISO 4217 metadata of a currency.
+type CurrencyInfo struct {
+	// The 3-letter currency code defined in ISO 4217.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The name of the currency, such as "US Dollar".
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The symbol of the currency, such as "$".
+	Symbol string `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// The number of digits after the decimal separator, such as 2 for cents or
+	// 0 for yen.
+	MinorUnits           int32    `protobuf:"varint,4,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CurrencyInfo) Reset()         { *m = CurrencyInfo{} }
+func (m *CurrencyInfo) String() string { return proto.CompactTextString(m) }
+func (*CurrencyInfo) ProtoMessage()    {}
+func (*CurrencyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{30}
+}
+
+func (m *CurrencyInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrencyInfo.Unmarshal(m, b)
+}
+func (m *CurrencyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrencyInfo.Marshal(b, m, deterministic)
+}
+func (m *CurrencyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrencyInfo.Merge(m, src)
+}
+func (m *CurrencyInfo) XXX_Size() int {
+	return xxx_messageInfo_CurrencyInfo.Size(m)
+}
+func (m *CurrencyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrencyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CurrencyInfo proto.InternalMessageInfo
+
+func (m *CurrencyInfo) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *CurrencyInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CurrencyInfo) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *CurrencyInfo) GetMinorUnits() int32 {
+	if m != nil {
+		return m.MinorUnits
+	}
+	return 0
+}
+
 type CurrencyConversionRequest struct {
 	From *Money `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	// The 3-letter currency code defined in ISO 4217.
-	ToCode               string   `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	ToCode string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// If set, converts with this version of the exchange rates instead of the
+	// current one, for example to audit a past order.
+	RateVersion string `protobuf:"bytes,3,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	// If set, converts with the exchange rates that were current at this time,
+	// in seconds since the epoch. Cannot be combined with rate_version.
+	AsOf                 int64    `protobuf:"varint,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -959,7 +1701,7 @@ func (m *CurrencyConversionRequest) Reset()         { *m = CurrencyConversionReq
 func (m *CurrencyConversionRequest) String() string { return proto.CompactTextString(m) }
 func (*CurrencyConversionRequest) ProtoMessage()    {}
 func (*CurrencyConversionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{20}
+	return fileDescriptor_ca53982754088a9d, []int{31}
 }
 
 func (m *CurrencyConversionRequest) XXX_Unmarshal(b []byte) error {
@@ -994,82 +1736,290 @@ func (m *CurrencyConversionRequest) GetToCode() string {
 	return ""
 }
 
-type CreditCardInfo struct {
-	CreditCardNumber          string   `protobuf:"bytes,1,opt,name=credit_card_number,json=creditCardNumber,proto3" json:"credit_card_number,omitempty"`
-	CreditCardCvv             int32    `protobuf:"varint,2,opt,name=credit_card_cvv,json=creditCardCvv,proto3" json:"credit_card_cvv,omitempty"`
-	CreditCardExpirationYear  int32    `protobuf:"varint,3,opt,name=credit_card_expiration_year,json=creditCardExpirationYear,proto3" json:"credit_card_expiration_year,omitempty"`
-	CreditCardExpirationMonth int32    `protobuf:"varint,4,opt,name=credit_card_expiration_month,json=creditCardExpirationMonth,proto3" json:"credit_card_expiration_month,omitempty"`
-	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
-	XXX_unrecognized          []byte   `json:"-"`
-	XXX_sizecache             int32    `json:"-"`
+func (m *CurrencyConversionRequest) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
 }
 
-func (m *CreditCardInfo) Reset()         { *m = CreditCardInfo{} }
-func (m *CreditCardInfo) String() string { return proto.CompactTextString(m) }
-func (*CreditCardInfo) ProtoMessage()    {}
-func (*CreditCardInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{21}
+func (m *CurrencyConversionRequest) GetAsOf() int64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
 }
 
-func (m *CreditCardInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreditCardInfo.Unmarshal(m, b)
+type CurrencyConversionResponse struct {
+	Result *Money `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// The version of the exchange rates used for the conversion.
+	RateVersion string `protobuf:"bytes,2,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	// When that version of the exchange rates became current, in seconds since
+	// the epoch.
+	RatesEffectiveAt     int64    `protobuf:"varint,3,opt,name=rates_effective_at,json=ratesEffectiveAt,proto3" json:"rates_effective_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *CreditCardInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreditCardInfo.Marshal(b, m, deterministic)
+
+func (m *CurrencyConversionResponse) Reset()         { *m = CurrencyConversionResponse{} }
+func (m *CurrencyConversionResponse) String() string { return proto.CompactTextString(m) }
+func (*CurrencyConversionResponse) ProtoMessage()    {}
+func (*CurrencyConversionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{32}
 }
-func (m *CreditCardInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreditCardInfo.Merge(m, src)
+
+func (m *CurrencyConversionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrencyConversionResponse.Unmarshal(m, b)
 }
-func (m *CreditCardInfo) XXX_Size() int {
-	return xxx_messageInfo_CreditCardInfo.Size(m)
+func (m *CurrencyConversionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrencyConversionResponse.Marshal(b, m, deterministic)
 }
-func (m *CreditCardInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreditCardInfo.DiscardUnknown(m)
+func (m *CurrencyConversionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrencyConversionResponse.Merge(m, src)
+}
+func (m *CurrencyConversionResponse) XXX_Size() int {
+	return xxx_messageInfo_CurrencyConversionResponse.Size(m)
+}
+func (m *CurrencyConversionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrencyConversionResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreditCardInfo proto.InternalMessageInfo
+var xxx_messageInfo_CurrencyConversionResponse proto.InternalMessageInfo
 
-func (m *CreditCardInfo) GetCreditCardNumber() string {
+func (m *CurrencyConversionResponse) GetResult() *Money {
 	if m != nil {
-		return m.CreditCardNumber
+		return m.Result
 	}
-	return ""
+	return nil
 }
 
-func (m *CreditCardInfo) GetCreditCardCvv() int32 {
+func (m *CurrencyConversionResponse) GetRateVersion() string {
 	if m != nil {
-		return m.CreditCardCvv
+		return m.RateVersion
 	}
-	return 0
+	return ""
 }
 
-func (m *CreditCardInfo) GetCreditCardExpirationYear() int32 {
+func (m *CurrencyConversionResponse) GetRatesEffectiveAt() int64 {
 	if m != nil {
-		return m.CreditCardExpirationYear
+		return m.RatesEffectiveAt
 	}
 	return 0
 }
 
-func (m *CreditCardInfo) GetCreditCardExpirationMonth() int32 {
-	if m != nil {
-		return m.CreditCardExpirationMonth
-	}
-	return 0
+type ConvertBatchRequest struct {
+	// The amounts to convert, in any supported currencies.
+	From []*Money `protobuf:"bytes,1,rep,name=from,proto3" json:"from,omitempty"`
+	// The 3-letter currency code defined in ISO 4217.
+	ToCode string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// As in CurrencyConversionRequest.
+	RateVersion          string   `protobuf:"bytes,3,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	AsOf                 int64    `protobuf:"varint,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type ChargeRequest struct {
-	Amount               *Money          `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	CreditCard           *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *ConvertBatchRequest) Reset()         { *m = ConvertBatchRequest{} }
+func (m *ConvertBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*ConvertBatchRequest) ProtoMessage()    {}
+func (*ConvertBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{33}
+}
+
+func (m *ConvertBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConvertBatchRequest.Unmarshal(m, b)
+}
+func (m *ConvertBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConvertBatchRequest.Marshal(b, m, deterministic)
+}
+func (m *ConvertBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConvertBatchRequest.Merge(m, src)
+}
+func (m *ConvertBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_ConvertBatchRequest.Size(m)
+}
+func (m *ConvertBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConvertBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConvertBatchRequest proto.InternalMessageInfo
+
+func (m *ConvertBatchRequest) GetFrom() []*Money {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ConvertBatchRequest) GetToCode() string {
+	if m != nil {
+		return m.ToCode
+	}
+	return ""
+}
+
+func (m *ConvertBatchRequest) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
+}
+
+func (m *ConvertBatchRequest) GetAsOf() int64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
+type ConvertBatchResponse struct {
+	// The converted amounts, in the order of ConvertBatchRequest.from.
+	Results []*Money `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// The version of the exchange rates used for the conversions.
+	RateVersion string `protobuf:"bytes,2,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+	// When that version of the exchange rates became current, in seconds since
+	// the epoch.
+	RatesEffectiveAt     int64    `protobuf:"varint,3,opt,name=rates_effective_at,json=ratesEffectiveAt,proto3" json:"rates_effective_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConvertBatchResponse) Reset()         { *m = ConvertBatchResponse{} }
+func (m *ConvertBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*ConvertBatchResponse) ProtoMessage()    {}
+func (*ConvertBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{34}
+}
+
+func (m *ConvertBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConvertBatchResponse.Unmarshal(m, b)
+}
+func (m *ConvertBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConvertBatchResponse.Marshal(b, m, deterministic)
+}
+func (m *ConvertBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConvertBatchResponse.Merge(m, src)
+}
+func (m *ConvertBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_ConvertBatchResponse.Size(m)
+}
+func (m *ConvertBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConvertBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConvertBatchResponse proto.InternalMessageInfo
+
+func (m *ConvertBatchResponse) GetResults() []*Money {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *ConvertBatchResponse) GetRateVersion() string {
+	if m != nil {
+		return m.RateVersion
+	}
+	return ""
+}
+
+func (m *ConvertBatchResponse) GetRatesEffectiveAt() int64 {
+	if m != nil {
+		return m.RatesEffectiveAt
+	}
+	return 0
+}
+
+type CreditCardInfo struct {
+	CreditCardNumber          string   `protobuf:"bytes,1,opt,name=credit_card_number,json=creditCardNumber,proto3" json:"credit_card_number,omitempty"`
+	CreditCardCvv             int32    `protobuf:"varint,2,opt,name=credit_card_cvv,json=creditCardCvv,proto3" json:"credit_card_cvv,omitempty"`
+	CreditCardExpirationYear  int32    `protobuf:"varint,3,opt,name=credit_card_expiration_year,json=creditCardExpirationYear,proto3" json:"credit_card_expiration_year,omitempty"`
+	CreditCardExpirationMonth int32    `protobuf:"varint,4,opt,name=credit_card_expiration_month,json=creditCardExpirationMonth,proto3" json:"credit_card_expiration_month,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *CreditCardInfo) Reset()         { *m = CreditCardInfo{} }
+func (m *CreditCardInfo) String() string { return proto.CompactTextString(m) }
+func (*CreditCardInfo) ProtoMessage()    {}
+func (*CreditCardInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{35}
+}
+
+func (m *CreditCardInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreditCardInfo.Unmarshal(m, b)
+}
+func (m *CreditCardInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreditCardInfo.Marshal(b, m, deterministic)
+}
+func (m *CreditCardInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreditCardInfo.Merge(m, src)
+}
+func (m *CreditCardInfo) XXX_Size() int {
+	return xxx_messageInfo_CreditCardInfo.Size(m)
+}
+func (m *CreditCardInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreditCardInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreditCardInfo proto.InternalMessageInfo
+
+func (m *CreditCardInfo) GetCreditCardNumber() string {
+	if m != nil {
+		return m.CreditCardNumber
+	}
+	return ""
+}
+
+func (m *CreditCardInfo) GetCreditCardCvv() int32 {
+	if m != nil {
+		return m.CreditCardCvv
+	}
+	return 0
+}
+
+func (m *CreditCardInfo) GetCreditCardExpirationYear() int32 {
+	if m != nil {
+		return m.CreditCardExpirationYear
+	}
+	return 0
+}
+
+func (m *CreditCardInfo) GetCreditCardExpirationMonth() int32 {
+	if m != nil {
+		return m.CreditCardExpirationMonth
+	}
+	return 0
+}
+
+type ChargeRequest struct {
+	Amount *Money `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Exactly one of credit_card and card_token must be set.
+	CreditCard *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Requests with the same idempotency_key charge the card only once and
+	// return the response of the first request. May also be set with the
+	// "idempotency-key" gRPC metadata.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// A token returned by Tokenize.
+	CardToken string `protobuf:"bytes,4,opt,name=card_token,json=cardToken,proto3" json:"card_token,omitempty"`
+	// The session or user paying, and the country of their billing address,
+	// for fraud checks.
+	SessionId            string   `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	BillingCountry       string   `protobuf:"bytes,6,opt,name=billing_country,json=billingCountry,proto3" json:"billing_country,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChargeRequest) Reset()         { *m = ChargeRequest{} }
 func (m *ChargeRequest) String() string { return proto.CompactTextString(m) }
 func (*ChargeRequest) ProtoMessage()    {}
 func (*ChargeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{22}
+	return fileDescriptor_ca53982754088a9d, []int{36}
 }
 
 func (m *ChargeRequest) XXX_Unmarshal(b []byte) error {
@@ -1104,6 +2054,34 @@ func (m *ChargeRequest) GetCreditCard() *CreditCardInfo {
 	return nil
 }
 
+func (m *ChargeRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *ChargeRequest) GetCardToken() string {
+	if m != nil {
+		return m.CardToken
+	}
+	return ""
+}
+
+func (m *ChargeRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *ChargeRequest) GetBillingCountry() string {
+	if m != nil {
+		return m.BillingCountry
+	}
+	return ""
+}
+
 type ChargeResponse struct {
 	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1115,7 +2093,7 @@ func (m *ChargeResponse) Reset()         { *m = ChargeResponse{} }
 func (m *ChargeResponse) String() string { return proto.CompactTextString(m) }
 func (*ChargeResponse) ProtoMessage()    {}
 func (*ChargeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{23}
+	return fileDescriptor_ca53982754088a9d, []int{37}
 }
 
 func (m *ChargeResponse) XXX_Unmarshal(b []byte) error {
@@ -1143,1322 +2121,3913 @@ func (m *ChargeResponse) GetTransactionId() string {
 	return ""
 }
 
-type OrderItem struct {
-	Item                 *CartItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	Cost                 *Money    `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type RefundRequest struct {
+	// The transaction_id returned by Charge or Authorize.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// The amount to refund, at most what was captured and not refunded yet.
+	// Defaults to all of it.
+	Amount *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Requests with the same idempotency_key refund the transaction only once
+	// and return the refund of the first request.
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OrderItem) Reset()         { *m = OrderItem{} }
-func (m *OrderItem) String() string { return proto.CompactTextString(m) }
-func (*OrderItem) ProtoMessage()    {}
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{24}
+func (m *RefundRequest) Reset()         { *m = RefundRequest{} }
+func (m *RefundRequest) String() string { return proto.CompactTextString(m) }
+func (*RefundRequest) ProtoMessage()    {}
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{38}
 }
 
-func (m *OrderItem) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+func (m *RefundRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundRequest.Unmarshal(m, b)
 }
-func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+func (m *RefundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundRequest.Marshal(b, m, deterministic)
 }
-func (m *OrderItem) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderItem.Merge(m, src)
+func (m *RefundRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundRequest.Merge(m, src)
 }
-func (m *OrderItem) XXX_Size() int {
-	return xxx_messageInfo_OrderItem.Size(m)
+func (m *RefundRequest) XXX_Size() int {
+	return xxx_messageInfo_RefundRequest.Size(m)
 }
-func (m *OrderItem) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+func (m *RefundRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+var xxx_messageInfo_RefundRequest proto.InternalMessageInfo
 
-func (m *OrderItem) GetItem() *CartItem {
+func (m *RefundRequest) GetTransactionId() string {
 	if m != nil {
-		return m.Item
+		return m.TransactionId
 	}
-	return nil
+	return ""
 }
 
-func (m *OrderItem) GetCost() *Money {
+func (m *RefundRequest) GetAmount() *Money {
 	if m != nil {
-		return m.Cost
+		return m.Amount
 	}
 	return nil
 }
 
-type OrderResult struct {
-	OrderId              string       `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ShippingTrackingId   string       `protobuf:"bytes,2,opt,name=shipping_tracking_id,json=shippingTrackingId,proto3" json:"shipping_tracking_id,omitempty"`
-	ShippingCost         *Money       `protobuf:"bytes,3,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
-	ShippingAddress      *Address     `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
-	Items                []*OrderItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+func (m *RefundRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type RefundResponse struct {
+	RefundId string `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	// The transaction after the refund.
+	Transaction          *Transaction `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *OrderResult) Reset()         { *m = OrderResult{} }
-func (m *OrderResult) String() string { return proto.CompactTextString(m) }
-func (*OrderResult) ProtoMessage()    {}
-func (*OrderResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{25}
+func (m *RefundResponse) Reset()         { *m = RefundResponse{} }
+func (m *RefundResponse) String() string { return proto.CompactTextString(m) }
+func (*RefundResponse) ProtoMessage()    {}
+func (*RefundResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{39}
 }
 
-func (m *OrderResult) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderResult.Unmarshal(m, b)
+func (m *RefundResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundResponse.Unmarshal(m, b)
 }
-func (m *OrderResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderResult.Marshal(b, m, deterministic)
+func (m *RefundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundResponse.Marshal(b, m, deterministic)
 }
-func (m *OrderResult) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderResult.Merge(m, src)
+func (m *RefundResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundResponse.Merge(m, src)
 }
-func (m *OrderResult) XXX_Size() int {
-	return xxx_messageInfo_OrderResult.Size(m)
+func (m *RefundResponse) XXX_Size() int {
+	return xxx_messageInfo_RefundResponse.Size(m)
 }
-func (m *OrderResult) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderResult.DiscardUnknown(m)
+func (m *RefundResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderResult proto.InternalMessageInfo
+var xxx_messageInfo_RefundResponse proto.InternalMessageInfo
 
-func (m *OrderResult) GetOrderId() string {
+func (m *RefundResponse) GetRefundId() string {
 	if m != nil {
-		return m.OrderId
+		return m.RefundId
 	}
 	return ""
 }
 
-func (m *OrderResult) GetShippingTrackingId() string {
+func (m *RefundResponse) GetTransaction() *Transaction {
 	if m != nil {
-		return m.ShippingTrackingId
+		return m.Transaction
 	}
-	return ""
+	return nil
 }
 
-func (m *OrderResult) GetShippingCost() *Money {
+type AuthorizeRequest struct {
+	Amount *Money `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// As in ChargeRequest.
+	CreditCard           *CreditCardInfo `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	IdempotencyKey       string          `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	CardToken            string          `protobuf:"bytes,4,opt,name=card_token,json=cardToken,proto3" json:"card_token,omitempty"`
+	SessionId            string          `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	BillingCountry       string          `protobuf:"bytes,6,opt,name=billing_country,json=billingCountry,proto3" json:"billing_country,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AuthorizeRequest) Reset()         { *m = AuthorizeRequest{} }
+func (m *AuthorizeRequest) String() string { return proto.CompactTextString(m) }
+func (*AuthorizeRequest) ProtoMessage()    {}
+func (*AuthorizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{40}
+}
+
+func (m *AuthorizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorizeRequest.Unmarshal(m, b)
+}
+func (m *AuthorizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorizeRequest.Marshal(b, m, deterministic)
+}
+func (m *AuthorizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorizeRequest.Merge(m, src)
+}
+func (m *AuthorizeRequest) XXX_Size() int {
+	return xxx_messageInfo_AuthorizeRequest.Size(m)
+}
+func (m *AuthorizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorizeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorizeRequest proto.InternalMessageInfo
+
+func (m *AuthorizeRequest) GetAmount() *Money {
 	if m != nil {
-		return m.ShippingCost
+		return m.Amount
 	}
 	return nil
 }
 
-func (m *OrderResult) GetShippingAddress() *Address {
+func (m *AuthorizeRequest) GetCreditCard() *CreditCardInfo {
 	if m != nil {
-		return m.ShippingAddress
+		return m.CreditCard
 	}
 	return nil
 }
 
-func (m *OrderResult) GetItems() []*OrderItem {
+func (m *AuthorizeRequest) GetIdempotencyKey() string {
 	if m != nil {
-		return m.Items
+		return m.IdempotencyKey
 	}
-	return nil
+	return ""
 }
 
-type SendOrderConfirmationRequest struct {
-	Email                string       `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Order                *OrderResult `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+func (m *AuthorizeRequest) GetCardToken() string {
+	if m != nil {
+		return m.CardToken
+	}
+	return ""
 }
 
-func (m *SendOrderConfirmationRequest) Reset()         { *m = SendOrderConfirmationRequest{} }
-func (m *SendOrderConfirmationRequest) String() string { return proto.CompactTextString(m) }
-func (*SendOrderConfirmationRequest) ProtoMessage()    {}
-func (*SendOrderConfirmationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{26}
+func (m *AuthorizeRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
 }
 
-func (m *SendOrderConfirmationRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SendOrderConfirmationRequest.Unmarshal(m, b)
+func (m *AuthorizeRequest) GetBillingCountry() string {
+	if m != nil {
+		return m.BillingCountry
+	}
+	return ""
 }
-func (m *SendOrderConfirmationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SendOrderConfirmationRequest.Marshal(b, m, deterministic)
+
+// FraudAssessment is the outcome of the fraud checks of a payment. Payments
+// declined by them fail with FAILED_PRECONDITION and the assessment in the
+// details of the status.
+type FraudAssessment struct {
+	// The sum of the scores of the rules that matched.
+	Score    int32                    `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	Decision FraudAssessment_Decision `protobuf:"varint,2,opt,name=decision,proto3,enum=hipstershop.FraudAssessment_Decision" json:"decision,omitempty"`
+	// Why the rules that matched did.
+	Reasons              []string `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *SendOrderConfirmationRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendOrderConfirmationRequest.Merge(m, src)
+
+func (m *FraudAssessment) Reset()         { *m = FraudAssessment{} }
+func (m *FraudAssessment) String() string { return proto.CompactTextString(m) }
+func (*FraudAssessment) ProtoMessage()    {}
+func (*FraudAssessment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{41}
 }
-func (m *SendOrderConfirmationRequest) XXX_Size() int {
-	return xxx_messageInfo_SendOrderConfirmationRequest.Size(m)
+
+func (m *FraudAssessment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FraudAssessment.Unmarshal(m, b)
 }
-func (m *SendOrderConfirmationRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendOrderConfirmationRequest.DiscardUnknown(m)
+func (m *FraudAssessment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FraudAssessment.Marshal(b, m, deterministic)
+}
+func (m *FraudAssessment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FraudAssessment.Merge(m, src)
+}
+func (m *FraudAssessment) XXX_Size() int {
+	return xxx_messageInfo_FraudAssessment.Size(m)
+}
+func (m *FraudAssessment) XXX_DiscardUnknown() {
+	xxx_messageInfo_FraudAssessment.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendOrderConfirmationRequest proto.InternalMessageInfo
+var xxx_messageInfo_FraudAssessment proto.InternalMessageInfo
 
-func (m *SendOrderConfirmationRequest) GetEmail() string {
+func (m *FraudAssessment) GetScore() int32 {
 	if m != nil {
-		return m.Email
+		return m.Score
 	}
-	return ""
+	return 0
 }
 
-func (m *SendOrderConfirmationRequest) GetOrder() *OrderResult {
+func (m *FraudAssessment) GetDecision() FraudAssessment_Decision {
 	if m != nil {
-		return m.Order
+		return m.Decision
+	}
+	return FraudAssessment_DECISION_UNSPECIFIED
+}
+
+func (m *FraudAssessment) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
 	}
 	return nil
 }
 
-type PlaceOrderRequest struct {
-	UserId               string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserCurrency         string          `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
-	Address              *Address        `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Email                string          `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	CreditCard           *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+type TokenizeRequest struct {
+	CreditCard           *CreditCardInfo `protobuf:"bytes,1,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *PlaceOrderRequest) Reset()         { *m = PlaceOrderRequest{} }
-func (m *PlaceOrderRequest) String() string { return proto.CompactTextString(m) }
-func (*PlaceOrderRequest) ProtoMessage()    {}
-func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{27}
+func (m *TokenizeRequest) Reset()         { *m = TokenizeRequest{} }
+func (m *TokenizeRequest) String() string { return proto.CompactTextString(m) }
+func (*TokenizeRequest) ProtoMessage()    {}
+func (*TokenizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{42}
 }
 
-func (m *PlaceOrderRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PlaceOrderRequest.Unmarshal(m, b)
+func (m *TokenizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenizeRequest.Unmarshal(m, b)
 }
-func (m *PlaceOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PlaceOrderRequest.Marshal(b, m, deterministic)
+func (m *TokenizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenizeRequest.Marshal(b, m, deterministic)
 }
-func (m *PlaceOrderRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PlaceOrderRequest.Merge(m, src)
+func (m *TokenizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenizeRequest.Merge(m, src)
 }
-func (m *PlaceOrderRequest) XXX_Size() int {
-	return xxx_messageInfo_PlaceOrderRequest.Size(m)
+func (m *TokenizeRequest) XXX_Size() int {
+	return xxx_messageInfo_TokenizeRequest.Size(m)
 }
-func (m *PlaceOrderRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PlaceOrderRequest.DiscardUnknown(m)
+func (m *TokenizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenizeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PlaceOrderRequest proto.InternalMessageInfo
+var xxx_messageInfo_TokenizeRequest proto.InternalMessageInfo
 
-func (m *PlaceOrderRequest) GetUserId() string {
+func (m *TokenizeRequest) GetCreditCard() *CreditCardInfo {
 	if m != nil {
-		return m.UserId
+		return m.CreditCard
 	}
-	return ""
+	return nil
 }
 
-func (m *PlaceOrderRequest) GetUserCurrency() string {
-	if m != nil {
-		return m.UserCurrency
-	}
-	return ""
+type TokenizeResponse struct {
+	// An opaque token of the card.
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	CardBrand            string   `protobuf:"bytes,2,opt,name=card_brand,json=cardBrand,proto3" json:"card_brand,omitempty"`
+	CardLastFour         string   `protobuf:"bytes,3,opt,name=card_last_four,json=cardLastFour,proto3" json:"card_last_four,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PlaceOrderRequest) GetAddress() *Address {
+func (m *TokenizeResponse) Reset()         { *m = TokenizeResponse{} }
+func (m *TokenizeResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenizeResponse) ProtoMessage()    {}
+func (*TokenizeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{43}
+}
+
+func (m *TokenizeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenizeResponse.Unmarshal(m, b)
+}
+func (m *TokenizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenizeResponse.Marshal(b, m, deterministic)
+}
+func (m *TokenizeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenizeResponse.Merge(m, src)
+}
+func (m *TokenizeResponse) XXX_Size() int {
+	return xxx_messageInfo_TokenizeResponse.Size(m)
+}
+func (m *TokenizeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenizeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenizeResponse proto.InternalMessageInfo
+
+func (m *TokenizeResponse) GetToken() string {
 	if m != nil {
-		return m.Address
+		return m.Token
 	}
-	return nil
+	return ""
 }
 
-func (m *PlaceOrderRequest) GetEmail() string {
+func (m *TokenizeResponse) GetCardBrand() string {
 	if m != nil {
-		return m.Email
+		return m.CardBrand
 	}
 	return ""
 }
 
-func (m *PlaceOrderRequest) GetCreditCard() *CreditCardInfo {
+func (m *TokenizeResponse) GetCardLastFour() string {
 	if m != nil {
-		return m.CreditCard
+		return m.CardLastFour
 	}
-	return nil
+	return ""
 }
 
-type PlaceOrderResponse struct {
-	Order                *OrderResult `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type CaptureRequest struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// The amount to capture, at most the authorized amount. Defaults to all
+	// of it.
+	Amount               *Money   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PlaceOrderResponse) Reset()         { *m = PlaceOrderResponse{} }
-func (m *PlaceOrderResponse) String() string { return proto.CompactTextString(m) }
-func (*PlaceOrderResponse) ProtoMessage()    {}
-func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{28}
+func (m *CaptureRequest) Reset()         { *m = CaptureRequest{} }
+func (m *CaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*CaptureRequest) ProtoMessage()    {}
+func (*CaptureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{44}
 }
 
-func (m *PlaceOrderResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PlaceOrderResponse.Unmarshal(m, b)
+func (m *CaptureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureRequest.Unmarshal(m, b)
 }
-func (m *PlaceOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PlaceOrderResponse.Marshal(b, m, deterministic)
+func (m *CaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureRequest.Marshal(b, m, deterministic)
 }
-func (m *PlaceOrderResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PlaceOrderResponse.Merge(m, src)
+func (m *CaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureRequest.Merge(m, src)
 }
-func (m *PlaceOrderResponse) XXX_Size() int {
-	return xxx_messageInfo_PlaceOrderResponse.Size(m)
+func (m *CaptureRequest) XXX_Size() int {
+	return xxx_messageInfo_CaptureRequest.Size(m)
 }
-func (m *PlaceOrderResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PlaceOrderResponse.DiscardUnknown(m)
+func (m *CaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PlaceOrderResponse proto.InternalMessageInfo
+var xxx_messageInfo_CaptureRequest proto.InternalMessageInfo
 
-func (m *PlaceOrderResponse) GetOrder() *OrderResult {
+func (m *CaptureRequest) GetTransactionId() string {
 	if m != nil {
-		return m.Order
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *CaptureRequest) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
 	}
 	return nil
 }
 
-type AdRequest struct {
-	// List of important key words from the current page describing the context.
-	ContextKeys          []string `protobuf:"bytes,1,rep,name=context_keys,json=contextKeys,proto3" json:"context_keys,omitempty"`
+type VoidRequest struct {
+	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AdRequest) Reset()         { *m = AdRequest{} }
-func (m *AdRequest) String() string { return proto.CompactTextString(m) }
-func (*AdRequest) ProtoMessage()    {}
-func (*AdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{29}
+func (m *VoidRequest) Reset()         { *m = VoidRequest{} }
+func (m *VoidRequest) String() string { return proto.CompactTextString(m) }
+func (*VoidRequest) ProtoMessage()    {}
+func (*VoidRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{45}
 }
 
-func (m *AdRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AdRequest.Unmarshal(m, b)
+func (m *VoidRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VoidRequest.Unmarshal(m, b)
 }
-func (m *AdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AdRequest.Marshal(b, m, deterministic)
+func (m *VoidRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VoidRequest.Marshal(b, m, deterministic)
 }
-func (m *AdRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AdRequest.Merge(m, src)
+func (m *VoidRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoidRequest.Merge(m, src)
 }
-func (m *AdRequest) XXX_Size() int {
-	return xxx_messageInfo_AdRequest.Size(m)
+func (m *VoidRequest) XXX_Size() int {
+	return xxx_messageInfo_VoidRequest.Size(m)
 }
-func (m *AdRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AdRequest.DiscardUnknown(m)
+func (m *VoidRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoidRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AdRequest proto.InternalMessageInfo
+var xxx_messageInfo_VoidRequest proto.InternalMessageInfo
 
-func (m *AdRequest) GetContextKeys() []string {
+func (m *VoidRequest) GetTransactionId() string {
 	if m != nil {
-		return m.ContextKeys
+		return m.TransactionId
 	}
-	return nil
+	return ""
 }
 
-type AdResponse struct {
-	Ads                  []*Ad    `protobuf:"bytes,1,rep,name=ads,proto3" json:"ads,omitempty"`
+type GetTransactionRequest struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Gets the last authorization with this idempotency_key if
+	// transaction_id is not set.
+	IdempotencyKey       string   `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AdResponse) Reset()         { *m = AdResponse{} }
-func (m *AdResponse) String() string { return proto.CompactTextString(m) }
-func (*AdResponse) ProtoMessage()    {}
-func (*AdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{30}
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{46}
 }
 
-func (m *AdResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AdResponse.Unmarshal(m, b)
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
 }
-func (m *AdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AdResponse.Marshal(b, m, deterministic)
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
 }
-func (m *AdResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AdResponse.Merge(m, src)
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
 }
-func (m *AdResponse) XXX_Size() int {
-	return xxx_messageInfo_AdResponse.Size(m)
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
 }
-func (m *AdResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AdResponse.DiscardUnknown(m)
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AdResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
 
-func (m *AdResponse) GetAds() []*Ad {
+func (m *GetTransactionRequest) GetTransactionId() string {
 	if m != nil {
-		return m.Ads
+		return m.TransactionId
 	}
-	return nil
+	return ""
 }
 
-type Ad struct {
-	// url to redirect to when an ad is clicked.
-	RedirectUrl string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
-	// short advertisement text to display.
-	Text                 string   `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+func (m *GetTransactionRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type ReconcileTransactionRequest struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Whether the payment gateway made the pending change.
+	Applied bool `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	// The ID of the authorization with the payment gateway, if the pending
+	// change is an authorization that the gateway made.
+	GatewayReference     string   `protobuf:"bytes,3,opt,name=gateway_reference,json=gatewayReference,proto3" json:"gateway_reference,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Ad) Reset()         { *m = Ad{} }
-func (m *Ad) String() string { return proto.CompactTextString(m) }
-func (*Ad) ProtoMessage()    {}
-func (*Ad) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca53982754088a9d, []int{31}
+func (m *ReconcileTransactionRequest) Reset()         { *m = ReconcileTransactionRequest{} }
+func (m *ReconcileTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconcileTransactionRequest) ProtoMessage()    {}
+func (*ReconcileTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{47}
 }
 
-func (m *Ad) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Ad.Unmarshal(m, b)
+func (m *ReconcileTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileTransactionRequest.Unmarshal(m, b)
 }
-func (m *Ad) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Ad.Marshal(b, m, deterministic)
+func (m *ReconcileTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileTransactionRequest.Marshal(b, m, deterministic)
 }
-func (m *Ad) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Ad.Merge(m, src)
+func (m *ReconcileTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileTransactionRequest.Merge(m, src)
 }
-func (m *Ad) XXX_Size() int {
-	return xxx_messageInfo_Ad.Size(m)
+func (m *ReconcileTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconcileTransactionRequest.Size(m)
 }
-func (m *Ad) XXX_DiscardUnknown() {
-	xxx_messageInfo_Ad.DiscardUnknown(m)
+func (m *ReconcileTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileTransactionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Ad proto.InternalMessageInfo
+var xxx_messageInfo_ReconcileTransactionRequest proto.InternalMessageInfo
 
-func (m *Ad) GetRedirectUrl() string {
+func (m *ReconcileTransactionRequest) GetTransactionId() string {
 	if m != nil {
-		return m.RedirectUrl
+		return m.TransactionId
 	}
 	return ""
 }
 
-func (m *Ad) GetText() string {
+func (m *ReconcileTransactionRequest) GetApplied() bool {
 	if m != nil {
-		return m.Text
+		return m.Applied
 	}
-	return ""
+	return false
 }
 
-func init() {
-	proto.RegisterType((*CartItem)(nil), "hipstershop.CartItem")
-	proto.RegisterType((*AddItemRequest)(nil), "hipstershop.AddItemRequest")
-	proto.RegisterType((*EmptyCartRequest)(nil), "hipstershop.EmptyCartRequest")
-	proto.RegisterType((*GetCartRequest)(nil), "hipstershop.GetCartRequest")
-	proto.RegisterType((*Cart)(nil), "hipstershop.Cart")
-	proto.RegisterType((*Empty)(nil), "hipstershop.Empty")
-	proto.RegisterType((*ListRecommendationsRequest)(nil), "hipstershop.ListRecommendationsRequest")
-	proto.RegisterType((*ListRecommendationsResponse)(nil), "hipstershop.ListRecommendationsResponse")
-	proto.RegisterType((*Product)(nil), "hipstershop.Product")
-	proto.RegisterType((*ListProductsResponse)(nil), "hipstershop.ListProductsResponse")
-	proto.RegisterType((*GetProductRequest)(nil), "hipstershop.GetProductRequest")
-	proto.RegisterType((*SearchProductsRequest)(nil), "hipstershop.SearchProductsRequest")
-	proto.RegisterType((*SearchProductsResponse)(nil), "hipstershop.SearchProductsResponse")
-	proto.RegisterType((*GetQuoteRequest)(nil), "hipstershop.GetQuoteRequest")
-	proto.RegisterType((*GetQuoteResponse)(nil), "hipstershop.GetQuoteResponse")
-	proto.RegisterType((*ShipOrderRequest)(nil), "hipstershop.ShipOrderRequest")
-	proto.RegisterType((*ShipOrderResponse)(nil), "hipstershop.ShipOrderResponse")
-	proto.RegisterType((*Address)(nil), "hipstershop.Address")
-	proto.RegisterType((*Money)(nil), "hipstershop.Money")
-	proto.RegisterType((*GetSupportedCurrenciesResponse)(nil), "hipstershop.GetSupportedCurrenciesResponse")
-	proto.RegisterType((*CurrencyConversionRequest)(nil), "hipstershop.CurrencyConversionRequest")
-	proto.RegisterType((*CreditCardInfo)(nil), "hipstershop.CreditCardInfo")
-	proto.RegisterType((*ChargeRequest)(nil), "hipstershop.ChargeRequest")
-	proto.RegisterType((*ChargeResponse)(nil), "hipstershop.ChargeResponse")
-	proto.RegisterType((*OrderItem)(nil), "hipstershop.OrderItem")
-	proto.RegisterType((*OrderResult)(nil), "hipstershop.OrderResult")
-	proto.RegisterType((*SendOrderConfirmationRequest)(nil), "hipstershop.SendOrderConfirmationRequest")
-	proto.RegisterType((*PlaceOrderRequest)(nil), "hipstershop.PlaceOrderRequest")
-	proto.RegisterType((*PlaceOrderResponse)(nil), "hipstershop.PlaceOrderResponse")
-	proto.RegisterType((*AdRequest)(nil), "hipstershop.AdRequest")
-	proto.RegisterType((*AdResponse)(nil), "hipstershop.AdResponse")
-	proto.RegisterType((*Ad)(nil), "hipstershop.Ad")
+func (m *ReconcileTransactionRequest) GetGatewayReference() string {
+	if m != nil {
+		return m.GatewayReference
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// CartServiceClient is the client API for CartService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CartServiceClient interface {
-	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*Empty, error)
-	GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*Cart, error)
-	EmptyCart(ctx context.Context, in *EmptyCartRequest, opts ...grpc.CallOption) (*Empty, error)
+type Transaction struct {
+	TransactionId string               `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	State         Transaction_State    `protobuf:"varint,2,opt,name=state,proto3,enum=hipstershop.Transaction_State" json:"state,omitempty"`
+	Authorized    *Money               `protobuf:"bytes,3,opt,name=authorized,proto3" json:"authorized,omitempty"`
+	Captured      *Money               `protobuf:"bytes,4,opt,name=captured,proto3" json:"captured,omitempty"`
+	Refunded      *Money               `protobuf:"bytes,5,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	Refunds       []*TransactionRefund `protobuf:"bytes,6,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	CardBrand     string               `protobuf:"bytes,7,opt,name=card_brand,json=cardBrand,proto3" json:"card_brand,omitempty"`
+	CardLastFour  string               `protobuf:"bytes,8,opt,name=card_last_four,json=cardLastFour,proto3" json:"card_last_four,omitempty"`
+	// Times in seconds since the epoch.
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// The ID of the authorization with the payment gateway.
+	GatewayReference string           `protobuf:"bytes,11,opt,name=gateway_reference,json=gatewayReference,proto3" json:"gateway_reference,omitempty"`
+	FraudAssessment  *FraudAssessment `protobuf:"bytes,12,opt,name=fraud_assessment,json=fraudAssessment,proto3" json:"fraud_assessment,omitempty"`
+	// The change that is being made with the payment gateway. A change whose
+	// outcome is unknown, e.g. because the gateway timed out or the service
+	// crashed, blocks the transaction until it is reconciled with
+	// ReconcileTransaction.
+	PendingChange *PendingChange `protobuf:"bytes,13,opt,name=pending_change,json=pendingChange,proto3" json:"pending_change,omitempty"`
+	// The idempotency_key of the AuthorizeRequest, if any.
+	IdempotencyKey       string   `protobuf:"bytes,14,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type cartServiceClient struct {
-	cc *grpc.ClientConn
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{48}
 }
 
-func NewCartServiceClient(cc *grpc.ClientConn) CartServiceClient {
-	return &cartServiceClient{cc}
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *Transaction) GetState() Transaction_State {
+	if m != nil {
+		return m.State
+	}
+	return Transaction_STATE_UNSPECIFIED
+}
+
+func (m *Transaction) GetAuthorized() *Money {
+	if m != nil {
+		return m.Authorized
+	}
+	return nil
+}
+
+func (m *Transaction) GetCaptured() *Money {
+	if m != nil {
+		return m.Captured
+	}
+	return nil
+}
+
+func (m *Transaction) GetRefunded() *Money {
+	if m != nil {
+		return m.Refunded
+	}
+	return nil
+}
+
+func (m *Transaction) GetRefunds() []*TransactionRefund {
+	if m != nil {
+		return m.Refunds
+	}
+	return nil
+}
+
+func (m *Transaction) GetCardBrand() string {
+	if m != nil {
+		return m.CardBrand
+	}
+	return ""
+}
+
+func (m *Transaction) GetCardLastFour() string {
+	if m != nil {
+		return m.CardLastFour
+	}
+	return ""
+}
+
+func (m *Transaction) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Transaction) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *Transaction) GetGatewayReference() string {
+	if m != nil {
+		return m.GatewayReference
+	}
+	return ""
+}
+
+func (m *Transaction) GetFraudAssessment() *FraudAssessment {
+	if m != nil {
+		return m.FraudAssessment
+	}
+	return nil
+}
+
+func (m *Transaction) GetPendingChange() *PendingChange {
+	if m != nil {
+		return m.PendingChange
+	}
+	return nil
+}
+
+func (m *Transaction) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type PendingChange struct {
+	// The state that the change moves the transaction to.
+	State  Transaction_State `protobuf:"varint,1,opt,name=state,proto3,enum=hipstershop.Transaction_State" json:"state,omitempty"`
+	Amount *Money            `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// In seconds since the epoch.
+	StartedAt int64 `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// The idempotency_key of a pending refund, if any.
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingChange) Reset()         { *m = PendingChange{} }
+func (m *PendingChange) String() string { return proto.CompactTextString(m) }
+func (*PendingChange) ProtoMessage()    {}
+func (*PendingChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{49}
+}
+
+func (m *PendingChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingChange.Unmarshal(m, b)
+}
+func (m *PendingChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingChange.Marshal(b, m, deterministic)
+}
+func (m *PendingChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingChange.Merge(m, src)
+}
+func (m *PendingChange) XXX_Size() int {
+	return xxx_messageInfo_PendingChange.Size(m)
+}
+func (m *PendingChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingChange proto.InternalMessageInfo
+
+func (m *PendingChange) GetState() Transaction_State {
+	if m != nil {
+		return m.State
+	}
+	return Transaction_STATE_UNSPECIFIED
+}
+
+func (m *PendingChange) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *PendingChange) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
+func (m *PendingChange) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type TransactionRefund struct {
+	RefundId  string `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Amount    *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreatedAt int64  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The idempotency_key of the RefundRequest, if any.
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionRefund) Reset()         { *m = TransactionRefund{} }
+func (m *TransactionRefund) String() string { return proto.CompactTextString(m) }
+func (*TransactionRefund) ProtoMessage()    {}
+func (*TransactionRefund) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{50}
+}
+
+func (m *TransactionRefund) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionRefund.Unmarshal(m, b)
+}
+func (m *TransactionRefund) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionRefund.Marshal(b, m, deterministic)
+}
+func (m *TransactionRefund) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionRefund.Merge(m, src)
+}
+func (m *TransactionRefund) XXX_Size() int {
+	return xxx_messageInfo_TransactionRefund.Size(m)
+}
+func (m *TransactionRefund) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionRefund.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionRefund proto.InternalMessageInfo
+
+func (m *TransactionRefund) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+func (m *TransactionRefund) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *TransactionRefund) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *TransactionRefund) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type OrderItem struct {
+	Item                 *CartItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Cost                 *Money    `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *OrderItem) Reset()         { *m = OrderItem{} }
+func (m *OrderItem) String() string { return proto.CompactTextString(m) }
+func (*OrderItem) ProtoMessage()    {}
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{51}
+}
+
+func (m *OrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+}
+func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+}
+func (m *OrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderItem.Merge(m, src)
+}
+func (m *OrderItem) XXX_Size() int {
+	return xxx_messageInfo_OrderItem.Size(m)
+}
+func (m *OrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+
+func (m *OrderItem) GetItem() *CartItem {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (m *OrderItem) GetCost() *Money {
+	if m != nil {
+		return m.Cost
+	}
+	return nil
+}
+
+type OrderResult struct {
+	OrderId              string       `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ShippingTrackingId   string       `protobuf:"bytes,2,opt,name=shipping_tracking_id,json=shippingTrackingId,proto3" json:"shipping_tracking_id,omitempty"`
+	ShippingCost         *Money       `protobuf:"bytes,3,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	ShippingAddress      *Address     `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	Items                []*OrderItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *OrderResult) Reset()         { *m = OrderResult{} }
+func (m *OrderResult) String() string { return proto.CompactTextString(m) }
+func (*OrderResult) ProtoMessage()    {}
+func (*OrderResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{52}
+}
+
+func (m *OrderResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderResult.Unmarshal(m, b)
+}
+func (m *OrderResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderResult.Marshal(b, m, deterministic)
+}
+func (m *OrderResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderResult.Merge(m, src)
+}
+func (m *OrderResult) XXX_Size() int {
+	return xxx_messageInfo_OrderResult.Size(m)
+}
+func (m *OrderResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderResult proto.InternalMessageInfo
+
+func (m *OrderResult) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *OrderResult) GetShippingTrackingId() string {
+	if m != nil {
+		return m.ShippingTrackingId
+	}
+	return ""
+}
+
+func (m *OrderResult) GetShippingCost() *Money {
+	if m != nil {
+		return m.ShippingCost
+	}
+	return nil
+}
+
+func (m *OrderResult) GetShippingAddress() *Address {
+	if m != nil {
+		return m.ShippingAddress
+	}
+	return nil
+}
+
+func (m *OrderResult) GetItems() []*OrderItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type SendOrderConfirmationRequest struct {
+	Email                string       `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Order                *OrderResult `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SendOrderConfirmationRequest) Reset()         { *m = SendOrderConfirmationRequest{} }
+func (m *SendOrderConfirmationRequest) String() string { return proto.CompactTextString(m) }
+func (*SendOrderConfirmationRequest) ProtoMessage()    {}
+func (*SendOrderConfirmationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{53}
+}
+
+func (m *SendOrderConfirmationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendOrderConfirmationRequest.Unmarshal(m, b)
+}
+func (m *SendOrderConfirmationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendOrderConfirmationRequest.Marshal(b, m, deterministic)
+}
+func (m *SendOrderConfirmationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendOrderConfirmationRequest.Merge(m, src)
+}
+func (m *SendOrderConfirmationRequest) XXX_Size() int {
+	return xxx_messageInfo_SendOrderConfirmationRequest.Size(m)
+}
+func (m *SendOrderConfirmationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendOrderConfirmationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendOrderConfirmationRequest proto.InternalMessageInfo
+
+func (m *SendOrderConfirmationRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *SendOrderConfirmationRequest) GetOrder() *OrderResult {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type SendCartReminderRequest struct {
+	UserId string      `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// last_updated is when the cart was last changed, in seconds since the
+	// epoch.
+	LastUpdated          int64    `protobuf:"varint,3,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendCartReminderRequest) Reset()         { *m = SendCartReminderRequest{} }
+func (m *SendCartReminderRequest) String() string { return proto.CompactTextString(m) }
+func (*SendCartReminderRequest) ProtoMessage()    {}
+func (*SendCartReminderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{54}
+}
+
+func (m *SendCartReminderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendCartReminderRequest.Unmarshal(m, b)
+}
+func (m *SendCartReminderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendCartReminderRequest.Marshal(b, m, deterministic)
+}
+func (m *SendCartReminderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendCartReminderRequest.Merge(m, src)
+}
+func (m *SendCartReminderRequest) XXX_Size() int {
+	return xxx_messageInfo_SendCartReminderRequest.Size(m)
+}
+func (m *SendCartReminderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendCartReminderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendCartReminderRequest proto.InternalMessageInfo
+
+func (m *SendCartReminderRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SendCartReminderRequest) GetItems() []*CartItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *SendCartReminderRequest) GetLastUpdated() int64 {
+	if m != nil {
+		return m.LastUpdated
+	}
+	return 0
+}
+
+type SendLoginLinkRequest struct {
+	Email                string   `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Link                 string   `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendLoginLinkRequest) Reset()         { *m = SendLoginLinkRequest{} }
+func (m *SendLoginLinkRequest) String() string { return proto.CompactTextString(m) }
+func (*SendLoginLinkRequest) ProtoMessage()    {}
+func (*SendLoginLinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{55}
+}
+
+func (m *SendLoginLinkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendLoginLinkRequest.Unmarshal(m, b)
+}
+func (m *SendLoginLinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendLoginLinkRequest.Marshal(b, m, deterministic)
+}
+func (m *SendLoginLinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendLoginLinkRequest.Merge(m, src)
+}
+func (m *SendLoginLinkRequest) XXX_Size() int {
+	return xxx_messageInfo_SendLoginLinkRequest.Size(m)
+}
+func (m *SendLoginLinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendLoginLinkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendLoginLinkRequest proto.InternalMessageInfo
+
+func (m *SendLoginLinkRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *SendLoginLinkRequest) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+type PlaceOrderRequest struct {
+	UserId       string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserCurrency string   `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
+	Address      *Address `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Email        string   `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// Exactly one of credit_card and card_token must be set. A card is
+	// tokenized before it is charged; passing a token returned by
+	// PaymentService.Tokenize keeps the card number out of the checkout.
+	CreditCard *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Requests of a user with the same idempotency_key place the order only
+	// once and return the result of the first request. May also be set with
+	// the "idempotency-key" gRPC metadata.
+	IdempotencyKey       string   `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	CardToken            string   `protobuf:"bytes,8,opt,name=card_token,json=cardToken,proto3" json:"card_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlaceOrderRequest) Reset()         { *m = PlaceOrderRequest{} }
+func (m *PlaceOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*PlaceOrderRequest) ProtoMessage()    {}
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{56}
+}
+
+func (m *PlaceOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceOrderRequest.Unmarshal(m, b)
+}
+func (m *PlaceOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *PlaceOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceOrderRequest.Merge(m, src)
+}
+func (m *PlaceOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_PlaceOrderRequest.Size(m)
+}
+func (m *PlaceOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceOrderRequest proto.InternalMessageInfo
+
+func (m *PlaceOrderRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *PlaceOrderRequest) GetUserCurrency() string {
+	if m != nil {
+		return m.UserCurrency
+	}
+	return ""
+}
+
+func (m *PlaceOrderRequest) GetAddress() *Address {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *PlaceOrderRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *PlaceOrderRequest) GetCreditCard() *CreditCardInfo {
+	if m != nil {
+		return m.CreditCard
+	}
+	return nil
+}
+
+func (m *PlaceOrderRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *PlaceOrderRequest) GetCardToken() string {
+	if m != nil {
+		return m.CardToken
+	}
+	return ""
+}
+
+type PlaceOrderResponse struct {
+	Order                *OrderResult `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PlaceOrderResponse) Reset()         { *m = PlaceOrderResponse{} }
+func (m *PlaceOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*PlaceOrderResponse) ProtoMessage()    {}
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{57}
+}
+
+func (m *PlaceOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceOrderResponse.Unmarshal(m, b)
+}
+func (m *PlaceOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceOrderResponse.Marshal(b, m, deterministic)
+}
+func (m *PlaceOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceOrderResponse.Merge(m, src)
+}
+func (m *PlaceOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_PlaceOrderResponse.Size(m)
+}
+func (m *PlaceOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceOrderResponse proto.InternalMessageInfo
+
+func (m *PlaceOrderResponse) GetOrder() *OrderResult {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type Order struct {
+	Result *OrderResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	UserId string       `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email  string       `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Total  *Money       `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Unix time in seconds at which the order was placed.
+	PlacedAt             int64    `protobuf:"varint,5,opt,name=placed_at,json=placedAt,proto3" json:"placed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+func (*Order) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{58}
+}
+
+func (m *Order) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Order.Unmarshal(m, b)
+}
+func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+}
+func (m *Order) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Order.Merge(m, src)
+}
+func (m *Order) XXX_Size() int {
+	return xxx_messageInfo_Order.Size(m)
+}
+func (m *Order) XXX_DiscardUnknown() {
+	xxx_messageInfo_Order.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Order proto.InternalMessageInfo
+
+func (m *Order) GetResult() *OrderResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *Order) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Order) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Order) GetTotal() *Money {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *Order) GetPlacedAt() int64 {
+	if m != nil {
+		return m.PlacedAt
+	}
+	return 0
+}
+
+type AddOrderRequest struct {
+	Order                *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddOrderRequest) Reset()         { *m = AddOrderRequest{} }
+func (m *AddOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*AddOrderRequest) ProtoMessage()    {}
+func (*AddOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{59}
+}
+
+func (m *AddOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddOrderRequest.Unmarshal(m, b)
+}
+func (m *AddOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *AddOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddOrderRequest.Merge(m, src)
+}
+func (m *AddOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_AddOrderRequest.Size(m)
+}
+func (m *AddOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddOrderRequest proto.InternalMessageInfo
+
+func (m *AddOrderRequest) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
+func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderRequest) ProtoMessage()    {}
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{60}
+}
+
+func (m *GetOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderRequest.Unmarshal(m, b)
+}
+func (m *GetOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderRequest.Merge(m, src)
+}
+func (m *GetOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderRequest.Size(m)
+}
+func (m *GetOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderRequest proto.InternalMessageInfo
+
+func (m *GetOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type ListOrdersByUserRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersByUserRequest) Reset()         { *m = ListOrdersByUserRequest{} }
+func (m *ListOrdersByUserRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersByUserRequest) ProtoMessage()    {}
+func (*ListOrdersByUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{61}
+}
+
+func (m *ListOrdersByUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersByUserRequest.Unmarshal(m, b)
+}
+func (m *ListOrdersByUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersByUserRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersByUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersByUserRequest.Merge(m, src)
+}
+func (m *ListOrdersByUserRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersByUserRequest.Size(m)
+}
+func (m *ListOrdersByUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersByUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersByUserRequest proto.InternalMessageInfo
+
+func (m *ListOrdersByUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+// Orders are sorted by placed_at, most recent first.
+type ListOrdersByUserResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersByUserResponse) Reset()         { *m = ListOrdersByUserResponse{} }
+func (m *ListOrdersByUserResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersByUserResponse) ProtoMessage()    {}
+func (*ListOrdersByUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{62}
+}
+
+func (m *ListOrdersByUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersByUserResponse.Unmarshal(m, b)
+}
+func (m *ListOrdersByUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersByUserResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersByUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersByUserResponse.Merge(m, src)
+}
+func (m *ListOrdersByUserResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersByUserResponse.Size(m)
+}
+func (m *ListOrdersByUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersByUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersByUserResponse proto.InternalMessageInfo
+
+func (m *ListOrdersByUserResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type AdRequest struct {
+	// List of important key words from the current page describing the context.
+	ContextKeys          []string `protobuf:"bytes,1,rep,name=context_keys,json=contextKeys,proto3" json:"context_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdRequest) Reset()         { *m = AdRequest{} }
+func (m *AdRequest) String() string { return proto.CompactTextString(m) }
+func (*AdRequest) ProtoMessage()    {}
+func (*AdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{63}
+}
+
+func (m *AdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdRequest.Unmarshal(m, b)
+}
+func (m *AdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdRequest.Marshal(b, m, deterministic)
+}
+func (m *AdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdRequest.Merge(m, src)
+}
+func (m *AdRequest) XXX_Size() int {
+	return xxx_messageInfo_AdRequest.Size(m)
+}
+func (m *AdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdRequest proto.InternalMessageInfo
+
+func (m *AdRequest) GetContextKeys() []string {
+	if m != nil {
+		return m.ContextKeys
+	}
+	return nil
+}
+
+type AdResponse struct {
+	Ads                  []*Ad    `protobuf:"bytes,1,rep,name=ads,proto3" json:"ads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdResponse) Reset()         { *m = AdResponse{} }
+func (m *AdResponse) String() string { return proto.CompactTextString(m) }
+func (*AdResponse) ProtoMessage()    {}
+func (*AdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{64}
+}
+
+func (m *AdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdResponse.Unmarshal(m, b)
+}
+func (m *AdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdResponse.Marshal(b, m, deterministic)
+}
+func (m *AdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdResponse.Merge(m, src)
+}
+func (m *AdResponse) XXX_Size() int {
+	return xxx_messageInfo_AdResponse.Size(m)
+}
+func (m *AdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdResponse proto.InternalMessageInfo
+
+func (m *AdResponse) GetAds() []*Ad {
+	if m != nil {
+		return m.Ads
+	}
+	return nil
+}
+
+type Ad struct {
+	// url to redirect to when an ad is clicked.
+	RedirectUrl string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	// short advertisement text to display.
+	Text                 string   `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ad) Reset()         { *m = Ad{} }
+func (m *Ad) String() string { return proto.CompactTextString(m) }
+func (*Ad) ProtoMessage()    {}
+func (*Ad) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{65}
+}
+
+func (m *Ad) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ad.Unmarshal(m, b)
+}
+func (m *Ad) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ad.Marshal(b, m, deterministic)
+}
+func (m *Ad) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ad.Merge(m, src)
+}
+func (m *Ad) XXX_Size() int {
+	return xxx_messageInfo_Ad.Size(m)
+}
+func (m *Ad) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ad.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ad proto.InternalMessageInfo
+
+func (m *Ad) GetRedirectUrl() string {
+	if m != nil {
+		return m.RedirectUrl
+	}
+	return ""
+}
+
+func (m *Ad) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+type CreateRequest struct {
+	// Fully-qualified gRPC service the fault applies to, e.g.
+	// "hipstershop.CartService".
+	Svc string `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	// Full method name to fail, e.g. "/hipstershop.CartService/GetCart".
+	// Empty or "*" matches every method of svc.
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// Percentage of matching calls to affect, between 0 and 100.
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// gRPC status code returned by affected calls. OK (0) only delays them.
+	Code int32 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	// Delay in milliseconds added to affected calls.
+	DelayMs              int64    `protobuf:"varint,5,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{66}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetSvc() string {
+	if m != nil {
+		return m.Svc
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetDelayMs() int64 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
+}
+
+// Removes every fault registered for svc.
+type DeleteRequest struct {
+	Svc                  string   `protobuf:"bytes,1,opt,name=svc,proto3" json:"svc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca53982754088a9d, []int{67}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetSvc() string {
+	if m != nil {
+		return m.Svc
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("hipstershop.ShipmentStatus_State", ShipmentStatus_State_name, ShipmentStatus_State_value)
+	proto.RegisterEnum("hipstershop.FraudAssessment_Decision", FraudAssessment_Decision_name, FraudAssessment_Decision_value)
+	proto.RegisterEnum("hipstershop.Transaction_State", Transaction_State_name, Transaction_State_value)
+	proto.RegisterType((*CartItem)(nil), "hipstershop.CartItem")
+	proto.RegisterType((*AddItemRequest)(nil), "hipstershop.AddItemRequest")
+	proto.RegisterType((*EmptyCartRequest)(nil), "hipstershop.EmptyCartRequest")
+	proto.RegisterType((*RemoveItemRequest)(nil), "hipstershop.RemoveItemRequest")
+	proto.RegisterType((*UpdateItemQuantityRequest)(nil), "hipstershop.UpdateItemQuantityRequest")
+	proto.RegisterType((*MergeCartsRequest)(nil), "hipstershop.MergeCartsRequest")
+	proto.RegisterType((*GetCartRequest)(nil), "hipstershop.GetCartRequest")
+	proto.RegisterType((*Cart)(nil), "hipstershop.Cart")
+	proto.RegisterType((*Empty)(nil), "hipstershop.Empty")
+	proto.RegisterType((*ListRecommendationsRequest)(nil), "hipstershop.ListRecommendationsRequest")
+	proto.RegisterType((*ListRecommendationsResponse)(nil), "hipstershop.ListRecommendationsResponse")
+	proto.RegisterType((*Product)(nil), "hipstershop.Product")
+	proto.RegisterType((*Dimensions)(nil), "hipstershop.Dimensions")
+	proto.RegisterType((*ListProductsResponse)(nil), "hipstershop.ListProductsResponse")
+	proto.RegisterType((*GetProductRequest)(nil), "hipstershop.GetProductRequest")
+	proto.RegisterType((*SearchProductsRequest)(nil), "hipstershop.SearchProductsRequest")
+	proto.RegisterType((*SearchProductsResponse)(nil), "hipstershop.SearchProductsResponse")
+	proto.RegisterType((*GetQuoteRequest)(nil), "hipstershop.GetQuoteRequest")
+	proto.RegisterType((*GetQuoteResponse)(nil), "hipstershop.GetQuoteResponse")
+	proto.RegisterType((*QuoteOption)(nil), "hipstershop.QuoteOption")
+	proto.RegisterType((*ShipOrderRequest)(nil), "hipstershop.ShipOrderRequest")
+	proto.RegisterType((*ShipOrderResponse)(nil), "hipstershop.ShipOrderResponse")
+	proto.RegisterType((*CancelShipmentRequest)(nil), "hipstershop.CancelShipmentRequest")
+	proto.RegisterType((*GetShipmentStatusRequest)(nil), "hipstershop.GetShipmentStatusRequest")
+	proto.RegisterType((*ValidateTrackingIdRequest)(nil), "hipstershop.ValidateTrackingIdRequest")
+	proto.RegisterType((*ValidateTrackingIdResponse)(nil), "hipstershop.ValidateTrackingIdResponse")
+	proto.RegisterType((*ShipmentStatus)(nil), "hipstershop.ShipmentStatus")
+	proto.RegisterType((*Address)(nil), "hipstershop.Address")
+	proto.RegisterType((*Money)(nil), "hipstershop.Money")
+	proto.RegisterType((*GetSupportedCurrenciesResponse)(nil), "hipstershop.GetSupportedCurrenciesResponse")
+	proto.RegisterType((*CurrencyInfo)(nil), "hipstershop.CurrencyInfo")
+	proto.RegisterType((*CurrencyConversionRequest)(nil), "hipstershop.CurrencyConversionRequest")
+	proto.RegisterType((*CurrencyConversionResponse)(nil), "hipstershop.CurrencyConversionResponse")
+	proto.RegisterType((*ConvertBatchRequest)(nil), "hipstershop.ConvertBatchRequest")
+	proto.RegisterType((*ConvertBatchResponse)(nil), "hipstershop.ConvertBatchResponse")
+	proto.RegisterType((*CreditCardInfo)(nil), "hipstershop.CreditCardInfo")
+	proto.RegisterType((*ChargeRequest)(nil), "hipstershop.ChargeRequest")
+	proto.RegisterType((*ChargeResponse)(nil), "hipstershop.ChargeResponse")
+	proto.RegisterType((*RefundRequest)(nil), "hipstershop.RefundRequest")
+	proto.RegisterType((*RefundResponse)(nil), "hipstershop.RefundResponse")
+	proto.RegisterType((*AuthorizeRequest)(nil), "hipstershop.AuthorizeRequest")
+	proto.RegisterType((*FraudAssessment)(nil), "hipstershop.FraudAssessment")
+	proto.RegisterType((*TokenizeRequest)(nil), "hipstershop.TokenizeRequest")
+	proto.RegisterType((*TokenizeResponse)(nil), "hipstershop.TokenizeResponse")
+	proto.RegisterType((*CaptureRequest)(nil), "hipstershop.CaptureRequest")
+	proto.RegisterType((*VoidRequest)(nil), "hipstershop.VoidRequest")
+	proto.RegisterType((*GetTransactionRequest)(nil), "hipstershop.GetTransactionRequest")
+	proto.RegisterType((*ReconcileTransactionRequest)(nil), "hipstershop.ReconcileTransactionRequest")
+	proto.RegisterType((*Transaction)(nil), "hipstershop.Transaction")
+	proto.RegisterType((*PendingChange)(nil), "hipstershop.PendingChange")
+	proto.RegisterType((*TransactionRefund)(nil), "hipstershop.TransactionRefund")
+	proto.RegisterType((*OrderItem)(nil), "hipstershop.OrderItem")
+	proto.RegisterType((*OrderResult)(nil), "hipstershop.OrderResult")
+	proto.RegisterType((*SendOrderConfirmationRequest)(nil), "hipstershop.SendOrderConfirmationRequest")
+	proto.RegisterType((*SendCartReminderRequest)(nil), "hipstershop.SendCartReminderRequest")
+	proto.RegisterType((*SendLoginLinkRequest)(nil), "hipstershop.SendLoginLinkRequest")
+	proto.RegisterType((*PlaceOrderRequest)(nil), "hipstershop.PlaceOrderRequest")
+	proto.RegisterType((*PlaceOrderResponse)(nil), "hipstershop.PlaceOrderResponse")
+	proto.RegisterType((*Order)(nil), "hipstershop.Order")
+	proto.RegisterType((*AddOrderRequest)(nil), "hipstershop.AddOrderRequest")
+	proto.RegisterType((*GetOrderRequest)(nil), "hipstershop.GetOrderRequest")
+	proto.RegisterType((*ListOrdersByUserRequest)(nil), "hipstershop.ListOrdersByUserRequest")
+	proto.RegisterType((*ListOrdersByUserResponse)(nil), "hipstershop.ListOrdersByUserResponse")
+	proto.RegisterType((*AdRequest)(nil), "hipstershop.AdRequest")
+	proto.RegisterType((*AdResponse)(nil), "hipstershop.AdResponse")
+	proto.RegisterType((*Ad)(nil), "hipstershop.Ad")
+	proto.RegisterType((*CreateRequest)(nil), "hipstershop.CreateRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "hipstershop.DeleteRequest")
+}
+
+func init() { proto.RegisterFile("demo.proto", fileDescriptor_ca53982754088a9d) }
+
+var fileDescriptor_ca53982754088a9d = []byte{
+	// 3466 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3a, 0x4b, 0x73, 0x1b, 0x47,
+	0x73, 0x5c, 0x10, 0xcf, 0xc6, 0x83, 0xe0, 0x98, 0x94, 0x20, 0x50, 0xd2, 0x27, 0x8e, 0x3e, 0x59,
+	0xf2, 0x27, 0x99, 0x9f, 0x8a, 0x71, 0x95, 0x13, 0x4b, 0xb1, 0x0d, 0x01, 0x20, 0x05, 0x8b, 0x22,
+	0xe9, 0xe5, 0x43, 0x56, 0x9c, 0x0a, 0x6a, 0xb5, 0x3b, 0x24, 0x37, 0x04, 0x76, 0xe1, 0xdd, 0x01,
+	0x6d, 0xa8, 0x72, 0x72, 0x52, 0xa9, 0x4a, 0x9c, 0x4a, 0x52, 0x95, 0xe4, 0xe0, 0x43, 0x6e, 0xc9,
+	0x39, 0xe7, 0xfc, 0x8b, 0x5c, 0x72, 0xce, 0x2d, 0xf7, 0x1c, 0x72, 0x4f, 0xcd, 0x6b, 0xb1, 0xbb,
+	0xd8, 0x05, 0xa9, 0xd8, 0xf1, 0x29, 0x27, 0x60, 0x7a, 0x7a, 0x7a, 0x7a, 0xba, 0x7b, 0x7a, 0xfa,
+	0xb1, 0x00, 0x16, 0x19, 0xba, 0x1b, 0x23, 0xcf, 0xa5, 0x2e, 0x2a, 0x9f, 0xd9, 0x23, 0x9f, 0x12,
+	0xcf, 0x3f, 0x73, 0x47, 0xb8, 0x0b, 0xc5, 0xb6, 0xe1, 0xd1, 0x1e, 0x25, 0x43, 0x74, 0x0b, 0x60,
+	0xe4, 0xb9, 0xd6, 0xd8, 0xa4, 0x7d, 0xdb, 0x6a, 0x68, 0x77, 0xb4, 0x07, 0x25, 0xbd, 0x24, 0x21,
+	0x3d, 0x0b, 0x35, 0xa1, 0xf8, 0xcd, 0xd8, 0x70, 0xa8, 0x4d, 0x27, 0x8d, 0xcc, 0x1d, 0xed, 0x41,
+	0x4e, 0x0f, 0xc6, 0xf8, 0x10, 0x6a, 0x2d, 0xcb, 0x62, 0x54, 0x74, 0xf2, 0xcd, 0x98, 0xf8, 0x14,
+	0x5d, 0x87, 0xc2, 0xd8, 0x27, 0xde, 0x94, 0x52, 0x9e, 0x0d, 0x7b, 0x16, 0xfa, 0x00, 0xb2, 0x36,
+	0x25, 0x43, 0x4e, 0xa2, 0xbc, 0xb9, 0xba, 0x11, 0xe2, 0x66, 0x43, 0xb1, 0xa2, 0x73, 0x14, 0xfc,
+	0x10, 0xea, 0xdd, 0xe1, 0x88, 0x4e, 0x18, 0xf8, 0x32, 0xba, 0xf8, 0x05, 0x2c, 0xeb, 0x64, 0xe8,
+	0x5e, 0x90, 0x2b, 0x71, 0x11, 0x3d, 0x6b, 0x26, 0x76, 0x56, 0xec, 0xc2, 0x8d, 0xa3, 0x91, 0x65,
+	0x50, 0x4e, 0xec, 0x4b, 0x79, 0xca, 0x9f, 0x48, 0x34, 0x22, 0xc0, 0xc5, 0x98, 0x00, 0x0f, 0x60,
+	0xf9, 0x25, 0xf1, 0x4e, 0x09, 0x3b, 0xaa, 0xaf, 0x36, 0xba, 0x03, 0x95, 0x13, 0xcf, 0x1d, 0xf6,
+	0xa3, 0xbb, 0x01, 0x83, 0x1d, 0x89, 0x1d, 0x6f, 0x02, 0x50, 0x37, 0x98, 0x17, 0x3b, 0x16, 0xa9,
+	0x2b, 0x66, 0xf1, 0x07, 0x50, 0xdb, 0x26, 0xf4, 0x4a, 0xd2, 0xdb, 0x81, 0x2c, 0xc3, 0x4b, 0x3f,
+	0xdb, 0x43, 0xc8, 0x31, 0x9d, 0xf8, 0x8d, 0xcc, 0x9d, 0xc5, 0x74, 0xbd, 0x09, 0x1c, 0x5c, 0x80,
+	0x1c, 0x57, 0x1c, 0x3e, 0x86, 0xe6, 0x8e, 0xed, 0x53, 0x9d, 0x98, 0xee, 0x70, 0x48, 0x1c, 0xcb,
+	0xa0, 0xb6, 0xeb, 0xf8, 0x97, 0x0a, 0xf2, 0x57, 0x50, 0x9e, 0x0a, 0x52, 0x6c, 0x59, 0xd2, 0x21,
+	0x90, 0xa4, 0x8f, 0x3f, 0x85, 0xb5, 0x44, 0xba, 0xfe, 0xc8, 0x75, 0x7c, 0x12, 0x5f, 0xaf, 0xcd,
+	0xac, 0xff, 0xdb, 0x0c, 0x14, 0xf6, 0xc5, 0x10, 0xd5, 0x20, 0x13, 0x30, 0x90, 0xb1, 0x2d, 0x84,
+	0x20, 0xeb, 0x18, 0x43, 0x22, 0xa5, 0xc9, 0xff, 0xa3, 0x3b, 0x50, 0xb6, 0x88, 0x6f, 0x7a, 0xf6,
+	0x88, 0x6d, 0xc4, 0xb5, 0x57, 0xd2, 0xc3, 0x20, 0xd4, 0x80, 0xc2, 0xc8, 0x36, 0xe9, 0xd8, 0x23,
+	0x8d, 0x2c, 0x9f, 0x55, 0x43, 0xf4, 0x5b, 0x28, 0x8d, 0x3c, 0xdb, 0x24, 0xfd, 0xb1, 0x6f, 0x35,
+	0x72, 0xdc, 0xea, 0x51, 0x44, 0x7a, 0x2f, 0x5d, 0x87, 0x4c, 0xf4, 0x22, 0x47, 0x3a, 0xf2, 0x2d,
+	0x74, 0x1b, 0xc0, 0x34, 0x28, 0x39, 0x75, 0x3d, 0x9b, 0xf8, 0x8d, 0xbc, 0x60, 0x7e, 0x0a, 0x41,
+	0x6b, 0x50, 0xfa, 0x96, 0xd8, 0xa7, 0x67, 0xb4, 0x7f, 0x7e, 0xda, 0x28, 0xdc, 0xd1, 0x1e, 0x68,
+	0x7a, 0x51, 0x00, 0x5e, 0x9c, 0xa2, 0x8f, 0x01, 0x2c, 0x7b, 0x48, 0x1c, 0x9f, 0x09, 0xa4, 0x51,
+	0xe4, 0xdb, 0x5d, 0x8f, 0x6c, 0xd7, 0x09, 0xa6, 0xf5, 0x10, 0x2a, 0x36, 0x00, 0xa6, 0x33, 0x6c,
+	0x8f, 0x01, 0x71, 0x4e, 0xe9, 0x59, 0xdf, 0x1c, 0x72, 0xd9, 0x68, 0x7a, 0x51, 0x00, 0xda, 0x43,
+	0x74, 0x03, 0x8a, 0xdf, 0xda, 0x96, 0x98, 0xcb, 0xf0, 0xb9, 0x02, 0x1f, 0xb7, 0x87, 0x6c, 0xdd,
+	0x99, 0xe0, 0xcd, 0x1c, 0x72, 0x31, 0x69, 0x7a, 0x51, 0x00, 0xda, 0x43, 0xfc, 0x1c, 0x56, 0x98,
+	0xd6, 0xa4, 0xe0, 0xa7, 0xea, 0x7a, 0x0c, 0x45, 0xa9, 0x1b, 0xa1, 0xab, 0xf2, 0xe6, 0x4a, 0x84,
+	0x63, 0xb9, 0x40, 0x0f, 0xb0, 0xf0, 0x5d, 0x58, 0xde, 0x26, 0x8a, 0x90, 0x32, 0xa7, 0x98, 0x22,
+	0xf1, 0x87, 0xb0, 0x7a, 0x40, 0x0c, 0xcf, 0x3c, 0x9b, 0x6e, 0x28, 0x10, 0x57, 0x20, 0xf7, 0xcd,
+	0x98, 0x78, 0x13, 0x89, 0x2b, 0x06, 0xf8, 0x39, 0x5c, 0x8b, 0xa3, 0x4b, 0xfe, 0x36, 0xa0, 0xe0,
+	0x11, 0x7f, 0x3c, 0xb8, 0x84, 0x3d, 0x85, 0x84, 0x1d, 0x58, 0xda, 0x26, 0xf4, 0xcb, 0xb1, 0x4b,
+	0x89, 0xda, 0x72, 0x03, 0x0a, 0x86, 0x65, 0x79, 0xc4, 0xf7, 0xf9, 0xa6, 0x71, 0x12, 0x2d, 0x31,
+	0xa7, 0x2b, 0xa4, 0x77, 0xbb, 0x6e, 0x63, 0xa8, 0x4f, 0xf7, 0x93, 0x3c, 0x7f, 0x08, 0x45, 0xd3,
+	0xf5, 0x29, 0x37, 0x3a, 0x2d, 0xd5, 0xe8, 0x0a, 0x0c, 0x87, 0xd9, 0xdc, 0x26, 0x14, 0x5c, 0x6e,
+	0xc8, 0x6a, 0xc7, 0x46, 0x04, 0x9b, 0xd3, 0xde, 0xe3, 0x08, 0xba, 0x42, 0xc4, 0x7f, 0xa6, 0x41,
+	0x39, 0x34, 0x81, 0xee, 0x42, 0xd5, 0x27, 0xde, 0x05, 0x33, 0xf5, 0x01, 0xb9, 0x20, 0x03, 0x29,
+	0xde, 0x8a, 0x04, 0xee, 0x30, 0x58, 0x84, 0xaf, 0xcc, 0xe5, 0x7c, 0xad, 0x43, 0x85, 0x7a, 0x86,
+	0xe3, 0xdb, 0xb4, 0x6f, 0x19, 0x13, 0x5f, 0xfa, 0xcd, 0xb2, 0x84, 0x75, 0x8c, 0x89, 0x8f, 0xff,
+	0x52, 0x83, 0xfa, 0xc1, 0x99, 0x3d, 0xda, 0xf3, 0x2c, 0xe2, 0xfd, 0x12, 0xf2, 0x66, 0xf6, 0xef,
+	0x7a, 0x96, 0x70, 0x5c, 0xc2, 0x15, 0x14, 0xf8, 0xb8, 0x67, 0xe1, 0x8f, 0x60, 0x39, 0xc4, 0xcb,
+	0xd4, 0x1d, 0x51, 0xcf, 0x30, 0xcf, 0x6d, 0xe7, 0x34, 0xe4, 0xc6, 0x15, 0xa8, 0x67, 0xe1, 0x03,
+	0x58, 0x6d, 0x1b, 0x8e, 0x49, 0x06, 0x6c, 0xed, 0x90, 0x38, 0x81, 0x49, 0x5f, 0xb6, 0x32, 0xc2,
+	0x4a, 0x26, 0xca, 0xca, 0x13, 0x68, 0x6c, 0x13, 0xaa, 0x28, 0x1e, 0x50, 0x83, 0x8e, 0xfd, 0xab,
+	0xd2, 0xc5, 0x4f, 0xe1, 0xc6, 0xb1, 0x31, 0xb0, 0xd9, 0x13, 0x78, 0x18, 0x40, 0xaf, 0xbc, 0xfa,
+	0x0b, 0x68, 0x26, 0xad, 0x96, 0xe2, 0x58, 0x81, 0xdc, 0x85, 0x31, 0x90, 0x0b, 0x8b, 0xba, 0x18,
+	0xa0, 0x6b, 0x90, 0xf7, 0x88, 0xe1, 0xbb, 0x8e, 0x3c, 0x87, 0x1c, 0xe1, 0x7f, 0xcf, 0x40, 0x2d,
+	0x7a, 0x88, 0xcb, 0xa5, 0xf2, 0x31, 0xe4, 0x7c, 0x6a, 0x50, 0xe1, 0xc3, 0x6b, 0x9b, 0xeb, 0x11,
+	0x6d, 0x46, 0x89, 0x6d, 0xb0, 0x1f, 0xa2, 0x0b, 0x7c, 0xf6, 0x82, 0x8f, 0xf9, 0xbb, 0x6f, 0xf5,
+	0x0d, 0xca, 0x75, 0xbb, 0xa8, 0x97, 0x24, 0xa4, 0x45, 0xd1, 0x87, 0x80, 0x88, 0x4f, 0xed, 0x21,
+	0x47, 0xb0, 0xc8, 0xc0, 0xbe, 0x60, 0x5e, 0x24, 0xcb, 0xd1, 0x96, 0x83, 0x99, 0x8e, 0x9c, 0x08,
+	0x1b, 0x61, 0xee, 0x0a, 0x46, 0x88, 0xcf, 0x21, 0xc7, 0xb9, 0x41, 0x65, 0x28, 0x1c, 0xed, 0xbe,
+	0xd8, 0xdd, 0x7b, 0xb5, 0x5b, 0x5f, 0x40, 0xcb, 0x50, 0xdd, 0x69, 0x3d, 0xeb, 0xee, 0xf4, 0xdb,
+	0x7a, 0xb7, 0x75, 0xd8, 0xed, 0xd4, 0x35, 0x54, 0x03, 0xe8, 0xed, 0xf6, 0x0f, 0xf5, 0xd6, 0xee,
+	0x41, 0xef, 0xb0, 0x9e, 0x41, 0x2b, 0x50, 0xdf, 0x3b, 0x3a, 0xec, 0x6f, 0xed, 0xe9, 0xfd, 0x4e,
+	0x77, 0xa7, 0x77, 0xdc, 0xd5, 0x5f, 0xd7, 0x17, 0x51, 0x15, 0x4a, 0x72, 0xd4, 0xed, 0xd4, 0xb3,
+	0x6c, 0xd8, 0x6e, 0xed, 0xb6, 0xbb, 0x3b, 0x3b, 0xdd, 0x4e, 0x3d, 0x87, 0xff, 0x46, 0x83, 0x82,
+	0xe4, 0x00, 0xdd, 0x83, 0x9a, 0x4f, 0x3d, 0x42, 0x68, 0x3f, 0x7c, 0x69, 0x4a, 0x7a, 0x55, 0x40,
+	0x15, 0x1a, 0x82, 0xac, 0xa9, 0xa2, 0xbf, 0x92, 0xce, 0xff, 0x33, 0x65, 0x0a, 0x51, 0x8b, 0x8b,
+	0x20, 0x06, 0xec, 0x35, 0x34, 0xdd, 0xb1, 0x43, 0xa5, 0x74, 0x4a, 0xba, 0x1a, 0x32, 0x83, 0x7d,
+	0x6b, 0x8f, 0xfa, 0xa6, 0x6b, 0x11, 0x2e, 0x94, 0x9c, 0x5e, 0x78, 0x6b, 0x8f, 0xda, 0xae, 0x45,
+	0xf0, 0x57, 0x90, 0xe3, 0xb7, 0x9f, 0x39, 0x12, 0x73, 0xec, 0x79, 0xc4, 0x31, 0x27, 0x02, 0x51,
+	0x3a, 0x12, 0x05, 0x64, 0xd8, 0x6c, 0xe3, 0xb1, 0x63, 0x53, 0x9f, 0x73, 0xb3, 0xa8, 0x8b, 0x01,
+	0x83, 0x3a, 0x86, 0xe3, 0x2a, 0x47, 0x21, 0x06, 0xf8, 0x7b, 0x0d, 0x6e, 0xb3, 0xbb, 0x30, 0x1e,
+	0x8d, 0x5c, 0x8f, 0x12, 0xab, 0x2d, 0x08, 0xd9, 0x64, 0xea, 0xe3, 0xef, 0x41, 0x2d, 0xb2, 0xa7,
+	0x8a, 0x1a, 0xaa, 0xe1, 0x4d, 0x7d, 0xf4, 0x7b, 0x00, 0x66, 0xb0, 0x58, 0x3a, 0x8b, 0x1b, 0x51,
+	0x67, 0x21, 0xf1, 0x7b, 0xce, 0x89, 0xab, 0x87, 0x90, 0xb1, 0x0b, 0x95, 0xf0, 0x1c, 0x97, 0xe6,
+	0xf4, 0x70, 0xfc, 0x7f, 0x62, 0xec, 0x71, 0x0d, 0xf2, 0xfe, 0x64, 0xf8, 0xc6, 0x1d, 0x48, 0x11,
+	0xcb, 0x11, 0xbb, 0x05, 0x43, 0xdb, 0x71, 0xbd, 0xbe, 0x10, 0x43, 0x96, 0x1f, 0x18, 0x38, 0xe8,
+	0x88, 0x41, 0xf0, 0xdf, 0x6b, 0x70, 0xa3, 0x1d, 0x70, 0xef, 0x5c, 0x10, 0x8f, 0xbd, 0xed, 0xea,
+	0x12, 0xbf, 0x0f, 0x59, 0x16, 0x48, 0xce, 0x79, 0x1c, 0xf8, 0x3c, 0x0b, 0xd2, 0xa8, 0x2b, 0xd4,
+	0x20, 0x2f, 0x26, 0x75, 0xb9, 0x02, 0xd6, 0xa1, 0xe2, 0x19, 0x94, 0xf4, 0x25, 0x5d, 0x15, 0x14,
+	0x31, 0xd8, 0xb1, 0x00, 0xa1, 0xf7, 0x20, 0x67, 0xf8, 0x7d, 0xf7, 0x44, 0x5e, 0x91, 0xac, 0xe1,
+	0xef, 0x9d, 0xe0, 0x1f, 0x35, 0x68, 0x26, 0xb1, 0x25, 0x15, 0xf1, 0x1b, 0xc8, 0x8b, 0x77, 0x74,
+	0x0e, 0x67, 0x12, 0x63, 0x86, 0x85, 0xcc, 0x2c, 0x0b, 0x8f, 0x00, 0xb1, 0xa1, 0xdf, 0x27, 0x27,
+	0x27, 0xc4, 0xa4, 0xf6, 0x05, 0x99, 0xde, 0xec, 0x3a, 0x9f, 0xe9, 0xaa, 0x89, 0x16, 0xc5, 0x7f,
+	0xad, 0xc1, 0x7b, 0x82, 0x27, 0xfa, 0xcc, 0xa0, 0xe6, 0xd9, 0xac, 0xb0, 0x16, 0x7f, 0x59, 0x61,
+	0xfd, 0x83, 0x06, 0x2b, 0x51, 0x86, 0xa4, 0x98, 0x1e, 0xc5, 0x63, 0x92, 0xc4, 0x67, 0x54, 0xa2,
+	0xfc, 0xfc, 0x82, 0xfa, 0x4f, 0x0d, 0x6a, 0x6d, 0x8f, 0x58, 0x36, 0x4b, 0x2f, 0x2c, 0x6e, 0xcf,
+	0x8f, 0x00, 0x99, 0x1c, 0xd2, 0x37, 0x0d, 0xcf, 0xea, 0x3b, 0xe3, 0xe1, 0x1b, 0xe2, 0x49, 0xeb,
+	0xae, 0x9b, 0x01, 0xee, 0x2e, 0x87, 0xa3, 0xf7, 0x61, 0x29, 0x8c, 0x6d, 0x5e, 0x5c, 0xc8, 0xa4,
+	0xb2, 0x3a, 0x45, 0x6d, 0x5f, 0x5c, 0xa0, 0xdf, 0x87, 0xb5, 0x30, 0x1e, 0xf9, 0x6e, 0x64, 0x7b,
+	0x3c, 0xda, 0xef, 0x4f, 0x88, 0xe1, 0xc9, 0x6b, 0xde, 0x98, 0xae, 0xe9, 0x06, 0x08, 0xaf, 0x89,
+	0xe1, 0xa1, 0xcf, 0xe0, 0x66, 0xca, 0xf2, 0xa1, 0xeb, 0xd0, 0x33, 0x79, 0x6b, 0x6e, 0x24, 0xad,
+	0x7f, 0xc9, 0x10, 0xf0, 0x5f, 0x64, 0xa0, 0xda, 0x3e, 0x33, 0xbc, 0xd3, 0x20, 0x94, 0xfb, 0x0d,
+	0xe4, 0x8d, 0x21, 0xf3, 0x66, 0xf3, 0x0c, 0x54, 0x60, 0xa0, 0xa7, 0x50, 0x0e, 0x6d, 0x2f, 0x03,
+	0x9e, 0xb5, 0xa8, 0xbf, 0x88, 0x48, 0x51, 0x87, 0x29, 0x2b, 0xe8, 0x3e, 0x2c, 0xd9, 0x16, 0x19,
+	0x8e, 0x5c, 0xca, 0xdd, 0xd2, 0x39, 0x99, 0x48, 0xbb, 0xa9, 0x85, 0xc0, 0x2f, 0xc8, 0x84, 0x3d,
+	0x5b, 0xfc, 0x78, 0xd4, 0x3d, 0x27, 0x8e, 0xf4, 0xb8, 0x25, 0x06, 0x39, 0x64, 0x00, 0x36, 0xed,
+	0x13, 0x9f, 0x69, 0x99, 0x3d, 0x97, 0x39, 0x31, 0x2d, 0x21, 0x3d, 0xbe, 0xcd, 0x1b, 0x7b, 0x30,
+	0x60, 0xaf, 0xa9, 0x72, 0xda, 0x79, 0xb1, 0x8d, 0x04, 0xb7, 0x05, 0x14, 0x7f, 0x0c, 0x35, 0x25,
+	0x8a, 0xa9, 0xd7, 0xe4, 0xa1, 0x98, 0x61, 0x52, 0x49, 0x5d, 0x3e, 0x1c, 0x21, 0x68, 0xcf, 0xc2,
+	0x3f, 0x68, 0x50, 0xd5, 0xc9, 0xc9, 0xd8, 0x09, 0x42, 0x88, 0xab, 0x2d, 0x0c, 0xc9, 0x3a, 0x73,
+	0xa9, 0xac, 0xaf, 0x2a, 0x2d, 0x6c, 0x43, 0x4d, 0x31, 0x23, 0x8f, 0xb1, 0x06, 0x25, 0x8f, 0x43,
+	0xa6, 0x8c, 0x14, 0x05, 0xa0, 0x67, 0xa1, 0x4f, 0xa0, 0x1c, 0x62, 0x4a, 0x32, 0x12, 0x0d, 0x8f,
+	0x0f, 0xa7, 0xf3, 0x7a, 0x18, 0x19, 0xff, 0x55, 0x06, 0xea, 0xad, 0x31, 0x3d, 0x73, 0x3d, 0xfb,
+	0xed, 0xff, 0x1b, 0x10, 0xfe, 0x37, 0x0d, 0x96, 0xb6, 0x3c, 0x63, 0x6c, 0xb5, 0x7c, 0xb6, 0x9a,
+	0x45, 0x61, 0x3c, 0x80, 0x30, 0x5d, 0x4f, 0xbc, 0x83, 0x39, 0x5d, 0x0c, 0x50, 0x0b, 0x8a, 0x16,
+	0x31, 0xed, 0xc0, 0x59, 0xd5, 0x36, 0xef, 0x45, 0x0e, 0x1d, 0xa3, 0xb2, 0xd1, 0x91, 0xc8, 0x7a,
+	0xb0, 0x8c, 0xc5, 0x20, 0x22, 0x84, 0x64, 0xc1, 0x00, 0x7b, 0xca, 0xd5, 0x10, 0x7f, 0x01, 0xc5,
+	0xce, 0x14, 0x6b, 0xa5, 0xd3, 0x6d, 0xf7, 0x0e, 0x7a, 0x7b, 0xbb, 0xfd, 0xa3, 0xdd, 0x83, 0xfd,
+	0x6e, 0xbb, 0xb7, 0xd5, 0xeb, 0x76, 0xea, 0x0b, 0x2c, 0x08, 0x6b, 0xed, 0xef, 0xeb, 0x7b, 0xc7,
+	0xdd, 0xba, 0x86, 0x00, 0xf2, 0x7a, 0xf7, 0xb8, 0xd7, 0x7d, 0x55, 0xcf, 0xb0, 0x89, 0x4e, 0xb7,
+	0xbd, 0xd3, 0xdb, 0xed, 0xd6, 0x17, 0xf1, 0x1e, 0x2c, 0x71, 0x19, 0x85, 0xf4, 0x1b, 0xd3, 0x99,
+	0xf6, 0x4e, 0x3a, 0xc3, 0x43, 0xa8, 0x4f, 0x09, 0x4e, 0x23, 0x66, 0xa1, 0x19, 0x99, 0xb0, 0x52,
+	0xa5, 0x15, 0xae, 0xb4, 0x37, 0x9e, 0xe1, 0x04, 0xe5, 0x26, 0x06, 0x79, 0xc6, 0x00, 0xe8, 0xd7,
+	0x50, 0xe3, 0xd3, 0x03, 0xc3, 0xa7, 0xfd, 0x13, 0x77, 0xec, 0x49, 0xdd, 0x57, 0x18, 0x74, 0xc7,
+	0xf0, 0xe9, 0x96, 0x3b, 0xf6, 0xb0, 0x09, 0xb5, 0xb6, 0x31, 0xa2, 0x63, 0x8f, 0xfc, 0xdf, 0x5d,
+	0x4d, 0xfc, 0x11, 0x94, 0x8f, 0x5d, 0xfb, 0x1d, 0x2f, 0x3f, 0x3e, 0x85, 0xd5, 0x6d, 0x42, 0xc3,
+	0x77, 0xeb, 0xdd, 0x38, 0x4c, 0xb0, 0xfe, 0x4c, 0xa2, 0x43, 0xf8, 0x41, 0x83, 0x35, 0x56, 0x4a,
+	0x72, 0x4c, 0x7b, 0x40, 0xfe, 0xf7, 0xfb, 0x35, 0xa0, 0x60, 0x8c, 0x46, 0x03, 0x9b, 0x08, 0x65,
+	0x14, 0x75, 0x35, 0x44, 0x0f, 0x61, 0xf9, 0xd4, 0xa0, 0xe4, 0x5b, 0x63, 0xd2, 0xf7, 0xc8, 0x09,
+	0x61, 0xa1, 0x8f, 0x0a, 0x98, 0xeb, 0x72, 0x42, 0x57, 0x70, 0xfc, 0x63, 0x1e, 0xca, 0x21, 0x26,
+	0xae, 0xba, 0xfb, 0x47, 0xd1, 0x9c, 0xe7, 0x76, 0x9a, 0x83, 0x8a, 0x26, 0x3c, 0x9b, 0x00, 0x86,
+	0xf2, 0x4f, 0x22, 0x99, 0x4d, 0xd6, 0x64, 0x08, 0x0b, 0x6d, 0x40, 0xd1, 0x14, 0x26, 0x63, 0x35,
+	0xb2, 0xa9, 0x2b, 0x02, 0x1c, 0x86, 0x2f, 0x9c, 0x29, 0x99, 0x5b, 0xff, 0x52, 0x38, 0xe8, 0x77,
+	0xa1, 0x20, 0xfe, 0x8b, 0xe2, 0x57, 0x39, 0xfd, 0x2c, 0xd2, 0x8d, 0x2b, 0xf4, 0xd8, 0x8d, 0x28,
+	0x5c, 0x7e, 0x23, 0x8a, 0xb3, 0x37, 0x82, 0x13, 0xf1, 0x88, 0xca, 0x01, 0x4b, 0x22, 0x07, 0x94,
+	0x90, 0x16, 0x8d, 0xa5, 0x88, 0x10, 0x4f, 0x11, 0x13, 0x55, 0x5d, 0x4e, 0x56, 0x35, 0xda, 0x86,
+	0xfa, 0x09, 0x73, 0x64, 0x7d, 0x23, 0xf0, 0x64, 0x8d, 0x0a, 0x97, 0xd0, 0xcd, 0x79, 0xde, 0x4e,
+	0x5f, 0x3a, 0x89, 0x02, 0x50, 0x0b, 0x6a, 0x23, 0xe2, 0x58, 0xdc, 0x03, 0x9f, 0x19, 0xce, 0x29,
+	0x69, 0x54, 0x39, 0x99, 0x66, 0xb4, 0x50, 0x25, 0x50, 0xda, 0x1c, 0x43, 0xaf, 0x8e, 0xc2, 0xc3,
+	0xa4, 0xdb, 0x52, 0x4b, 0xbc, 0x2d, 0x6f, 0x55, 0x96, 0xba, 0x0a, 0xcb, 0x07, 0x87, 0xad, 0xc3,
+	0x6e, 0xcc, 0x6f, 0xd6, 0x00, 0x5a, 0x47, 0x87, 0xcf, 0xf7, 0xf4, 0xde, 0x1f, 0xf0, 0x64, 0xb5,
+	0x02, 0xc5, 0x76, 0x6b, 0xff, 0xf0, 0x88, 0x65, 0xa1, 0x19, 0xe6, 0x48, 0x8f, 0xf7, 0x7a, 0x9d,
+	0x6e, 0xa7, 0xbe, 0x88, 0xae, 0x01, 0xda, 0x6f, 0xe9, 0x87, 0xbd, 0xd6, 0xce, 0xce, 0xeb, 0xbe,
+	0xde, 0xdd, 0x3a, 0xda, 0xed, 0xf0, 0x4c, 0xb5, 0x02, 0xc5, 0x60, 0x94, 0x63, 0x2b, 0xb6, 0x5a,
+	0x3d, 0x96, 0xb4, 0xe6, 0xf1, 0xbf, 0x6a, 0x50, 0x8d, 0x9c, 0x62, 0x6a, 0xf6, 0xda, 0xbb, 0x98,
+	0xfd, 0xbb, 0xc4, 0x15, 0xec, 0xf1, 0xa3, 0x86, 0x17, 0xad, 0x09, 0x48, 0x48, 0x2b, 0x31, 0xec,
+	0xc8, 0x26, 0xca, 0xed, 0x9f, 0x34, 0x58, 0x9e, 0xb1, 0xdd, 0xf9, 0xa1, 0xc7, 0x3b, 0xb2, 0x19,
+	0x32, 0xdb, 0xc5, 0xb8, 0xd9, 0x5e, 0x99, 0xcd, 0x3f, 0x82, 0x12, 0xaf, 0x5e, 0xf1, 0x96, 0x90,
+	0x6a, 0xd6, 0x68, 0x97, 0x36, 0x6b, 0x58, 0x8a, 0x64, 0xba, 0xfe, 0x3c, 0x4e, 0xf9, 0x3c, 0xfe,
+	0x3e, 0x03, 0x65, 0x55, 0x1e, 0x63, 0x39, 0x5c, 0xb8, 0x82, 0xa5, 0x45, 0x2a, 0x58, 0xe8, 0x31,
+	0xac, 0xf8, 0x67, 0xf6, 0x68, 0xc4, 0xcc, 0x3a, 0x5c, 0xf0, 0x11, 0x5e, 0x1c, 0xa9, 0xb9, 0xc3,
+	0x70, 0xe1, 0xa7, 0x1a, 0xac, 0xe0, 0xdc, 0xa4, 0x7b, 0xb4, 0x8a, 0x42, 0x6c, 0xbb, 0x3e, 0x45,
+	0x9f, 0x41, 0x3d, 0x58, 0xa8, 0x6a, 0x20, 0xd9, 0x39, 0x35, 0x9b, 0x25, 0x85, 0x2d, 0x01, 0xe8,
+	0x91, 0x2a, 0x20, 0xe6, 0xb8, 0xcb, 0xba, 0x16, 0x59, 0x15, 0x08, 0x54, 0x55, 0x6c, 0x2d, 0xb8,
+	0x79, 0x40, 0x1c, 0x8b, 0xc3, 0xdb, 0xae, 0x73, 0x62, 0x7b, 0x43, 0x23, 0xfc, 0xe2, 0xac, 0x40,
+	0x8e, 0x0c, 0x0d, 0x5b, 0x95, 0x50, 0xc5, 0x00, 0x6d, 0x40, 0x8e, 0x8b, 0x26, 0x31, 0x06, 0x0d,
+	0xc9, 0x54, 0x17, 0x68, 0xac, 0xec, 0x71, 0x9d, 0x6d, 0x23, 0x3a, 0x40, 0x43, 0xdb, 0x09, 0x15,
+	0x48, 0x7f, 0x96, 0x46, 0x0f, 0xcb, 0x2b, 0xb9, 0x33, 0x95, 0xfe, 0x4f, 0x9a, 0x5d, 0x99, 0xc1,
+	0x44, 0xff, 0xcc, 0xc2, 0x9f, 0xc3, 0x0a, 0xe3, 0x61, 0xc7, 0x3d, 0xb5, 0x9d, 0x1d, 0xdb, 0x39,
+	0x9f, 0x7f, 0x44, 0x04, 0xd9, 0x81, 0xed, 0x9c, 0xab, 0x02, 0x08, 0xfb, 0x8f, 0xff, 0x31, 0x03,
+	0xcb, 0xfb, 0x03, 0xc3, 0x24, 0x7b, 0xde, 0x55, 0x0e, 0x70, 0x17, 0xaa, 0x7c, 0x42, 0x15, 0x6e,
+	0x24, 0xad, 0x0a, 0x03, 0xaa, 0xba, 0x43, 0xb8, 0x34, 0xb7, 0x78, 0x95, 0xfa, 0x70, 0xc0, 0x6d,
+	0x2e, 0xcc, 0x6d, 0x2c, 0xd2, 0xcb, 0xff, 0xe4, 0xe8, 0xbc, 0x70, 0x85, 0xe8, 0xbc, 0x18, 0x8b,
+	0xce, 0x71, 0x07, 0x50, 0x58, 0x3c, 0x41, 0xd3, 0x42, 0x1a, 0x8b, 0x76, 0x35, 0x63, 0xf9, 0x17,
+	0x0d, 0x72, 0x1c, 0x8c, 0x1e, 0xc7, 0x2a, 0x30, 0xe9, 0x4b, 0x25, 0x5e, 0x58, 0x17, 0x99, 0x88,
+	0x2e, 0x02, 0xb1, 0x2d, 0x86, 0xc5, 0xf6, 0x80, 0x85, 0xb3, 0xd4, 0x18, 0xcc, 0x89, 0x1e, 0x04,
+	0x02, 0xf3, 0x8e, 0x23, 0x76, 0x34, 0xee, 0xd3, 0x72, 0xdc, 0xb8, 0x8a, 0x02, 0xd0, 0xa2, 0xf8,
+	0x09, 0x2c, 0xb5, 0x2c, 0x2b, 0x62, 0x14, 0x0f, 0xa2, 0x87, 0x46, 0x09, 0x9c, 0xcb, 0xe3, 0x3e,
+	0xe2, 0x3d, 0x9a, 0xc8, 0xe2, 0x74, 0x4f, 0x84, 0x37, 0xe1, 0x3a, 0xeb, 0x5c, 0x71, 0x74, 0xff,
+	0xd9, 0xe4, 0xc8, 0x9f, 0xae, 0x4a, 0x6d, 0xa9, 0x6e, 0x41, 0x63, 0x76, 0xcd, 0xb4, 0xc8, 0xc5,
+	0x49, 0x27, 0x17, 0x6f, 0x04, 0x57, 0x12, 0x03, 0x6f, 0x40, 0xa9, 0x15, 0x84, 0xce, 0xeb, 0x50,
+	0x31, 0x5d, 0x87, 0x92, 0xef, 0x28, 0xb3, 0x17, 0x55, 0xa4, 0x2c, 0x4b, 0xd8, 0x0b, 0x32, 0xf1,
+	0xf1, 0x6f, 0x01, 0x5a, 0xd3, 0xd4, 0x76, 0x1d, 0x16, 0x0d, 0x4b, 0x6d, 0xb3, 0x14, 0x33, 0x72,
+	0x9d, 0xcd, 0xe1, 0x27, 0x90, 0x69, 0xf1, 0x4e, 0x0b, 0x33, 0x4d, 0x8f, 0x98, 0xb4, 0x3f, 0xf6,
+	0xd4, 0xb5, 0x2c, 0x2b, 0xd8, 0x91, 0xc7, 0x2f, 0x27, 0xdb, 0x45, 0x5d, 0x4e, 0xf6, 0x1f, 0xff,
+	0x09, 0x54, 0xdb, 0xfc, 0x91, 0x51, 0x1c, 0xd6, 0x61, 0xd1, 0xbf, 0x30, 0xe5, 0x72, 0xf6, 0x97,
+	0x41, 0xc6, 0x9e, 0x2d, 0x57, 0xb1, 0xbf, 0xbc, 0x59, 0x4a, 0x3c, 0x93, 0x85, 0x3b, 0xa2, 0x47,
+	0xa8, 0x86, 0x41, 0x51, 0x54, 0xd4, 0x65, 0xf8, 0x7f, 0xa6, 0x17, 0x8b, 0x0c, 0x8c, 0x49, 0x7f,
+	0xe8, 0x4b, 0x1b, 0x28, 0xf0, 0xf1, 0x4b, 0x1f, 0xaf, 0x43, 0xb5, 0x43, 0x06, 0x64, 0xce, 0xee,
+	0x9b, 0xff, 0xbc, 0x08, 0x65, 0xe6, 0xb6, 0x0e, 0x44, 0x17, 0x0a, 0x3d, 0xe5, 0x65, 0x6f, 0xfe,
+	0xba, 0xad, 0xc5, 0xef, 0x7c, 0xe8, 0xd3, 0x81, 0x66, 0x54, 0x25, 0xa2, 0x9d, 0xbd, 0x80, 0x9e,
+	0x40, 0x41, 0xb6, 0xd4, 0x63, 0xab, 0xa3, 0x8d, 0xf6, 0xe6, 0xf2, 0x8c, 0xdb, 0xc4, 0x0b, 0xe8,
+	0x73, 0x28, 0x05, 0xdf, 0x33, 0xa0, 0x5b, 0xb3, 0xf4, 0xc3, 0x04, 0x92, 0xb7, 0x7f, 0x06, 0x30,
+	0xfd, 0xc8, 0x01, 0x45, 0x83, 0x9d, 0x99, 0xaf, 0x1f, 0x52, 0x68, 0xe8, 0x80, 0x66, 0xbf, 0x6d,
+	0x40, 0xef, 0x47, 0x70, 0x53, 0x3f, 0x7e, 0x48, 0xa1, 0xd9, 0x02, 0x98, 0x7e, 0xbe, 0x10, 0xe3,
+	0x6b, 0xe6, 0xbb, 0x86, 0x44, 0xe1, 0x6c, 0xfe, 0xa9, 0x06, 0xab, 0xd1, 0x7e, 0xbe, 0xd2, 0xd8,
+	0x1f, 0xc3, 0x7b, 0x09, 0xcd, 0x7e, 0x74, 0x3f, 0x42, 0x25, 0xfd, 0x33, 0x83, 0xe6, 0x83, 0xcb,
+	0x11, 0xc5, 0x65, 0x61, 0x5c, 0x64, 0x60, 0x55, 0xf6, 0x73, 0xdb, 0x06, 0x35, 0x06, 0xee, 0xa9,
+	0xe2, 0x62, 0x1b, 0x2a, 0xe1, 0xe6, 0x35, 0x4a, 0x10, 0x44, 0x73, 0x7d, 0x66, 0xa7, 0x78, 0x2f,
+	0x19, 0x2f, 0xa0, 0x0e, 0xc0, 0xb4, 0x77, 0x1d, 0x93, 0xd5, 0x4c, 0x53, 0xbb, 0x99, 0xd8, 0x6a,
+	0xc6, 0x0b, 0xe8, 0x6b, 0xa8, 0x45, 0xbb, 0xd5, 0x08, 0x47, 0x30, 0x13, 0x3b, 0xdf, 0xcd, 0xbb,
+	0x73, 0x71, 0x02, 0x29, 0xfc, 0x5d, 0x16, 0x96, 0x0e, 0x64, 0x80, 0xa3, 0xce, 0xdf, 0x83, 0xa2,
+	0x6a, 0x32, 0xa3, 0x9b, 0x71, 0xa6, 0xc3, 0xbd, 0xee, 0xe6, 0xad, 0x94, 0xd9, 0x40, 0x02, 0x3b,
+	0x50, 0x0a, 0x9a, 0xa4, 0xb1, 0x7b, 0x10, 0x6f, 0xe4, 0x36, 0x6f, 0xa7, 0x4d, 0x07, 0xd4, 0xbe,
+	0x80, 0x5a, 0xb4, 0x79, 0x1a, 0x93, 0x44, 0x62, 0x67, 0x35, 0xc5, 0x8e, 0x5f, 0xf3, 0xef, 0x0a,
+	0x62, 0xed, 0xc6, 0x7b, 0xf1, 0xf3, 0x24, 0xf6, 0x54, 0x9b, 0x6b, 0x73, 0xba, 0x8c, 0x78, 0x01,
+	0xbd, 0x82, 0xea, 0x2b, 0x56, 0xc1, 0x0f, 0xb8, 0xfc, 0x59, 0xc8, 0x3e, 0xd6, 0xd0, 0x29, 0xa0,
+	0xd9, 0x66, 0x6b, 0xec, 0x3e, 0xa7, 0xf6, 0x72, 0x9b, 0xf7, 0x2f, 0xc5, 0x0b, 0xac, 0xe2, 0xbf,
+	0x33, 0xb0, 0xa4, 0x02, 0x28, 0x65, 0x15, 0x5f, 0xc3, 0xb5, 0xe4, 0xc6, 0x5a, 0xe2, 0xfd, 0x78,
+	0x38, 0x73, 0xe4, 0xf4, 0x8e, 0x1c, 0x5e, 0x40, 0xdb, 0x50, 0x90, 0xbd, 0x8f, 0xd8, 0x71, 0x52,
+	0xbb, 0x5a, 0xcd, 0x84, 0x58, 0x02, 0x2f, 0x20, 0x02, 0x75, 0x49, 0xe8, 0x95, 0x4d, 0xcf, 0x74,
+	0x83, 0x12, 0xff, 0xca, 0x14, 0xef, 0x5f, 0x8a, 0x17, 0xf0, 0x7b, 0x04, 0x95, 0x70, 0xaf, 0x06,
+	0xdd, 0x89, 0x2e, 0x9d, 0xed, 0x2b, 0x35, 0xd7, 0xe7, 0x60, 0x04, 0x72, 0xff, 0x8f, 0x2c, 0xd4,
+	0xf6, 0x8d, 0x09, 0x57, 0xbb, 0x14, 0x7b, 0x1b, 0xf2, 0xa2, 0x12, 0x8f, 0xa2, 0x19, 0x7e, 0xa4,
+	0x53, 0xd1, 0x5c, 0x4b, 0x9c, 0x0b, 0xd8, 0x6d, 0x43, 0x5e, 0x26, 0xa1, 0xcd, 0xd8, 0x43, 0x12,
+	0xaa, 0xd4, 0x37, 0xd7, 0x12, 0xe7, 0x02, 0x22, 0x5b, 0x50, 0x0a, 0x0a, 0xdc, 0xb1, 0xbb, 0x1c,
+	0x2f, 0x7c, 0x37, 0x53, 0x8b, 0xe6, 0xfc, 0x65, 0x2b, 0xc8, 0x3a, 0x64, 0xec, 0x61, 0x8d, 0x56,
+	0x27, 0xe7, 0xd2, 0x78, 0x0a, 0x59, 0x56, 0x66, 0x44, 0x51, 0x9c, 0x50, 0xe5, 0x71, 0xee, 0xea,
+	0x7d, 0xfe, 0xb5, 0x5c, 0x08, 0x16, 0xf3, 0x23, 0x89, 0xb5, 0xc8, 0xb9, 0x14, 0x7b, 0x50, 0x54,
+	0xa5, 0xdc, 0x98, 0xcb, 0x8c, 0x95, 0x8c, 0x9b, 0xb7, 0x52, 0x66, 0x03, 0x31, 0xff, 0x21, 0xac,
+	0x24, 0x55, 0x28, 0xd1, 0x83, 0x98, 0x76, 0x52, 0x8b, 0x98, 0xf3, 0x18, 0xdd, 0xfc, 0x3e, 0x03,
+	0x95, 0x2e, 0x0b, 0xcd, 0x95, 0x7d, 0x7d, 0x05, 0xab, 0x89, 0xf9, 0x29, 0xfa, 0x20, 0xf6, 0x80,
+	0xa4, 0xe7, 0xb0, 0x29, 0x1e, 0x76, 0x17, 0xea, 0xf1, 0x94, 0x14, 0xfd, 0x7a, 0x86, 0x68, 0x42,
+	0xc6, 0x9a, 0x42, 0xef, 0x39, 0x54, 0x23, 0xe9, 0x25, 0x5a, 0x9f, 0x21, 0x16, 0x4f, 0x3d, 0x93,
+	0x29, 0x6d, 0xbe, 0x81, 0xa5, 0xf6, 0x19, 0x31, 0xcf, 0xdd, 0x71, 0x70, 0xcd, 0xf6, 0x00, 0xa6,
+	0x99, 0x55, 0xec, 0xa9, 0x9e, 0xc9, 0x48, 0x9b, 0xbf, 0x4a, 0x9d, 0x0f, 0xae, 0xf2, 0x7f, 0x69,
+	0x50, 0xe1, 0x30, 0xb5, 0xc3, 0xa7, 0x50, 0x54, 0x39, 0x4c, 0xcc, 0x44, 0x62, 0xa9, 0x4d, 0xca,
+	0xf1, 0x3f, 0xe5, 0xaf, 0x72, 0xd2, 0xfa, 0x58, 0x76, 0xd3, 0x4c, 0x48, 0x31, 0xf0, 0x02, 0x32,
+	0xa0, 0x1e, 0x4f, 0x52, 0x62, 0xea, 0x48, 0xc9, 0x7b, 0x9a, 0xf7, 0x2e, 0xc1, 0x0a, 0xce, 0xfc,
+	0x9c, 0xe5, 0x2f, 0xea, 0xbc, 0x4f, 0x20, 0xbf, 0xcd, 0x3e, 0x28, 0xf1, 0xd1, 0xb5, 0x78, 0x2e,
+	0x22, 0xe9, 0x5e, 0x9f, 0x81, 0x07, 0x94, 0xfe, 0x5c, 0x83, 0xca, 0x96, 0x31, 0x1e, 0x04, 0xfa,
+	0xf9, 0x04, 0xf2, 0x22, 0xf9, 0x88, 0xbb, 0xc1, 0x70, 0x46, 0x92, 0x22, 0xb9, 0x4f, 0x20, 0x2f,
+	0x52, 0x87, 0xd8, 0xda, 0x48, 0x3e, 0x91, 0x62, 0x2a, 0x9f, 0x41, 0xf9, 0x90, 0xf8, 0x01, 0x1b,
+	0x8f, 0x21, 0xcb, 0x86, 0x89, 0x4f, 0x5e, 0x22, 0x81, 0x37, 0x79, 0xfe, 0x29, 0xf6, 0xef, 0xfc,
+	0xcf, 0x00, 0xcc, 0x5e, 0xf6, 0x7d, 0x98, 0x2d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CartServiceClient is the client API for CartService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CartServiceClient interface {
+	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*Cart, error)
+	EmptyCart(ctx context.Context, in *EmptyCartRequest, opts ...grpc.CallOption) (*Empty, error)
+	// RemoveItem removes a product from a cart. Removing a product that is not
+	// in the cart does nothing.
+	RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	// UpdateItemQuantity sets the quantity of a product in a cart. A quantity
+	// of zero removes the product.
+	UpdateItemQuantity(ctx context.Context, in *UpdateItemQuantityRequest, opts ...grpc.CallOption) (*Empty, error)
+	// MergeCarts moves the items of one cart into another, adding up the
+	// quantities of products in both, deletes the first cart and returns the
+	// merged one. It is used when an anonymous shopper signs in.
+	MergeCarts(ctx context.Context, in *MergeCartsRequest, opts ...grpc.CallOption) (*Cart, error)
+}
+
+type cartServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCartServiceClient(cc *grpc.ClientConn) CartServiceClient {
+	return &cartServiceClient{cc}
+}
+
+func (c *cartServiceClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.CartService/AddItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, "/hipstershop.CartService/GetCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) EmptyCart(ctx context.Context, in *EmptyCartRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.CartService/EmptyCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.CartService/RemoveItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) UpdateItemQuantity(ctx context.Context, in *UpdateItemQuantityRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.CartService/UpdateItemQuantity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) MergeCarts(ctx context.Context, in *MergeCartsRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, "/hipstershop.CartService/MergeCarts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CartServiceServer is the server API for CartService service.
+type CartServiceServer interface {
+	AddItem(context.Context, *AddItemRequest) (*Empty, error)
+	GetCart(context.Context, *GetCartRequest) (*Cart, error)
+	EmptyCart(context.Context, *EmptyCartRequest) (*Empty, error)
+	// RemoveItem removes a product from a cart. Removing a product that is not
+	// in the cart does nothing.
+	RemoveItem(context.Context, *RemoveItemRequest) (*Empty, error)
+	// UpdateItemQuantity sets the quantity of a product in a cart. A quantity
+	// of zero removes the product.
+	UpdateItemQuantity(context.Context, *UpdateItemQuantityRequest) (*Empty, error)
+	// MergeCarts moves the items of one cart into another, adding up the
+	// quantities of products in both, deletes the first cart and returns the
+	// merged one. It is used when an anonymous shopper signs in.
+	MergeCarts(context.Context, *MergeCartsRequest) (*Cart, error)
+}
+
+// UnimplementedCartServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCartServiceServer struct {
+}
+
+func (*UnimplementedCartServiceServer) AddItem(ctx context.Context, req *AddItemRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (*UnimplementedCartServiceServer) GetCart(ctx context.Context, req *GetCartRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCart not implemented")
+}
+func (*UnimplementedCartServiceServer) EmptyCart(ctx context.Context, req *EmptyCartRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmptyCart not implemented")
+}
+func (*UnimplementedCartServiceServer) RemoveItem(ctx context.Context, req *RemoveItemRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItem not implemented")
+}
+func (*UnimplementedCartServiceServer) UpdateItemQuantity(ctx context.Context, req *UpdateItemQuantityRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItemQuantity not implemented")
+}
+func (*UnimplementedCartServiceServer) MergeCarts(ctx context.Context, req *MergeCartsRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCarts not implemented")
+}
+
+func RegisterCartServiceServer(s *grpc.Server, srv CartServiceServer) {
+	s.RegisterService(&_CartService_serviceDesc, srv)
+}
+
+func _CartService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CartService/AddItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).AddItem(ctx, req.(*AddItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_GetCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).GetCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CartService/GetCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).GetCart(ctx, req.(*GetCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_EmptyCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).EmptyCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CartService/EmptyCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).EmptyCart(ctx, req.(*EmptyCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_RemoveItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).RemoveItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CartService/RemoveItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).RemoveItem(ctx, req.(*RemoveItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_UpdateItemQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).UpdateItemQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CartService/UpdateItemQuantity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).UpdateItemQuantity(ctx, req.(*UpdateItemQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_MergeCarts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).MergeCarts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CartService/MergeCarts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).MergeCarts(ctx, req.(*MergeCartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CartService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.CartService",
+	HandlerType: (*CartServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddItem",
+			Handler:    _CartService_AddItem_Handler,
+		},
+		{
+			MethodName: "GetCart",
+			Handler:    _CartService_GetCart_Handler,
+		},
+		{
+			MethodName: "EmptyCart",
+			Handler:    _CartService_EmptyCart_Handler,
+		},
+		{
+			MethodName: "RemoveItem",
+			Handler:    _CartService_RemoveItem_Handler,
+		},
+		{
+			MethodName: "UpdateItemQuantity",
+			Handler:    _CartService_UpdateItemQuantity_Handler,
+		},
+		{
+			MethodName: "MergeCarts",
+			Handler:    _CartService_MergeCarts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+// RecommendationServiceClient is the client API for RecommendationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RecommendationServiceClient interface {
+	ListRecommendations(ctx context.Context, in *ListRecommendationsRequest, opts ...grpc.CallOption) (*ListRecommendationsResponse, error)
+}
+
+type recommendationServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRecommendationServiceClient(cc *grpc.ClientConn) RecommendationServiceClient {
+	return &recommendationServiceClient{cc}
+}
+
+func (c *recommendationServiceClient) ListRecommendations(ctx context.Context, in *ListRecommendationsRequest, opts ...grpc.CallOption) (*ListRecommendationsResponse, error) {
+	out := new(ListRecommendationsResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.RecommendationService/ListRecommendations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecommendationServiceServer is the server API for RecommendationService service.
+type RecommendationServiceServer interface {
+	ListRecommendations(context.Context, *ListRecommendationsRequest) (*ListRecommendationsResponse, error)
+}
+
+// UnimplementedRecommendationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedRecommendationServiceServer struct {
+}
+
+func (*UnimplementedRecommendationServiceServer) ListRecommendations(ctx context.Context, req *ListRecommendationsRequest) (*ListRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecommendations not implemented")
+}
+
+func RegisterRecommendationServiceServer(s *grpc.Server, srv RecommendationServiceServer) {
+	s.RegisterService(&_RecommendationService_serviceDesc, srv)
+}
+
+func _RecommendationService_ListRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).ListRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.RecommendationService/ListRecommendations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).ListRecommendations(ctx, req.(*ListRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RecommendationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.RecommendationService",
+	HandlerType: (*RecommendationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRecommendations",
+			Handler:    _RecommendationService_ListRecommendations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+// ProductCatalogServiceClient is the client API for ProductCatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProductCatalogServiceClient interface {
+	ListProducts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error)
+	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+}
+
+type productCatalogServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProductCatalogServiceClient(cc *grpc.ClientConn) ProductCatalogServiceClient {
+	return &productCatalogServiceClient{cc}
+}
+
+func (c *productCatalogServiceClient) ListProducts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.ProductCatalogService/ListProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, "/hipstershop.ProductCatalogService/GetProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
+	out := new(SearchProductsResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.ProductCatalogService/SearchProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProductCatalogServiceServer is the server API for ProductCatalogService service.
+type ProductCatalogServiceServer interface {
+	ListProducts(context.Context, *Empty) (*ListProductsResponse, error)
+	GetProduct(context.Context, *GetProductRequest) (*Product, error)
+	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+}
+
+// UnimplementedProductCatalogServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedProductCatalogServiceServer struct {
+}
+
+func (*UnimplementedProductCatalogServiceServer) ListProducts(ctx context.Context, req *Empty) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (*UnimplementedProductCatalogServiceServer) GetProduct(ctx context.Context, req *GetProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (*UnimplementedProductCatalogServiceServer) SearchProducts(ctx context.Context, req *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+
+func RegisterProductCatalogServiceServer(s *grpc.Server, srv ProductCatalogServiceServer) {
+	s.RegisterService(&_ProductCatalogService_serviceDesc, srv)
+}
+
+func _ProductCatalogService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ProductCatalogService/ListProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).ListProducts(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ProductCatalogService/GetProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ProductCatalogService/SearchProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ProductCatalogService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.ProductCatalogService",
+	HandlerType: (*ProductCatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListProducts",
+			Handler:    _ProductCatalogService_ListProducts_Handler,
+		},
+		{
+			MethodName: "GetProduct",
+			Handler:    _ProductCatalogService_GetProduct_Handler,
+		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _ProductCatalogService_SearchProducts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+// ShippingServiceClient is the client API for ShippingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ShippingServiceClient interface {
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
+	ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error)
+	CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetShipmentStatus(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (*ShipmentStatus, error)
+	// Streams the status of a shipment whenever it changes, starting with the
+	// current status. The stream ends once the shipment is delivered or
+	// cancelled.
+	WatchShipment(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (ShippingService_WatchShipmentClient, error)
+	// Checks the format and check digit of a tracking ID. It does not check
+	// that a shipment with the ID exists.
+	ValidateTrackingId(ctx context.Context, in *ValidateTrackingIdRequest, opts ...grpc.CallOption) (*ValidateTrackingIdResponse, error)
+}
+
+type shippingServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewShippingServiceClient(cc *grpc.ClientConn) ShippingServiceClient {
+	return &shippingServiceClient{cc}
+}
+
+func (c *shippingServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error) {
+	out := new(GetQuoteResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/GetQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error) {
+	out := new(ShipOrderResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/ShipOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/CancelShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) GetShipmentStatus(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (*ShipmentStatus, error) {
+	out := new(ShipmentStatus)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/GetShipmentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shippingServiceClient) WatchShipment(ctx context.Context, in *GetShipmentStatusRequest, opts ...grpc.CallOption) (ShippingService_WatchShipmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ShippingService_serviceDesc.Streams[0], "/hipstershop.ShippingService/WatchShipment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shippingServiceWatchShipmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShippingService_WatchShipmentClient interface {
+	Recv() (*ShipmentStatus, error)
+	grpc.ClientStream
+}
+
+type shippingServiceWatchShipmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *shippingServiceWatchShipmentClient) Recv() (*ShipmentStatus, error) {
+	m := new(ShipmentStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shippingServiceClient) ValidateTrackingId(ctx context.Context, in *ValidateTrackingIdRequest, opts ...grpc.CallOption) (*ValidateTrackingIdResponse, error) {
+	out := new(ValidateTrackingIdResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/ValidateTrackingId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShippingServiceServer is the server API for ShippingService service.
+type ShippingServiceServer interface {
+	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
+	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+	CancelShipment(context.Context, *CancelShipmentRequest) (*Empty, error)
+	GetShipmentStatus(context.Context, *GetShipmentStatusRequest) (*ShipmentStatus, error)
+	// Streams the status of a shipment whenever it changes, starting with the
+	// current status. The stream ends once the shipment is delivered or
+	// cancelled.
+	WatchShipment(*GetShipmentStatusRequest, ShippingService_WatchShipmentServer) error
+	// Checks the format and check digit of a tracking ID. It does not check
+	// that a shipment with the ID exists.
+	ValidateTrackingId(context.Context, *ValidateTrackingIdRequest) (*ValidateTrackingIdResponse, error)
+}
+
+// UnimplementedShippingServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedShippingServiceServer struct {
+}
+
+func (*UnimplementedShippingServiceServer) GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (*UnimplementedShippingServiceServer) ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipOrder not implemented")
+}
+func (*UnimplementedShippingServiceServer) CancelShipment(ctx context.Context, req *CancelShipmentRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelShipment not implemented")
+}
+func (*UnimplementedShippingServiceServer) GetShipmentStatus(ctx context.Context, req *GetShipmentStatusRequest) (*ShipmentStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShipmentStatus not implemented")
+}
+func (*UnimplementedShippingServiceServer) WatchShipment(req *GetShipmentStatusRequest, srv ShippingService_WatchShipmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchShipment not implemented")
+}
+func (*UnimplementedShippingServiceServer) ValidateTrackingId(ctx context.Context, req *ValidateTrackingIdRequest) (*ValidateTrackingIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTrackingId not implemented")
+}
+
+func RegisterShippingServiceServer(s *grpc.Server, srv ShippingServiceServer) {
+	s.RegisterService(&_ShippingService_serviceDesc, srv)
+}
+
+func _ShippingService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/GetQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_ShipOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).ShipOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/ShipOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).ShipOrder(ctx, req.(*ShipOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_CancelShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).CancelShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/CancelShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).CancelShipment(ctx, req.(*CancelShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_GetShipmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).GetShipmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/GetShipmentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).GetShipmentStatus(ctx, req.(*GetShipmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShippingService_WatchShipment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetShipmentStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShippingServiceServer).WatchShipment(m, &shippingServiceWatchShipmentServer{stream})
+}
+
+type ShippingService_WatchShipmentServer interface {
+	Send(*ShipmentStatus) error
+	grpc.ServerStream
+}
+
+type shippingServiceWatchShipmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *shippingServiceWatchShipmentServer) Send(m *ShipmentStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ShippingService_ValidateTrackingId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTrackingIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShippingServiceServer).ValidateTrackingId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.ShippingService/ValidateTrackingId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShippingServiceServer).ValidateTrackingId(ctx, req.(*ValidateTrackingIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ShippingService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.ShippingService",
+	HandlerType: (*ShippingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuote",
+			Handler:    _ShippingService_GetQuote_Handler,
+		},
+		{
+			MethodName: "ShipOrder",
+			Handler:    _ShippingService_ShipOrder_Handler,
+		},
+		{
+			MethodName: "CancelShipment",
+			Handler:    _ShippingService_CancelShipment_Handler,
+		},
+		{
+			MethodName: "GetShipmentStatus",
+			Handler:    _ShippingService_GetShipmentStatus_Handler,
+		},
+		{
+			MethodName: "ValidateTrackingId",
+			Handler:    _ShippingService_ValidateTrackingId_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchShipment",
+			Handler:       _ShippingService_WatchShipment_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "demo.proto",
 }
 
-func (c *cartServiceClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/hipstershop.CartService/AddItem", in, out, opts...)
+// CurrencyServiceClient is the client API for CurrencyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CurrencyServiceClient interface {
+	GetSupportedCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetSupportedCurrenciesResponse, error)
+	Convert(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*Money, error)
+	// ConvertWithRates converts like Convert, and also returns the version of
+	// the exchange rates used.
+	ConvertWithRates(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*CurrencyConversionResponse, error)
+	// ConvertBatch converts many amounts to one currency with the same version
+	// of the exchange rates.
+	ConvertBatch(ctx context.Context, in *ConvertBatchRequest, opts ...grpc.CallOption) (*ConvertBatchResponse, error)
+}
+
+type currencyServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCurrencyServiceClient(cc *grpc.ClientConn) CurrencyServiceClient {
+	return &currencyServiceClient{cc}
+}
+
+func (c *currencyServiceClient) GetSupportedCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetSupportedCurrenciesResponse, error) {
+	out := new(GetSupportedCurrenciesResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/GetSupportedCurrencies", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cartServiceClient) GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*Cart, error) {
-	out := new(Cart)
-	err := c.cc.Invoke(ctx, "/hipstershop.CartService/GetCart", in, out, opts...)
+func (c *currencyServiceClient) Convert(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*Money, error) {
+	out := new(Money)
+	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/Convert", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cartServiceClient) EmptyCart(ctx context.Context, in *EmptyCartRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/hipstershop.CartService/EmptyCart", in, out, opts...)
+func (c *currencyServiceClient) ConvertWithRates(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*CurrencyConversionResponse, error) {
+	out := new(CurrencyConversionResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/ConvertWithRates", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// CartServiceServer is the server API for CartService service.
-type CartServiceServer interface {
-	AddItem(context.Context, *AddItemRequest) (*Empty, error)
-	GetCart(context.Context, *GetCartRequest) (*Cart, error)
-	EmptyCart(context.Context, *EmptyCartRequest) (*Empty, error)
+func (c *currencyServiceClient) ConvertBatch(ctx context.Context, in *ConvertBatchRequest, opts ...grpc.CallOption) (*ConvertBatchResponse, error) {
+	out := new(ConvertBatchResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/ConvertBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterCartServiceServer(s *grpc.Server, srv CartServiceServer) {
-	s.RegisterService(&_CartService_serviceDesc, srv)
+// CurrencyServiceServer is the server API for CurrencyService service.
+type CurrencyServiceServer interface {
+	GetSupportedCurrencies(context.Context, *Empty) (*GetSupportedCurrenciesResponse, error)
+	Convert(context.Context, *CurrencyConversionRequest) (*Money, error)
+	// ConvertWithRates converts like Convert, and also returns the version of
+	// the exchange rates used.
+	ConvertWithRates(context.Context, *CurrencyConversionRequest) (*CurrencyConversionResponse, error)
+	// ConvertBatch converts many amounts to one currency with the same version
+	// of the exchange rates.
+	ConvertBatch(context.Context, *ConvertBatchRequest) (*ConvertBatchResponse, error)
+}
+
+// UnimplementedCurrencyServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCurrencyServiceServer struct {
+}
+
+func (*UnimplementedCurrencyServiceServer) GetSupportedCurrencies(ctx context.Context, req *Empty) (*GetSupportedCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportedCurrencies not implemented")
+}
+func (*UnimplementedCurrencyServiceServer) Convert(ctx context.Context, req *CurrencyConversionRequest) (*Money, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (*UnimplementedCurrencyServiceServer) ConvertWithRates(ctx context.Context, req *CurrencyConversionRequest) (*CurrencyConversionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertWithRates not implemented")
+}
+func (*UnimplementedCurrencyServiceServer) ConvertBatch(ctx context.Context, req *ConvertBatchRequest) (*ConvertBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertBatch not implemented")
+}
+
+func RegisterCurrencyServiceServer(s *grpc.Server, srv CurrencyServiceServer) {
+	s.RegisterService(&_CurrencyService_serviceDesc, srv)
+}
+
+func _CurrencyService_GetSupportedCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).GetSupportedCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CurrencyService/GetSupportedCurrencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).GetSupportedCurrencies(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyConversionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CurrencyService/Convert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).Convert(ctx, req.(*CurrencyConversionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ConvertWithRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyConversionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ConvertWithRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CurrencyService/ConvertWithRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ConvertWithRates(ctx, req.(*CurrencyConversionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ConvertBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ConvertBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.CurrencyService/ConvertBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ConvertBatch(ctx, req.(*ConvertBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CurrencyService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.CurrencyService",
+	HandlerType: (*CurrencyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSupportedCurrencies",
+			Handler:    _CurrencyService_GetSupportedCurrencies_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _CurrencyService_Convert_Handler,
+		},
+		{
+			MethodName: "ConvertWithRates",
+			Handler:    _CurrencyService_ConvertWithRates_Handler,
+		},
+		{
+			MethodName: "ConvertBatch",
+			Handler:    _CurrencyService_ConvertBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+// PaymentServiceClient is the client API for PaymentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PaymentServiceClient interface {
+	// Charge authorizes and captures an amount at once.
+	Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
+	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*Transaction, error)
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*Transaction, error)
+	Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*Transaction, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// Tokenize stores a card in the vault, and returns a token that Charge and
+	// Authorize accept in its place.
+	Tokenize(ctx context.Context, in *TokenizeRequest, opts ...grpc.CallOption) (*TokenizeResponse, error)
+	// ReconcileTransaction settles the pending change of a transaction, once
+	// its outcome was looked up with the payment gateway.
+	ReconcileTransaction(ctx context.Context, in *ReconcileTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+}
+
+type paymentServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPaymentServiceClient(cc *grpc.ClientConn) PaymentServiceClient {
+	return &paymentServiceClient{cc}
+}
+
+func (c *paymentServiceClient) Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error) {
+	out := new(ChargeResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Charge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Refund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Authorize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Capture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Void", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Tokenize(ctx context.Context, in *TokenizeRequest, opts ...grpc.CallOption) (*TokenizeResponse, error) {
+	out := new(TokenizeResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Tokenize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReconcileTransaction(ctx context.Context, in *ReconcileTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/ReconcileTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentServiceServer is the server API for PaymentService service.
+type PaymentServiceServer interface {
+	// Charge authorizes and captures an amount at once.
+	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
+	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
+	Authorize(context.Context, *AuthorizeRequest) (*Transaction, error)
+	Capture(context.Context, *CaptureRequest) (*Transaction, error)
+	Void(context.Context, *VoidRequest) (*Transaction, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error)
+	// Tokenize stores a card in the vault, and returns a token that Charge and
+	// Authorize accept in its place.
+	Tokenize(context.Context, *TokenizeRequest) (*TokenizeResponse, error)
+	// ReconcileTransaction settles the pending change of a transaction, once
+	// its outcome was looked up with the payment gateway.
+	ReconcileTransaction(context.Context, *ReconcileTransactionRequest) (*Transaction, error)
+}
+
+// UnimplementedPaymentServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPaymentServiceServer struct {
+}
+
+func (*UnimplementedPaymentServiceServer) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Charge not implemented")
+}
+func (*UnimplementedPaymentServiceServer) Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
+func (*UnimplementedPaymentServiceServer) Authorize(ctx context.Context, req *AuthorizeRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (*UnimplementedPaymentServiceServer) Capture(ctx context.Context, req *CaptureRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (*UnimplementedPaymentServiceServer) Void(ctx context.Context, req *VoidRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Void not implemented")
+}
+func (*UnimplementedPaymentServiceServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedPaymentServiceServer) Tokenize(ctx context.Context, req *TokenizeRequest) (*TokenizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tokenize not implemented")
+}
+func (*UnimplementedPaymentServiceServer) ReconcileTransaction(ctx context.Context, req *ReconcileTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileTransaction not implemented")
+}
+
+func RegisterPaymentServiceServer(s *grpc.Server, srv PaymentServiceServer) {
+	s.RegisterService(&_PaymentService_serviceDesc, srv)
+}
+
+func _PaymentService_Charge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChargeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Charge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.PaymentService/Charge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Charge(ctx, req.(*ChargeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.PaymentService/Refund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _CartService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddItemRequest)
+func _PaymentService_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CartServiceServer).AddItem(ctx, in)
+		return srv.(PaymentServiceServer).Authorize(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.CartService/AddItem",
+		FullMethod: "/hipstershop.PaymentService/Authorize",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CartServiceServer).AddItem(ctx, req.(*AddItemRequest))
+		return srv.(PaymentServiceServer).Authorize(ctx, req.(*AuthorizeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CartService_GetCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCartRequest)
+func _PaymentService_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CartServiceServer).GetCart(ctx, in)
+		return srv.(PaymentServiceServer).Capture(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.CartService/GetCart",
+		FullMethod: "/hipstershop.PaymentService/Capture",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CartServiceServer).GetCart(ctx, req.(*GetCartRequest))
+		return srv.(PaymentServiceServer).Capture(ctx, req.(*CaptureRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CartService_EmptyCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyCartRequest)
+func _PaymentService_Void_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CartServiceServer).EmptyCart(ctx, in)
+		return srv.(PaymentServiceServer).Void(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.CartService/EmptyCart",
+		FullMethod: "/hipstershop.PaymentService/Void",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CartServiceServer).EmptyCart(ctx, req.(*EmptyCartRequest))
+		return srv.(PaymentServiceServer).Void(ctx, req.(*VoidRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _CartService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.CartService",
-	HandlerType: (*CartServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddItem",
-			Handler:    _CartService_AddItem_Handler,
-		},
-		{
-			MethodName: "GetCart",
-			Handler:    _CartService_GetCart_Handler,
-		},
-		{
-			MethodName: "EmptyCart",
-			Handler:    _CartService_EmptyCart_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "demo.proto",
-}
-
-// RecommendationServiceClient is the client API for RecommendationService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RecommendationServiceClient interface {
-	ListRecommendations(ctx context.Context, in *ListRecommendationsRequest, opts ...grpc.CallOption) (*ListRecommendationsResponse, error)
-}
-
-type recommendationServiceClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewRecommendationServiceClient(cc *grpc.ClientConn) RecommendationServiceClient {
-	return &recommendationServiceClient{cc}
-}
-
-func (c *recommendationServiceClient) ListRecommendations(ctx context.Context, in *ListRecommendationsRequest, opts ...grpc.CallOption) (*ListRecommendationsResponse, error) {
-	out := new(ListRecommendationsResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.RecommendationService/ListRecommendations", in, out, opts...)
-	if err != nil {
+func _PaymentService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// RecommendationServiceServer is the server API for RecommendationService service.
-type RecommendationServiceServer interface {
-	ListRecommendations(context.Context, *ListRecommendationsRequest) (*ListRecommendationsResponse, error)
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.PaymentService/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterRecommendationServiceServer(s *grpc.Server, srv RecommendationServiceServer) {
-	s.RegisterService(&_RecommendationService_serviceDesc, srv)
+func _PaymentService_Tokenize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Tokenize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.PaymentService/Tokenize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Tokenize(ctx, req.(*TokenizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _RecommendationService_ListRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRecommendationsRequest)
+func _PaymentService_ReconcileTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileTransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RecommendationServiceServer).ListRecommendations(ctx, in)
+		return srv.(PaymentServiceServer).ReconcileTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.RecommendationService/ListRecommendations",
+		FullMethod: "/hipstershop.PaymentService/ReconcileTransaction",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RecommendationServiceServer).ListRecommendations(ctx, req.(*ListRecommendationsRequest))
+		return srv.(PaymentServiceServer).ReconcileTransaction(ctx, req.(*ReconcileTransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _RecommendationService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.RecommendationService",
-	HandlerType: (*RecommendationServiceServer)(nil),
+var _PaymentService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.PaymentService",
+	HandlerType: (*PaymentServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListRecommendations",
-			Handler:    _RecommendationService_ListRecommendations_Handler,
+			MethodName: "Charge",
+			Handler:    _PaymentService_Charge_Handler,
+		},
+		{
+			MethodName: "Refund",
+			Handler:    _PaymentService_Refund_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _PaymentService_Authorize_Handler,
+		},
+		{
+			MethodName: "Capture",
+			Handler:    _PaymentService_Capture_Handler,
+		},
+		{
+			MethodName: "Void",
+			Handler:    _PaymentService_Void_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _PaymentService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "Tokenize",
+			Handler:    _PaymentService_Tokenize_Handler,
+		},
+		{
+			MethodName: "ReconcileTransaction",
+			Handler:    _PaymentService_ReconcileTransaction_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
-// ProductCatalogServiceClient is the client API for ProductCatalogService service.
+// EmailServiceClient is the client API for EmailService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ProductCatalogServiceClient interface {
-	ListProducts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListProductsResponse, error)
-	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error)
-	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+type EmailServiceClient interface {
+	SendOrderConfirmation(ctx context.Context, in *SendOrderConfirmationRequest, opts ...grpc.CallOption) (*Empty, error)
+	// SendCartReminder reminds a user of a cart they left without checking
+	// out.
+	SendCartReminder(ctx context.Context, in *SendCartReminderRequest, opts ...grpc.CallOption) (*Empty, error)
+	// SendLoginLink sends a shopper the link that signs them in.
+	SendLoginLink(ctx context.Context, in *SendLoginLinkRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
-type productCatalogServiceClient struct {
+type emailServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewProductCatalogServiceClient(cc *grpc.ClientConn) ProductCatalogServiceClient {
-	return &productCatalogServiceClient{cc}
+func NewEmailServiceClient(cc *grpc.ClientConn) EmailServiceClient {
+	return &emailServiceClient{cc}
 }
 
-func (c *productCatalogServiceClient) ListProducts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListProductsResponse, error) {
-	out := new(ListProductsResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.ProductCatalogService/ListProducts", in, out, opts...)
+func (c *emailServiceClient) SendOrderConfirmation(ctx context.Context, in *SendOrderConfirmationRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.EmailService/SendOrderConfirmation", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *productCatalogServiceClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error) {
-	out := new(Product)
-	err := c.cc.Invoke(ctx, "/hipstershop.ProductCatalogService/GetProduct", in, out, opts...)
+func (c *emailServiceClient) SendCartReminder(ctx context.Context, in *SendCartReminderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.EmailService/SendCartReminder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *productCatalogServiceClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
-	out := new(SearchProductsResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.ProductCatalogService/SearchProducts", in, out, opts...)
+func (c *emailServiceClient) SendLoginLink(ctx context.Context, in *SendLoginLinkRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.EmailService/SendLoginLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ProductCatalogServiceServer is the server API for ProductCatalogService service.
-type ProductCatalogServiceServer interface {
-	ListProducts(context.Context, *Empty) (*ListProductsResponse, error)
-	GetProduct(context.Context, *GetProductRequest) (*Product, error)
-	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+// EmailServiceServer is the server API for EmailService service.
+type EmailServiceServer interface {
+	SendOrderConfirmation(context.Context, *SendOrderConfirmationRequest) (*Empty, error)
+	// SendCartReminder reminds a user of a cart they left without checking
+	// out.
+	SendCartReminder(context.Context, *SendCartReminderRequest) (*Empty, error)
+	// SendLoginLink sends a shopper the link that signs them in.
+	SendLoginLink(context.Context, *SendLoginLinkRequest) (*Empty, error)
 }
 
-func RegisterProductCatalogServiceServer(s *grpc.Server, srv ProductCatalogServiceServer) {
-	s.RegisterService(&_ProductCatalogService_serviceDesc, srv)
+// UnimplementedEmailServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEmailServiceServer struct {
 }
 
-func _ProductCatalogService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func (*UnimplementedEmailServiceServer) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOrderConfirmation not implemented")
+}
+func (*UnimplementedEmailServiceServer) SendCartReminder(ctx context.Context, req *SendCartReminderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendCartReminder not implemented")
+}
+func (*UnimplementedEmailServiceServer) SendLoginLink(ctx context.Context, req *SendLoginLinkRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendLoginLink not implemented")
+}
+
+func RegisterEmailServiceServer(s *grpc.Server, srv EmailServiceServer) {
+	s.RegisterService(&_EmailService_serviceDesc, srv)
+}
+
+func _EmailService_SendOrderConfirmation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOrderConfirmationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductCatalogServiceServer).ListProducts(ctx, in)
+		return srv.(EmailServiceServer).SendOrderConfirmation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.ProductCatalogService/ListProducts",
+		FullMethod: "/hipstershop.EmailService/SendOrderConfirmation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductCatalogServiceServer).ListProducts(ctx, req.(*Empty))
+		return srv.(EmailServiceServer).SendOrderConfirmation(ctx, req.(*SendOrderConfirmationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductCatalogService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProductRequest)
+func _EmailService_SendCartReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCartReminderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductCatalogServiceServer).GetProduct(ctx, in)
+		return srv.(EmailServiceServer).SendCartReminder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.ProductCatalogService/GetProduct",
+		FullMethod: "/hipstershop.EmailService/SendCartReminder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductCatalogServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+		return srv.(EmailServiceServer).SendCartReminder(ctx, req.(*SendCartReminderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductCatalogService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchProductsRequest)
+func _EmailService_SendLoginLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendLoginLinkRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductCatalogServiceServer).SearchProducts(ctx, in)
+		return srv.(EmailServiceServer).SendLoginLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.ProductCatalogService/SearchProducts",
+		FullMethod: "/hipstershop.EmailService/SendLoginLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductCatalogServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+		return srv.(EmailServiceServer).SendLoginLink(ctx, req.(*SendLoginLinkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ProductCatalogService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.ProductCatalogService",
-	HandlerType: (*ProductCatalogServiceServer)(nil),
+var _EmailService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.EmailService",
+	HandlerType: (*EmailServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListProducts",
-			Handler:    _ProductCatalogService_ListProducts_Handler,
+			MethodName: "SendOrderConfirmation",
+			Handler:    _EmailService_SendOrderConfirmation_Handler,
 		},
 		{
-			MethodName: "GetProduct",
-			Handler:    _ProductCatalogService_GetProduct_Handler,
+			MethodName: "SendCartReminder",
+			Handler:    _EmailService_SendCartReminder_Handler,
 		},
 		{
-			MethodName: "SearchProducts",
-			Handler:    _ProductCatalogService_SearchProducts_Handler,
+			MethodName: "SendLoginLink",
+			Handler:    _EmailService_SendLoginLink_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
-// ShippingServiceClient is the client API for ShippingService service.
+// CheckoutServiceClient is the client API for CheckoutService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ShippingServiceClient interface {
-	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
-	ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error)
+type CheckoutServiceClient interface {
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
 }
 
-type shippingServiceClient struct {
+type checkoutServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewShippingServiceClient(cc *grpc.ClientConn) ShippingServiceClient {
-	return &shippingServiceClient{cc}
+func NewCheckoutServiceClient(cc *grpc.ClientConn) CheckoutServiceClient {
+	return &checkoutServiceClient{cc}
 }
 
-func (c *shippingServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error) {
-	out := new(GetQuoteResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/GetQuote", in, out, opts...)
+func (c *checkoutServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
+	out := new(PlaceOrderResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.CheckoutService/PlaceOrder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *shippingServiceClient) ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error) {
-	out := new(ShipOrderResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.ShippingService/ShipOrder", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// CheckoutServiceServer is the server API for CheckoutService service.
+type CheckoutServiceServer interface {
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
 }
 
-// ShippingServiceServer is the server API for ShippingService service.
-type ShippingServiceServer interface {
-	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
-	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+// UnimplementedCheckoutServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCheckoutServiceServer struct {
 }
 
-func RegisterShippingServiceServer(s *grpc.Server, srv ShippingServiceServer) {
-	s.RegisterService(&_ShippingService_serviceDesc, srv)
+func (*UnimplementedCheckoutServiceServer) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
 }
 
-func _ShippingService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetQuoteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ShippingServiceServer).GetQuote(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hipstershop.ShippingService/GetQuote",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShippingServiceServer).GetQuote(ctx, req.(*GetQuoteRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func RegisterCheckoutServiceServer(s *grpc.Server, srv CheckoutServiceServer) {
+	s.RegisterService(&_CheckoutService_serviceDesc, srv)
 }
 
-func _ShippingService_ShipOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShipOrderRequest)
+func _CheckoutService_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShippingServiceServer).ShipOrder(ctx, in)
+		return srv.(CheckoutServiceServer).PlaceOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.ShippingService/ShipOrder",
+		FullMethod: "/hipstershop.CheckoutService/PlaceOrder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShippingServiceServer).ShipOrder(ctx, req.(*ShipOrderRequest))
+		return srv.(CheckoutServiceServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ShippingService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.ShippingService",
-	HandlerType: (*ShippingServiceServer)(nil),
+var _CheckoutService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.CheckoutService",
+	HandlerType: (*CheckoutServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetQuote",
-			Handler:    _ShippingService_GetQuote_Handler,
-		},
-		{
-			MethodName: "ShipOrder",
-			Handler:    _ShippingService_ShipOrder_Handler,
+			MethodName: "PlaceOrder",
+			Handler:    _CheckoutService_PlaceOrder_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
-// CurrencyServiceClient is the client API for CurrencyService service.
+// OrderServiceClient is the client API for OrderService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CurrencyServiceClient interface {
-	GetSupportedCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetSupportedCurrenciesResponse, error)
-	Convert(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*Money, error)
+type OrderServiceClient interface {
+	AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	ListOrdersByUser(ctx context.Context, in *ListOrdersByUserRequest, opts ...grpc.CallOption) (*ListOrdersByUserResponse, error)
 }
 
-type currencyServiceClient struct {
+type orderServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewCurrencyServiceClient(cc *grpc.ClientConn) CurrencyServiceClient {
-	return &currencyServiceClient{cc}
+func NewOrderServiceClient(cc *grpc.ClientConn) OrderServiceClient {
+	return &orderServiceClient{cc}
 }
 
-func (c *currencyServiceClient) GetSupportedCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetSupportedCurrenciesResponse, error) {
-	out := new(GetSupportedCurrenciesResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/GetSupportedCurrencies", in, out, opts...)
+func (c *orderServiceClient) AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/AddOrder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *currencyServiceClient) Convert(ctx context.Context, in *CurrencyConversionRequest, opts ...grpc.CallOption) (*Money, error) {
-	out := new(Money)
-	err := c.cc.Invoke(ctx, "/hipstershop.CurrencyService/Convert", in, out, opts...)
+func (c *orderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/GetOrder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// CurrencyServiceServer is the server API for CurrencyService service.
-type CurrencyServiceServer interface {
-	GetSupportedCurrencies(context.Context, *Empty) (*GetSupportedCurrenciesResponse, error)
-	Convert(context.Context, *CurrencyConversionRequest) (*Money, error)
+func (c *orderServiceClient) ListOrdersByUser(ctx context.Context, in *ListOrdersByUserRequest, opts ...grpc.CallOption) (*ListOrdersByUserResponse, error) {
+	out := new(ListOrdersByUserResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.OrderService/ListOrdersByUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterCurrencyServiceServer(s *grpc.Server, srv CurrencyServiceServer) {
-	s.RegisterService(&_CurrencyService_serviceDesc, srv)
+// OrderServiceServer is the server API for OrderService service.
+type OrderServiceServer interface {
+	AddOrder(context.Context, *AddOrderRequest) (*Empty, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	ListOrdersByUser(context.Context, *ListOrdersByUserRequest) (*ListOrdersByUserResponse, error)
 }
 
-func _CurrencyService_GetSupportedCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+// UnimplementedOrderServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOrderServiceServer struct {
+}
+
+func (*UnimplementedOrderServiceServer) AddOrder(ctx context.Context, req *AddOrderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrder not implemented")
+}
+func (*UnimplementedOrderServiceServer) GetOrder(ctx context.Context, req *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedOrderServiceServer) ListOrdersByUser(ctx context.Context, req *ListOrdersByUserRequest) (*ListOrdersByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrdersByUser not implemented")
+}
+
+func RegisterOrderServiceServer(s *grpc.Server, srv OrderServiceServer) {
+	s.RegisterService(&_OrderService_serviceDesc, srv)
+}
+
+func _OrderService_AddOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CurrencyServiceServer).GetSupportedCurrencies(ctx, in)
+		return srv.(OrderServiceServer).AddOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.CurrencyService/GetSupportedCurrencies",
+		FullMethod: "/hipstershop.OrderService/AddOrder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CurrencyServiceServer).GetSupportedCurrencies(ctx, req.(*Empty))
+		return srv.(OrderServiceServer).AddOrder(ctx, req.(*AddOrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CurrencyService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CurrencyConversionRequest)
+func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CurrencyServiceServer).Convert(ctx, in)
+		return srv.(OrderServiceServer).GetOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.CurrencyService/Convert",
+		FullMethod: "/hipstershop.OrderService/GetOrder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CurrencyServiceServer).Convert(ctx, req.(*CurrencyConversionRequest))
+		return srv.(OrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _CurrencyService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.CurrencyService",
-	HandlerType: (*CurrencyServiceServer)(nil),
+func _OrderService_ListOrdersByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrdersByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hipstershop.OrderService/ListOrdersByUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrdersByUser(ctx, req.(*ListOrdersByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OrderService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetSupportedCurrencies",
-			Handler:    _CurrencyService_GetSupportedCurrencies_Handler,
+			MethodName: "AddOrder",
+			Handler:    _OrderService_AddOrder_Handler,
 		},
 		{
-			MethodName: "Convert",
-			Handler:    _CurrencyService_Convert_Handler,
+			MethodName: "GetOrder",
+			Handler:    _OrderService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrdersByUser",
+			Handler:    _OrderService_ListOrdersByUser_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
-// PaymentServiceClient is the client API for PaymentService service.
+// AdServiceClient is the client API for AdService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PaymentServiceClient interface {
-	Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
+type AdServiceClient interface {
+	GetAds(ctx context.Context, in *AdRequest, opts ...grpc.CallOption) (*AdResponse, error)
 }
 
-type paymentServiceClient struct {
+type adServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewPaymentServiceClient(cc *grpc.ClientConn) PaymentServiceClient {
-	return &paymentServiceClient{cc}
+func NewAdServiceClient(cc *grpc.ClientConn) AdServiceClient {
+	return &adServiceClient{cc}
 }
 
-func (c *paymentServiceClient) Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error) {
-	out := new(ChargeResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.PaymentService/Charge", in, out, opts...)
+func (c *adServiceClient) GetAds(ctx context.Context, in *AdRequest, opts ...grpc.CallOption) (*AdResponse, error) {
+	out := new(AdResponse)
+	err := c.cc.Invoke(ctx, "/hipstershop.AdService/GetAds", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// PaymentServiceServer is the server API for PaymentService service.
-type PaymentServiceServer interface {
-	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
+// AdServiceServer is the server API for AdService service.
+type AdServiceServer interface {
+	GetAds(context.Context, *AdRequest) (*AdResponse, error)
 }
 
-func RegisterPaymentServiceServer(s *grpc.Server, srv PaymentServiceServer) {
-	s.RegisterService(&_PaymentService_serviceDesc, srv)
+// UnimplementedAdServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdServiceServer struct {
 }
 
-func _PaymentService_Charge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChargeRequest)
+func (*UnimplementedAdServiceServer) GetAds(ctx context.Context, req *AdRequest) (*AdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAds not implemented")
+}
+
+func RegisterAdServiceServer(s *grpc.Server, srv AdServiceServer) {
+	s.RegisterService(&_AdService_serviceDesc, srv)
+}
+
+func _AdService_GetAds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PaymentServiceServer).Charge(ctx, in)
+		return srv.(AdServiceServer).GetAds(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.PaymentService/Charge",
+		FullMethod: "/hipstershop.AdService/GetAds",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaymentServiceServer).Charge(ctx, req.(*ChargeRequest))
+		return srv.(AdServiceServer).GetAds(ctx, req.(*AdRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _PaymentService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.PaymentService",
-	HandlerType: (*PaymentServiceServer)(nil),
+var _AdService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.AdService",
+	HandlerType: (*AdServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Charge",
-			Handler:    _PaymentService_Charge_Handler,
+			MethodName: "GetAds",
+			Handler:    _AdService_GetAds_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
-// EmailServiceClient is the client API for EmailService service.
+// FaultServiceClient is the client API for FaultService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type EmailServiceClient interface {
-	SendOrderConfirmation(ctx context.Context, in *SendOrderConfirmationRequest, opts ...grpc.CallOption) (*Empty, error)
+type FaultServiceClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
-type emailServiceClient struct {
+type faultServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewEmailServiceClient(cc *grpc.ClientConn) EmailServiceClient {
-	return &emailServiceClient{cc}
+func NewFaultServiceClient(cc *grpc.ClientConn) FaultServiceClient {
+	return &faultServiceClient{cc}
 }
 
-func (c *emailServiceClient) SendOrderConfirmation(ctx context.Context, in *SendOrderConfirmationRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (c *faultServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/hipstershop.EmailService/SendOrderConfirmation", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hipstershop.FaultService/Create", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// EmailServiceServer is the server API for EmailService service.
-type EmailServiceServer interface {
-	SendOrderConfirmation(context.Context, *SendOrderConfirmationRequest) (*Empty, error)
+func (c *faultServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.FaultService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterEmailServiceServer(s *grpc.Server, srv EmailServiceServer) {
-	s.RegisterService(&_EmailService_serviceDesc, srv)
+// FaultServiceServer is the server API for FaultService service.
+type FaultServiceServer interface {
+	Create(context.Context, *CreateRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
 }
 
-func _EmailService_SendOrderConfirmation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendOrderConfirmationRequest)
+// UnimplementedFaultServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFaultServiceServer struct {
+}
+
+func (*UnimplementedFaultServiceServer) Create(ctx context.Context, req *CreateRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedFaultServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+
+func RegisterFaultServiceServer(s *grpc.Server, srv FaultServiceServer) {
+	s.RegisterService(&_FaultService_serviceDesc, srv)
+}
+
+func _FaultService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(EmailServiceServer).SendOrderConfirmation(ctx, in)
+		return srv.(FaultServiceServer).Create(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.EmailService/SendOrderConfirmation",
+		FullMethod: "/hipstershop.FaultService/Create",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EmailServiceServer).SendOrderConfirmation(ctx, req.(*SendOrderConfirmationRequest))
+		return srv.(FaultServiceServer).Create(ctx, req.(*CreateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _EmailService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.EmailService",
-	HandlerType: (*EmailServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "SendOrderConfirmation",
-			Handler:    _EmailService_SendOrderConfirmation_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "demo.proto",
-}
-
-// CheckoutServiceClient is the client API for CheckoutService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CheckoutServiceClient interface {
-	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
-}
-
-type checkoutServiceClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewCheckoutServiceClient(cc *grpc.ClientConn) CheckoutServiceClient {
-	return &checkoutServiceClient{cc}
-}
-
-func (c *checkoutServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
-	out := new(PlaceOrderResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.CheckoutService/PlaceOrder", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// CheckoutServiceServer is the server API for CheckoutService service.
-type CheckoutServiceServer interface {
-	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
-}
-
-func RegisterCheckoutServiceServer(s *grpc.Server, srv CheckoutServiceServer) {
-	s.RegisterService(&_CheckoutService_serviceDesc, srv)
-}
-
-func _CheckoutService_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PlaceOrderRequest)
+func _FaultService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CheckoutServiceServer).PlaceOrder(ctx, in)
+		return srv.(FaultServiceServer).Delete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.CheckoutService/PlaceOrder",
+		FullMethod: "/hipstershop.FaultService/Delete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CheckoutServiceServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+		return srv.(FaultServiceServer).Delete(ctx, req.(*DeleteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _CheckoutService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.CheckoutService",
-	HandlerType: (*CheckoutServiceServer)(nil),
+var _FaultService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.FaultService",
+	HandlerType: (*FaultServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "PlaceOrder",
-			Handler:    _CheckoutService_PlaceOrder_Handler,
+			MethodName: "Create",
+			Handler:    _FaultService_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _FaultService_Delete_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
-// AdServiceClient is the client API for AdService service.
+// TestServiceClient is the client API for TestService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AdServiceClient interface {
-	GetAds(ctx context.Context, in *AdRequest, opts ...grpc.CallOption) (*AdResponse, error)
+type TestServiceClient interface {
+	Test(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
-type adServiceClient struct {
+type testServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewAdServiceClient(cc *grpc.ClientConn) AdServiceClient {
-	return &adServiceClient{cc}
+func NewTestServiceClient(cc *grpc.ClientConn) TestServiceClient {
+	return &testServiceClient{cc}
 }
 
-func (c *adServiceClient) GetAds(ctx context.Context, in *AdRequest, opts ...grpc.CallOption) (*AdResponse, error) {
-	out := new(AdResponse)
-	err := c.cc.Invoke(ctx, "/hipstershop.AdService/GetAds", in, out, opts...)
+func (c *testServiceClient) Test(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/hipstershop.TestService/Test", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// AdServiceServer is the server API for AdService service.
-type AdServiceServer interface {
-	GetAds(context.Context, *AdRequest) (*AdResponse, error)
+// TestServiceServer is the server API for TestService service.
+type TestServiceServer interface {
+	Test(context.Context, *Empty) (*Empty, error)
 }
 
-func RegisterAdServiceServer(s *grpc.Server, srv AdServiceServer) {
-	s.RegisterService(&_AdService_serviceDesc, srv)
+// UnimplementedTestServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTestServiceServer struct {
 }
 
-func _AdService_GetAds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdRequest)
+func (*UnimplementedTestServiceServer) Test(ctx context.Context, req *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Test not implemented")
+}
+
+func RegisterTestServiceServer(s *grpc.Server, srv TestServiceServer) {
+	s.RegisterService(&_TestService_serviceDesc, srv)
+}
+
+func _TestService_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AdServiceServer).GetAds(ctx, in)
+		return srv.(TestServiceServer).Test(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hipstershop.AdService/GetAds",
+		FullMethod: "/hipstershop.TestService/Test",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdServiceServer).GetAds(ctx, req.(*AdRequest))
+		return srv.(TestServiceServer).Test(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _AdService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "hipstershop.AdService",
-	HandlerType: (*AdServiceServer)(nil),
+var _TestService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hipstershop.TestService",
+	HandlerType: (*TestServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetAds",
-			Handler:    _AdService_GetAds_Handler,
+			MethodName: "Test",
+			Handler:    _TestService_Test_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
-
-func init() { proto.RegisterFile("demo.proto", fileDescriptor_ca53982754088a9d) }
-
-var fileDescriptor_ca53982754088a9d = []byte{
-	// 1500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xef, 0x72, 0x13, 0xb7,
-	0x16, 0xcf, 0x26, 0xb1, 0x1d, 0x1f, 0xc7, 0x4e, 0xa2, 0x9b, 0x04, 0xb3, 0x81, 0x10, 0x94, 0x81,
-	0x0b, 0x17, 0x08, 0x4c, 0xee, 0x9d, 0xe1, 0x03, 0xdc, 0xd2, 0x8c, 0xc9, 0x18, 0x4f, 0xa1, 0xd0,
-	0x0d, 0xe9, 0xd0, 0xa1, 0x53, 0xcf, 0xb2, 0x12, 0xf1, 0x96, 0xec, 0x6a, 0x91, 0xb4, 0x19, 0xcc,
-	0xc7, 0xf6, 0x01, 0xfa, 0x1e, 0x7d, 0x81, 0xce, 0xf4, 0x11, 0xfa, 0xbd, 0xaf, 0xd0, 0xe7, 0xe8,
-	0x48, 0xbb, 0xda, 0x7f, 0xb1, 0x13, 0xf8, 0xd2, 0x6f, 0xab, 0xa3, 0x9f, 0xce, 0xf9, 0xe9, 0xe8,
-	0xfc, 0xb3, 0x01, 0x08, 0x0d, 0xd8, 0x4e, 0xc4, 0x99, 0x64, 0xa8, 0x35, 0xf2, 0x23, 0x21, 0x29,
-	0x17, 0x23, 0x16, 0xe1, 0x7d, 0x58, 0xe8, 0xb9, 0x5c, 0x0e, 0x24, 0x0d, 0xd0, 0x65, 0x80, 0x88,
-	0x33, 0x12, 0x7b, 0x72, 0xe8, 0x93, 0xae, 0xb5, 0x65, 0xdd, 0x68, 0x3a, 0xcd, 0x54, 0x32, 0x20,
-	0xc8, 0x86, 0x85, 0xf7, 0xb1, 0x1b, 0x4a, 0x5f, 0x8e, 0xbb, 0xb3, 0x5b, 0xd6, 0x8d, 0x9a, 0x93,
-	0xad, 0xf1, 0x4b, 0xe8, 0xec, 0x11, 0xa2, 0xb4, 0x38, 0xf4, 0x7d, 0x4c, 0x85, 0x44, 0x17, 0xa0,
-	0x11, 0x0b, 0xca, 0x73, 0x4d, 0x75, 0xb5, 0x1c, 0x10, 0x74, 0x13, 0xe6, 0x7d, 0x49, 0x03, 0xad,
-	0xa2, 0xb5, 0xbb, 0xb6, 0x53, 0x60, 0xb3, 0x63, 0xa8, 0x38, 0x1a, 0x82, 0x6f, 0xc1, 0xf2, 0x7e,
-	0x10, 0xc9, 0xb1, 0x12, 0x9f, 0xa7, 0x17, 0xdf, 0x84, 0x4e, 0x9f, 0xca, 0x4f, 0x82, 0x3e, 0x85,
-	0x79, 0x85, 0x9b, 0xce, 0xf1, 0x16, 0xd4, 0x14, 0x01, 0xd1, 0x9d, 0xdd, 0x9a, 0x9b, 0x4e, 0x32,
-	0xc1, 0xe0, 0x06, 0xd4, 0x34, 0x4b, 0xfc, 0x2d, 0xd8, 0x4f, 0x7d, 0x21, 0x1d, 0xea, 0xb1, 0x20,
-	0xa0, 0x21, 0x71, 0xa5, 0xcf, 0x42, 0x71, 0xae, 0x43, 0xae, 0x40, 0x2b, 0x77, 0x7b, 0x62, 0xb2,
-	0xe9, 0x40, 0xe6, 0x77, 0x81, 0xbf, 0x80, 0x8d, 0x89, 0x7a, 0x45, 0xc4, 0x42, 0x41, 0xab, 0xe7,
-	0xad, 0x53, 0xe7, 0x7f, 0xb7, 0xa0, 0xf1, 0x22, 0x59, 0xa2, 0x0e, 0xcc, 0x66, 0x04, 0x66, 0x7d,
-	0x82, 0x10, 0xcc, 0x87, 0x6e, 0x40, 0xf5, 0x6b, 0x34, 0x1d, 0xfd, 0x8d, 0xb6, 0xa0, 0x45, 0xa8,
-	0xf0, 0xb8, 0x1f, 0x29, 0x43, 0xdd, 0x39, 0xbd, 0x55, 0x14, 0xa1, 0x2e, 0x34, 0x22, 0xdf, 0x93,
-	0x31, 0xa7, 0xdd, 0x79, 0xbd, 0x6b, 0x96, 0xe8, 0x2e, 0x34, 0x23, 0xee, 0x7b, 0x74, 0x18, 0x0b,
-	0xd2, 0xad, 0xe9, 0x27, 0x46, 0x25, 0xef, 0x3d, 0x63, 0x21, 0x1d, 0x3b, 0x0b, 0x1a, 0x74, 0x28,
-	0x08, 0xda, 0x04, 0xf0, 0x5c, 0x49, 0x8f, 0x18, 0xf7, 0xa9, 0xe8, 0xd6, 0x13, 0xf2, 0xb9, 0x04,
-	0x3f, 0x81, 0x55, 0x75, 0xf9, 0x94, 0x7f, 0x7e, 0xeb, 0x7b, 0xb0, 0x90, 0x5e, 0x31, 0xb9, 0x72,
-	0x6b, 0x77, 0xb5, 0x64, 0x27, 0x3d, 0xe0, 0x64, 0x28, 0xbc, 0x0d, 0x2b, 0x7d, 0x6a, 0x14, 0x99,
-	0x57, 0xa9, 0xf8, 0x03, 0xdf, 0x81, 0xb5, 0x03, 0xea, 0x72, 0x6f, 0x94, 0x1b, 0x4c, 0x80, 0xab,
-	0x50, 0x7b, 0x1f, 0x53, 0x3e, 0x4e, 0xb1, 0xc9, 0x02, 0x3f, 0x81, 0xf5, 0x2a, 0x3c, 0xe5, 0xb7,
-	0x03, 0x0d, 0x4e, 0x45, 0x7c, 0x7c, 0x0e, 0x3d, 0x03, 0xc2, 0x21, 0x2c, 0xf5, 0xa9, 0xfc, 0x26,
-	0x66, 0x92, 0x1a, 0x93, 0x3b, 0xd0, 0x70, 0x09, 0xe1, 0x54, 0x08, 0x6d, 0xb4, 0xaa, 0x62, 0x2f,
-	0xd9, 0x73, 0x0c, 0xe8, 0xf3, 0xa2, 0x76, 0x0f, 0x96, 0x73, 0x7b, 0x29, 0xe7, 0x3b, 0xb0, 0xe0,
-	0x31, 0x21, 0xf5, 0xdb, 0x59, 0x53, 0xdf, 0xae, 0xa1, 0x30, 0x87, 0x82, 0x60, 0x06, 0xcb, 0x07,
-	0x23, 0x3f, 0x7a, 0xce, 0x09, 0xe5, 0xff, 0x08, 0xe7, 0xff, 0xc1, 0x4a, 0xc1, 0x60, 0x1e, 0xfe,
-	0x92, 0xbb, 0xde, 0x3b, 0x3f, 0x3c, 0xca, 0x73, 0x0b, 0x8c, 0x68, 0x40, 0xf0, 0x2f, 0x16, 0x34,
-	0x52, 0xbb, 0xe8, 0x1a, 0x74, 0x84, 0xe4, 0x94, 0xca, 0x61, 0x91, 0x65, 0xd3, 0x69, 0x27, 0x52,
-	0x03, 0x43, 0x30, 0xef, 0x99, 0x32, 0xd7, 0x74, 0xf4, 0xb7, 0x0a, 0x00, 0x21, 0x5d, 0x49, 0xd3,
-	0x7c, 0x48, 0x16, 0x2a, 0x13, 0x3c, 0x16, 0x87, 0x92, 0x8f, 0x4d, 0x26, 0xa4, 0x4b, 0x74, 0x11,
-	0x16, 0x3e, 0xfa, 0xd1, 0xd0, 0x63, 0x84, 0xea, 0x44, 0xa8, 0x39, 0x8d, 0x8f, 0x7e, 0xd4, 0x63,
-	0x84, 0xe2, 0x57, 0x50, 0xd3, 0xae, 0x44, 0xdb, 0xd0, 0xf6, 0x62, 0xce, 0x69, 0xe8, 0x8d, 0x13,
-	0x60, 0xc2, 0x66, 0xd1, 0x08, 0x15, 0x5a, 0x19, 0x8e, 0x43, 0x5f, 0x0a, 0xcd, 0x66, 0xce, 0x49,
-	0x16, 0x4a, 0x1a, 0xba, 0x21, 0x13, 0x9a, 0x4e, 0xcd, 0x49, 0x16, 0xb8, 0x0f, 0x9b, 0x7d, 0x2a,
-	0x0f, 0xe2, 0x28, 0x62, 0x5c, 0x52, 0xd2, 0x4b, 0xf4, 0xf8, 0x34, 0x8f, 0xcb, 0x6b, 0xd0, 0x29,
-	0x99, 0x34, 0x05, 0xa3, 0x5d, 0xb4, 0x29, 0xf0, 0xf7, 0x70, 0xb1, 0x97, 0x09, 0xc2, 0x13, 0xca,
-	0x85, 0xcf, 0x42, 0xf3, 0xc8, 0xd7, 0x61, 0xfe, 0x2d, 0x67, 0xc1, 0x19, 0x31, 0xa2, 0xf7, 0x55,
-	0xc9, 0x93, 0x2c, 0xb9, 0x58, 0xe2, 0xc9, 0xba, 0x64, 0xda, 0x01, 0x7f, 0x59, 0xd0, 0xe9, 0x71,
-	0x4a, 0x7c, 0x55, 0xaf, 0xc9, 0x20, 0x7c, 0xcb, 0xd0, 0x6d, 0x40, 0x9e, 0x96, 0x0c, 0x3d, 0x97,
-	0x93, 0x61, 0x18, 0x07, 0x6f, 0x28, 0x4f, 0xfd, 0xb1, 0xec, 0x65, 0xd8, 0xaf, 0xb5, 0x1c, 0x5d,
-	0x87, 0xa5, 0x22, 0xda, 0x3b, 0x39, 0x49, 0x5b, 0x52, 0x3b, 0x87, 0xf6, 0x4e, 0x4e, 0xd0, 0xff,
-	0x61, 0xa3, 0x88, 0xa3, 0x1f, 0x22, 0x9f, 0xeb, 0xf2, 0x39, 0x1c, 0x53, 0x97, 0xa7, 0xbe, 0xeb,
-	0xe6, 0x67, 0xf6, 0x33, 0xc0, 0x77, 0xd4, 0xe5, 0xe8, 0x11, 0x5c, 0x9a, 0x72, 0x3c, 0x60, 0xa1,
-	0x1c, 0xe9, 0x27, 0xaf, 0x39, 0x17, 0x27, 0x9d, 0x7f, 0xa6, 0x00, 0x78, 0x0c, 0xed, 0xde, 0xc8,
-	0xe5, 0x47, 0x59, 0x4e, 0xff, 0x07, 0xea, 0x6e, 0xa0, 0x22, 0xe4, 0x0c, 0xe7, 0xa5, 0x08, 0xf4,
-	0x10, 0x5a, 0x05, 0xeb, 0x69, 0xc3, 0xdc, 0x28, 0x67, 0x48, 0xc9, 0x89, 0x0e, 0xe4, 0x4c, 0xf0,
-	0x7d, 0xe8, 0x18, 0xd3, 0xf9, 0xd3, 0x4b, 0xee, 0x86, 0xc2, 0xf5, 0xf4, 0x15, 0xb2, 0x64, 0x69,
-	0x17, 0xa4, 0x03, 0x82, 0x7f, 0x80, 0xa6, 0xce, 0x30, 0x3d, 0x13, 0x98, 0x6e, 0x6d, 0x9d, 0xdb,
-	0xad, 0x55, 0x54, 0xa8, 0xca, 0x90, 0xf2, 0x9c, 0x18, 0x15, 0x6a, 0x1f, 0xff, 0x34, 0x0b, 0x2d,
-	0x93, 0xc2, 0xf1, 0xb1, 0x54, 0x89, 0xc2, 0xd4, 0x32, 0x27, 0xd4, 0xd0, 0xeb, 0x01, 0x41, 0xf7,
-	0x60, 0x55, 0x8c, 0xfc, 0x28, 0x52, 0xb9, 0x5d, 0x4c, 0xf2, 0x24, 0x9a, 0x90, 0xd9, 0x7b, 0x99,
-	0x25, 0x3b, 0xba, 0x0f, 0xed, 0xec, 0x84, 0x66, 0x33, 0x37, 0x95, 0xcd, 0xa2, 0x01, 0xf6, 0x98,
-	0x90, 0xe8, 0x11, 0x2c, 0x67, 0x07, 0x4d, 0x6d, 0x98, 0x3f, 0xa3, 0x82, 0x2d, 0x19, 0xb4, 0xa9,
-	0x19, 0xb7, 0x4d, 0x25, 0xab, 0xe9, 0x4a, 0xb6, 0x5e, 0x3a, 0x95, 0x39, 0xd4, 0x94, 0x32, 0x02,
-	0x97, 0x0e, 0x68, 0x48, 0xb4, 0xbc, 0xc7, 0xc2, 0xb7, 0x3e, 0x0f, 0x74, 0xd8, 0x14, 0xda, 0x0d,
-	0x0d, 0x5c, 0xff, 0xd8, 0xb4, 0x1b, 0xbd, 0x40, 0x3b, 0x50, 0xd3, 0xae, 0x49, 0x7d, 0xdc, 0x3d,
-	0x6d, 0x23, 0xf1, 0xa9, 0x93, 0xc0, 0xf0, 0x9f, 0x16, 0xac, 0xbc, 0x38, 0x76, 0x3d, 0x5a, 0xaa,
-	0xd1, 0x53, 0x27, 0x91, 0x6d, 0x68, 0xeb, 0x0d, 0x53, 0x0a, 0x52, 0x3f, 0x2f, 0x2a, 0xa1, 0xa9,
-	0x06, 0xc5, 0x0a, 0x3f, 0xf7, 0x29, 0x15, 0x3e, 0xbb, 0x49, 0xad, 0x78, 0x93, 0x4a, 0x6c, 0xd7,
-	0x3f, 0x2f, 0xb6, 0x1f, 0x03, 0x2a, 0x5e, 0x2b, 0x6b, 0xb9, 0xa9, 0x77, 0xac, 0x4f, 0xf3, 0xce,
-	0x0e, 0x34, 0xf7, 0x88, 0x71, 0xca, 0x55, 0x58, 0xf4, 0x58, 0x28, 0xe9, 0x07, 0x39, 0x7c, 0x47,
-	0xc7, 0xa6, 0x2a, 0xb6, 0x52, 0xd9, 0x57, 0x74, 0x2c, 0xf0, 0x5d, 0x00, 0x85, 0x4f, 0xad, 0x5d,
-	0x85, 0x39, 0x97, 0x98, 0xe6, 0xbe, 0x54, 0xf1, 0x81, 0xa3, 0xf6, 0xf0, 0x03, 0x98, 0xdd, 0x23,
-	0x4a, 0xb3, 0x62, 0xce, 0xa9, 0x27, 0x87, 0x31, 0x37, 0x2f, 0xda, 0x32, 0xb2, 0x43, 0x7e, 0xac,
-	0xfa, 0x8d, 0xb2, 0x62, 0xfa, 0x8d, 0xfa, 0xde, 0xfd, 0xc3, 0x82, 0x96, 0xca, 0xb0, 0x03, 0xca,
-	0x4f, 0x7c, 0x8f, 0xa2, 0x87, 0xba, 0x8b, 0xe9, 0xa4, 0xdc, 0xa8, 0x7a, 0xbc, 0x30, 0x78, 0xdb,
-	0xe5, 0x50, 0x4f, 0x26, 0xd3, 0x19, 0xf4, 0x00, 0x1a, 0xe9, 0x74, 0x5c, 0x39, 0x5d, 0x9e, 0x99,
-	0xed, 0x95, 0x53, 0x19, 0x8e, 0x67, 0xd0, 0x97, 0xd0, 0xcc, 0xe6, 0x70, 0x74, 0xf9, 0xb4, 0xfe,
-	0xa2, 0x82, 0x89, 0xe6, 0x77, 0x7f, 0xb6, 0x60, 0xad, 0x3c, 0xbf, 0x9a, 0x6b, 0xfd, 0x08, 0xff,
-	0x9a, 0x30, 0xdc, 0xa2, 0x7f, 0x97, 0xd4, 0x4c, 0x1f, 0xab, 0xed, 0x1b, 0xe7, 0x03, 0x93, 0x07,
-	0x53, 0x2c, 0x66, 0x61, 0x2d, 0x1d, 0xbc, 0x7a, 0xae, 0x74, 0x8f, 0xd9, 0x91, 0x61, 0xd1, 0x87,
-	0xc5, 0xe2, 0x94, 0x89, 0x26, 0xdc, 0xc2, 0xbe, 0x7a, 0xca, 0x52, 0x75, 0xe8, 0xc3, 0x33, 0xe8,
-	0x31, 0x40, 0x3e, 0x64, 0xa2, 0xcd, 0xaa, 0xab, 0xcb, 0xd3, 0xa7, 0x3d, 0x71, 0x26, 0xc4, 0x33,
-	0xe8, 0x35, 0x74, 0xca, 0x63, 0x25, 0xc2, 0x25, 0xe4, 0xc4, 0x11, 0xd5, 0xde, 0x3e, 0x13, 0x93,
-	0x79, 0xe1, 0x57, 0x0b, 0x96, 0x0e, 0xd2, 0xe2, 0x65, 0xee, 0x3f, 0x80, 0x05, 0x33, 0x0d, 0xa2,
-	0x4b, 0x55, 0xd2, 0xc5, 0xa1, 0xd4, 0xbe, 0x3c, 0x65, 0x37, 0xf3, 0xc0, 0x53, 0x68, 0x66, 0x43,
-	0x5a, 0x25, 0x58, 0xaa, 0xd3, 0xa2, 0xbd, 0x39, 0x6d, 0x3b, 0x23, 0xfb, 0x9b, 0x05, 0x4b, 0xa6,
-	0xf4, 0x18, 0xb2, 0xaf, 0x61, 0x7d, 0xf2, 0x90, 0x33, 0xf1, 0xd9, 0x6e, 0x55, 0x09, 0x9f, 0x31,
-	0x1d, 0xe1, 0x19, 0xd4, 0x87, 0x46, 0x32, 0xf0, 0x48, 0x74, 0xbd, 0x9c, 0x0b, 0xd3, 0xc6, 0x21,
-	0x7b, 0x42, 0x73, 0xc1, 0x33, 0xbb, 0x87, 0xd0, 0x79, 0xe1, 0x8e, 0x03, 0x1a, 0x66, 0x19, 0xdc,
-	0x83, 0x7a, 0xd2, 0x91, 0x91, 0x5d, 0xd6, 0x5c, 0x9c, 0x10, 0xec, 0x8d, 0x89, 0x7b, 0x99, 0x43,
-	0x46, 0xb0, 0xb8, 0xaf, 0x2a, 0xa8, 0x51, 0xfa, 0x4a, 0xfd, 0x60, 0x99, 0xd0, 0x48, 0xd0, 0xcd,
-	0x4a, 0x34, 0x4c, 0x6f, 0x36, 0x53, 0x72, 0xf6, 0x0d, 0x2c, 0xf5, 0x46, 0xd4, 0x7b, 0xc7, 0xe2,
-	0xec, 0x06, 0xcf, 0x01, 0xf2, 0xba, 0x5b, 0x89, 0xee, 0x53, 0x7d, 0xc6, 0xbe, 0x32, 0x75, 0x3f,
-	0xbb, 0xcd, 0x13, 0x55, 0x82, 0x8d, 0xf6, 0x07, 0x50, 0xef, 0xab, 0x19, 0x5c, 0xa0, 0xf5, 0x6a,
-	0x39, 0x4d, 0x35, 0x5e, 0x38, 0x25, 0x37, 0x9a, 0xde, 0xd4, 0xf5, 0x9f, 0x1b, 0xff, 0xfd, 0x3b,
-	0x00, 0x00, 0xff, 0xff, 0xb2, 0xa0, 0x6e, 0x6c, 0xea, 0x10, 0x00, 0x00,
-}
@@ -18,24 +18,33 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
+	"github.com/triplewy/microservices-demo/src/shippingservice/fault"
 	pb "github.com/triplewy/microservices-demo/src/shippingservice/genproto"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const (
-	defaultPort = "50051"
+	defaultPort      = "50051"
+	defaultRatesFile = "rates.json"
 )
 
-var log *logrus.Logger
+var (
+	log *logrus.Logger
+
+	// shipments records the shipments created by ShipOrder.
+	shipments = newShipmentTracker(defaultTimeline)
+)
 
 func init() {
 	log = logrus.New()
@@ -58,14 +67,60 @@ func main() {
 	}
 	port = fmt.Sprintf(":%s", port)
 
+	t, err := timelineFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	shipments = newShipmentTracker(t)
+	log.Infof("shipment timeline: %+v", t)
+
+	if v := os.Getenv("TRACKING_ID_SEED"); v != "" {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatalf("environment variable \"TRACKING_ID_SEED\" is not an integer: %q", v)
+		}
+		trackingIDs = newTrackingIDGenerator(seed)
+		log.Infof("tracking IDs seeded with %d", seed)
+	}
+
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	srv := grpc.NewServer()
 	svc := &server{}
+	switch engine := os.Getenv("QUOTE_ENGINE"); engine {
+	case "", "rates":
+		path := defaultRatesFile
+		if value, ok := os.LookupEnv("RATES_FILE"); ok {
+			path = value
+		}
+		rates, err := loadRateTable(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		svc.quotes = rates
+		log.Infof("quoting shipments with rates from %s", path)
+	case "count":
+		svc.quotes = countQuoter{}
+		log.Info("quoting shipments by item count")
+	default:
+		log.Fatalf("unknown QUOTE_ENGINE %q", engine)
+	}
+	if addr := os.Getenv("PRODUCT_CATALOG_SERVICE_ADDR"); addr != "" {
+		conn, err := grpc.Dial(addr, grpc.WithInsecure())
+		if err != nil {
+			log.Fatalf("failed to connect to product catalog %s: %v", addr, err)
+		}
+		svc.catalog = pb.NewProductCatalogServiceClient(conn)
+	} else {
+		log.Warn("PRODUCT_CATALOG_SERVICE_ADDR not set, quoting with default package weights")
+	}
+
+	faults := fault.NewInjector()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor))
 	pb.RegisterShippingServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, newHealthServer())
+	pb.RegisterFaultServiceServer(srv, faults)
 	log.Infof("Shipping Service listening on port %s", port)
 
 	// Register reflection service on gRPC server.
@@ -75,42 +130,80 @@ func main() {
 	}
 }
 
-// server controls RPC service responses.
-type server struct{}
-
-// Check is for health checking.
-func (s *server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+// newHealthServer returns a health server that reports the service as
+// serving, since it has no dependencies that could make it unable to.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("hipstershop.ShippingService", healthpb.HealthCheckResponse_SERVING)
+	return hs
 }
 
-func (s *server) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+// server controls RPC service responses.
+type server struct {
+	// quotes prices shipments. Shipments are priced by item count if it is
+	// nil.
+	quotes quoteEngine
+	// catalog provides the weight and size of products. Products are
+	// assumed to be of unknown weight and size if it is nil.
+	catalog pb.ProductCatalogServiceClient
 }
 
-// GetQuote produces a shipping quote (cost) in USD.
+// GetQuote produces shipping quotes (cost) in USD for every available
+// service level.
 func (s *server) GetQuote(ctx context.Context, in *pb.GetQuoteRequest) (*pb.GetQuoteResponse, error) {
 	log.Info("[GetQuote] received request")
 	defer log.Info("[GetQuote] completed request")
 
-	// 1. Our quote system requires the total number of items to be shipped.
-	count := 0
-	for _, item := range in.Items {
-		count += int(item.Quantity)
-	}
+	// 1. Look up the weight and size of the items to be shipped.
+	items := s.parcelItems(ctx, in.Items)
 
-	// 2. Generate a quote based on the total number of items to be shipped.
-	quote := CreateQuoteFromCount(count)
+	// 2. Quote every service level that ships to the address.
+	quotes := s.quotes
+	if quotes == nil {
+		quotes = countQuoter{}
+	}
+	options, err := quotes.quote(in.Address, items)
+	if err == errNoZone {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot ship to %s, %s", in.Address.GetState(), in.Address.GetCountry())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to quote shipment: %v", err)
+	}
 
 	// 3. Generate a response.
 	return &pb.GetQuoteResponse{
-		CostUsd: &pb.Money{
-			CurrencyCode: "USD",
-			Units:        int64(quote.Dollars),
-			Nanos:        int32(quote.Cents * 10000000)},
+		CostUsd: options[0].CostUsd,
+		Options: options,
 	}, nil
 
 }
 
+// parcelItems returns the items to be shipped with the weight and size of
+// their products. Products that cannot be looked up are quoted as if their
+// weight and size were unknown.
+func (s *server) parcelItems(ctx context.Context, cart []*pb.CartItem) []parcelItem {
+	products := make(map[string]*pb.Product)
+	out := make([]parcelItem, len(cart))
+	for i, item := range cart {
+		out[i].quantity = int(item.Quantity)
+		if s.catalog == nil {
+			continue
+		}
+		p, ok := products[item.ProductId]
+		if !ok {
+			var err error
+			p, err = s.catalog.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductId})
+			if err != nil {
+				log.Warnf("failed to look up product %q, quoting default weight: %v", item.ProductId, err)
+			}
+			products[item.ProductId] = p
+		}
+		out[i].weightKg = p.GetWeightKg()
+		out[i].dimensions = p.GetDimensions()
+	}
+	return out
+}
+
 // ShipOrder mocks that the requested items will be shipped.
 // It supplies a tracking ID for notional lookup of shipment delivery status.
 func (s *server) ShipOrder(ctx context.Context, in *pb.ShipOrderRequest) (*pb.ShipOrderResponse, error) {
@@ -118,10 +211,102 @@ func (s *server) ShipOrder(ctx context.Context, in *pb.ShipOrderRequest) (*pb.Sh
 	defer log.Info("[ShipOrder] completed request")
 	// 1. Create a Tracking ID
 	baseAddress := fmt.Sprintf("%s, %s, %s", in.Address.StreetAddress, in.Address.City, in.Address.State)
-	id := CreateTrackingId(baseAddress)
+	id := shipments.add(CreateTrackingId(baseAddress), in.OrderId, in.Address)
 
 	// 2. Generate a response.
 	return &pb.ShipOrderResponse{
 		TrackingId: id,
 	}, nil
 }
+
+// CancelShipment mocks that a shipment created by ShipOrder is called off.
+// The shipment is looked up by order ID if no tracking ID is given, so that
+// a shipment whose tracking ID was lost can still be cancelled.
+func (s *server) CancelShipment(ctx context.Context, in *pb.CancelShipmentRequest) (*pb.Empty, error) {
+	log.Infof("[CancelShipment] received request for tracking_id=%q order_id=%q", in.TrackingId, in.OrderId)
+	defer log.Info("[CancelShipment] completed request")
+	id := in.TrackingId
+	if id == "" {
+		if in.OrderId == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "tracking_id or order_id must be set")
+		}
+		var err error
+		if id, err = shipments.trackingID(in.OrderId); err != nil {
+			return nil, status.Errorf(codes.NotFound, "no shipment of order %q", in.OrderId)
+		}
+	}
+	if err := shipments.cancel(id); err != nil {
+		return nil, shipmentError(id, err)
+	}
+	return &pb.Empty{}, nil
+}
+
+// GetShipmentStatus returns the current status of a shipment.
+func (s *server) GetShipmentStatus(ctx context.Context, in *pb.GetShipmentStatusRequest) (*pb.ShipmentStatus, error) {
+	if in.TrackingId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "tracking_id must be set")
+	}
+	st, _, _, err := shipments.status(in.TrackingId)
+	if err != nil {
+		return nil, shipmentError(in.TrackingId, err)
+	}
+	return st, nil
+}
+
+// ValidateTrackingId reports whether a tracking ID is well formed and has a
+// valid check digit. It does not check that the shipment exists.
+func (s *server) ValidateTrackingId(ctx context.Context, in *pb.ValidateTrackingIdRequest) (*pb.ValidateTrackingIdResponse, error) {
+	if in.TrackingId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "tracking_id must be set")
+	}
+	if err := validateTrackingID(in.TrackingId); err != nil {
+		return &pb.ValidateTrackingIdResponse{Reason: err.Error()}, nil
+	}
+	return &pb.ValidateTrackingIdResponse{Valid: true}, nil
+}
+
+// WatchShipment streams the status of a shipment until it is delivered or
+// cancelled.
+func (s *server) WatchShipment(in *pb.GetShipmentStatusRequest, stream pb.ShippingService_WatchShipmentServer) error {
+	log.Infof("[WatchShipment] received request for tracking_id=%q", in.TrackingId)
+	defer log.Info("[WatchShipment] completed request")
+	if in.TrackingId == "" {
+		return status.Errorf(codes.InvalidArgument, "tracking_id must be set")
+	}
+	ctx := stream.Context()
+	for {
+		st, next, cancelled, err := shipments.status(in.TrackingId)
+		if err != nil {
+			return shipmentError(in.TrackingId, err)
+		}
+		if err := stream.Send(st); err != nil {
+			return err
+		}
+		if next.IsZero() {
+			return nil
+		}
+		t := time.NewTimer(time.Until(next))
+		select {
+		case <-t.C:
+		case <-cancelled:
+			t.Stop()
+		case <-ctx.Done():
+			t.Stop()
+			if ctx.Err() == context.DeadlineExceeded {
+				return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+			}
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		}
+	}
+}
+
+// shipmentError converts an error of the shipment tracker to a gRPC status.
+func shipmentError(trackingID string, err error) error {
+	switch err {
+	case errShipmentNotFound:
+		return status.Errorf(codes.NotFound, "no shipment with tracking_id %q", trackingID)
+	case errShipmentDelivered:
+		return status.Errorf(codes.FailedPrecondition, "shipment %q is already delivered", trackingID)
+	}
+	return status.Errorf(codes.Internal, "%v", err)
+}
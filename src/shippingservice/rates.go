@@ -0,0 +1,299 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "github.com/triplewy/microservices-demo/src/shippingservice/genproto"
+)
+
+const (
+	nanosPerUnit = 1000000000
+	nanosPerCent = 10000000
+
+	defaultVolumetricDivisor = 5000
+)
+
+// errNoZone is returned for addresses that no zone of a rate table covers.
+var errNoZone = errors.New("address is not in any shipping zone")
+
+// parcelItem is an item of a shipment. weightKg and dimensions are unset if
+// the weight or size of the product is unknown.
+type parcelItem struct {
+	quantity   int
+	weightKg   float64
+	dimensions *pb.Dimensions
+}
+
+// quoteEngine prices the shipment of items to an address.
+type quoteEngine interface {
+	// quote returns the available service levels, cheapest first.
+	quote(dest *pb.Address, items []parcelItem) ([]*pb.QuoteOption, error)
+}
+
+// countQuoter prices shipments by their number of items only, with a single
+// standard service level.
+type countQuoter struct{}
+
+func (countQuoter) quote(dest *pb.Address, items []parcelItem) ([]*pb.QuoteOption, error) {
+	count := 0
+	for _, item := range items {
+		count += item.quantity
+	}
+	quote := CreateQuoteFromCount(count)
+	return []*pb.QuoteOption{{
+		ServiceLevel: "standard",
+		CostUsd: &pb.Money{
+			CurrencyCode: "USD",
+			Units:        int64(quote.Dollars),
+			Nanos:        int32(quote.Cents * 10000000)},
+	}}, nil
+}
+
+// usd is an amount of US dollars in a rate file, such as "4.99". It is held
+// in nanos to avoid rounding errors.
+type usd int64
+
+func (u *usd) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("amounts must be strings such as \"4.99\": %v", err)
+	}
+	parts := strings.SplitN(s, ".", 2)
+	units, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || units < 0 {
+		return fmt.Errorf("invalid amount %q", s)
+	}
+	var nanos int64
+	if len(parts) == 2 {
+		frac := parts[1]
+		if frac == "" || len(frac) > 9 {
+			return fmt.Errorf("invalid amount %q", s)
+		}
+		if nanos, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64); err != nil || nanos < 0 {
+			return fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	*u = usd(units*nanosPerUnit + nanos)
+	return nil
+}
+
+// money returns u rounded to whole cents.
+func (u usd) money() *pb.Money {
+	cents := (int64(u) + nanosPerCent/2) / nanosPerCent
+	return &pb.Money{
+		CurrencyCode: "USD",
+		Units:        cents / 100,
+		Nanos:        int32(cents%100) * nanosPerCent,
+	}
+}
+
+// zone is a region that shares the same rates. Every criterion that is set
+// must match an address for the zone to cover it; a zone without criteria
+// covers every address.
+type zone struct {
+	Name string `json:"name"`
+	// Countries are matched case-insensitively.
+	Countries []string `json:"countries,omitempty"`
+	// States are matched case-insensitively.
+	States []string `json:"states,omitempty"`
+	// ZipPrefixes are matched against the zip code padded with zeros to 5
+	// digits, so that "02" covers Massachusetts.
+	ZipPrefixes []string `json:"zip_prefixes,omitempty"`
+}
+
+func (z *zone) covers(a *pb.Address) bool {
+	if len(z.Countries) > 0 && !containsFold(z.Countries, a.GetCountry()) {
+		return false
+	}
+	if len(z.States) > 0 && !containsFold(z.States, a.GetState()) {
+		return false
+	}
+	if len(z.ZipPrefixes) > 0 {
+		zip := fmt.Sprintf("%05d", a.GetZipCode())
+		for _, p := range z.ZipPrefixes {
+			if strings.HasPrefix(zip, p) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, s string) bool {
+	s = strings.TrimSpace(s)
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// rate is the price of a service level in a zone: a base price plus a price
+// for every started kilogram of billable weight.
+type rate struct {
+	Base  usd `json:"base_usd"`
+	PerKg usd `json:"per_kg_usd"`
+}
+
+type serviceLevel struct {
+	Name        string `json:"name"`
+	TransitDays int32  `json:"transit_days"`
+	// Rates are keyed by zone name. The service level is not offered in
+	// zones without a rate.
+	Rates map[string]rate `json:"rates"`
+}
+
+// rateTable prices shipments by destination zone and billable weight, which
+// is the larger of the actual weight and the volumetric weight of the items.
+type rateTable struct {
+	// Zones are matched in order; the first zone that covers an address
+	// applies.
+	Zones         []zone         `json:"zones"`
+	ServiceLevels []serviceLevel `json:"service_levels"`
+	// VolumetricDivisor converts cubic centimeters to volumetric kilograms.
+	VolumetricDivisor float64 `json:"volumetric_divisor,omitempty"`
+	// DefaultWeightKg is used for products of unknown weight.
+	DefaultWeightKg float64 `json:"default_weight_kg"`
+}
+
+// loadRateTable reads a rate table from a JSON file.
+func loadRateTable(path string) (*rateTable, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate file: %v", err)
+	}
+	var t rateTable
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse rate file %s: %v", path, err)
+	}
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate file %s: %v", path, err)
+	}
+	return &t, nil
+}
+
+func (t *rateTable) validate() error {
+	if t.VolumetricDivisor == 0 {
+		t.VolumetricDivisor = defaultVolumetricDivisor
+	}
+	if t.VolumetricDivisor < 0 {
+		return errors.New("volumetric_divisor must be positive")
+	}
+	if t.DefaultWeightKg <= 0 {
+		return errors.New("default_weight_kg must be positive")
+	}
+	if len(t.Zones) == 0 {
+		return errors.New("no zones")
+	}
+	zones := make(map[string]bool)
+	for _, z := range t.Zones {
+		if z.Name == "" || zones[z.Name] {
+			return fmt.Errorf("zone names must be unique and non-empty, got %q", z.Name)
+		}
+		zones[z.Name] = true
+	}
+	if len(t.ServiceLevels) == 0 {
+		return errors.New("no service levels")
+	}
+	for _, sl := range t.ServiceLevels {
+		if sl.Name == "" {
+			return errors.New("service level without a name")
+		}
+		for name := range sl.Rates {
+			if !zones[name] {
+				return fmt.Errorf("service level %q has a rate for unknown zone %q", sl.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// zoneOf returns the first zone that covers a.
+func (t *rateTable) zoneOf(a *pb.Address) (*zone, error) {
+	for i := range t.Zones {
+		if t.Zones[i].covers(a) {
+			return &t.Zones[i], nil
+		}
+	}
+	return nil, errNoZone
+}
+
+// billableWeight returns the larger of the actual and the volumetric weight
+// of items, in kilograms.
+func (t *rateTable) billableWeight(items []parcelItem) float64 {
+	var actual, volume float64
+	for _, item := range items {
+		w := item.weightKg
+		if w <= 0 {
+			w = t.DefaultWeightKg
+		}
+		actual += w * float64(item.quantity)
+		if d := item.dimensions; d != nil {
+			volume += d.GetLengthCm() * d.GetWidthCm() * d.GetHeightCm() * float64(item.quantity)
+		}
+	}
+	return math.Max(actual, volume/t.VolumetricDivisor)
+}
+
+func (t *rateTable) quote(dest *pb.Address, items []parcelItem) ([]*pb.QuoteOption, error) {
+	z, err := t.zoneOf(dest)
+	if err != nil {
+		return nil, err
+	}
+	kg := int64(math.Ceil(t.billableWeight(items)))
+	var out []*pb.QuoteOption
+	var costs []usd
+	for _, sl := range t.ServiceLevels {
+		r, ok := sl.Rates[z.Name]
+		if !ok {
+			continue
+		}
+		cost := r.Base + r.PerKg*usd(kg)
+		out = append(out, &pb.QuoteOption{
+			ServiceLevel: sl.Name,
+			CostUsd:      cost.money(),
+			TransitDays:  sl.TransitDays,
+		})
+		costs = append(costs, cost)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no service level ships to zone %q", z.Name)
+	}
+	sort.Stable(byCost{out, costs})
+	return out, nil
+}
+
+type byCost struct {
+	options []*pb.QuoteOption
+	costs   []usd
+}
+
+func (b byCost) Len() int           { return len(b.options) }
+func (b byCost) Less(i, j int) bool { return b.costs[i] < b.costs[j] }
+func (b byCost) Swap(i, j int) {
+	b.options[i], b.options[j] = b.options[j], b.options[i]
+	b.costs[i], b.costs[j] = b.costs[j], b.costs[i]
+}
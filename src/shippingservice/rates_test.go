@@ -0,0 +1,207 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/triplewy/microservices-demo/src/shippingservice/genproto"
+)
+
+// fakeCatalog serves products from a map.
+type fakeCatalog map[string]*pb.Product
+
+func (c fakeCatalog) ListProducts(ctx context.Context, in *pb.Empty, opts ...grpc.CallOption) (*pb.ListProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func (c fakeCatalog) GetProduct(ctx context.Context, in *pb.GetProductRequest, opts ...grpc.CallOption) (*pb.Product, error) {
+	if p, ok := c[in.Id]; ok {
+		return p, nil
+	}
+	return nil, status.Error(codes.NotFound, "")
+}
+
+func (c fakeCatalog) SearchProducts(ctx context.Context, in *pb.SearchProductsRequest, opts ...grpc.CallOption) (*pb.SearchProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func TestUSD(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		units int64
+		nanos int32
+	}{
+		{`"4.99"`, 4, 990000000},
+		{`"12"`, 12, 0},
+		{`"0.5"`, 0, 500000000},
+		{`"1.005"`, 1, 10000000},
+		{`"1.004"`, 1, 0},
+	} {
+		var u usd
+		if err := json.Unmarshal([]byte(tc.in), &u); err != nil {
+			t.Errorf("parsing %s: %v", tc.in, err)
+			continue
+		}
+		if m := u.money(); m.Units != tc.units || m.Nanos != tc.nanos {
+			t.Errorf("%s = %d.%09d, want %d.%09d", tc.in, m.Units, m.Nanos, tc.units, tc.nanos)
+		}
+	}
+	for _, in := range []string{`4.99`, `"-1"`, `"1."`, `"1.2.3"`, `"1.0000000001"`} {
+		var u usd
+		if err := json.Unmarshal([]byte(in), &u); err == nil {
+			t.Errorf("parsing %s succeeded, want error", in)
+		}
+	}
+}
+
+func TestRateTableZones(t *testing.T) {
+	rates, err := loadRateTable(defaultRatesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []parcelItem{{quantity: 1, weightKg: 2}}
+	for _, tc := range []struct {
+		address *pb.Address
+		levels  []string
+		units   int64 // of the cheapest option
+		nanos   int32
+	}{
+		{&pb.Address{Country: "United States", State: "CA"}, []string{"standard", "express", "overnight"}, 5, 990000000},
+		{&pb.Address{Country: "united states", State: "NY"}, []string{"standard", "express", "overnight"}, 7, 490000000},
+		{&pb.Address{Country: "Canada", State: "ON"}, []string{"standard", "express"}, 15, 990000000},
+		{&pb.Address{Country: "England"}, []string{"standard", "express"}, 25, 990000000},
+	} {
+		options, err := rates.quote(tc.address, items)
+		if err != nil {
+			t.Errorf("quote to %v: %v", tc.address, err)
+			continue
+		}
+		var levels []string
+		for _, o := range options {
+			levels = append(levels, o.ServiceLevel)
+		}
+		if len(levels) != len(tc.levels) {
+			t.Errorf("quote to %v offers %v, want %v", tc.address, levels, tc.levels)
+			continue
+		}
+		for i := range levels {
+			if levels[i] != tc.levels[i] {
+				t.Errorf("quote to %v offers %v, want %v", tc.address, levels, tc.levels)
+				break
+			}
+		}
+		if c := options[0].CostUsd; c.Units != tc.units || c.Nanos != tc.nanos {
+			t.Errorf("cheapest quote to %v = %d.%09d, want %d.%09d", tc.address, c.Units, c.Nanos, tc.units, tc.nanos)
+		}
+	}
+}
+
+func TestZoneZipPrefixes(t *testing.T) {
+	z := &zone{Name: "massachusetts", ZipPrefixes: []string{"02"}}
+	for _, tc := range []struct {
+		zip  int32
+		want bool
+	}{
+		{2139, true},
+		{20500, false},
+		{94043, false},
+	} {
+		if got := z.covers(&pb.Address{ZipCode: tc.zip}); got != tc.want {
+			t.Errorf("zone with prefix 02 covers %05d = %v, want %v", tc.zip, got, tc.want)
+		}
+	}
+}
+
+func TestBillableWeight(t *testing.T) {
+	rates := &rateTable{VolumetricDivisor: 5000, DefaultWeightKg: 1}
+	for _, tc := range []struct {
+		items []parcelItem
+		want  float64
+	}{
+		{[]parcelItem{{quantity: 3}}, 3},
+		{[]parcelItem{{quantity: 2, weightKg: 0.25}}, 0.5},
+		// 50x40x30cm is 12kg of volume, more than it weighs.
+		{[]parcelItem{{quantity: 1, weightKg: 2, dimensions: &pb.Dimensions{LengthCm: 50, WidthCm: 40, HeightCm: 30}}}, 12},
+	} {
+		if got := rates.billableWeight(tc.items); got != tc.want {
+			t.Errorf("billableWeight(%+v) = %v, want %v", tc.items, got, tc.want)
+		}
+	}
+}
+
+func TestRateTableValidation(t *testing.T) {
+	for _, js := range []string{
+		`{"default_weight_kg": 1, "service_levels": [{"name": "standard"}]}`,
+		`{"default_weight_kg": 1, "zones": [{"name": "a"}]}`,
+		`{"zones": [{"name": "a"}], "service_levels": [{"name": "standard"}]}`,
+		`{"default_weight_kg": 1, "zones": [{"name": "a"}, {"name": "a"}], "service_levels": [{"name": "standard"}]}`,
+		`{"default_weight_kg": 1, "zones": [{"name": "a"}], "service_levels": [{"name": "standard", "rates": {"b": {}}}]}`,
+	} {
+		var rates rateTable
+		if err := json.Unmarshal([]byte(js), &rates); err != nil {
+			t.Fatal(err)
+		}
+		if err := rates.validate(); err == nil {
+			t.Errorf("validate(%s) succeeded, want error", js)
+		}
+	}
+}
+
+func TestGetQuoteWithRates(t *testing.T) {
+	rates, err := loadRateTable(defaultRatesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server{
+		quotes: rates,
+		catalog: fakeCatalog{
+			"heavy": {Id: "heavy", WeightKg: 4.2},
+		},
+	}
+	res, err := s.GetQuote(context.Background(), &pb.GetQuoteRequest{
+		Address: &pb.Address{Country: "United States", State: "NY"},
+		Items: []*pb.CartItem{
+			{ProductId: "heavy", Quantity: 2},
+			// Unknown to the catalog, so quoted at the default weight.
+			{ProductId: "missing", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 9.4kg is billed as 10kg: 5.99 + 10 * 0.75.
+	if c := res.CostUsd; c.Units != 13 || c.Nanos != 490000000 {
+		t.Errorf("cost = %d.%09d, want 13.49", c.Units, c.Nanos)
+	}
+	if len(res.Options) != 3 || res.Options[0].ServiceLevel != "standard" || res.Options[2].TransitDays != 1 {
+		t.Errorf("options = %v", res.Options)
+	}
+
+	s.quotes = &rateTable{
+		DefaultWeightKg: 1,
+		Zones:           []zone{{Name: "us", Countries: []string{"United States"}}},
+		ServiceLevels:   []serviceLevel{{Name: "standard", Rates: map[string]rate{"us": {}}}},
+	}
+	if _, err := s.GetQuote(context.Background(), &pb.GetQuoteRequest{Address: &pb.Address{Country: "France"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("quote outside every zone: got %v, want InvalidArgument", err)
+	}
+}
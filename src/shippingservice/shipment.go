@@ -0,0 +1,225 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	pb "github.com/triplewy/microservices-demo/src/shippingservice/genproto"
+)
+
+// shipmentRetention is how long delivered and cancelled shipments are
+// remembered.
+const shipmentRetention = 24 * time.Hour
+
+var (
+	errShipmentNotFound  = errors.New("shipment not found")
+	errShipmentDelivered = errors.New("shipment is already delivered")
+)
+
+// timeline is how long a shipment stays in each state before delivery.
+type timeline struct {
+	LabelCreated   time.Duration
+	InTransit      time.Duration
+	OutForDelivery time.Duration
+}
+
+var defaultTimeline = timeline{
+	LabelCreated:   30 * time.Second,
+	InTransit:      2 * time.Minute,
+	OutForDelivery: time.Minute,
+}
+
+// timelineFromEnv returns defaultTimeline with the durations set in the
+// LABEL_CREATED_DURATION, IN_TRANSIT_DURATION and OUT_FOR_DELIVERY_DURATION
+// environment variables replaced.
+func timelineFromEnv() (timeline, error) {
+	t := defaultTimeline
+	for env, d := range map[string]*time.Duration{
+		"LABEL_CREATED_DURATION":    &t.LabelCreated,
+		"IN_TRANSIT_DURATION":       &t.InTransit,
+		"OUT_FOR_DELIVERY_DURATION": &t.OutForDelivery,
+	} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed < 0 {
+			return t, fmt.Errorf("environment variable %q is not a non-negative duration: %q", env, v)
+		}
+		*d = parsed
+	}
+	return t, nil
+}
+
+// shipment is a recorded shipment. Its state is derived from the time it
+// was created, so it progresses without any background work.
+type shipment struct {
+	trackingID string
+	orderID    string
+	address    *pb.Address
+	created    time.Time
+	timeline   timeline
+
+	cancelledAt time.Time
+	// cancelled is closed when the shipment is cancelled.
+	cancelled chan struct{}
+}
+
+// status returns the status of s at now, and the time of its next change, or
+// the zero time if it will not change anymore.
+func (s *shipment) status(now time.Time) (*pb.ShipmentStatus, time.Time) {
+	st := &pb.ShipmentStatus{TrackingId: s.trackingID, Address: s.address}
+	if !s.cancelledAt.IsZero() {
+		st.State = pb.ShipmentStatus_CANCELLED
+		st.UpdatedAt = s.cancelledAt.Unix()
+		return st, time.Time{}
+	}
+
+	inTransit := s.created.Add(s.timeline.LabelCreated)
+	outForDelivery := inTransit.Add(s.timeline.InTransit)
+	delivered := outForDelivery.Add(s.timeline.OutForDelivery)
+	var entered, next time.Time
+	switch {
+	case now.Before(inTransit):
+		st.State, entered, next = pb.ShipmentStatus_LABEL_CREATED, s.created, inTransit
+	case now.Before(outForDelivery):
+		st.State, entered, next = pb.ShipmentStatus_IN_TRANSIT, inTransit, outForDelivery
+	case now.Before(delivered):
+		st.State, entered, next = pb.ShipmentStatus_OUT_FOR_DELIVERY, outForDelivery, delivered
+	default:
+		st.State, entered = pb.ShipmentStatus_DELIVERED, delivered
+	}
+	st.UpdatedAt = entered.Unix()
+	if !next.IsZero() {
+		st.EstimatedDelivery = delivered.Unix()
+	}
+	return st, next
+}
+
+// finishedAt returns the time at which s was delivered or cancelled, or the
+// zero time if it is still under way at now.
+func (s *shipment) finishedAt(now time.Time) time.Time {
+	if !s.cancelledAt.IsZero() {
+		return s.cancelledAt
+	}
+	delivered := s.created.Add(s.timeline.LabelCreated + s.timeline.InTransit + s.timeline.OutForDelivery)
+	if now.Before(delivered) {
+		return time.Time{}
+	}
+	return delivered
+}
+
+// shipmentTracker records shipments and reports their status.
+type shipmentTracker struct {
+	mu        sync.Mutex
+	timeline  timeline
+	now       func() time.Time
+	shipments map[string]*shipment
+	// orders maps order IDs to the tracking IDs of their shipments.
+	orders map[string]string
+}
+
+func newShipmentTracker(t timeline) *shipmentTracker {
+	return &shipmentTracker{
+		timeline:  t,
+		now:       time.Now,
+		shipments: make(map[string]*shipment),
+		orders:    make(map[string]string),
+	}
+}
+
+// add records a new shipment of an order to address and returns its tracking
+// ID, and forgets shipments that finished more than shipmentRetention ago. If
+// the order, when set, already has a shipment, add returns its tracking ID
+// instead, so that shipping an order can be retried.
+func (t *shipmentTracker) add(trackingID, orderID string, address *pb.Address) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	for id, s := range t.shipments {
+		if f := s.finishedAt(now); !f.IsZero() && now.Sub(f) > shipmentRetention {
+			delete(t.shipments, id)
+			if s.orderID != "" {
+				delete(t.orders, s.orderID)
+			}
+		}
+	}
+	if id, ok := t.orders[orderID]; ok {
+		return id
+	}
+	t.shipments[trackingID] = &shipment{
+		trackingID: trackingID,
+		orderID:    orderID,
+		address:    address,
+		created:    now,
+		timeline:   t.timeline,
+		cancelled:  make(chan struct{}),
+	}
+	if orderID != "" {
+		t.orders[orderID] = trackingID
+	}
+	return trackingID
+}
+
+// trackingID returns the tracking ID of the shipment of an order.
+func (t *shipmentTracker) trackingID(orderID string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id, ok := t.orders[orderID]
+	if !ok {
+		return "", errShipmentNotFound
+	}
+	return id, nil
+}
+
+// status returns the current status of a shipment, the time of its next
+// change and a channel that is closed if it is cancelled.
+func (t *shipmentTracker) status(trackingID string) (*pb.ShipmentStatus, time.Time, <-chan struct{}, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.shipments[trackingID]
+	if !ok {
+		return nil, time.Time{}, nil, errShipmentNotFound
+	}
+	st, next := s.status(t.now())
+	return st, next, s.cancelled, nil
+}
+
+// cancel calls off a shipment that was not delivered yet. Cancelling a
+// cancelled shipment succeeds, so that cancellations can be retried.
+func (t *shipmentTracker) cancel(trackingID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.shipments[trackingID]
+	if !ok {
+		return errShipmentNotFound
+	}
+	if !s.cancelledAt.IsZero() {
+		return nil
+	}
+	now := t.now()
+	if !s.finishedAt(now).IsZero() {
+		return errShipmentDelivered
+	}
+	s.cancelledAt = now
+	close(s.cancelled)
+	return nil
+}
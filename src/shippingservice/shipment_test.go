@@ -0,0 +1,196 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/triplewy/microservices-demo/src/shippingservice/genproto"
+)
+
+func TestShipmentLifecycle(t *testing.T) {
+	start := time.Unix(1000, 0)
+	now := start
+	tr := newShipmentTracker(timeline{LabelCreated: time.Minute, InTransit: time.Hour, OutForDelivery: 10 * time.Minute})
+	tr.now = func() time.Time { return now }
+	tr.add("AB-1", "order-1", &pb.Address{City: "London"})
+
+	for _, tc := range []struct {
+		after     time.Duration
+		state     pb.ShipmentStatus_State
+		updatedAt time.Duration
+	}{
+		{0, pb.ShipmentStatus_LABEL_CREATED, 0},
+		{59 * time.Second, pb.ShipmentStatus_LABEL_CREATED, 0},
+		{time.Minute, pb.ShipmentStatus_IN_TRANSIT, time.Minute},
+		{61 * time.Minute, pb.ShipmentStatus_OUT_FOR_DELIVERY, 61 * time.Minute},
+		{2 * time.Hour, pb.ShipmentStatus_DELIVERED, 71 * time.Minute},
+	} {
+		now = start.Add(tc.after)
+		st, next, _, err := tr.status("AB-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.State != tc.state || st.UpdatedAt != start.Add(tc.updatedAt).Unix() {
+			t.Errorf("after %v: got %v updated at %d, want %v updated at %d",
+				tc.after, st.State, st.UpdatedAt, tc.state, start.Add(tc.updatedAt).Unix())
+		}
+		if delivered := st.State == pb.ShipmentStatus_DELIVERED; delivered != next.IsZero() {
+			t.Errorf("after %v: next change at %v", tc.after, next)
+		}
+	}
+
+	if err := tr.cancel("AB-1"); err != errShipmentDelivered {
+		t.Errorf("cancel of a delivered shipment: got %v, want errShipmentDelivered", err)
+	}
+	if _, _, _, err := tr.status("missing"); err != errShipmentNotFound {
+		t.Errorf("status of a missing shipment: got %v, want errShipmentNotFound", err)
+	}
+
+	// Delivered shipments are forgotten after shipmentRetention.
+	now = now.Add(shipmentRetention + time.Second)
+	tr.add("AB-2", "", nil)
+	if _, _, _, err := tr.status("AB-1"); err != errShipmentNotFound {
+		t.Errorf("status of an expired shipment: got %v, want errShipmentNotFound", err)
+	}
+	if _, err := tr.trackingID("order-1"); err != errShipmentNotFound {
+		t.Errorf("tracking ID of an expired order: got %v, want errShipmentNotFound", err)
+	}
+}
+
+func TestCancelShipment(t *testing.T) {
+	shipments = newShipmentTracker(defaultTimeline)
+	s := server{}
+	ctx := context.Background()
+
+	res, err := s.ShipOrder(ctx, &pb.ShipOrderRequest{Address: &pb.Address{City: "London"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CancelShipment(ctx, &pb.CancelShipmentRequest{TrackingId: res.TrackingId}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := s.GetShipmentStatus(ctx, &pb.GetShipmentStatusRequest{TrackingId: res.TrackingId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.State != pb.ShipmentStatus_CANCELLED || st.EstimatedDelivery != 0 {
+		t.Errorf("status after cancelling = %v", st)
+	}
+	if _, err := s.CancelShipment(ctx, &pb.CancelShipmentRequest{TrackingId: res.TrackingId}); err != nil {
+		t.Errorf("cancelling twice: got %v, want success", err)
+	}
+	if _, err := s.GetShipmentStatus(ctx, &pb.GetShipmentStatusRequest{TrackingId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("status of a missing shipment: got %v, want NotFound", err)
+	}
+}
+
+func TestCancelShipmentOfOrder(t *testing.T) {
+	shipments = newShipmentTracker(defaultTimeline)
+	s := server{}
+	ctx := context.Background()
+
+	req := &pb.ShipOrderRequest{Address: &pb.Address{City: "London"}, OrderId: "order-1"}
+	res, err := s.ShipOrder(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := s.ShipOrder(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.TrackingId != res.TrackingId {
+		t.Errorf("shipping an order twice: got tracking IDs %q and %q", res.TrackingId, again.TrackingId)
+	}
+	if _, err := s.CancelShipment(ctx, &pb.CancelShipmentRequest{OrderId: "order-1"}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := s.GetShipmentStatus(ctx, &pb.GetShipmentStatusRequest{TrackingId: res.TrackingId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.State != pb.ShipmentStatus_CANCELLED {
+		t.Errorf("status after cancelling by order = %v", st.State)
+	}
+	if _, err := s.CancelShipment(ctx, &pb.CancelShipmentRequest{OrderId: "order-2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("cancelling an unshipped order: got %v, want NotFound", err)
+	}
+	if _, err := s.CancelShipment(ctx, &pb.CancelShipmentRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("cancelling without IDs: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestWatchShipment(t *testing.T) {
+	shipments = newShipmentTracker(timeline{
+		LabelCreated:   20 * time.Millisecond,
+		InTransit:      20 * time.Millisecond,
+		OutForDelivery: 20 * time.Millisecond,
+	})
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterShippingServiceServer(srv, &server{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewShippingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := client.ShipOrder(ctx, &pb.ShipOrderRequest{Address: &pb.Address{City: "London"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := client.WatchShipment(ctx, &pb.GetShipmentStatusRequest{TrackingId: res.TrackingId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []pb.ShipmentStatus_State
+	for {
+		st, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, st.State)
+	}
+	want := []pb.ShipmentStatus_State{
+		pb.ShipmentStatus_LABEL_CREATED,
+		pb.ShipmentStatus_IN_TRANSIT,
+		pb.ShipmentStatus_OUT_FOR_DELIVERY,
+		pb.ShipmentStatus_DELIVERED,
+	}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("watched states %v, want %v", states, want)
+	}
+}
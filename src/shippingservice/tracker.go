@@ -15,42 +15,148 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"math/bits"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
 )
 
-// seeded determines if the random number generator is ready.
-var seeded bool = false
+const (
+	// trackingIDSpace is the number of distinct tracking IDs for a salt:
+	// two letters and nine digits.
+	trackingIDSpace = 26 * 26 * 1000000000
+)
+
+// trackingIDs generates the tracking IDs of CreateTrackingId.
+var trackingIDs = newTrackingIDGenerator(time.Now().UnixNano())
 
 // CreateTrackingId generates a tracking ID.
 func CreateTrackingId(salt string) string {
-	if !seeded {
-		rand.Seed(time.Now().UnixNano())
-		seeded = true
+	return trackingIDs.generate(salt)
+}
+
+// trackingIDGenerator generates tracking IDs of the form
+// "LL-<len(salt)>DDD-<len(salt)/2>DDDDDDC", where L is a letter, D a digit
+// and C a check digit. IDs look random but are a permutation of a counter,
+// so a generator never repeats an ID within its first trackingIDSpace IDs.
+// Generators with the same seed produce the same IDs.
+type trackingIDGenerator struct {
+	mu   sync.Mutex
+	n    uint64
+	mult uint64 // coprime with trackingIDSpace, so that n*mult is a permutation
+	add  uint64
+}
+
+func newTrackingIDGenerator(seed int64) *trackingIDGenerator {
+	r := rand.New(rand.NewSource(seed))
+	mult := uint64(r.Int63n(trackingIDSpace))
+	for gcd(mult, trackingIDSpace) != 1 {
+		mult++
 	}
+	return &trackingIDGenerator{
+		mult: mult % trackingIDSpace,
+		add:  uint64(r.Int63n(trackingIDSpace)),
+	}
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// generate returns the next tracking ID. It is safe for concurrent use.
+func (g *trackingIDGenerator) generate(salt string) string {
+	g.mu.Lock()
+	n := g.n
+	g.n++
+	g.mu.Unlock()
 
-	return fmt.Sprintf("%c%c-%d%s-%d%s",
-		getRandomLetterCode(),
-		getRandomLetterCode(),
+	hi, lo := bits.Mul64(n, g.mult)
+	x := (bits.Rem64(hi, lo, trackingIDSpace) + g.add) % trackingIDSpace
+	letters, digits := x/1000000000, x%1000000000
+
+	payload := fmt.Sprintf("%c%c-%d%03d-%d%06d",
+		'A'+letters/26,
+		'A'+letters%26,
 		len(salt),
-		getRandomNumber(3),
+		digits/1000000,
 		len(salt)/2,
-		getRandomNumber(7),
+		digits%1000000,
 	)
+	return payload + string('0'+checkDigit(payload))
+}
+
+// checkDigit returns the Luhn check digit of the letters and digits of s.
+// Letters count as two digits, A as 10 through Z as 35.
+func checkDigit(s string) byte {
+	var digits []byte
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, byte(c-'0'))
+		case c >= 'A' && c <= 'Z':
+			v := byte(c-'A') + 10
+			digits = append(digits, v/10, v%10)
+		}
+	}
+	// Double every second digit from the right, starting with the
+	// rightmost, since the check digit will be appended to its right.
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i])
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte((10 - sum%10) % 10)
 }
 
-// getRandomLetterCode generates a code point value for a capital letter.
-func getRandomLetterCode() uint32 {
-	return 65 + uint32(rand.Intn(25))
+// validateTrackingID reports why id is not a well-formed tracking ID, or
+// returns nil if it is.
+func validateTrackingID(id string) error {
+	parts := strings.Split(id, "-")
+	if len(parts) != 3 {
+		return errors.New("tracking IDs have three parts separated by dashes")
+	}
+	if len(parts[0]) != 2 || !isUpper(parts[0]) {
+		return errors.New("tracking IDs start with two capital letters")
+	}
+	if len(parts[1]) < 4 || !isDigits(parts[1]) {
+		return errors.New("the second part of tracking IDs has at least four digits")
+	}
+	if len(parts[2]) < 8 || !isDigits(parts[2]) {
+		return errors.New("the third part of tracking IDs has at least eight digits")
+	}
+	last := len(id) - 1
+	if id[last]-'0' != checkDigit(id[:last]) {
+		return errors.New("the check digit does not match")
+	}
+	return nil
 }
 
-// getRandomNumber generates a string representation of a number with the requested number of digits.
-func getRandomNumber(digits int) string {
-	str := ""
-	for i := 0; i < digits; i++ {
-		str = fmt.Sprintf("%s%d", str, rand.Intn(10))
+func isUpper(s string) bool {
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
 	}
+	return true
+}
 
-	return str
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
 }
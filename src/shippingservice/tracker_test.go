@@ -0,0 +1,126 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/triplewy/microservices-demo/src/shippingservice/genproto"
+)
+
+const testSalt = "Muffin Man, London, "
+
+func TestTrackingIDsAreUnique(t *testing.T) {
+	g := newTrackingIDGenerator(1)
+	const workers, perWorker = 8, 5000
+	ids := make(chan string, workers*perWorker)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				ids <- g.generate(testSalt)
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	letters := make(map[byte]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate tracking ID %s", id)
+		}
+		seen[id] = true
+		if err := validateTrackingID(id); err != nil {
+			t.Fatalf("generated tracking ID %s is invalid: %v", id, err)
+		}
+		letters[id[0]], letters[id[1]] = true, true
+	}
+	if !letters['A'] || !letters['Z'] {
+		t.Errorf("tracking IDs use letters %v, want A through Z", letters)
+	}
+}
+
+func TestTrackingIDsAreSeedable(t *testing.T) {
+	a, b := newTrackingIDGenerator(42), newTrackingIDGenerator(42)
+	for i := 0; i < 100; i++ {
+		if x, y := a.generate(testSalt), b.generate(testSalt); x != y {
+			t.Fatalf("ID %d: %s and %s differ with the same seed", i, x, y)
+		}
+	}
+	if x, y := newTrackingIDGenerator(1).generate(testSalt), newTrackingIDGenerator(2).generate(testSalt); x == y {
+		t.Errorf("different seeds generated the same ID %s", x)
+	}
+}
+
+func TestValidateTrackingID(t *testing.T) {
+	id := newTrackingIDGenerator(7).generate(testSalt)
+	if err := validateTrackingID(id); err != nil {
+		t.Fatalf("validateTrackingID(%s): %v", id, err)
+	}
+	// The check digit catches any single changed digit.
+	for i := range id {
+		if id[i] < '0' || id[i] > '9' {
+			continue
+		}
+		changed := []byte(id)
+		changed[i] = '0' + (id[i]-'0'+1)%10
+		if err := validateTrackingID(string(changed)); err == nil {
+			t.Errorf("validateTrackingID(%s) succeeded, want error", changed)
+		}
+	}
+	for _, bad := range []string{
+		"",
+		strings.Replace(id, "-", "", 1),
+		strings.ToLower(id[:2]) + id[2:],
+		id[:3] + "x" + id[4:],
+		id[:len(id)-3],
+	} {
+		if err := validateTrackingID(bad); err == nil {
+			t.Errorf("validateTrackingID(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestValidateTrackingIdRPC(t *testing.T) {
+	s := server{}
+	ctx := context.Background()
+	res, err := s.ValidateTrackingId(ctx, &pb.ValidateTrackingIdRequest{TrackingId: CreateTrackingId(testSalt)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Valid || res.Reason != "" {
+		t.Errorf("valid ID: got %v", res)
+	}
+	res, err = s.ValidateTrackingId(ctx, &pb.ValidateTrackingIdRequest{TrackingId: "AB-123-456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Valid || res.Reason == "" {
+		t.Errorf("malformed ID: got %v", res)
+	}
+	if _, err := s.ValidateTrackingId(ctx, &pb.ValidateTrackingIdRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty ID: got %v, want InvalidArgument", err)
+	}
+}